@@ -6,8 +6,10 @@ import (
 	"net/http"
 	"os"
 	"strconv"
+	"time"
 
 	"commerce/api/internal/constants"
+	"commerce/internal/shared/database"
 
 	db "github.com/akhakpouri/gorm-kit/database"
 	pg "github.com/akhakpouri/gorm-kit/pg"
@@ -32,12 +34,44 @@ type databaseConfig struct {
 }
 
 type authConfig struct {
-	Domain   string
-	Audience string
+	Domain     string
+	Audience   string
+	RolesClaim string
+	JWKS       string
 }
 
+type orderConfig struct {
+	NumberPrefix string
+}
+
+type privacyConfig struct {
+	ErasureInterval time.Duration
+}
+
+// mediaConfig is where uploaded images are kept. URL is what they are served
+// at: a path, served by the API from Dir, or a full URL when something else
+// serves Dir.
+type mediaConfig struct {
+	Dir            string
+	URL            string
+	MaxUploadBytes int64
+}
+
+// importConfig is for product CSV imports. Queued ones are looked for every
+// Interval.
+type importConfig struct {
+	Interval       time.Duration
+	MaxUploadBytes int64
+}
+
+type carrierConfig struct {
+	PollInterval  time.Duration
+	SimulatorStep time.Duration
+}
+
+// Connect opens the database with auditing of writes turned on.
 func (d *databaseConfig) Connect() (*gorm.DB, error) {
-	return pg.Connect(db.DbConfig{
+	conn, err := pg.Connect(db.DbConfig{
 		Host:     d.Host,
 		Port:     d.Port,
 		User:     d.User,
@@ -46,12 +80,21 @@ func (d *databaseConfig) Connect() (*gorm.DB, error) {
 		SSLMode:  d.SSLMode,
 		Schema:   d.Schema,
 	})
+	if err != nil {
+		return nil, err
+	}
+	return conn, database.RegisterAudit(conn)
 }
 
 type Config struct {
 	Server   serverConfig
 	Database databaseConfig
 	Auth     authConfig
+	Carrier  carrierConfig
+	Order    orderConfig
+	Privacy  privacyConfig
+	Media    mediaConfig
+	Import   importConfig
 }
 
 func NewConfig() *Config {
@@ -81,8 +124,29 @@ func NewConfig() *Config {
 			Schema:   GetEnvOrPanic(constants.EnvKeys.DBSchema),
 		},
 		Auth: authConfig{
-			Domain:   GetEnvOrPanic(constants.EnvKeys.AuthDomain),
-			Audience: GetEnvOrPanic(constants.EnvKeys.AuthAudience),
+			Domain:     GetEnvOrPanic(constants.EnvKeys.AuthDomain),
+			Audience:   GetEnvOrPanic(constants.EnvKeys.AuthAudience),
+			RolesClaim: GetEnvOrDefault(constants.EnvKeys.AuthRolesClaim, "https://commerce.api/roles"),
+			JWKS:       os.Getenv(constants.EnvKeys.AuthJWKS),
+		},
+		Carrier: carrierConfig{
+			PollInterval:  GetDurationEnvOrDefault(constants.EnvKeys.CarrierPoll, 5*time.Minute),
+			SimulatorStep: GetDurationEnvOrDefault(constants.EnvKeys.CarrierSimStep, time.Hour),
+		},
+		Order: orderConfig{
+			NumberPrefix: GetEnvOrDefault(constants.EnvKeys.OrderNumberPrefix, "ORD"),
+		},
+		Privacy: privacyConfig{
+			ErasureInterval: GetDurationEnvOrDefault(constants.EnvKeys.ErasureInterval, time.Hour),
+		},
+		Media: mediaConfig{
+			Dir:            GetEnvOrDefault(constants.EnvKeys.MediaDir, "media"),
+			URL:            GetEnvOrDefault(constants.EnvKeys.MediaURL, "/media"),
+			MaxUploadBytes: GetIntEnvOrDefault(constants.EnvKeys.MediaMaxUpload, 10<<20),
+		},
+		Import: importConfig{
+			Interval:       GetDurationEnvOrDefault(constants.EnvKeys.ImportInterval, 10*time.Second),
+			MaxUploadBytes: GetIntEnvOrDefault(constants.EnvKeys.ImportMaxUpload, 20<<20),
 		},
 	}
 
@@ -98,11 +162,44 @@ func GetEnvOrPanic(key string) string {
 	return value
 }
 
+func GetEnvOrDefault(key string, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// GetDurationEnvOrDefault panics on a zero or negative duration as well as an
+// unparsable one: every duration setting is an interval or a step.
+func GetDurationEnvOrDefault(key string, fallback time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		panic(fmt.Sprintf("invalid %s value: %s", key, value))
+	}
+	return d
+}
+
+func GetIntEnvOrDefault(key string, fallback int64) int64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		panic(fmt.Sprintf("invalid %s value: %s", key, value))
+	}
+	return n
+}
+
 func (conf *Config) CorsNew() gin.HandlerFunc {
 	allowedOrigin := GetEnvOrPanic(constants.EnvKeys.CorsAllowedOrigin)
 
 	return cors.New(cors.Config{
-		AllowMethods:     []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete},
+		AllowMethods:     []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete},
 		AllowHeaders:     []string{constants.Headers.Origin},
 		ExposeHeaders:    []string{constants.Headers.ContentLength},
 		AllowCredentials: true,
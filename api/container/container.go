@@ -1,22 +1,53 @@
 package container
 
 import (
+	"commerce/api/configs"
+	"commerce/api/internal/carrier"
+	"commerce/api/internal/storage"
+	"commerce/internal/shared/catalog"
+	"time"
+
 	address_repo "commerce/internal/shared/repositories/address"
+	api_key_repo "commerce/internal/shared/repositories/api-key"
+	audit_event_repo "commerce/internal/shared/repositories/audit-event"
 	category_repo "commerce/internal/shared/repositories/category"
+	erasure_request_repo "commerce/internal/shared/repositories/erasure-request"
+	import_job_repo "commerce/internal/shared/repositories/import-job"
+	invoice_repo "commerce/internal/shared/repositories/invoice"
 	order_repo "commerce/internal/shared/repositories/order"
 	order_item_repo "commerce/internal/shared/repositories/order-item"
 	payment_repo "commerce/internal/shared/repositories/payment"
 	product_repo "commerce/internal/shared/repositories/product"
+	product_image_repo "commerce/internal/shared/repositories/product-image"
+	product_option_repo "commerce/internal/shared/repositories/product-option"
+	product_price_repo "commerce/internal/shared/repositories/product-price"
+	product_variant_repo "commerce/internal/shared/repositories/product-variant"
+	return_request_repo "commerce/internal/shared/repositories/return-request"
 	review_repo "commerce/internal/shared/repositories/review"
+	shipment_repo "commerce/internal/shared/repositories/shipment"
+	"commerce/internal/shared/repositories/uow"
 	user_repo "commerce/internal/shared/repositories/user"
+	user_role_repo "commerce/internal/shared/repositories/user-role"
 
 	address_service "commerce/api/internal/services/address"
+	api_key_service "commerce/api/internal/services/api-key"
+	audit_event_service "commerce/api/internal/services/audit-event"
 	category_service "commerce/api/internal/services/category"
+	invoice_service "commerce/api/internal/services/invoice"
 	order_service "commerce/api/internal/services/order"
 	order_item_service "commerce/api/internal/services/order-item"
 	payment_service "commerce/api/internal/services/payment"
+	privacy_service "commerce/api/internal/services/privacy"
 	product_service "commerce/api/internal/services/product"
+	product_image_service "commerce/api/internal/services/product-image"
+	product_import_service "commerce/api/internal/services/product-import"
+	product_price_service "commerce/api/internal/services/product-price"
+	product_variant_service "commerce/api/internal/services/product-variant"
+	return_request_service "commerce/api/internal/services/return-request"
 	review_service "commerce/api/internal/services/review"
+	role_service "commerce/api/internal/services/role"
+	shipment_service "commerce/api/internal/services/shipment"
+	shipping_service "commerce/api/internal/services/shipping"
 	tax_service "commerce/api/internal/services/tax"
 	user_service "commerce/api/internal/services/user"
 
@@ -25,38 +56,77 @@ import (
 
 type Container struct {
 	AddressService   address_service.AddressServiceI
+	ApiKeyService    api_key_service.ApiKeyServiceI
+	AuditService     audit_event_service.AuditEventServiceI
 	CategoryService  category_service.CategoryServiceI
+	InvoiceService   invoice_service.InvoiceServiceI
 	OrderService     order_service.OrderServiceI
 	OrderItemService order_item_service.OrderItemServiceI
 	PaymentService   payment_service.PaymentServiceI
+	PrivacyService   privacy_service.PrivacyServiceI
 	ProductService   product_service.ProductServiceI
+	VariantService   product_variant_service.ProductVariantServiceI
+	ImageService     product_image_service.ProductImageServiceI
+	ImportService    product_import_service.ProductImportServiceI
+	PriceService     product_price_service.ProductPriceServiceI
+	ReturnService    return_request_service.ReturnRequestServiceI
 	ReviewService    review_service.ReviewServiceI
+	RoleService      role_service.RoleServiceI
+	ShipmentService  shipment_service.ShipmentServiceI
+	ShippingService  shipping_service.ShippingServiceI
 	TaxService       tax_service.TaxServiceI
 	UserService      user_service.UserServiceI
 }
 
-func NewContainer(db *gorm.DB) *Container {
+func NewContainer(db *gorm.DB, config *configs.Config, carriers carrier.Registry, store storage.BlobStore) *Container {
 	addressRepo := address_repo.NewAddressRepository(db)
+	apiKeyRepo := api_key_repo.NewApiKeyRepository(db)
+	auditEventRepo := audit_event_repo.NewAuditEventRepository(db)
 	categoryRepo := category_repo.NewCategoryRepository(db)
+	erasureRequestRepo := erasure_request_repo.NewErasureRequestRepository(db)
+	importJobRepo := import_job_repo.NewImportJobRepository(db)
+	invoiceRepo := invoice_repo.NewInvoiceRepository(db)
 	orderItemRepo := order_item_repo.NewOrderItemRepository(db)
 	orderRepo := order_repo.NewOrderRepository(db)
 	paymentRepo := payment_repo.NewPaymentRepository(db)
 	productRepo := product_repo.NewProductRepository(db)
+	productImageRepo := product_image_repo.NewProductImageRepository(db)
+	productOptionRepo := product_option_repo.NewProductOptionRepository(db)
+	productPriceRepo := product_price_repo.NewProductPriceRepository(db)
+	productVariantRepo := product_variant_repo.NewProductVariantRepository(db)
+	returnRequestRepo := return_request_repo.NewReturnRequestRepository(db)
 	reviewRepo := review_repo.NewReviewRepository(db)
+	shipmentRepo := shipment_repo.NewShipmentRepository(db)
 	userRepo := user_repo.NewUserRepository(db)
+	userRoleRepo := user_role_repo.NewUserRoleRepository(db)
+	unitOfWork := uow.NewUnitOfWork(db)
 
 	taxService := tax_service.NewTaxService()
-	orderService := order_service.NewOrderService(orderRepo, taxService)
+	shippingService := shipping_service.NewShippingService(productRepo)
+	orderNumbers := order_service.NewOrderNumberGenerator(orderRepo, config.Order.NumberPrefix, time.Now)
+	orderService := order_service.NewOrderService(orderRepo, addressRepo, productRepo, unitOfWork, orderNumbers, taxService, shippingService)
 
 	return &Container{
 		AddressService:   address_service.NewAddressService(addressRepo),
+		ApiKeyService:    api_key_service.NewApiKeyService(apiKeyRepo, time.Now),
+		AuditService:     audit_event_service.NewAuditEventService(auditEventRepo),
 		CategoryService:  category_service.NewCategoryService(categoryRepo),
+		InvoiceService:   invoice_service.NewInvoiceService(invoiceRepo, orderRepo, paymentRepo, taxService),
 		OrderItemService: order_item_service.NewOrderItemService(orderItemRepo),
 		OrderService:     orderService,
 		TaxService:       taxService,
 		PaymentService:   payment_service.NewPaymentService(paymentRepo),
-		ProductService:   product_service.NewProductService(productRepo),
+		PrivacyService:   privacy_service.NewPrivacyService(userRepo, addressRepo, orderRepo, paymentRepo, reviewRepo, erasureRequestRepo, unitOfWork, time.Now),
+		ProductService:   product_service.NewProductService(productRepo, store),
+		VariantService:   product_variant_service.NewProductVariantService(productRepo, productOptionRepo, productVariantRepo),
+		ImageService:     product_image_service.NewProductImageService(productRepo, productImageRepo, store),
+		ImportService:    product_import_service.NewProductImportService(catalog.NewCatalog(db), importJobRepo, time.Now),
+		PriceService:     product_price_service.NewProductPriceService(productRepo, productPriceRepo, time.Now),
+		ReturnService:    return_request_service.NewReturnRequestService(returnRequestRepo, unitOfWork),
 		ReviewService:    review_service.NewReviewService(reviewRepo),
+		RoleService:      role_service.NewRoleService(userRoleRepo),
+		ShipmentService:  shipment_service.NewShipmentService(shipmentRepo, orderRepo, unitOfWork, carriers),
+		ShippingService:  shippingService,
 		UserService:      user_service.NewUserService(userRepo),
 	}
 }
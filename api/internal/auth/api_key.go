@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"commerce/api/internal/constants"
+	errdto "commerce/api/internal/dto/err"
+	apiKeyService "commerce/api/internal/services/api-key"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ApiKeyOr authenticates requests that carry an X-Api-Key header against svc
+// and hands every other request to jwt, normally [Gin]. Either way the routes
+// behind it see an [Identity], so [RequireScope] works unchanged.
+//
+// Key identities are M2M: they have no user, [ResolveIdentity] skips them and
+// ownership checks let them through, like a back-office client.
+func ApiKeyOr(jwt gin.HandlerFunc, svc apiKeyService.ApiKeyServiceI) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		key := ctx.GetHeader(constants.Headers.ApiKey)
+		if key == "" {
+			jwt(ctx)
+			return
+		}
+
+		apiKey, err := svc.Authenticate(ctx.Request.Context(), key)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errdto.ErrorResponse{
+				Code:    http.StatusUnauthorized,
+				Message: "Failed to validate API key.",
+			})
+			return
+		}
+
+		id := &Identity{
+			Subject: "apikey:" + apiKey.Prefix + "@clients",
+			Scopes:  apiKey.Scopes,
+		}
+		if apiKey.ExpiresDate != nil {
+			id.ExpiresAt = *apiKey.ExpiresDate
+		}
+		ctx.Set(constants.ContextKeys.Identity, id)
+	}
+}
@@ -0,0 +1,95 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	apikeydto "commerce/api/internal/dto/api-key"
+	apiKeyService "commerce/api/internal/services/api-key"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func newApiKeyRouter(t *testing.T, svc *MockApiKeyServiceI, scope string) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/protected", ApiKeyOr(Gin(newTestMiddleware(t)), svc), RequireScope(scope), func(c *gin.Context) {
+		id := GetIdentity(c)
+		c.JSON(http.StatusOK, gin.H{"sub": id.Subject, "m2m": id.IsM2M()})
+	})
+	return r
+}
+
+func TestApiKeyOr_ValidKey_SetsIdentity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc := NewMockApiKeyServiceI(ctrl)
+	svc.EXPECT().Authenticate(gomock.Any(), "ck_abc123_secret").Return(&apikeydto.ApiKey{
+		Prefix: "abc123",
+		Scopes: []string{"orders:read"},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+	req.Header.Set("X-Api-Key", "ck_abc123_secret")
+	w := httptest.NewRecorder()
+	newApiKeyRouter(t, svc, "orders:read").ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"sub":"apikey:abc123@clients","m2m":true}`, w.Body.String())
+}
+
+func TestApiKeyOr_KeyWithoutScope_Returns403(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc := NewMockApiKeyServiceI(ctrl)
+	svc.EXPECT().Authenticate(gomock.Any(), "ck_abc123_secret").Return(&apikeydto.ApiKey{
+		Prefix: "abc123",
+		Scopes: []string{"orders:read"},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+	req.Header.Set("X-Api-Key", "ck_abc123_secret")
+	w := httptest.NewRecorder()
+	newApiKeyRouter(t, svc, "orders:write").ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestApiKeyOr_InvalidKey_Returns401(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc := NewMockApiKeyServiceI(ctrl)
+	svc.EXPECT().Authenticate(gomock.Any(), "ck_abc123_wrong").Return(nil, apiKeyService.ErrInvalidApiKey)
+
+	req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+	req.Header.Set("X-Api-Key", "ck_abc123_wrong")
+	w := httptest.NewRecorder()
+	newApiKeyRouter(t, svc, "orders:read").ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestApiKeyOr_NoKey_FallsBackToJWT(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc := NewMockApiKeyServiceI(ctrl)
+	// no EXPECT — the key service must not be consulted
+
+	req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+	w := httptest.NewRecorder()
+	newApiKeyRouter(t, svc, "orders:read").ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnauthorized, w.Code, "no bearer token either")
+
+	token := signHS256(t, map[string]any{
+		"iss":   testIssuer,
+		"aud":   testAudience,
+		"sub":   "client@clients",
+		"exp":   9999999999,
+		"scope": "orders:read",
+	})
+	req = httptest.NewRequest(http.MethodGet, "/protected", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w = httptest.NewRecorder()
+	newApiKeyRouter(t, svc, "orders:read").ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
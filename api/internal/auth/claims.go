@@ -2,16 +2,52 @@ package auth
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log/slog"
 	"strings"
 )
 
+// DefaultRolesClaim is the custom claim the Auth0 action writes roles to.
+const DefaultRolesClaim = "https://commerce.api/roles"
+
 type Claim struct {
 	Scope     string `json:"scope"`
 	FirstName string `json:"given_name"`
 	LastName  string `json:"family_name"`
 	Email     string `json:"email"`
+	// EmailVerified is whether the identity provider has checked that the
+	// user owns Email.
+	EmailVerified bool `json:"email_verified"`
+	// Roles is read from the namespaced custom claim named by rolesClaim.
+	Roles []string `json:"-"`
+
+	rolesClaim string
+}
+
+// NewClaim returns an empty claim that reads roles from rolesClaim, or from
+// [DefaultRolesClaim] when it is empty.
+func NewClaim(rolesClaim string) *Claim {
+	return &Claim{rolesClaim: rolesClaim}
+}
+
+func (c *Claim) UnmarshalJSON(data []byte) error {
+	type plain Claim
+	if err := json.Unmarshal(data, (*plain)(c)); err != nil {
+		return err
+	}
+	namespace := c.rolesClaim
+	if namespace == "" {
+		namespace = DefaultRolesClaim
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if roles, ok := raw[namespace]; ok {
+		return json.Unmarshal(roles, &c.Roles)
+	}
+	return nil
 }
 
 func (c *Claim) Validate(ctx context.Context) error {
@@ -2,9 +2,11 @@ package auth
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestClaim_Validate(t *testing.T) {
@@ -53,3 +55,24 @@ func TestClaim_HasScope(t *testing.T) {
 		})
 	}
 }
+
+func TestClaim_UnmarshalRoles(t *testing.T) {
+	token := []byte(`{
+		"scope": "orders:read",
+		"https://commerce.api/roles": ["support"],
+		"https://shop.example/roles": ["admin"]
+	}`)
+
+	c := NewClaim("")
+	require.NoError(t, json.Unmarshal(token, c))
+	assert.Equal(t, "orders:read", c.Scope)
+	assert.Equal(t, []string{"support"}, c.Roles, "default namespace")
+
+	c = NewClaim("https://shop.example/roles")
+	require.NoError(t, json.Unmarshal(token, c))
+	assert.Equal(t, []string{"admin"}, c.Roles, "configured namespace")
+
+	c = NewClaim("https://missing.example/roles")
+	require.NoError(t, json.Unmarshal(token, c))
+	assert.Empty(t, c.Roles)
+}
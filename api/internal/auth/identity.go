@@ -1,10 +1,68 @@
 package auth
 
-import "time"
+import (
+	"commerce/api/internal/constants"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
 
 type Identity struct {
 	Subject   string
 	Scopes    []string //parsed from `scope` claims
+	Roles     []string //parsed from the roles claim, plus roles assigned in the API
 	ExpiresAt time.Time
 	UserId    *uint
 }
+
+// IsM2M reports whether the caller is a machine-to-machine client (a back
+// office or another service) rather than a user.
+func (i *Identity) IsM2M() bool {
+	return strings.HasSuffix(i.Subject, "@clients")
+}
+
+// IsUser reports whether the caller is the user with the given id.
+func (i *Identity) IsUser(userId uint) bool {
+	return i.UserId != nil && *i.UserId == userId
+}
+
+// IsAdmin reports whether the caller holds the admin role.
+func (i *Identity) IsAdmin() bool {
+	return i.HasRole(RoleAdmin)
+}
+
+// IsStaff reports whether the caller holds the admin or support role.
+func (i *Identity) IsStaff() bool {
+	return i.IsAdmin() || i.HasRole(RoleSupport)
+}
+
+// HasRole reports whether the caller holds role.
+func (i *Identity) HasRole(role string) bool {
+	return slices.Contains(i.Roles, role)
+}
+
+// HasScope reports whether the token carries scope or one of the caller's
+// roles grants it.
+func (i *Identity) HasScope(scope string) bool {
+	if slices.Contains(i.Scopes, scope) {
+		return true
+	}
+	for _, role := range i.Roles {
+		if slices.Contains(Permissions[role], scope) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetIdentity returns the identity set by [Gin], or nil on unauthenticated routes.
+func GetIdentity(ctx *gin.Context) *Identity {
+	v, exists := ctx.Get(constants.ContextKeys.Identity)
+	if !exists {
+		return nil
+	}
+	id, _ := v.(*Identity)
+	return id
+}
@@ -0,0 +1,26 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdentityIsM2M(t *testing.T) {
+	assert.True(t, (&Identity{Subject: "abc123@clients"}).IsM2M())
+	assert.False(t, (&Identity{Subject: "auth0|abc123"}).IsM2M())
+}
+
+func TestIdentityIsUser(t *testing.T) {
+	userId := uint(7)
+	assert.True(t, (&Identity{UserId: &userId}).IsUser(7))
+	assert.False(t, (&Identity{UserId: &userId}).IsUser(8))
+	assert.False(t, (&Identity{Subject: "abc123@clients"}).IsUser(7), "M2M identities have no user")
+}
+
+func TestIdentityIsStaff(t *testing.T) {
+	assert.True(t, (&Identity{Roles: []string{RoleAdmin}}).IsStaff())
+	assert.True(t, (&Identity{Roles: []string{RoleSupport}}).IsStaff())
+	assert.False(t, (&Identity{Roles: []string{RoleCustomer}}).IsStaff())
+	assert.False(t, (&Identity{Subject: "abc123@clients"}).IsStaff())
+}
@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"log/slog"
 	"net/http"
-	"slices"
 	"strings"
 	"time"
 
@@ -50,6 +49,7 @@ func Gin(mw *middleware.JWTMiddleware) gin.HandlerFunc {
 			}
 			if cc, ok := vc.CustomClaims.(*Claim); ok && cc != nil {
 				id.Scopes = strings.Fields(cc.Scope) //split on whitespace.
+				id.Roles = cc.Roles
 			}
 
 			ctx.Set(constants.ContextKeys.Identity, id)
@@ -75,7 +75,7 @@ func RequireScope(expected string) gin.HandlerFunc {
 			return
 		}
 		id := v.(*Identity)
-		if !slices.Contains(id.Scopes, expected) {
+		if !id.HasScope(expected) {
 			ctx.AbortWithStatusJSON(http.StatusForbidden, errdto.ErrorResponse{
 				Code:    http.StatusForbidden,
 				Message: "insufficient scope",
@@ -0,0 +1,133 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../services/api-key/api_key_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=../services/api-key/api_key_service.go -destination=mock_api_key_service_test.go -package=auth
+//
+
+// Package auth is a generated GoMock package.
+package auth
+
+import (
+	apikey "commerce/api/internal/dto/api-key"
+	page "commerce/api/internal/dto/page"
+	query "commerce/internal/shared/repositories/query"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockApiKeyServiceI is a mock of ApiKeyServiceI interface.
+type MockApiKeyServiceI struct {
+	ctrl     *gomock.Controller
+	recorder *MockApiKeyServiceIMockRecorder
+	isgomock struct{}
+}
+
+// MockApiKeyServiceIMockRecorder is the mock recorder for MockApiKeyServiceI.
+type MockApiKeyServiceIMockRecorder struct {
+	mock *MockApiKeyServiceI
+}
+
+// NewMockApiKeyServiceI creates a new mock instance.
+func NewMockApiKeyServiceI(ctrl *gomock.Controller) *MockApiKeyServiceI {
+	mock := &MockApiKeyServiceI{ctrl: ctrl}
+	mock.recorder = &MockApiKeyServiceIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApiKeyServiceI) EXPECT() *MockApiKeyServiceIMockRecorder {
+	return m.recorder
+}
+
+// Authenticate mocks base method.
+func (m *MockApiKeyServiceI) Authenticate(ctx context.Context, key string) (*apikey.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authenticate", ctx, key)
+	ret0, _ := ret[0].(*apikey.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authenticate indicates an expected call of Authenticate.
+func (mr *MockApiKeyServiceIMockRecorder) Authenticate(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockApiKeyServiceI)(nil).Authenticate), ctx, key)
+}
+
+// Create mocks base method.
+func (m *MockApiKeyServiceI) Create(ctx context.Context, request apikey.CreateApiKey) (*apikey.IssuedApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, request)
+	ret0, _ := ret[0].(*apikey.IssuedApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockApiKeyServiceIMockRecorder) Create(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockApiKeyServiceI)(nil).Create), ctx, request)
+}
+
+// GetAll mocks base method.
+func (m *MockApiKeyServiceI) GetAll(ctx context.Context, opts query.Options) (*page.Page[apikey.ApiKey], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, opts)
+	ret0, _ := ret[0].(*page.Page[apikey.ApiKey])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockApiKeyServiceIMockRecorder) GetAll(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockApiKeyServiceI)(nil).GetAll), ctx, opts)
+}
+
+// GetById mocks base method.
+func (m *MockApiKeyServiceI) GetById(ctx context.Context, id uint) (*apikey.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(*apikey.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockApiKeyServiceIMockRecorder) GetById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockApiKeyServiceI)(nil).GetById), ctx, id)
+}
+
+// Revoke mocks base method.
+func (m *MockApiKeyServiceI) Revoke(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockApiKeyServiceIMockRecorder) Revoke(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockApiKeyServiceI)(nil).Revoke), ctx, id)
+}
+
+// Rotate mocks base method.
+func (m *MockApiKeyServiceI) Rotate(ctx context.Context, id uint) (*apikey.IssuedApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rotate", ctx, id)
+	ret0, _ := ret[0].(*apikey.IssuedApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rotate indicates an expected call of Rotate.
+func (mr *MockApiKeyServiceIMockRecorder) Rotate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rotate", reflect.TypeOf((*MockApiKeyServiceI)(nil).Rotate), ctx, id)
+}
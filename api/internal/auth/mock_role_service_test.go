@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../services/role/role_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=../services/role/role_service.go -destination=mock_role_service_test.go -package=auth
+//
+
+// Package auth is a generated GoMock package.
+package auth
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRoleServiceI is a mock of RoleServiceI interface.
+type MockRoleServiceI struct {
+	ctrl     *gomock.Controller
+	recorder *MockRoleServiceIMockRecorder
+	isgomock struct{}
+}
+
+// MockRoleServiceIMockRecorder is the mock recorder for MockRoleServiceI.
+type MockRoleServiceIMockRecorder struct {
+	mock *MockRoleServiceI
+}
+
+// NewMockRoleServiceI creates a new mock instance.
+func NewMockRoleServiceI(ctrl *gomock.Controller) *MockRoleServiceI {
+	mock := &MockRoleServiceI{ctrl: ctrl}
+	mock.recorder = &MockRoleServiceIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRoleServiceI) EXPECT() *MockRoleServiceIMockRecorder {
+	return m.recorder
+}
+
+// Assign mocks base method.
+func (m *MockRoleServiceI) Assign(ctx context.Context, userId uint, roles []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Assign", ctx, userId, roles)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Assign indicates an expected call of Assign.
+func (mr *MockRoleServiceIMockRecorder) Assign(ctx, userId, roles any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Assign", reflect.TypeOf((*MockRoleServiceI)(nil).Assign), ctx, userId, roles)
+}
+
+// GetByUserId mocks base method.
+func (m *MockRoleServiceI) GetByUserId(ctx context.Context, userId uint) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserId", ctx, userId)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserId indicates an expected call of GetByUserId.
+func (mr *MockRoleServiceIMockRecorder) GetByUserId(ctx, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserId", reflect.TypeOf((*MockRoleServiceI)(nil).GetByUserId), ctx, userId)
+}
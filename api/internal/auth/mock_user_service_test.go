@@ -10,7 +10,10 @@
 package auth
 
 import (
+	page "commerce/api/internal/dto/page"
 	user "commerce/api/internal/dto/user"
+	query "commerce/internal/shared/repositories/query"
+	context "context"
 	reflect "reflect"
 
 	gomock "go.uber.org/mock/gomock"
@@ -40,105 +43,105 @@ func (m *MockUserServiceI) EXPECT() *MockUserServiceIMockRecorder {
 	return m.recorder
 }
 
-// Authenticate mocks base method.
-func (m *MockUserServiceI) Authenticate(email, password string) (*user.User, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Authenticate", email, password)
-	ret0, _ := ret[0].(*user.User)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// Authenticate indicates an expected call of Authenticate.
-func (mr *MockUserServiceIMockRecorder) Authenticate(email, password any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockUserServiceI)(nil).Authenticate), email, password)
-}
-
 // Delete mocks base method.
-func (m *MockUserServiceI) Delete(id uint) error {
+func (m *MockUserServiceI) Delete(ctx context.Context, id uint) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", id)
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockUserServiceIMockRecorder) Delete(id any) *gomock.Call {
+func (mr *MockUserServiceIMockRecorder) Delete(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUserServiceI)(nil).Delete), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUserServiceI)(nil).Delete), ctx, id)
 }
 
 // GetAll mocks base method.
-func (m *MockUserServiceI) GetAll() ([]*user.User, error) {
+func (m *MockUserServiceI) GetAll(ctx context.Context, opts query.Options) (*page.Page[user.User], error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll")
-	ret0, _ := ret[0].([]*user.User)
+	ret := m.ctrl.Call(m, "GetAll", ctx, opts)
+	ret0, _ := ret[0].(*page.Page[user.User])
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockUserServiceIMockRecorder) GetAll() *gomock.Call {
+func (mr *MockUserServiceIMockRecorder) GetAll(ctx, opts any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockUserServiceI)(nil).GetAll))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockUserServiceI)(nil).GetAll), ctx, opts)
 }
 
 // GetByEmail mocks base method.
-func (m *MockUserServiceI) GetByEmail(email string) (*user.User, error) {
+func (m *MockUserServiceI) GetByEmail(ctx context.Context, email string) (*user.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByEmail", email)
+	ret := m.ctrl.Call(m, "GetByEmail", ctx, email)
 	ret0, _ := ret[0].(*user.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByEmail indicates an expected call of GetByEmail.
-func (mr *MockUserServiceIMockRecorder) GetByEmail(email any) *gomock.Call {
+func (mr *MockUserServiceIMockRecorder) GetByEmail(ctx, email any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEmail", reflect.TypeOf((*MockUserServiceI)(nil).GetByEmail), email)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEmail", reflect.TypeOf((*MockUserServiceI)(nil).GetByEmail), ctx, email)
 }
 
 // GetById mocks base method.
-func (m *MockUserServiceI) GetById(id uint) (*user.User, error) {
+func (m *MockUserServiceI) GetById(ctx context.Context, id uint) (*user.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetById", id)
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
 	ret0, _ := ret[0].(*user.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetById indicates an expected call of GetById.
-func (mr *MockUserServiceIMockRecorder) GetById(id any) *gomock.Call {
+func (mr *MockUserServiceIMockRecorder) GetById(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockUserServiceI)(nil).GetById), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockUserServiceI)(nil).GetById), ctx, id)
 }
 
 // ResolveByAuth mocks base method.
-func (m *MockUserServiceI) ResolveByAuth(sub, email, firstName, lastName string) (*user.User, error) {
+func (m *MockUserServiceI) ResolveByAuth(ctx context.Context, sub, email string, emailVerified bool, firstName, lastName string) (*user.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ResolveByAuth", sub, email, firstName, lastName)
+	ret := m.ctrl.Call(m, "ResolveByAuth", ctx, sub, email, emailVerified, firstName, lastName)
 	ret0, _ := ret[0].(*user.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ResolveByAuth indicates an expected call of ResolveByAuth.
-func (mr *MockUserServiceIMockRecorder) ResolveByAuth(sub, email, firstName, lastName any) *gomock.Call {
+func (mr *MockUserServiceIMockRecorder) ResolveByAuth(ctx, sub, email, emailVerified, firstName, lastName any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveByAuth", reflect.TypeOf((*MockUserServiceI)(nil).ResolveByAuth), sub, email, firstName, lastName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveByAuth", reflect.TypeOf((*MockUserServiceI)(nil).ResolveByAuth), ctx, sub, email, emailVerified, firstName, lastName)
 }
 
 // Save mocks base method.
-func (m *MockUserServiceI) Save(arg0 *user.User) error {
+func (m *MockUserServiceI) Save(ctx context.Context, arg1 *user.User) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Save", arg0)
+	ret := m.ctrl.Call(m, "Save", ctx, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Save indicates an expected call of Save.
-func (mr *MockUserServiceIMockRecorder) Save(arg0 any) *gomock.Call {
+func (mr *MockUserServiceIMockRecorder) Save(ctx, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockUserServiceI)(nil).Save), ctx, arg1)
+}
+
+// UpdateProfile mocks base method.
+func (m *MockUserServiceI) UpdateProfile(ctx context.Context, id uint, profile user.Profile) (*user.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProfile", ctx, id, profile)
+	ret0, _ := ret[0].(*user.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProfile indicates an expected call of UpdateProfile.
+func (mr *MockUserServiceIMockRecorder) UpdateProfile(ctx, id, profile any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockUserServiceI)(nil).Save), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfile", reflect.TypeOf((*MockUserServiceI)(nil).UpdateProfile), ctx, id, profile)
 }
@@ -0,0 +1,170 @@
+package auth
+
+import (
+	errdto "commerce/api/internal/dto/err"
+	"commerce/api/internal/helpers"
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CanAccess reports whether the caller may act on a resource that belongs to
+// ownerId. Users only reach their own resources; admins and M2M clients reach
+// everyone's.
+func (i *Identity) CanAccess(ownerId uint) bool {
+	return i.IsM2M() || i.IsAdmin() || i.IsUser(ownerId)
+}
+
+// CanRead reports whether the caller may read a resource that belongs to
+// ownerId. Support staff read everyone's resources to help customers, but
+// can't change them.
+func (i *Identity) CanRead(ownerId uint) bool {
+	return i.CanAccess(ownerId) || i.IsStaff()
+}
+
+// Authorize is the ownership check for handlers that have loaded a resource.
+// It aborts with 403 and returns false unless the caller may access a resource
+// owned by ownerId.
+func Authorize(ctx *gin.Context, ownerId uint) bool {
+	return authorize(ctx, ownerId, (*Identity).CanAccess)
+}
+
+// AuthorizeRead is [Authorize] for handlers that only read the resource, so
+// staff pass it as well as the owner.
+func AuthorizeRead(ctx *gin.Context, ownerId uint) bool {
+	return authorize(ctx, ownerId, (*Identity).CanRead)
+}
+
+func authorize(ctx *gin.Context, ownerId uint, can func(*Identity, uint) bool) bool {
+	id := GetIdentity(ctx)
+	if id == nil || !can(id, ownerId) {
+		forbid(ctx)
+		return false
+	}
+	return true
+}
+
+// RequireOwner is the ownership check for routes that name the owning user
+// in a path parameter, such as /users/:user_id/orders.
+func RequireOwner(param string) gin.HandlerFunc {
+	return requireOwner(param, Authorize)
+}
+
+// RequireReadOwner is [RequireOwner] for read-only routes, which staff pass
+// as well.
+func RequireReadOwner(param string) gin.HandlerFunc {
+	return requireOwner(param, AuthorizeRead)
+}
+
+func requireOwner(param string, check func(*gin.Context, uint) bool) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userId, err := helpers.ParseParamToUint(ctx.Param(param))
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, errdto.ErrorResponse{
+				Code:    http.StatusBadRequest,
+				Message: err.Error(),
+			})
+			return
+		}
+		if check(ctx, *userId) {
+			ctx.Next()
+		}
+	}
+}
+
+// RequireUser is for routes that act on the caller's own account. Callers
+// without a user, such as M2M clients, are rejected with 403.
+func RequireUser() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id := GetIdentity(ctx)
+		if id == nil || id.UserId == nil {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, errdto.ErrorResponse{
+				Code:    http.StatusForbidden,
+				Message: "this endpoint requires a user token",
+			})
+			return
+		}
+		ctx.Next()
+	}
+}
+
+// OwnerOf looks up the user that owns the resource with the given id.
+type OwnerOf func(ctx context.Context, id uint) (uint, error)
+
+// AuthorizeOwnerOf is [Authorize] for resources that belong to a user through
+// another resource, such as a payment through its order. It responds 404 when
+// the owning resource can't be found.
+func AuthorizeOwnerOf(ctx *gin.Context, id uint, ownerOf OwnerOf) bool {
+	return authorizeOwnerOf(ctx, id, ownerOf, Authorize)
+}
+
+// AuthorizeReadOwnerOf is [AuthorizeOwnerOf] for handlers that only read the
+// resource, so staff pass it as well as the owner.
+func AuthorizeReadOwnerOf(ctx *gin.Context, id uint, ownerOf OwnerOf) bool {
+	return authorizeOwnerOf(ctx, id, ownerOf, AuthorizeRead)
+}
+
+func authorizeOwnerOf(ctx *gin.Context, id uint, ownerOf OwnerOf, check func(*gin.Context, uint) bool) bool {
+	ownerId, err := ownerOf(ctx.Request.Context(), id)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusNotFound, errdto.ErrorResponse{
+			Code:    http.StatusNotFound,
+			Message: err.Error(),
+		})
+		return false
+	}
+	return check(ctx, ownerId)
+}
+
+// RequireOwnerOf is the ownership check for routes whose path parameter names
+// a resource rather than a user, such as /orders/:id/cancel.
+func RequireOwnerOf(param string, ownerOf OwnerOf) gin.HandlerFunc {
+	return requireOwnerOf(param, ownerOf, AuthorizeOwnerOf)
+}
+
+// RequireReadOwnerOf is [RequireOwnerOf] for read-only routes, such as
+// /orders/:id/payments, which staff pass as well.
+func RequireReadOwnerOf(param string, ownerOf OwnerOf) gin.HandlerFunc {
+	return requireOwnerOf(param, ownerOf, AuthorizeReadOwnerOf)
+}
+
+func requireOwnerOf(param string, ownerOf OwnerOf, check func(*gin.Context, uint, OwnerOf) bool) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := helpers.ParseParamToUint(ctx.Param(param))
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, errdto.ErrorResponse{
+				Code:    http.StatusBadRequest,
+				Message: err.Error(),
+			})
+			return
+		}
+		if check(ctx, *id, ownerOf) {
+			ctx.Next()
+		}
+	}
+}
+
+// IncludeDeleted reads the include_deleted query parameter of list routes. Only
+// admins may see deleted rows: anyone else asking for them gets 403, and ok is
+// false so the handler stops.
+func IncludeDeleted(ctx *gin.Context) (include bool, ok bool) {
+	if ctx.DefaultQuery("include_deleted", "false") != "true" {
+		return false, true
+	}
+	if id := GetIdentity(ctx); id == nil || !id.IsAdmin() {
+		ctx.AbortWithStatusJSON(http.StatusForbidden, errdto.ErrorResponse{
+			Code:    http.StatusForbidden,
+			Message: "include_deleted is only available to admins",
+		})
+		return false, false
+	}
+	return true, true
+}
+
+func forbid(ctx *gin.Context) {
+	ctx.AbortWithStatusJSON(http.StatusForbidden, errdto.ErrorResponse{
+		Code:    http.StatusForbidden,
+		Message: "access to another user's resources is not allowed",
+	})
+}
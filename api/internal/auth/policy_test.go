@@ -0,0 +1,148 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"commerce/api/internal/constants"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func newPolicyRouter(identity *Identity, path string, guard gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET(path, func(c *gin.Context) {
+		if identity != nil {
+			c.Set(constants.ContextKeys.Identity, identity)
+		}
+		c.Next()
+	}, guard, func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return r
+}
+
+func serve(r *gin.Engine, target string) int {
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+	return w.Code
+}
+
+func TestIdentityIsAdmin(t *testing.T) {
+	assert.True(t, (&Identity{Roles: []string{"support", RoleAdmin}}).IsAdmin())
+	assert.False(t, (&Identity{Roles: []string{"support"}}).IsAdmin())
+	assert.False(t, (&Identity{}).IsAdmin())
+}
+
+func TestIdentityCanAccess(t *testing.T) {
+	userId := uint(7)
+	assert.True(t, (&Identity{UserId: &userId}).CanAccess(7))
+	assert.False(t, (&Identity{UserId: &userId}).CanAccess(8))
+	assert.True(t, (&Identity{UserId: &userId, Roles: []string{RoleAdmin}}).CanAccess(8))
+	assert.True(t, (&Identity{Subject: "abc123@clients"}).CanAccess(8))
+}
+
+func TestIdentityCanRead(t *testing.T) {
+	userId := uint(7)
+	assert.True(t, (&Identity{UserId: &userId}).CanRead(7))
+	assert.False(t, (&Identity{UserId: &userId}).CanRead(8))
+	assert.True(t, (&Identity{UserId: &userId, Roles: []string{RoleSupport}}).CanRead(8))
+	assert.False(t, (&Identity{UserId: &userId, Roles: []string{RoleSupport}}).CanAccess(8), "support only reads")
+}
+
+func TestRequireOwner(t *testing.T) {
+	userId := uint(7)
+	r := newPolicyRouter(&Identity{UserId: &userId}, "/users/:user_id/orders", RequireOwner("user_id"))
+
+	assert.Equal(t, http.StatusOK, serve(r, "/users/7/orders"))
+	assert.Equal(t, http.StatusForbidden, serve(r, "/users/8/orders"))
+	assert.Equal(t, http.StatusBadRequest, serve(r, "/users/abc/orders"))
+}
+
+func TestRequireOwner_NoIdentity_Returns403(t *testing.T) {
+	r := newPolicyRouter(nil, "/users/:user_id/orders", RequireOwner("user_id"))
+
+	assert.Equal(t, http.StatusForbidden, serve(r, "/users/7/orders"))
+}
+
+func TestRequireOwnerOf(t *testing.T) {
+	userId := uint(7)
+	owners := map[uint]uint{1: 7, 2: 8}
+	ownerOf := func(_ context.Context, id uint) (uint, error) {
+		owner, ok := owners[id]
+		if !ok {
+			return 0, errors.New("record not found")
+		}
+		return owner, nil
+	}
+
+	r := newPolicyRouter(&Identity{UserId: &userId}, "/orders/:id/payments", RequireOwnerOf("id", ownerOf))
+	assert.Equal(t, http.StatusOK, serve(r, "/orders/1/payments"))
+	assert.Equal(t, http.StatusForbidden, serve(r, "/orders/2/payments"))
+	assert.Equal(t, http.StatusNotFound, serve(r, "/orders/3/payments"))
+
+	admin := newPolicyRouter(&Identity{UserId: &userId, Roles: []string{RoleAdmin}}, "/orders/:id/payments", RequireOwnerOf("id", ownerOf))
+	assert.Equal(t, http.StatusOK, serve(admin, "/orders/2/payments"))
+}
+
+func TestSupportReadsAnotherUsersOrder(t *testing.T) {
+	userId := uint(9)
+	support := &Identity{UserId: &userId, Roles: []string{RoleSupport}}
+	ownerOf := func(_ context.Context, id uint) (uint, error) { return 7, nil }
+
+	r := newPolicyRouter(support, "/orders/:id", func(c *gin.Context) {
+		if AuthorizeRead(c, 7) {
+			c.Next()
+		}
+	})
+	assert.Equal(t, http.StatusOK, serve(r, "/orders/1"))
+
+	r = newPolicyRouter(support, "/orders/:id/payments", RequireReadOwnerOf("id", ownerOf))
+	assert.Equal(t, http.StatusOK, serve(r, "/orders/1/payments"))
+
+	r = newPolicyRouter(support, "/users/:user_id/orders", RequireReadOwner("user_id"))
+	assert.Equal(t, http.StatusOK, serve(r, "/users/7/orders"))
+
+	r = newPolicyRouter(support, "/orders/:id/cancel", RequireOwnerOf("id", ownerOf))
+	assert.Equal(t, http.StatusForbidden, serve(r, "/orders/1/cancel"), "support can't act on the order")
+}
+
+func TestRequireReadOwner_Customer(t *testing.T) {
+	userId := uint(7)
+	r := newPolicyRouter(&Identity{UserId: &userId}, "/users/:user_id/orders", RequireReadOwner("user_id"))
+
+	assert.Equal(t, http.StatusOK, serve(r, "/users/7/orders"))
+	assert.Equal(t, http.StatusForbidden, serve(r, "/users/8/orders"))
+}
+
+func TestRequireUser(t *testing.T) {
+	userId := uint(7)
+
+	assert.Equal(t, http.StatusOK, serve(newPolicyRouter(&Identity{UserId: &userId}, "/me", RequireUser()), "/me"))
+	assert.Equal(t, http.StatusForbidden, serve(newPolicyRouter(&Identity{Subject: "abc123@clients"}, "/me", RequireUser()), "/me"))
+	assert.Equal(t, http.StatusForbidden, serve(newPolicyRouter(nil, "/me", RequireUser()), "/me"))
+}
+
+func TestIncludeDeleted(t *testing.T) {
+	userId := uint(7)
+	guard := func(c *gin.Context) {
+		if include, ok := IncludeDeleted(c); ok && include {
+			c.Header("X-Include-Deleted", "true")
+		}
+	}
+	user := newPolicyRouter(&Identity{UserId: &userId}, "/products", guard)
+	admin := newPolicyRouter(&Identity{UserId: &userId, Roles: []string{RoleAdmin}}, "/products", guard)
+
+	assert.Equal(t, http.StatusOK, serve(user, "/products"))
+	assert.Equal(t, http.StatusForbidden, serve(user, "/products?include_deleted=true"))
+	assert.Equal(t, http.StatusOK, serve(admin, "/products?include_deleted=true"))
+
+	w := httptest.NewRecorder()
+	admin.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/products?include_deleted=true", nil))
+	assert.Equal(t, "true", w.Header().Get("X-Include-Deleted"))
+}
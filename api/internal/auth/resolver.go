@@ -3,17 +3,20 @@ package auth
 import (
 	"commerce/api/internal/constants"
 	errdto "commerce/api/internal/dto/err"
+	roleService "commerce/api/internal/services/role"
 	userService "commerce/api/internal/services/user"
+	"commerce/internal/shared/database"
+	"errors"
 	"log/slog"
 	"net/http"
-	"strings"
+	"slices"
 
 	middleware "github.com/auth0/go-jwt-middleware/v3"
 	"github.com/auth0/go-jwt-middleware/v3/validator"
 	"github.com/gin-gonic/gin"
 )
 
-func ResolveIdentity(svc userService.UserServiceI) gin.HandlerFunc {
+func ResolveIdentity(svc userService.UserServiceI, roles roleService.RoleServiceI) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		v, exists := ctx.Get(constants.ContextKeys.Identity)
 		if !exists {
@@ -21,9 +24,10 @@ func ResolveIdentity(svc userService.UserServiceI) gin.HandlerFunc {
 			return
 		}
 		id := v.(*Identity)
+		setActor(ctx, id)
 
 		//if M2M then skip
-		if strings.HasSuffix(id.Subject, "@clients") {
+		if id.IsM2M() {
 			return
 		}
 
@@ -48,12 +52,42 @@ func ResolveIdentity(svc userService.UserServiceI) gin.HandlerFunc {
 			return
 		}
 
-		u, err := svc.ResolveByAuth(id.Subject, cc.Email, cc.FirstName, cc.LastName)
+		u, err := svc.ResolveByAuth(ctx.Request.Context(), id.Subject, cc.Email, cc.EmailVerified, cc.FirstName, cc.LastName)
+		if errors.Is(err, userService.ErrAccountDeleted) {
+			ctx.AbortWithStatusJSON(
+				http.StatusForbidden,
+				errdto.ErrorResponse{
+					Code:    http.StatusForbidden,
+					Message: err.Error()})
+			return
+		}
 		if err != nil {
 			slog.Error("resolver: failed to resolve user", "sub", id.Subject, "error", err)
 			ctx.AbortWithStatus(http.StatusInternalServerError)
 			return
 		}
 		id.UserId = &u.Id
+		setActor(ctx, id)
+
+		//roles assigned in the API add to the ones in the token
+		assigned, err := roles.GetByUserId(ctx.Request.Context(), u.Id)
+		if err != nil {
+			slog.Error("resolver: failed to get user roles", "user-id", u.Id, "error", err)
+			ctx.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+		for _, role := range assigned {
+			if !slices.Contains(id.Roles, role) {
+				id.Roles = append(id.Roles, role)
+			}
+		}
 	}
 }
+
+// setActor attributes the writes made while handling the request to id in
+// the audit log. It runs again once the user is resolved so their id is
+// recorded too.
+func setActor(ctx *gin.Context, id *Identity) {
+	actor := database.Actor{Subject: id.Subject, UserId: id.UserId}
+	ctx.Request = ctx.Request.WithContext(database.WithActor(ctx.Request.Context(), actor))
+}
@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"net/http/httptest"
@@ -8,6 +9,8 @@ import (
 
 	"commerce/api/internal/constants"
 	userdto "commerce/api/internal/dto/user"
+	userService "commerce/api/internal/services/user"
+	"commerce/internal/shared/database"
 
 	"github.com/auth0/go-jwt-middleware/v3/core"
 	"github.com/auth0/go-jwt-middleware/v3/validator"
@@ -19,11 +22,11 @@ import (
 
 // runResolverTest builds a Gin router that:
 //  1. simulates the upstream Gin() middleware by setting Identity in context (if non-nil)
-//  2. chains ResolveIdentity(svc)
+//  2. chains ResolveIdentity(svc, roles)
 //  3. terminates in a sink handler that 200s
 //
 // Tests assert on the response recorder + observe mutations to the Identity pointer.
-func runResolverTest(t *testing.T, id *Identity, claims *Claim, svc *MockUserServiceI) *httptest.ResponseRecorder {
+func runResolverTest(t *testing.T, id *Identity, claims *Claim, svc *MockUserServiceI, roles *MockRoleServiceI) *httptest.ResponseRecorder {
 	t.Helper()
 	gin.SetMode(gin.TestMode)
 	r := gin.New()
@@ -33,7 +36,7 @@ func runResolverTest(t *testing.T, id *Identity, claims *Claim, svc *MockUserSer
 				c.Set(constants.ContextKeys.Identity, id)
 			}
 		},
-		ResolveIdentity(svc),
+		ResolveIdentity(svc, roles),
 		func(c *gin.Context) { c.Status(http.StatusOK) },
 	)
 
@@ -55,9 +58,10 @@ func runResolverTest(t *testing.T, id *Identity, claims *Claim, svc *MockUserSer
 func TestResolveIdentity_NoIdentity_Returns401(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	svc := NewMockUserServiceI(ctrl)
+	roles := NewMockRoleServiceI(ctrl)
 	// svc must not be called — gomock will fail the test if it is
 
-	w := runResolverTest(t, nil, nil, svc)
+	w := runResolverTest(t, nil, nil, svc, roles)
 	assert.Equal(t, http.StatusUnauthorized, w.Code)
 }
 
@@ -65,10 +69,11 @@ func TestResolveIdentity_NoIdentity_Returns401(t *testing.T) {
 func TestResolveIdentity_M2MSub_SkipsLookup(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	svc := NewMockUserServiceI(ctrl)
+	roles := NewMockRoleServiceI(ctrl)
 	// no EXPECT calls — service must not be invoked
 
 	id := &Identity{Subject: "abc123@clients"}
-	w := runResolverTest(t, id, nil, svc)
+	w := runResolverTest(t, id, nil, svc, roles)
 
 	assert.Equal(t, http.StatusOK, w.Code)
 	assert.Nil(t, id.UserId)
@@ -78,11 +83,12 @@ func TestResolveIdentity_M2MSub_SkipsLookup(t *testing.T) {
 func TestResolveIdentity_MissingEmail_Returns401(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	svc := NewMockUserServiceI(ctrl)
+	roles := NewMockRoleServiceI(ctrl)
 	// service must not be called
 
 	id := &Identity{Subject: "auth0|abc123"}
 	claims := &Claim{Scope: "products:read"} // Email is empty
-	w := runResolverTest(t, id, claims, svc)
+	w := runResolverTest(t, id, claims, svc, roles)
 
 	assert.Equal(t, http.StatusUnauthorized, w.Code)
 	assert.Contains(t, w.Body.String(), "non-M2M token missing required")
@@ -93,8 +99,9 @@ func TestResolveIdentity_MissingEmail_Returns401(t *testing.T) {
 func TestResolveIdentity_HappyPath_SetsUserId(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	svc := NewMockUserServiceI(ctrl)
+	roles := NewMockRoleServiceI(ctrl)
 	svc.EXPECT().
-		ResolveByAuth("auth0|abc123", "ali@example.com", "Ali", "Khakpouri").
+		ResolveByAuth(gomock.Any(), "auth0|abc123", "ali@example.com", true, "Ali", "Khakpouri").
 		Return(&userdto.User{
 			Id:        42,
 			Email:     "ali@example.com",
@@ -102,15 +109,17 @@ func TestResolveIdentity_HappyPath_SetsUserId(t *testing.T) {
 			LastName:  "Khakpouri",
 			AuthSub:   "auth0|abc123",
 		}, nil)
+	roles.EXPECT().GetByUserId(gomock.Any(), uint(42)).Return(nil, nil)
 
 	id := &Identity{Subject: "auth0|abc123"}
 	claims := &Claim{
-		Scope:     "products:read",
-		Email:     "ali@example.com",
-		FirstName: "Ali",
-		LastName:  "Khakpouri",
+		Scope:         "products:read",
+		Email:         "ali@example.com",
+		EmailVerified: true,
+		FirstName:     "Ali",
+		LastName:      "Khakpouri",
 	}
-	w := runResolverTest(t, id, claims, svc)
+	w := runResolverTest(t, id, claims, svc, roles)
 
 	require.NotNil(t, id.UserId)
 	assert.Equal(t, uint(42), *id.UserId)
@@ -124,34 +133,136 @@ func TestResolveIdentity_HappyPath_SetsUserId(t *testing.T) {
 func TestResolveIdentity_ServiceError_Returns500(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	svc := NewMockUserServiceI(ctrl)
+	roles := NewMockRoleServiceI(ctrl)
 	svc.EXPECT().
-		ResolveByAuth("auth0|abc123", "ali@example.com", "Ali", "Khakpouri").
+		ResolveByAuth(gomock.Any(), "auth0|abc123", "ali@example.com", true, "Ali", "Khakpouri").
 		Return(nil, errors.New("db down"))
 
 	id := &Identity{Subject: "auth0|abc123"}
 	claims := &Claim{
-		Scope:     "products:read",
-		Email:     "ali@example.com",
-		FirstName: "Ali",
-		LastName:  "Khakpouri",
+		Scope:         "products:read",
+		Email:         "ali@example.com",
+		EmailVerified: true,
+		FirstName:     "Ali",
+		LastName:      "Khakpouri",
 	}
-	w := runResolverTest(t, id, claims, svc)
+	w := runResolverTest(t, id, claims, svc, roles)
 	assert.Nil(t, id.UserId)
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
 	assert.Nil(t, id.UserId)
 }
 
+// A deleted account is refused with 403 rather than failing with 500.
+func TestResolveIdentity_DeletedAccount_Returns403(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc := NewMockUserServiceI(ctrl)
+	roles := NewMockRoleServiceI(ctrl)
+	svc.EXPECT().
+		ResolveByAuth(gomock.Any(), "auth0|abc123", "ali@example.com", true, "Ali", "Khakpouri").
+		Return(nil, userService.ErrAccountDeleted)
+
+	id := &Identity{Subject: "auth0|abc123"}
+	claims := &Claim{
+		Email:         "ali@example.com",
+		EmailVerified: true,
+		FirstName:     "Ali",
+		LastName:      "Khakpouri",
+	}
+	w := runResolverTest(t, id, claims, svc, roles)
+	assert.Nil(t, id.UserId)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
 // 6. Identity set but no claims in request context — Gin() middleware was skipped or
 // failed silently upstream. Resolver should refuse, not panic. (This test will FAIL
 // until the nil-check fix described above is in resolver.go.)
 func TestResolveIdentity_NoClaimsContext_Returns401(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	svc := NewMockUserServiceI(ctrl)
+	roles := NewMockRoleServiceI(ctrl)
 	// service must not be called
 
 	id := &Identity{Subject: "auth0|abc123"}
-	w := runResolverTest(t, id, nil, svc) // claims=nil → no SetClaims call
+	w := runResolverTest(t, id, nil, svc, roles) // claims=nil → no SetClaims call
 
 	assert.Equal(t, http.StatusUnauthorized, w.Code)
 	assert.Nil(t, id.UserId) // for m2m skip + reject cases``
 }
+
+// 7. Roles assigned in the API are added to the ones in the token, without duplicates.
+func TestResolveIdentity_MergesAssignedRoles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc := NewMockUserServiceI(ctrl)
+	roles := NewMockRoleServiceI(ctrl)
+	svc.EXPECT().
+		ResolveByAuth(gomock.Any(), "auth0|abc123", "ali@example.com", true, "Ali", "Khakpouri").
+		Return(&userdto.User{Id: 42}, nil)
+	roles.EXPECT().GetByUserId(gomock.Any(), uint(42)).Return([]string{RoleAdmin, RoleSupport}, nil)
+
+	id := &Identity{Subject: "auth0|abc123", Roles: []string{RoleSupport}}
+	claims := &Claim{
+		Email:         "ali@example.com",
+		EmailVerified: true,
+		FirstName:     "Ali",
+		LastName:      "Khakpouri",
+	}
+	w := runResolverTest(t, id, claims, svc, roles)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.ElementsMatch(t, []string{RoleSupport, RoleAdmin}, id.Roles)
+}
+
+// 8. Failing to load assigned roles is a 500, not a silent downgrade.
+func TestResolveIdentity_RoleError_Returns500(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc := NewMockUserServiceI(ctrl)
+	roles := NewMockRoleServiceI(ctrl)
+	svc.EXPECT().
+		ResolveByAuth(gomock.Any(), "auth0|abc123", "ali@example.com", true, "Ali", "Khakpouri").
+		Return(&userdto.User{Id: 42}, nil)
+	roles.EXPECT().GetByUserId(gomock.Any(), uint(42)).Return(nil, errors.New("db down"))
+
+	id := &Identity{Subject: "auth0|abc123"}
+	claims := &Claim{
+		Email:         "ali@example.com",
+		EmailVerified: true,
+		FirstName:     "Ali",
+		LastName:      "Khakpouri",
+	}
+	w := runResolverTest(t, id, claims, svc, roles)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+// 9. Writes made while resolving and handling the request are audited as the caller.
+func TestResolveIdentity_SetsAuditActor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc := NewMockUserServiceI(ctrl)
+	roles := NewMockRoleServiceI(ctrl)
+	svc.EXPECT().
+		ResolveByAuth(gomock.Any(), "auth0|abc123", "ali@example.com", true, "Ali", "Khakpouri").
+		DoAndReturn(func(ctx context.Context, _, _ string, _ bool, _, _ string) (*userdto.User, error) {
+			actor := database.ActorFrom(ctx)
+			assert.Equal(t, "auth0|abc123", actor.Subject)
+			assert.Nil(t, actor.UserId)
+			return &userdto.User{Id: 42}, nil
+		})
+	roles.EXPECT().GetByUserId(gomock.Any(), uint(42)).
+		DoAndReturn(func(ctx context.Context, _ uint) ([]string, error) {
+			actor := database.ActorFrom(ctx)
+			require.NotNil(t, actor.UserId)
+			assert.Equal(t, uint(42), *actor.UserId)
+			return nil, nil
+		})
+
+	id := &Identity{Subject: "auth0|abc123"}
+	claims := &Claim{
+		Email:         "ali@example.com",
+		EmailVerified: true,
+		FirstName:     "Ali",
+		LastName:      "Khakpouri",
+	}
+	w := runResolverTest(t, id, claims, svc, roles)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
@@ -0,0 +1,73 @@
+package auth
+
+import (
+	errdto "commerce/api/internal/dto/err"
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// RoleCustomer is the default for shoppers. It grants nothing beyond the
+	// scopes in their token.
+	RoleCustomer = "customer"
+	// RoleSupport lets staff look at orders and accounts to help customers
+	// without being able to change them, and fulfil orders.
+	RoleSupport = "support"
+	// RoleAdmin grants every scope and lets a user act on other users'
+	// resources.
+	RoleAdmin = "admin"
+)
+
+// Permissions are the scopes each role grants on top of the ones in the token.
+var Permissions = map[string][]string{
+	RoleCustomer: {},
+	RoleSupport: {
+		Scopes.Category.Read,
+		Scopes.Orders.Read,
+		Scopes.Payment.Read,
+		Scopes.Products.Read,
+		Scopes.Returns.Read,
+		Scopes.Reviews.Read,
+		Scopes.Users.Read,
+	},
+	RoleAdmin: Scopes.All(),
+}
+
+// IsRole reports whether role is one this API knows about.
+func IsRole(role string) bool {
+	_, ok := Permissions[role]
+	return ok
+}
+
+// RequireRole rejects callers that don't hold role.
+func RequireRole(role string) gin.HandlerFunc {
+	return RequireAny(role)
+}
+
+// RequireStaff rejects callers who aren't staff, admin or support. Fulfilling
+// orders goes through it: customers hold orders:write to place orders, which
+// mustn't let them ship their own.
+func RequireStaff() gin.HandlerFunc {
+	return RequireAny(RoleAdmin, RoleSupport)
+}
+
+// RequireAny rejects callers that hold none of roles.
+func RequireAny(roles ...string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id := GetIdentity(ctx)
+		if id == nil {
+			ctx.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+		if !slices.ContainsFunc(roles, id.HasRole) {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, errdto.ErrorResponse{
+				Code:    http.StatusForbidden,
+				Message: "insufficient role",
+			})
+			return
+		}
+		ctx.Next()
+	}
+}
@@ -0,0 +1,62 @@
+package auth
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdentityHasScope(t *testing.T) {
+	customer := &Identity{Scopes: []string{"orders:read"}, Roles: []string{RoleCustomer}}
+	assert.True(t, customer.HasScope("orders:read"))
+	assert.False(t, customer.HasScope("users:read"))
+
+	support := &Identity{Roles: []string{RoleSupport}}
+	assert.True(t, support.HasScope("orders:read"), "support reads orders without the scope in its token")
+	assert.False(t, support.HasScope("orders:write"))
+
+	admin := &Identity{Roles: []string{RoleAdmin}}
+	assert.True(t, admin.HasScope("users:delete"))
+
+	unknown := &Identity{Roles: []string{"superuser"}}
+	assert.False(t, unknown.HasScope("orders:read"), "unknown roles grant nothing")
+}
+
+func TestIsRole(t *testing.T) {
+	assert.True(t, IsRole(RoleSupport))
+	assert.False(t, IsRole("superuser"))
+}
+
+func TestRequireScope_GrantedByRole(t *testing.T) {
+	r := newPolicyRouter(&Identity{Roles: []string{RoleSupport}}, "/orders", RequireScope(Scopes.Orders.Read))
+	assert.Equal(t, http.StatusOK, serve(r, "/orders"))
+
+	r = newPolicyRouter(&Identity{Roles: []string{RoleSupport}}, "/orders", RequireScope(Scopes.Orders.Write))
+	assert.Equal(t, http.StatusForbidden, serve(r, "/orders"))
+}
+
+func TestRequireAny(t *testing.T) {
+	guard := RequireAny(RoleSupport, RoleAdmin)
+
+	assert.Equal(t, http.StatusOK, serve(newPolicyRouter(&Identity{Roles: []string{RoleAdmin}}, "/x", guard), "/x"))
+	assert.Equal(t, http.StatusOK, serve(newPolicyRouter(&Identity{Roles: []string{RoleSupport}}, "/x", guard), "/x"))
+	assert.Equal(t, http.StatusForbidden, serve(newPolicyRouter(&Identity{Roles: []string{RoleCustomer}}, "/x", guard), "/x"))
+	assert.Equal(t, http.StatusUnauthorized, serve(newPolicyRouter(nil, "/x", guard), "/x"))
+}
+
+func TestRequireStaff(t *testing.T) {
+	guard := RequireStaff()
+	customer := &Identity{Scopes: []string{Scopes.Orders.Write}, Roles: []string{RoleCustomer}}
+
+	assert.Equal(t, http.StatusForbidden, serve(newPolicyRouter(customer, "/x", guard), "/x"), "orders:write doesn't make a customer staff")
+	assert.Equal(t, http.StatusOK, serve(newPolicyRouter(&Identity{Roles: []string{RoleSupport}}, "/x", guard), "/x"))
+	assert.Equal(t, http.StatusOK, serve(newPolicyRouter(&Identity{Roles: []string{RoleAdmin}}, "/x", guard), "/x"))
+}
+
+func TestRequireRole(t *testing.T) {
+	guard := RequireRole(RoleAdmin)
+
+	assert.Equal(t, http.StatusOK, serve(newPolicyRouter(&Identity{Roles: []string{RoleAdmin}}, "/x", guard), "/x"))
+	assert.Equal(t, http.StatusForbidden, serve(newPolicyRouter(&Identity{Roles: []string{RoleSupport}}, "/x", guard), "/x"))
+}
@@ -1,5 +1,7 @@
 package auth
 
+import "slices"
+
 type rwScopes struct {
 	Read, Write string
 }
@@ -9,8 +11,8 @@ type userScopes struct {
 }
 
 type scopes struct {
-	Category, Orders, Payment, Products, Reviews rwScopes
-	Users                                        userScopes
+	Category, Orders, Payment, Products, Returns, Reviews rwScopes
+	Users                                                 userScopes
 }
 
 var Scopes = scopes{
@@ -18,6 +20,25 @@ var Scopes = scopes{
 	Orders:   rwScopes{Read: "orders:read", Write: "orders:write"},
 	Payment:  rwScopes{Read: "payment:read", Write: "payment:write"},
 	Products: rwScopes{Read: "products:read", Write: "products:write"},
+	Returns:  rwScopes{Read: "returns:read", Write: "returns:write"},
 	Reviews:  rwScopes{Read: "reviews:read", Write: "reviews:write"},
 	Users:    userScopes{Read: "users:read", Write: "users:write", Delete: "users:delete"},
 }
+
+// All lists every scope the API checks.
+func (s scopes) All() []string {
+	return []string{
+		s.Category.Read, s.Category.Write,
+		s.Orders.Read, s.Orders.Write,
+		s.Payment.Read, s.Payment.Write,
+		s.Products.Read, s.Products.Write,
+		s.Returns.Read, s.Returns.Write,
+		s.Reviews.Read, s.Reviews.Write,
+		s.Users.Read, s.Users.Write, s.Users.Delete,
+	}
+}
+
+// IsScope reports whether scope is one this API checks.
+func IsScope(scope string) bool {
+	return slices.Contains(Scopes.All(), scope)
+}
@@ -1,13 +1,16 @@
 package auth
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/auth0/go-jwt-middleware/v3/jwks"
 	"github.com/auth0/go-jwt-middleware/v3/validator"
+	"github.com/lestrrat-go/jwx/v3/jwk"
 )
 
 // Signature verification - Using Auth0’s public keys from JWKS
@@ -15,28 +18,28 @@ import (
 // Audience validation - aud claim matches your API identifier
 // Expiration check - Token hasn’t expired (exp claim)
 // Time validity - Token is currently valid (nbf and iat claims)
-func NewValidator(domain, audience string) (*validator.Validator, error) {
+//
+// keySet overrides where the public keys come from. Empty uses the tenant's
+// JWKS, an http(s) URL fetches from there, and anything else is read once as
+// a JWKS file, which is how development runs without Auth0 (see utils jwks).
+func NewValidator(domain, audience, rolesClaim, keySet string) (*validator.Validator, error) {
 	issuer, err := url.Parse("https://" + domain + "/")
 	if err != nil {
 		slog.Error("failed to parse the url", "error", err, "domain", domain)
 		return nil, fmt.Errorf("failed to parse the url %q %w", domain, err)
 	}
-	provider, err := jwks.NewCachingProvider(
-		jwks.WithIssuerURL(issuer),
-		jwks.WithCacheTTL(5*time.Minute),
-	)
+	keyFunc, err := newKeyFunc(issuer, keySet)
 	if err != nil {
-		slog.Error("failed to create a JWKS provider", "error", err)
-		return nil, fmt.Errorf("failed to create a JWKS provider %q: %w", domain, err)
+		return nil, err
 	}
 
 	jwtValidator, err := validator.New(
-		validator.WithKeyFunc(provider.KeyFunc),
+		validator.WithKeyFunc(keyFunc),
 		validator.WithAlgorithm(validator.RS256),
 		validator.WithIssuer(issuer.String()),
 		validator.WithAudience(audience),
 		validator.WithCustomClaims(func() validator.CustomClaims {
-			return &Claim{}
+			return NewClaim(rolesClaim)
 		}),
 	)
 	if err != nil {
@@ -46,3 +49,31 @@ func NewValidator(domain, audience string) (*validator.Validator, error) {
 
 	return jwtValidator, nil
 }
+
+func newKeyFunc(issuer *url.URL, keySet string) (func(context.Context) (any, error), error) {
+	if keySet != "" && !strings.HasPrefix(keySet, "https://") && !strings.HasPrefix(keySet, "http://") {
+		set, err := jwk.ReadFile(keySet)
+		if err != nil {
+			slog.Error("failed to read the JWKS file", "error", err, "path", keySet)
+			return nil, fmt.Errorf("failed to read the JWKS file %q: %w", keySet, err)
+		}
+		slog.Warn("validating tokens against a local JWKS file", "path", keySet)
+		return func(context.Context) (any, error) { return set, nil }, nil
+	}
+
+	options := []any{jwks.WithIssuerURL(issuer), jwks.WithCacheTTL(5 * time.Minute)}
+	if keySet != "" {
+		uri, err := url.Parse(keySet)
+		if err != nil {
+			slog.Error("failed to parse the JWKS url", "error", err, "url", keySet)
+			return nil, fmt.Errorf("failed to parse the JWKS url %q: %w", keySet, err)
+		}
+		options = append(options, jwks.WithCustomJWKSURI(uri))
+	}
+	provider, err := jwks.NewCachingProvider(options...)
+	if err != nil {
+		slog.Error("failed to create a JWKS provider", "error", err)
+		return nil, fmt.Errorf("failed to create a JWKS provider %q: %w", issuer.Host, err)
+	}
+	return provider.KeyFunc, nil
+}
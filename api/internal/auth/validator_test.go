@@ -0,0 +1,104 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/auth0/go-jwt-middleware/v3/validator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDomain = "commerce.local"
+
+func signRS256(t *testing.T, key *rsa.PrivateKey, kid string, claims map[string]any) string {
+	t.Helper()
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": kid})
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+	body := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(body))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+	return body + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func writeJWKS(t *testing.T, key *rsa.PublicKey, kid string) string {
+	t.Helper()
+	set := map[string]any{"keys": []map[string]string{{
+		"kty": "RSA",
+		"use": "sig",
+		"alg": "RS256",
+		"kid": kid,
+		"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}}}
+	data, err := json.Marshal(set)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, data, 0o644))
+	return path
+}
+
+func devClaims() map[string]any {
+	now := time.Now()
+	return map[string]any{
+		"iss":                        "https://" + testDomain + "/",
+		"aud":                        []string{testAudience},
+		"sub":                        testSubject,
+		"iat":                        now.Unix(),
+		"exp":                        now.Add(time.Hour).Unix(),
+		"scope":                      "orders:read",
+		"email":                      "dev@example.com",
+		"https://commerce.api/roles": []string{RoleSupport},
+	}
+}
+
+func TestNewValidator_JWKSFile(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	jwksPath := writeJWKS(t, &key.PublicKey, "dev")
+
+	v, err := NewValidator(testDomain, testAudience, "", jwksPath)
+	require.NoError(t, err)
+
+	validated, err := v.ValidateToken(context.Background(), signRS256(t, key, "dev", devClaims()))
+	require.NoError(t, err)
+	vc, ok := validated.(*validator.ValidatedClaims)
+	require.True(t, ok)
+	assert.Equal(t, testSubject, vc.RegisteredClaims.Subject)
+	cc, ok := vc.CustomClaims.(*Claim)
+	require.True(t, ok)
+	assert.Equal(t, "orders:read", cc.Scope)
+	assert.Equal(t, []string{RoleSupport}, cc.Roles)
+}
+
+func TestNewValidator_JWKSFile_RejectsOtherKey(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	jwksPath := writeJWKS(t, &key.PublicKey, "dev")
+
+	v, err := NewValidator(testDomain, testAudience, "", jwksPath)
+	require.NoError(t, err)
+
+	_, err = v.ValidateToken(context.Background(), signRS256(t, other, "dev", devClaims()))
+	assert.Error(t, err)
+}
+
+func TestNewValidator_MissingJWKSFile(t *testing.T) {
+	_, err := NewValidator(testDomain, testAudience, "", filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,72 @@
+package carrier
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Carrier is implemented by every shipping carrier integration. Calls may go
+// over the network, hence the context.
+type Carrier interface {
+	// Code is the value stored in Shipment.Carrier for shipments handled by this carrier.
+	Code() string
+	Rate(ctx context.Context, request RateRequest) ([]Rate, error)
+	CreateLabel(ctx context.Context, request LabelRequest) (*Label, error)
+	Track(ctx context.Context, trackingNumber string) (*Tracking, error)
+}
+
+type TrackingStatus string
+
+const (
+	TrackingStatusLabelCreated   TrackingStatus = "label_created"
+	TrackingStatusInTransit      TrackingStatus = "in_transit"
+	TrackingStatusOutForDelivery TrackingStatus = "out_for_delivery"
+	TrackingStatusDelivered      TrackingStatus = "delivered"
+)
+
+type RateRequest struct {
+	Weight float64
+	State  string
+}
+
+type Rate struct {
+	Service       string
+	Amount        float64
+	EstimatedDays int
+}
+
+type LabelRequest struct {
+	OrderId uint
+	Method  string
+	State   string
+}
+
+type Label struct {
+	TrackingNumber string
+}
+
+type Tracking struct {
+	TrackingNumber string
+	Status         TrackingStatus
+	UpdatedDate    time.Time
+}
+
+// Registry looks carriers up by their code.
+type Registry map[string]Carrier
+
+func NewRegistry(carriers ...Carrier) Registry {
+	r := make(Registry, len(carriers))
+	for _, c := range carriers {
+		r[c.Code()] = c
+	}
+	return r
+}
+
+func (r Registry) Get(code string) (Carrier, error) {
+	c, ok := r[code]
+	if !ok {
+		return nil, fmt.Errorf("carrier %q is not supported", code)
+	}
+	return c, nil
+}
@@ -0,0 +1,93 @@
+package carrier
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const SimulatorCode = "simulator"
+
+// Simulator is a local, deterministic carrier for development and tests. Its
+// tracking numbers embed the time the label was created, and a parcel moves
+// one tracking status forward every step after that, so the same tracking
+// number reports the same status at the same moment across restarts.
+type Simulator struct {
+	step  time.Duration
+	clock func() time.Time
+	seq   atomic.Uint32
+}
+
+var simulatorProgress = []TrackingStatus{
+	TrackingStatusLabelCreated,
+	TrackingStatusInTransit,
+	TrackingStatusOutForDelivery,
+	TrackingStatusDelivered,
+}
+
+// NewSimulator panics unless step is positive.
+func NewSimulator(step time.Duration, clock func() time.Time) Carrier {
+	if step <= 0 {
+		panic(fmt.Sprintf("carrier simulator step must be positive, got %s", step))
+	}
+	return &Simulator{step: step, clock: clock}
+}
+
+// Code implements [Carrier].
+func (s *Simulator) Code() string {
+	return SimulatorCode
+}
+
+// Rate implements [Carrier].
+func (s *Simulator) Rate(_ context.Context, request RateRequest) ([]Rate, error) {
+	weight := math.Max(1, math.Ceil(request.Weight))
+	return []Rate{
+		{Service: "ground", Amount: round(4.99 + 0.75*weight), EstimatedDays: 5},
+		{Service: "express", Amount: round(14.99 + 1.50*weight), EstimatedDays: 2},
+	}, nil
+}
+
+// CreateLabel implements [Carrier].
+func (s *Simulator) CreateLabel(_ context.Context, _ LabelRequest) (*Label, error) {
+	seq := s.seq.Add(1) % 10000
+	return &Label{
+		TrackingNumber: fmt.Sprintf("SIM-%d-%04d", s.clock().Unix(), seq),
+	}, nil
+}
+
+// Track implements [Carrier].
+func (s *Simulator) Track(_ context.Context, trackingNumber string) (*Tracking, error) {
+	created, err := parseSimulatorTrackingNumber(trackingNumber)
+	if err != nil {
+		return nil, err
+	}
+	stage := 0
+	if elapsed := s.clock().Sub(created); elapsed > 0 {
+		stage = min(int(elapsed/s.step), len(simulatorProgress)-1)
+	}
+	return &Tracking{
+		TrackingNumber: trackingNumber,
+		Status:         simulatorProgress[stage],
+		UpdatedDate:    created.Add(time.Duration(stage) * s.step),
+	}, nil
+}
+
+func parseSimulatorTrackingNumber(trackingNumber string) (time.Time, error) {
+	parts := strings.Split(trackingNumber, "-")
+	if len(parts) != 3 || parts[0] != "SIM" {
+		return time.Time{}, fmt.Errorf("invalid simulator tracking number: %q", trackingNumber)
+	}
+	seconds, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid simulator tracking number: %q", trackingNumber)
+	}
+	return time.Unix(seconds, 0), nil
+}
+
+func round(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,65 @@
+package carrier
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimulatorAdvancesTracking(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	sim := NewSimulator(time.Hour, func() time.Time { return now })
+
+	label, err := sim.CreateLabel(context.Background(), LabelRequest{OrderId: 1})
+	assert.NoError(t, err)
+
+	expected := []TrackingStatus{
+		TrackingStatusLabelCreated,
+		TrackingStatusInTransit,
+		TrackingStatusOutForDelivery,
+		TrackingStatusDelivered,
+		TrackingStatusDelivered,
+	}
+	for i, status := range expected {
+		tracking, err := sim.Track(context.Background(), label.TrackingNumber)
+		assert.NoError(t, err)
+		assert.Equal(t, status, tracking.Status, "status after %d steps", i)
+		now = now.Add(time.Hour)
+	}
+}
+
+func TestSimulatorIsDeterministic(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+	label, _ := NewSimulator(time.Hour, clock).CreateLabel(context.Background(), LabelRequest{})
+
+	now = now.Add(150 * time.Minute)
+	first, _ := NewSimulator(time.Hour, clock).Track(context.Background(), label.TrackingNumber)
+	second, _ := NewSimulator(time.Hour, clock).Track(context.Background(), label.TrackingNumber)
+	assert.Equal(t, first, second)
+	assert.Equal(t, TrackingStatusOutForDelivery, first.Status)
+}
+
+func TestSimulatorInvalidTrackingNumber(t *testing.T) {
+	sim := NewSimulator(time.Hour, time.Now)
+	tracking, err := sim.Track(context.Background(), "1Z999AA10123456784")
+	assert.Error(t, err)
+	assert.Nil(t, tracking)
+}
+
+func TestSimulatorRejectsNonPositiveStep(t *testing.T) {
+	assert.Panics(t, func() { NewSimulator(0, time.Now) })
+	assert.Panics(t, func() { NewSimulator(-time.Hour, time.Now) })
+}
+
+func TestRegistry(t *testing.T) {
+	registry := NewRegistry(NewSimulator(time.Hour, time.Now))
+	c, err := registry.Get(SimulatorCode)
+	assert.NoError(t, err)
+	assert.Equal(t, SimulatorCode, c.Code())
+
+	_, err = registry.Get("ups")
+	assert.Error(t, err)
+}
@@ -15,11 +15,23 @@ var EnvKeys = envKeys{
 	DBSchema:          "DB_SCHEMA",
 	AuthDomain:        "AUTH_DOMAIN",
 	AuthAudience:      "AUTH_AUDIENCE",
+	AuthRolesClaim:    "AUTH_ROLES_CLAIM",
+	AuthJWKS:          "AUTH_JWKS",
+	CarrierPoll:       "CARRIER_POLL_INTERVAL",
+	CarrierSimStep:    "CARRIER_SIMULATOR_STEP",
+	ErasureInterval:   "ERASURE_INTERVAL",
+	OrderNumberPrefix: "ORDER_NUMBER_PREFIX",
+	MediaDir:          "MEDIA_DIR",
+	MediaURL:          "MEDIA_URL",
+	MediaMaxUpload:    "MEDIA_MAX_UPLOAD_BYTES",
+	ImportInterval:    "IMPORT_INTERVAL",
+	ImportMaxUpload:   "IMPORT_MAX_UPLOAD_BYTES",
 }
 
 var Headers = headers{
 	Origin:        "Origin",
 	ContentLength: "Content-Length",
+	ApiKey:        "X-Api-Key",
 }
 
 var ContextKeys = contextKeys{
@@ -45,9 +57,21 @@ type envKeys struct {
 	DBSchema          string
 	AuthDomain        string
 	AuthAudience      string
+	AuthRolesClaim    string
+	AuthJWKS          string
+	CarrierPoll       string
+	CarrierSimStep    string
+	ErasureInterval   string
+	OrderNumberPrefix string
+	MediaDir          string
+	MediaURL          string
+	MediaMaxUpload    string
+	ImportInterval    string
+	ImportMaxUpload   string
 }
 
 type headers struct {
 	Origin        string
 	ContentLength string
+	ApiKey        string
 }
@@ -5,11 +5,11 @@ import "commerce/internal/shared/models"
 type Address struct {
 	Id         uint   `json:"id"`
 	UserId     uint   `json:"user_id"`
-	Street     string `json:"street"`
-	City       string `json:"city"`
-	State      string `json:"state"`
-	PostalCode string `json:"postal_code"`
-	Country    string `json:"country"`
+	Street     string `json:"street" binding:"required,max=255"`
+	City       string `json:"city" binding:"required,max=100"`
+	State      string `json:"state" binding:"required,max=50"`
+	PostalCode string `json:"postal_code" binding:"required,max=15"`
+	Country    string `json:"country" binding:"required,max=75"`
 	IsDefault  bool   `json:"is_default"`
 }
 
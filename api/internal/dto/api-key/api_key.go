@@ -0,0 +1,49 @@
+package apikey
+
+import (
+	"commerce/internal/shared/models"
+	"strings"
+	"time"
+)
+
+type ApiKey struct {
+	Id           uint       `json:"id"`
+	Name         string     `json:"name"`
+	Owner        string     `json:"owner"`
+	Prefix       string     `json:"prefix"`
+	Scopes       []string   `json:"scopes"`
+	CreatedDate  time.Time  `json:"created_date"`
+	ExpiresDate  *time.Time `json:"expires_date,omitempty"`
+	LastUsedDate *time.Time `json:"last_used_date,omitempty"`
+	RevokedDate  *time.Time `json:"revoked_date,omitempty"`
+}
+
+// CreateApiKey is the request to issue a key. A key without an expiry date
+// stays valid until it is revoked.
+type CreateApiKey struct {
+	Name        string     `json:"name" binding:"required,max=100"`
+	Owner       string     `json:"owner" binding:"required,max=100"`
+	Scopes      []string   `json:"scopes" binding:"required,min=1"`
+	ExpiresDate *time.Time `json:"expires_date"`
+}
+
+// IssuedApiKey carries the full key. It is only returned when a key is
+// created or rotated; the API can't show it again.
+type IssuedApiKey struct {
+	ApiKey
+	Key string `json:"key"`
+}
+
+func FromModel(apiKey *models.ApiKey) *ApiKey {
+	return &ApiKey{
+		Id:           apiKey.Id,
+		Name:         apiKey.Name,
+		Owner:        apiKey.Owner,
+		Prefix:       apiKey.Prefix,
+		Scopes:       strings.Fields(apiKey.Scopes),
+		CreatedDate:  apiKey.CreatedDate,
+		ExpiresDate:  apiKey.ExpiresDate,
+		LastUsedDate: apiKey.LastUsedDate,
+		RevokedDate:  apiKey.RevokedDate,
+	}
+}
@@ -0,0 +1,52 @@
+package auditevent
+
+import (
+	"commerce/internal/shared/models"
+	"encoding/json"
+	"time"
+)
+
+type AuditEvent struct {
+	Id          uint            `json:"id"`
+	Subject     string          `json:"subject"`
+	UserId      *uint           `json:"user_id,omitempty"`
+	Action      string          `json:"action"`
+	EntityType  string          `json:"entity_type"`
+	EntityId    uint            `json:"entity_id"`
+	Before      json.RawMessage `json:"before,omitempty" swaggertype:"object"`
+	After       json.RawMessage `json:"after,omitempty" swaggertype:"object"`
+	CreatedDate time.Time       `json:"created_date"`
+}
+
+// AuditEventQuery filters the audit log. Dates are RFC 3339; from is
+// inclusive and to exclusive. Paging is read separately, as on other lists.
+type AuditEventQuery struct {
+	Subject    string     `form:"subject"`
+	UserId     *uint      `form:"user_id"`
+	Action     string     `form:"action" binding:"omitempty,oneof=create update delete"`
+	EntityType string     `form:"entity_type"`
+	EntityId   *uint      `form:"entity_id"`
+	From       *time.Time `form:"from"`
+	To         *time.Time `form:"to"`
+}
+
+func FromModel(event *models.AuditEvent) *AuditEvent {
+	return &AuditEvent{
+		Id:          event.Id,
+		Subject:     event.Subject,
+		UserId:      event.UserId,
+		Action:      string(event.Action),
+		EntityType:  event.EntityType,
+		EntityId:    event.EntityId,
+		Before:      raw(event.Before),
+		After:       raw(event.After),
+		CreatedDate: event.CreatedDate,
+	}
+}
+
+func raw(diff *string) json.RawMessage {
+	if diff == nil {
+		return nil
+	}
+	return json.RawMessage(*diff)
+}
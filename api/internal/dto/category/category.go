@@ -4,9 +4,9 @@ import "commerce/internal/shared/models"
 
 type Category struct {
 	Id          uint   `json:"id"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Slug        string `json:"slug"`
+	Name        string `json:"name" binding:"required,max=255"`
+	Description string `json:"description" binding:"max=255"`
+	Slug        string `json:"slug" binding:"max=100"`
 	ParentId    *uint  `json:"parent_id"`
 	IsActive    bool   `json:"is_active"`
 }
@@ -0,0 +1,87 @@
+package invoice
+
+import (
+	"commerce/api/internal/dto/order"
+	"commerce/internal/shared/models"
+	"time"
+)
+
+type Invoice struct {
+	Id                uint               `json:"id"`
+	InvoiceNumber     string             `json:"invoice_number"`
+	Type              string             `json:"type"`
+	OrderId           uint               `json:"order_id"`
+	OrderNumber       string             `json:"order_number"`
+	CreditedInvoiceId *uint              `json:"credited_invoice_id,omitempty"`
+	Reason            string             `json:"reason,omitempty"`
+	IssuedDate        time.Time          `json:"issued_date"`
+	BillingAddress    order.OrderAddress `json:"billing_address"`
+	ShippingAddress   order.OrderAddress `json:"shipping_address"`
+	Currency          string             `json:"currency"`
+	Lines             []InvoiceLine      `json:"lines"`
+	SubTotalAmount    float64            `json:"sub_total_amount"`
+	ShippingAmount    float64            `json:"shipping_amount"`
+	Tax               TaxBreakdown       `json:"tax"`
+	TotalAmount       float64            `json:"total_amount"`
+}
+
+type InvoiceLine struct {
+	ProductId   *uint   `json:"product_id,omitempty"`
+	Description string  `json:"description"`
+	Quantity    int     `json:"quantity"`
+	UnitPrice   float64 `json:"unit_price"`
+	Amount      float64 `json:"amount"`
+}
+
+// TaxBreakdown shows how the tax on an invoice was arrived at: the rate of
+// the billing state applied to the taxable amount, which includes shipping
+// only in states that tax it.
+type TaxBreakdown struct {
+	State         string  `json:"state"`
+	Rate          float64 `json:"rate"`
+	TaxableAmount float64 `json:"taxable_amount"`
+	Amount        float64 `json:"amount"`
+}
+
+func FromModel(invoice *models.Invoice) *Invoice {
+	lines := make([]InvoiceLine, len(invoice.Lines))
+	for i, line := range invoice.Lines {
+		lines[i] = InvoiceLine{
+			ProductId:   line.ProductId,
+			Description: line.Description,
+			Quantity:    line.Quantity,
+			UnitPrice:   line.UnitPrice,
+			Amount:      line.Amount,
+		}
+	}
+
+	return &Invoice{
+		Id:                invoice.Id,
+		InvoiceNumber:     invoice.InvoiceNumber,
+		Type:              string(invoice.Type),
+		OrderId:           invoice.OrderId,
+		OrderNumber:       invoice.OrderNumber,
+		CreditedInvoiceId: invoice.CreditedInvoiceId,
+		Reason:            invoice.Reason,
+		IssuedDate:        invoice.IssuedDate,
+		BillingAddress:    *order.AddressFromModel(&invoice.BillingAddress),
+		ShippingAddress:   *order.AddressFromModel(&invoice.ShippingAddress),
+		Currency:          invoice.Currency,
+		Lines:             lines,
+		SubTotalAmount:    invoice.SubTotalAmount,
+		ShippingAmount:    invoice.ShippingAmount,
+		Tax: TaxBreakdown{
+			State:         invoice.TaxState,
+			Rate:          invoice.TaxRate,
+			TaxableAmount: invoice.TaxableAmount,
+			Amount:        invoice.TaxAmount,
+		},
+		TotalAmount: invoice.TotalAmount,
+	}
+}
+
+// IsCreditNote reports whether the document gives money back rather than
+// asking for it.
+func (i *Invoice) IsCreditNote() bool {
+	return i.Type == string(models.InvoiceTypeCreditNote)
+}
@@ -6,7 +6,8 @@ type OrderItem struct {
 	Id        uint    `json:"id"`
 	OrderId   uint    `json:"order_id"`
 	ProductId uint    `json:"product_id"`
-	Quantity  int     `json:"quantity"`
+	VariantId *uint   `json:"variant_id,omitempty"`
+	Quantity  int     `json:"quantity" binding:"required,gt=0"`
 	UnitPrice float64 `json:"unit_price"`
 }
 
@@ -15,6 +16,7 @@ func FromModel(orderItem *models.OrderItem) *OrderItem {
 		Id:        orderItem.Id,
 		OrderId:   orderItem.OrderId,
 		ProductId: orderItem.ProductId,
+		VariantId: orderItem.VariantId,
 		Quantity:  orderItem.Quantity,
 		UnitPrice: orderItem.UnitPrice,
 	}
@@ -27,6 +29,7 @@ func ToModel(orderItem *OrderItem) *models.OrderItem {
 		},
 		OrderId:   orderItem.OrderId,
 		ProductId: orderItem.ProductId,
+		VariantId: orderItem.VariantId,
 		Quantity:  orderItem.Quantity,
 		UnitPrice: orderItem.UnitPrice,
 	}
@@ -0,0 +1,5 @@
+package order
+
+type CancelOrder struct {
+	Reason string `json:"reason" binding:"required"`
+}
@@ -2,18 +2,28 @@ package order
 
 import (
 	orderitem "commerce/api/internal/dto/order-item"
+	"commerce/api/internal/dto/shipment"
 	"commerce/internal/shared/models"
+	"time"
 )
 
 type Order struct {
-	Id             uint                  `json:"id"`
-	UserId         uint                  `json:"user_id"`
-	Status         string                `json:"status"`
-	TaxAmount      float64               `json:"tax_amount"`
-	TotalAmount    float64               `json:"total_amount"`
-	SubTotalAmount float64               `json:"sub_total_amount"`
-	BillingState   string                `json:"billing_state"`
-	OrderItems     []orderitem.OrderItem `json:"order_items,omitempty"`
+	Id              uint                  `json:"id"`
+	OrderNumber     string                `json:"order_number"`
+	UserId          uint                  `json:"user_id"`
+	Status          string                `json:"status"`
+	CancelReason    string                `json:"cancel_reason,omitempty"`
+	CancelledDate   *time.Time            `json:"cancelled_date,omitempty"`
+	TaxAmount       float64               `json:"tax_amount"`
+	TotalAmount     float64               `json:"total_amount"`
+	SubTotalAmount  float64               `json:"sub_total_amount"`
+	ShippingAmount  float64               `json:"shipping_amount"`
+	ShippingMethod  string                `json:"shipping_method,omitempty"`
+	BillingAddress  OrderAddress          `json:"billing_address"`
+	ShippingAddress OrderAddress          `json:"shipping_address"`
+	OrderItems      []orderitem.OrderItem `json:"order_items,omitempty" binding:"dive"`
+	Shipments       []shipment.Shipment   `json:"shipments,omitempty"`
+	DeletedDate     *time.Time            `json:"deleted_date,omitempty"`
 }
 
 func FromModel(order *models.Order) *Order {
@@ -21,16 +31,28 @@ func FromModel(order *models.Order) *Order {
 	for i, item := range order.OrderItems {
 		orderItems[i] = *orderitem.FromModel(&item)
 	}
+	shipments := make([]shipment.Shipment, len(order.Shipments))
+	for i, s := range order.Shipments {
+		shipments[i] = *shipment.FromModel(&s)
+	}
 
 	return &Order{
-		Id:             order.Id,
-		UserId:         order.UserId,
-		TotalAmount:    order.TotalAmount,
-		TaxAmount:      order.TaxAmount,
-		Status:         string(order.Status),
-		OrderItems:     orderItems,
-		SubTotalAmount: order.SubTotalAmount,
-		BillingState:   order.BillingAddress.State,
+		Id:              order.Id,
+		OrderNumber:     order.OrderNumber,
+		UserId:          order.UserId,
+		TotalAmount:     order.TotalAmount,
+		TaxAmount:       order.TaxAmount,
+		Status:          string(order.Status),
+		CancelReason:    order.CancelReason,
+		CancelledDate:   order.CancelledDate,
+		OrderItems:      orderItems,
+		SubTotalAmount:  order.SubTotalAmount,
+		ShippingAmount:  order.ShippingAmount,
+		ShippingMethod:  order.ShippingMethod,
+		BillingAddress:  *AddressFromModel(&order.BillingAddress),
+		ShippingAddress: *AddressFromModel(&order.ShippingAddress),
+		Shipments:       shipments,
+		DeletedDate:     order.DeletedTime(),
 	}
 }
 
@@ -41,11 +63,16 @@ func ToModel(order *Order) *models.Order {
 	}
 
 	return &models.Order{
-		UserId:         order.UserId,
-		TotalAmount:    order.TotalAmount,
-		TaxAmount:      order.TaxAmount,
-		Status:         models.OrderStatus(order.Status),
-		SubTotalAmount: order.SubTotalAmount,
-		OrderItems:     orderItems,
+		OrderNumber:     order.OrderNumber,
+		UserId:          order.UserId,
+		TotalAmount:     order.TotalAmount,
+		TaxAmount:       order.TaxAmount,
+		Status:          models.OrderStatus(order.Status),
+		SubTotalAmount:  order.SubTotalAmount,
+		ShippingAmount:  order.ShippingAmount,
+		ShippingMethod:  order.ShippingMethod,
+		BillingAddress:  AddressToModel(&order.BillingAddress),
+		ShippingAddress: AddressToModel(&order.ShippingAddress),
+		OrderItems:      orderItems,
 	}
 }
@@ -0,0 +1,37 @@
+package order
+
+import "commerce/internal/shared/models"
+
+// OrderAddress is either a reference to an address book entry, by address_id,
+// or an inline address. Orders always return the inline snapshot, along with
+// the address_id it was copied from when there was one.
+type OrderAddress struct {
+	AddressId  *uint  `json:"address_id,omitempty"`
+	Street     string `json:"street,omitempty"`
+	City       string `json:"city,omitempty"`
+	State      string `json:"state,omitempty"`
+	PostalCode string `json:"postal_code,omitempty"`
+	Country    string `json:"country,omitempty"`
+}
+
+func AddressFromModel(address *models.AddressSnapshot) *OrderAddress {
+	return &OrderAddress{
+		AddressId:  address.AddressId,
+		Street:     address.Street,
+		City:       address.City,
+		State:      address.State,
+		PostalCode: address.PostalCode,
+		Country:    address.Country,
+	}
+}
+
+func AddressToModel(address *OrderAddress) models.AddressSnapshot {
+	return models.AddressSnapshot{
+		AddressId:  address.AddressId,
+		Street:     address.Street,
+		City:       address.City,
+		State:      address.State,
+		PostalCode: address.PostalCode,
+		Country:    address.Country,
+	}
+}
@@ -0,0 +1,25 @@
+package page
+
+import "commerce/internal/shared/repositories/query"
+
+// Page is the envelope of every list response. NextCursor is passed back as
+// the cursor parameter to get the following page, and is null on the last
+// one. Total counts every item the filters match, across all pages.
+type Page[T any] struct {
+	Items      []*T    `json:"items"`
+	NextCursor *string `json:"next_cursor"`
+	Total      int64   `json:"total"`
+}
+
+// FromPage converts a page of models with the DTO package's FromModel.
+func FromPage[M any, T any](p *query.Page[M], fromModel func(*M) *T) *Page[T] {
+	items := make([]*T, 0, len(p.Items))
+	for _, item := range p.Items {
+		items = append(items, fromModel(item))
+	}
+	page := &Page[T]{Items: items, Total: p.Total}
+	if p.NextCursor != "" {
+		page.NextCursor = &p.NextCursor
+	}
+	return page
+}
@@ -6,25 +6,27 @@ import (
 )
 
 type Payment struct {
-	Id            uint    `json:"id"`
-	OrderId       uint    `json:"order_id"`
-	Amount        float64 `json:"amount"`
-	PaymentMethod string  `json:"payment_method"`
-	Status        string  `json:"status"`
-	Currency      string  `json:"currency"`
-	Gateway       string  `json:"gateway"`
-	PaidAt        string  `json:"paid_at"`
+	Id             uint    `json:"id"`
+	OrderId        uint    `json:"order_id"`
+	Amount         float64 `json:"amount"`
+	RefundedAmount float64 `json:"refunded_amount"`
+	PaymentMethod  string  `json:"payment_method"`
+	Status         string  `json:"status"`
+	Currency       string  `json:"currency"`
+	Gateway        string  `json:"gateway"`
+	PaidAt         string  `json:"paid_at"`
 }
 
 func FromModel(payment *models.Payment) *Payment {
 	return &Payment{
-		Id:            payment.Id,
-		OrderId:       payment.OrderId,
-		Amount:        payment.Amount,
-		PaymentMethod: string(payment.PaymentMethod),
-		Status:        string(payment.Status),
-		Currency:      payment.Currency,
-		Gateway:       string(payment.PaymentGateway),
+		Id:             payment.Id,
+		OrderId:        payment.OrderId,
+		Amount:         payment.Amount,
+		RefundedAmount: payment.RefundedAmount,
+		PaymentMethod:  string(payment.PaymentMethod),
+		Status:         string(payment.Status),
+		Currency:       payment.Currency,
+		Gateway:        string(payment.PaymentGateway),
 		PaidAt: func() string {
 			if payment.PaidAt != nil {
 				return payment.PaidAt.Format("01/02/2006 15:04:05")
@@ -0,0 +1,28 @@
+package privacy
+
+import (
+	"commerce/internal/shared/models"
+	"time"
+)
+
+type ErasureRequest struct {
+	Id            uint       `json:"id"`
+	UserId        uint       `json:"user_id"`
+	RequestedBy   string     `json:"requested_by"`
+	Status        string     `json:"status"`
+	CreatedDate   time.Time  `json:"created_date"`
+	CompletedDate *time.Time `json:"completed_date,omitempty"`
+	Error         string     `json:"error,omitempty"`
+}
+
+func FromModel(request *models.ErasureRequest) *ErasureRequest {
+	return &ErasureRequest{
+		Id:            request.Id,
+		UserId:        request.UserId,
+		RequestedBy:   request.RequestedBy,
+		Status:        string(request.Status),
+		CreatedDate:   request.CreatedDate,
+		CompletedDate: request.CompletedDate,
+		Error:         request.Error,
+	}
+}
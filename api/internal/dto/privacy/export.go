@@ -0,0 +1,20 @@
+package privacy
+
+import (
+	address "commerce/api/internal/dto/address"
+	order "commerce/api/internal/dto/order"
+	payment "commerce/api/internal/dto/payment"
+	review "commerce/api/internal/dto/review"
+	user "commerce/api/internal/dto/user"
+	"time"
+)
+
+// Export is everything the API holds about a user.
+type Export struct {
+	ExportedDate time.Time          `json:"exported_date"`
+	Profile      *user.User         `json:"profile"`
+	Addresses    []*address.Address `json:"addresses"`
+	Orders       []*order.Order     `json:"orders"`
+	Payments     []*payment.Payment `json:"payments"`
+	Reviews      []*review.Review   `json:"reviews"`
+}
@@ -0,0 +1,49 @@
+package product
+
+import "commerce/internal/shared/models"
+
+// Image is a product image. Url is the uploaded file, and Thumbnails maps
+// each thumbnail size, such as small, to its URL. Position orders a
+// product's images from 0.
+type Image struct {
+	Id         uint              `json:"id"`
+	Url        string            `json:"url"`
+	Thumbnails map[string]string `json:"thumbnails"`
+	AltText    string            `json:"alt_text" binding:"max=255"`
+	Position   int               `json:"position" binding:"gte=0"`
+	IsPrimary  bool              `json:"is_primary"`
+	Width      int               `json:"width"`
+	Height     int               `json:"height"`
+}
+
+// ImageFromModel converts an image; url resolves the keys of its files.
+func ImageFromModel(image *models.ProductImage, url func(key string) string) *Image {
+	thumbnails := make(map[string]string, len(models.ThumbnailSizes))
+	for size := range models.ThumbnailSizes {
+		thumbnails[size] = url(image.ThumbnailPath(size))
+	}
+	return &Image{
+		Id:         image.Id,
+		Url:        url(image.Path),
+		Thumbnails: thumbnails,
+		AltText:    image.AltText,
+		Position:   image.Position,
+		IsPrimary:  image.IsPrimary,
+		Width:      image.Width,
+		Height:     image.Height,
+	}
+}
+
+func ImagesFromModels(images []models.ProductImage, url func(key string) string) []Image {
+	dtos := make([]Image, len(images))
+	for i := range images {
+		dtos[i] = *ImageFromModel(&images[i], url)
+	}
+	return dtos
+}
+
+// ImageUpload is the form sent with an uploaded image's file.
+type ImageUpload struct {
+	AltText   string `form:"alt_text" binding:"max=255"`
+	IsPrimary bool   `form:"is_primary"`
+}
@@ -0,0 +1,99 @@
+package product
+
+import (
+	"commerce/internal/shared/catalog"
+	"commerce/internal/shared/models"
+	"encoding/json"
+	"time"
+)
+
+// ImportOptions are the query parameters of an import. A dry run checks the
+// file and counts what would change without writing anything; Async queues
+// the import as a job instead of running it in the request.
+type ImportOptions struct {
+	DryRun bool `form:"dry_run"`
+	Async  bool `form:"async"`
+}
+
+// RowError is a problem with one row of an imported file. Row is the line it
+// starts on, the header being line 1; Column is empty for problems with the
+// row as a whole.
+type RowError struct {
+	Row     int    `json:"row"`
+	Sku     string `json:"sku,omitempty"`
+	Column  string `json:"column,omitempty"`
+	Message string `json:"message"`
+}
+
+// ImportResult is what an import did, or would do on a dry run. Nothing is
+// written unless Applied, which it isn't if there are any errors.
+type ImportResult struct {
+	DryRun    bool       `json:"dry_run"`
+	Applied   bool       `json:"applied"`
+	Rows      int        `json:"rows"`
+	Created   int        `json:"created"`
+	Updated   int        `json:"updated"`
+	Unchanged int        `json:"unchanged"`
+	Errors    []RowError `json:"errors"`
+}
+
+// ImportJob is an import queued to run in the background. Result is set once
+// it has run, and Error if it couldn't.
+type ImportJob struct {
+	Id            uint          `json:"id"`
+	Status        string        `json:"status"`
+	DryRun        bool          `json:"dry_run"`
+	RequestedBy   string        `json:"requested_by"`
+	CreatedDate   time.Time     `json:"created_date"`
+	StartedDate   *time.Time    `json:"started_date,omitempty"`
+	CompletedDate *time.Time    `json:"completed_date,omitempty"`
+	Result        *ImportResult `json:"result,omitempty"`
+	Error         string        `json:"error,omitempty"`
+}
+
+func RowErrorsFromCatalog(errs []catalog.RowError) []RowError {
+	dtos := make([]RowError, len(errs))
+	for i, e := range errs {
+		dtos[i] = RowError{Row: e.Row, Sku: e.Sku, Column: e.Column, Message: e.Message}
+	}
+	return dtos
+}
+
+func ImportResultFromCatalog(result *catalog.ImportResult) *ImportResult {
+	return &ImportResult{
+		DryRun:    result.DryRun,
+		Applied:   result.Applied(),
+		Rows:      result.Rows,
+		Created:   result.Created,
+		Updated:   result.Updated,
+		Unchanged: result.Unchanged,
+		Errors:    RowErrorsFromCatalog(result.Errors),
+	}
+}
+
+func ImportJobFromModel(job *models.ImportJob) *ImportJob {
+	dto := &ImportJob{
+		Id:            job.Id,
+		Status:        string(job.Status),
+		DryRun:        job.DryRun,
+		RequestedBy:   job.RequestedBy,
+		CreatedDate:   job.CreatedDate,
+		StartedDate:   job.StartedDate,
+		CompletedDate: job.CompletedDate,
+		Error:         job.Error,
+	}
+	if job.Errors != nil {
+		result := &ImportResult{
+			DryRun:    job.DryRun,
+			Rows:      job.Rows,
+			Created:   job.Created,
+			Updated:   job.Updated,
+			Unchanged: job.Unchanged,
+			Errors:    []RowError{},
+		}
+		_ = json.Unmarshal([]byte(*job.Errors), &result.Errors)
+		result.Applied = !job.DryRun && len(result.Errors) == 0
+		dto.Result = result
+	}
+	return dto
+}
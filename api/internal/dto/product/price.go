@@ -0,0 +1,54 @@
+package product
+
+import (
+	"commerce/internal/shared/models"
+	"time"
+)
+
+// Price statuses: a scheduled price hasn't started, a current one is within
+// its window and an ended one is history.
+const (
+	PriceScheduled = "scheduled"
+	PriceCurrent   = "current"
+	PriceEnded     = "ended"
+)
+
+// Price is a scheduled price of a product, or of one of its variants when
+// VariantId is set. It applies from EffectiveFrom, now when left out, until
+// EffectiveTo or, without one, until a later price starts. CompareAtPrice is
+// shown struck through and must be more than Price. Status, CreatedBy and
+// CreatedDate are only read.
+type Price struct {
+	Id             uint       `json:"id"`
+	VariantId      *uint      `json:"variant_id,omitempty"`
+	Price          float32    `json:"price" binding:"gte=0"`
+	CompareAtPrice *float32   `json:"compare_at_price,omitempty" binding:"omitempty,gte=0"`
+	EffectiveFrom  *time.Time `json:"effective_from"`
+	EffectiveTo    *time.Time `json:"effective_to,omitempty"`
+	Status         string     `json:"status"`
+	CreatedBy      string     `json:"created_by"`
+	CreatedDate    time.Time  `json:"created_date"`
+}
+
+// PriceFromModel converts a price, with its status at now.
+func PriceFromModel(price *models.ProductPrice, now time.Time) *Price {
+	status := PriceCurrent
+	switch {
+	case price.EffectiveFrom.After(now):
+		status = PriceScheduled
+	case !price.ActiveAt(now):
+		status = PriceEnded
+	}
+	effectiveFrom := price.EffectiveFrom
+	return &Price{
+		Id:             price.Id,
+		VariantId:      price.VariantId,
+		Price:          price.Price,
+		CompareAtPrice: price.CompareAtPrice,
+		EffectiveFrom:  &effectiveFrom,
+		EffectiveTo:    price.EffectiveTo,
+		Status:         status,
+		CreatedBy:      price.CreatedBy,
+		CreatedDate:    price.CreatedDate,
+	}
+}
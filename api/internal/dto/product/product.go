@@ -4,19 +4,37 @@ import (
 	"commerce/api/internal/dto/category"
 	"commerce/api/internal/dto/review"
 	"commerce/internal/shared/models"
+	"time"
 )
 
+// Product is read and written whole, except Stock, which an update only writes
+// when the request has it. CategoryIds is only read from requests:
+// when given, the product's categories are replaced with those ids. Price is
+// the list price; ActivePrice is what the product sells for now, which a
+// scheduled price can change, with CompareAtPrice to strike through. Both are
+// ignored in requests.
 type Product struct {
-	Id          uint                `json:"id"`
-	Name        string              `json:"name"`
-	Price       float32             `json:"price"`
-	Description string              `json:"description"`
-	Sku         string              `json:"sku"`
-	Stock       int                 `json:"stock"`
-	IsActive    bool                `json:"is_active"`
-	IsFeatured  bool                `json:"is_featured"`
-	Categories  []category.Category `json:"categories,omitempty"`
-	Reviews     []review.Review     `json:"reviews,omitempty"`
+	Id             uint                `json:"id"`
+	Name           string              `json:"name" binding:"required,max=150"`
+	Price          float32             `json:"price" binding:"gte=0"`
+	ActivePrice    float32             `json:"active_price"`
+	CompareAtPrice *float32            `json:"compare_at_price,omitempty"`
+	Description    string              `json:"description"`
+	Sku            string              `json:"sku" binding:"required,max=100"`
+	Stock          int                 `json:"stock" binding:"gte=0"`
+	IsActive       bool                `json:"is_active"`
+	IsFeatured     bool                `json:"is_featured"`
+	Weight         float64             `json:"weight" binding:"gte=0"`
+	Length         float64             `json:"length" binding:"gte=0"`
+	Width          float64             `json:"width" binding:"gte=0"`
+	Height         float64             `json:"height" binding:"gte=0"`
+	CategoryIds    []uint              `json:"category_ids,omitempty"`
+	Categories     []category.Category `json:"categories,omitempty"`
+	Reviews        []review.Review     `json:"reviews,omitempty"`
+	Options        []Option            `json:"options,omitempty"`
+	Variants       []Variant           `json:"variants,omitempty"`
+	Images         []Image             `json:"images,omitempty"`
+	DeletedDate    *time.Time          `json:"deleted_date,omitempty"`
 }
 
 func FromModel(product *models.Product) *Product {
@@ -30,17 +48,47 @@ func FromModel(product *models.Product) *Product {
 		reviews[i] = *review.FromModel(&r)
 	}
 
+	options := make([]Option, len(product.Options))
+	for i := range product.Options {
+		options[i] = *OptionFromModel(&product.Options[i])
+	}
+	variants := make([]Variant, len(product.Variants))
+	for i := range product.Variants {
+		variants[i] = *VariantFromModel(&product.Variants[i], product)
+	}
+	activePrice, compareAtPrice := product.PriceAt(time.Now())
+
 	return &Product{
-		Id:          product.Id,
-		Name:        product.Name,
-		Price:       product.Price,
-		Description: product.Description,
-		Sku:         product.Sku,
-		Stock:       product.Stock,
-		IsActive:    product.IsActive,
-		IsFeatured:  product.IsFeatured,
-		Categories:  categories,
-		Reviews:     reviews,
+		Id:             product.Id,
+		Name:           product.Name,
+		Price:          product.Price,
+		ActivePrice:    activePrice,
+		CompareAtPrice: compareAtPrice,
+		Description:    product.Description,
+		Sku:            product.Sku,
+		Stock:          product.Stock,
+		IsActive:       product.IsActive,
+		IsFeatured:     product.IsFeatured,
+		Weight:         product.Weight,
+		Length:         product.Length,
+		Width:          product.Width,
+		Height:         product.Height,
+		Categories:     categories,
+		Reviews:        reviews,
+		Options:        options,
+		Variants:       variants,
+		DeletedDate:    product.DeletedTime(),
+	}
+}
+
+// FromModelWithImages returns FromModel for responses that show the
+// product's images. Only the blob store knows where those are, so url
+// resolves the keys they are stored under.
+func FromModelWithImages(url func(key string) string) func(*models.Product) *Product {
+	return func(model *models.Product) *Product {
+		product := FromModel(model)
+		product.Images = ImagesFromModels(model.Images, url)
+		return product
 	}
 }
 
@@ -61,5 +109,14 @@ func ToModel(product *Product) *models.Product {
 		Stock:       product.Stock,
 		IsActive:    product.IsActive,
 		IsFeatured:  product.IsFeatured,
+		Weight:      product.Weight,
+		Length:      product.Length,
+		Width:       product.Width,
+		Height:      product.Height,
 	}
 }
+
+// Categories is the body that sets the categories a product is in.
+type Categories struct {
+	CategoryIds []uint `json:"category_ids" binding:"required"`
+}
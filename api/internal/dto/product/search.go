@@ -0,0 +1,75 @@
+package product
+
+import (
+	"commerce/api/internal/dto/page"
+	"commerce/internal/shared/models"
+	repo "commerce/internal/shared/repositories/product"
+)
+
+// SearchQuery is a catalogue search. Q uses web search syntax: quoted phrases,
+// or and a leading - to exclude a word. Paging and sort are read separately,
+// as on other lists.
+type SearchQuery struct {
+	Q          string   `form:"q"`
+	MinPrice   *float64 `form:"min_price" binding:"omitempty,gte=0"`
+	MaxPrice   *float64 `form:"max_price" binding:"omitempty,gte=0"`
+	CategoryId *uint    `form:"category_id"`
+	InStock    bool     `form:"in_stock"`
+	Featured   bool     `form:"featured"`
+	MinRating  *float64 `form:"min_rating" binding:"omitempty,min=1,max=5"`
+}
+
+// SearchResult is a page of search results with facets over all of them.
+type SearchResult struct {
+	page.Page[Product]
+	Facets Facets `json:"facets"`
+}
+
+// Facets count the results by category and by price. Each is counted as if
+// its own filter weren't set, so the other options stay visible.
+type Facets struct {
+	Categories []CategoryFacet `json:"categories"`
+	Prices     []PriceFacet    `json:"prices"`
+}
+
+type CategoryFacet struct {
+	CategoryId uint   `json:"category_id"`
+	Name       string `json:"name"`
+	Count      int64  `json:"count"`
+}
+
+// PriceFacet counts the results priced from Min up to but not including Max;
+// Max is null on the last bucket.
+type PriceFacet struct {
+	Min   float64  `json:"min"`
+	Max   *float64 `json:"max"`
+	Count int64    `json:"count"`
+}
+
+func ToSearchFilter(search SearchQuery) repo.SearchFilter {
+	return repo.SearchFilter{
+		Text:       search.Q,
+		MinPrice:   search.MinPrice,
+		MaxPrice:   search.MaxPrice,
+		CategoryId: search.CategoryId,
+		InStock:    search.InStock,
+		Featured:   search.Featured,
+		MinRating:  search.MinRating,
+	}
+}
+
+// FromSearchResult converts a search result, using fromModel for the products.
+func FromSearchResult(result *repo.SearchResult, fromModel func(*models.Product) *Product) *SearchResult {
+	categories := make([]CategoryFacet, len(result.Categories))
+	for i, c := range result.Categories {
+		categories[i] = CategoryFacet{CategoryId: c.CategoryId, Name: c.Name, Count: c.Count}
+	}
+	prices := make([]PriceFacet, len(result.Prices))
+	for i, p := range result.Prices {
+		prices[i] = PriceFacet{Min: p.Min, Max: p.Max, Count: p.Count}
+	}
+	return &SearchResult{
+		Page:   *page.FromPage(result.Page, fromModel),
+		Facets: Facets{Categories: categories, Prices: prices},
+	}
+}
@@ -0,0 +1,105 @@
+package product
+
+import (
+	"commerce/internal/shared/models"
+	"time"
+)
+
+// Option is a way a product varies, with the values its variants pick from.
+// Values are saved in the order given; leaving one out deletes it.
+type Option struct {
+	Id     uint          `json:"id"`
+	Name   string        `json:"name" binding:"required,max=50"`
+	Values []OptionValue `json:"values" binding:"required,min=1,dive"`
+}
+
+type OptionValue struct {
+	Id    uint   `json:"id"`
+	Value string `json:"value" binding:"required,max=50"`
+}
+
+// Variant is one combination of option values, one per option of its product.
+// Price is what it sells for now: a scheduled price for the variant, else
+// PriceOverride when set, else the product's active price. CompareAtPrice
+// comes with the scheduled price that set it. Options maps each option's
+// name to the variant's value for it.
+type Variant struct {
+	Id             uint              `json:"id"`
+	Sku            string            `json:"sku" binding:"required,max=100"`
+	Price          float32           `json:"price"`
+	CompareAtPrice *float32          `json:"compare_at_price,omitempty"`
+	PriceOverride  *float32          `json:"price_override,omitempty" binding:"omitempty,gte=0"`
+	Stock          int               `json:"stock" binding:"gte=0"`
+	IsActive       bool              `json:"is_active"`
+	OptionValueIds []uint            `json:"option_value_ids"`
+	Options        map[string]string `json:"options"`
+}
+
+func OptionFromModel(option *models.ProductOption) *Option {
+	values := make([]OptionValue, len(option.Values))
+	for i, v := range option.Values {
+		values[i] = OptionValue{Id: v.Id, Value: v.Value}
+	}
+	return &Option{Id: option.Id, Name: option.Name, Values: values}
+}
+
+func OptionToModel(productId uint, option *Option) *models.ProductOption {
+	values := make([]models.OptionValue, len(option.Values))
+	for i, v := range option.Values {
+		values[i] = models.OptionValue{
+			Base:     models.Base{Id: v.Id},
+			OptionId: option.Id,
+			Value:    v.Value,
+			Position: i,
+		}
+	}
+	return &models.ProductOption{
+		Base:      models.Base{Id: option.Id},
+		ProductId: productId,
+		Name:      option.Name,
+		Values:    values,
+	}
+}
+
+// VariantFromModel converts a variant of product, whose prices give the
+// variant's and whose options name its values.
+func VariantFromModel(variant *models.ProductVariant, product *models.Product) *Variant {
+	names := make(map[uint]string, len(product.Options))
+	for _, option := range product.Options {
+		names[option.Id] = option.Name
+	}
+	ids := make([]uint, len(variant.OptionValues))
+	values := make(map[string]string, len(variant.OptionValues))
+	for i, v := range variant.OptionValues {
+		ids[i] = v.OptionValueId
+		values[names[v.OptionValue.OptionId]] = v.OptionValue.Value
+	}
+	price, compareAtPrice := product.VariantPriceAt(variant, time.Now())
+	return &Variant{
+		Id:             variant.Id,
+		Sku:            variant.Sku,
+		Price:          price,
+		CompareAtPrice: compareAtPrice,
+		PriceOverride:  variant.Price,
+		Stock:          variant.Stock,
+		IsActive:       variant.IsActive,
+		OptionValueIds: ids,
+		Options:        values,
+	}
+}
+
+func VariantToModel(productId uint, variant *Variant) *models.ProductVariant {
+	values := make([]models.VariantOptionValue, len(variant.OptionValueIds))
+	for i, id := range variant.OptionValueIds {
+		values[i] = models.VariantOptionValue{VariantId: variant.Id, OptionValueId: id}
+	}
+	return &models.ProductVariant{
+		Base:         models.Base{Id: variant.Id},
+		ProductId:    productId,
+		Sku:          variant.Sku,
+		Price:        variant.PriceOverride,
+		Stock:        variant.Stock,
+		IsActive:     variant.IsActive,
+		OptionValues: values,
+	}
+}
@@ -0,0 +1,63 @@
+package returnrequest
+
+import (
+	"commerce/internal/shared/models"
+	"time"
+)
+
+type ReturnRequest struct {
+	Id           uint         `json:"id"`
+	OrderId      uint         `json:"order_id"`
+	PaymentId    *uint        `json:"payment_id,omitempty"`
+	Reason       string       `json:"reason" binding:"required"`
+	Status       string       `json:"status"`
+	RefundAmount float64      `json:"refund_amount"`
+	ReceivedDate *time.Time   `json:"received_date,omitempty"`
+	Items        []ReturnItem `json:"items" binding:"required,min=1,dive"`
+}
+
+type ReturnItem struct {
+	Id          uint `json:"id"`
+	OrderItemId uint `json:"order_item_id" binding:"required"`
+	Quantity    int  `json:"quantity" binding:"required,gt=0"`
+}
+
+func FromModel(returnRequest *models.ReturnRequest) *ReturnRequest {
+	items := make([]ReturnItem, len(returnRequest.Items))
+	for i, item := range returnRequest.Items {
+		items[i] = ReturnItem{
+			Id:          item.Id,
+			OrderItemId: item.OrderItemId,
+			Quantity:    item.Quantity,
+		}
+	}
+
+	return &ReturnRequest{
+		Id:           returnRequest.Id,
+		OrderId:      returnRequest.OrderId,
+		PaymentId:    returnRequest.PaymentId,
+		Reason:       returnRequest.Reason,
+		Status:       string(returnRequest.Status),
+		RefundAmount: returnRequest.RefundAmount,
+		ReceivedDate: returnRequest.ReceivedDate,
+		Items:        items,
+	}
+}
+
+func ToModel(returnRequest *ReturnRequest) *models.ReturnRequest {
+	items := make([]models.ReturnItem, len(returnRequest.Items))
+	for i, item := range returnRequest.Items {
+		items[i] = models.ReturnItem{
+			OrderItemId: item.OrderItemId,
+			Quantity:    item.Quantity,
+		}
+	}
+
+	return &models.ReturnRequest{
+		OrderId:      returnRequest.OrderId,
+		Reason:       returnRequest.Reason,
+		Status:       models.ReturnStatus(returnRequest.Status),
+		RefundAmount: returnRequest.RefundAmount,
+		Items:        items,
+	}
+}
@@ -6,9 +6,9 @@ type Review struct {
 	Id        uint   `json:"id"`
 	ProductId uint   `json:"product_id"`
 	UserId    uint   `json:"user_id"`
-	Rating    int    `json:"rating"`
-	Title     string `json:"title"`
-	Comment   string `json:"comment"`
+	Rating    int    `json:"rating" binding:"required,min=1,max=5"`
+	Title     string `json:"title" binding:"max=100"`
+	Comment   string `json:"comment" binding:"max=500"`
 }
 
 func FromModel(review *models.Review) *Review {
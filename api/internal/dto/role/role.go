@@ -0,0 +1,19 @@
+package role
+
+// Role is a role the API knows about and the scopes it grants.
+type Role struct {
+	Name        string   `json:"name"`
+	Permissions []string `json:"permissions"`
+}
+
+// UserRoles are the roles assigned to a user in the API. Roles carried in the
+// user's token aren't included.
+type UserRoles struct {
+	UserId uint     `json:"user_id"`
+	Roles  []string `json:"roles"`
+}
+
+// AssignRoles replaces a user's assigned roles. An empty list revokes them all.
+type AssignRoles struct {
+	Roles []string `json:"roles" binding:"required"`
+}
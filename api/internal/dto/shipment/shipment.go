@@ -0,0 +1,64 @@
+package shipment
+
+import (
+	"commerce/internal/shared/models"
+	"time"
+)
+
+type Shipment struct {
+	Id             uint           `json:"id"`
+	OrderId        uint           `json:"order_id"`
+	Carrier        string         `json:"carrier" binding:"required"`
+	TrackingNumber string         `json:"tracking_number,omitempty"`
+	TrackingStatus string         `json:"tracking_status,omitempty"`
+	ShippedDate    time.Time      `json:"shipped_date"`
+	DeliveredDate  *time.Time     `json:"delivered_date,omitempty"`
+	Items          []ShipmentItem `json:"items" binding:"required,min=1,dive"`
+}
+
+type ShipmentItem struct {
+	Id          uint `json:"id"`
+	OrderItemId uint `json:"order_item_id" binding:"required"`
+	Quantity    int  `json:"quantity" binding:"required,gt=0"`
+}
+
+func FromModel(shipment *models.Shipment) *Shipment {
+	items := make([]ShipmentItem, len(shipment.Items))
+	for i, item := range shipment.Items {
+		items[i] = ShipmentItem{
+			Id:          item.Id,
+			OrderItemId: item.OrderItemId,
+			Quantity:    item.Quantity,
+		}
+	}
+
+	return &Shipment{
+		Id:             shipment.Id,
+		OrderId:        shipment.OrderId,
+		Carrier:        shipment.Carrier,
+		TrackingNumber: shipment.TrackingNumber,
+		TrackingStatus: shipment.TrackingStatus,
+		ShippedDate:    shipment.ShippedDate,
+		DeliveredDate:  shipment.DeliveredDate,
+		Items:          items,
+	}
+}
+
+func ToModel(shipment *Shipment) *models.Shipment {
+	items := make([]models.ShipmentItem, len(shipment.Items))
+	for i, item := range shipment.Items {
+		items[i] = models.ShipmentItem{
+			OrderItemId: item.OrderItemId,
+			Quantity:    item.Quantity,
+		}
+	}
+
+	return &models.Shipment{
+		OrderId:        shipment.OrderId,
+		Carrier:        shipment.Carrier,
+		TrackingNumber: shipment.TrackingNumber,
+		TrackingStatus: shipment.TrackingStatus,
+		ShippedDate:    shipment.ShippedDate,
+		Items:          items,
+	}
+}
@@ -0,0 +1,48 @@
+package shipping
+
+import "commerce/api/internal/dto/address"
+
+type WeightRate struct {
+	MaxWeight float64 `json:"max_weight"`
+	Rate      float64 `json:"rate"`
+}
+
+type ShippingMethod struct {
+	Code                  string       `json:"code"`
+	Name                  string       `json:"name"`
+	Zone                  string       `json:"zone"`
+	EstimatedDays         int          `json:"estimated_days"`
+	Rates                 []WeightRate `json:"rates"`
+	AdditionalRatePerLb   float64      `json:"additional_rate_per_lb"`
+	FreeShippingThreshold float64      `json:"free_shipping_threshold,omitempty"`
+}
+
+type ShippingZone struct {
+	Code   string   `json:"code"`
+	Name   string   `json:"name"`
+	States []string `json:"states"`
+}
+
+type QuoteItem struct {
+	ProductId uint    `json:"product_id" binding:"required"`
+	VariantId *uint   `json:"variant_id,omitempty"`
+	Quantity  int     `json:"quantity" binding:"required,gt=0"`
+	UnitPrice float64 `json:"unit_price,omitempty"`
+}
+
+type QuoteRequest struct {
+	OrderId         *uint           `json:"order_id,omitempty"`
+	Items           []QuoteItem     `json:"items,omitempty"`
+	Method          string          `json:"method,omitempty"`
+	ShippingAddress address.Address `json:"shipping_address" binding:"-"` // only the state is used
+}
+
+type Quote struct {
+	Method         string  `json:"method"`
+	Name           string  `json:"name"`
+	Zone           string  `json:"zone"`
+	Amount         float64 `json:"amount"`
+	BillableWeight float64 `json:"billable_weight"`
+	FreeShipping   bool    `json:"free_shipping"`
+	EstimatedDays  int     `json:"estimated_days"`
+}
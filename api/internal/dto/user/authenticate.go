@@ -1,6 +0,0 @@
-package user
-
-type Authenticate struct {
-	Email    string `json:"email" binding:"required,email"`
-	Password string `json:"password" binding:"required"`
-}
@@ -0,0 +1,8 @@
+package user
+
+// Profile is the part of a user that users may change about themselves.
+// Fields left out of the request are kept as they are.
+type Profile struct {
+	FirstName *string `json:"first_name" binding:"omitempty,min=1"`
+	LastName  *string `json:"last_name" binding:"omitempty,min=1"`
+}
@@ -7,8 +7,7 @@ type User struct {
 	FirstName string `json:"first_name"`
 	LastName  string `json:"last_name"`
 	Email     string `json:"email"`
-	Password  string `json:"password,omitempty"`
-	AuthSub   string `json:"-"`
+	AuthSub   string `json:"auth_sub" binding:"required,max=250"`
 }
 
 func FromModel(user *models.User) *User {
@@ -26,7 +25,6 @@ func ToModel(user *User) *models.User {
 		FirstName: user.FirstName,
 		LastName:  user.LastName,
 		Email:     user.Email,
-		Password:  user.Password,
 		AuthSub:   user.AuthSub,
 	}
 }
@@ -4,11 +4,15 @@ import (
 	auth "commerce/api/internal/auth"
 	"commerce/api/internal/helpers"
 	"commerce/api/internal/services/address"
+	"commerce/internal/shared/repositories/query"
+	"errors"
 
 	dto "commerce/api/internal/dto/address"
 	err_dto "commerce/api/internal/dto/err"
+	"commerce/api/internal/dto/page"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 type AddressHandler struct {
@@ -22,6 +26,8 @@ func NewAddressHandler(svc address.AddressServiceI) *AddressHandler {
 func (h *AddressHandler) RegisterRoutes(rg *gin.RouterGroup) {
 	rg.GET("/:id", auth.RequireScope(auth.Scopes.Users.Read), h.GetById)
 	rg.POST("/", auth.RequireScope(auth.Scopes.Users.Write), h.Save)
+	rg.PUT("/:id", auth.RequireScope(auth.Scopes.Users.Write), h.Update)
+	rg.PATCH("/:id", auth.RequireScope(auth.Scopes.Users.Write), h.Patch)
 	rg.DELETE("/:id", auth.RequireScope(auth.Scopes.Users.Write), h.Delete)
 }
 
@@ -38,6 +44,7 @@ func (h *AddressHandler) RegisterRoutes(rg *gin.RouterGroup) {
 //	@Failure	500	{object}	err_dto.ErrorResponse
 //	@Failure	401 {object}	err_dto.ErrorResponse
 //	@Failure	403 {object}	err_dto.ErrorResponse
+//	@Failure	404 {object}	err_dto.ErrorResponse
 func (h *AddressHandler) GetById(c *gin.Context) {
 	var address *dto.Address
 	id, err := helpers.ParseParamToUint(c.Param("id"))
@@ -46,12 +53,15 @@ func (h *AddressHandler) GetById(c *gin.Context) {
 		c.JSON(response.Code, response)
 		return
 	}
-	address, err = h.svc.GetById(*id)
+	address, err = h.svc.GetById(c.Request.Context(), *id)
 	if err != nil {
 		response := err_dto.ErrorResponse{Code: 404, Message: err.Error()}
 		c.JSON(response.Code, response)
 		return
 	}
+	if !auth.AuthorizeRead(c, address.UserId) {
+		return
+	}
 	c.JSON(200, address)
 }
 
@@ -76,8 +86,17 @@ func (h *AddressHandler) Delete(c *gin.Context) {
 		c.JSON(response.Code, response)
 		return
 	}
+	address, err := h.svc.GetById(c.Request.Context(), *id)
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 404, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	if !auth.Authorize(c, address.UserId) {
+		return
+	}
 	hard := c.DefaultQuery("hard", "false") == "true"
-	err = h.svc.Delete(*id, hard)
+	err = h.svc.Delete(c.Request.Context(), *id, hard)
 	if err != nil {
 		response := err_dto.ErrorResponse{Code: 404, Message: err.Error()}
 		c.JSON(response.Code, response)
@@ -106,7 +125,10 @@ func (h *AddressHandler) Save(c *gin.Context) {
 		c.JSON(errorResponse.Code, errorResponse)
 		return
 	}
-	err := h.svc.Save(address)
+	if !auth.Authorize(c, address.UserId) {
+		return
+	}
+	err := h.svc.Save(c.Request.Context(), address)
 	if err != nil {
 		errorResponse := err_dto.ErrorResponse{Code: 500, Message: err.Error()}
 		c.JSON(errorResponse.Code, errorResponse)
@@ -115,6 +137,103 @@ func (h *AddressHandler) Save(c *gin.Context) {
 	c.JSON(201, address)
 }
 
+// UpdateAddress godoc
+//
+//	@Summary		Replace the address
+//	@Description	Fields left out are reset. The address stays with its user.
+//	@Tags			address
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path	int			true	"Address ID"
+//	@Param			address	body	dto.Address	true	"Provide address object"
+//	@Router			/api/address/{id} [put]
+//	@Success		200	{object}	dto.Address
+//	@Failure		400	{object}	err_dto.ErrorResponse
+//	@Failure		500	{object}	err_dto.ErrorResponse
+//	@Failure		401 {object}	err_dto.ErrorResponse
+//	@Failure		403 {object}	err_dto.ErrorResponse
+//	@Failure		404 {object}	err_dto.ErrorResponse
+func (h *AddressHandler) Update(c *gin.Context) {
+	id, err := helpers.ParseParamToUint(c.Param("id"))
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	var address dto.Address
+	if err := c.ShouldBindJSON(&address); err != nil {
+		response := err_dto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	existing, err := h.svc.GetById(c.Request.Context(), *id)
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 404, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	if !auth.Authorize(c, existing.UserId) {
+		return
+	}
+	h.update(c, *id, &address)
+}
+
+// PatchAddress godoc
+//
+//	@Summary		Update the address
+//	@Description	Applies a JSON merge patch (RFC 7396): fields given replace the address's, null resets one and fields left out are kept.
+//	@Tags			address
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path	int			true	"Address ID"
+//	@Param			patch	body	dto.Address	true	"Fields to change"
+//	@Router			/api/address/{id} [patch]
+//	@Success		200	{object}	dto.Address
+//	@Failure		400	{object}	err_dto.ErrorResponse
+//	@Failure		500	{object}	err_dto.ErrorResponse
+//	@Failure		401 {object}	err_dto.ErrorResponse
+//	@Failure		403 {object}	err_dto.ErrorResponse
+//	@Failure		404 {object}	err_dto.ErrorResponse
+func (h *AddressHandler) Patch(c *gin.Context) {
+	id, err := helpers.ParseParamToUint(c.Param("id"))
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	address, err := h.svc.GetById(c.Request.Context(), *id)
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 404, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	if !auth.Authorize(c, address.UserId) {
+		return
+	}
+	if err := helpers.BindMergePatch(c, address); err != nil {
+		response := err_dto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	h.update(c, *id, address)
+}
+
+func (h *AddressHandler) update(c *gin.Context, id uint, address *dto.Address) {
+	err := h.svc.Update(c.Request.Context(), id, address)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		response := err_dto.ErrorResponse{Code: 404, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 500, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	c.JSON(200, address)
+}
+
 // GetAddress godoc
 //
 //	@Summary	Get the list of addresses by user
@@ -122,11 +241,18 @@ func (h *AddressHandler) Save(c *gin.Context) {
 //	@Produce	json
 //	@Security	BearerAuth
 //	@Param		user_id		path		int		true	"user id"
+//	@Param		limit	query	int		false	"Page size, up to 200; defaults to 50"
+//	@Param		cursor	query	string	false	"next_cursor of the previous page"
+//	@Param		offset	query	int		false	"Items to skip, instead of a cursor"
+//	@Param		sort	query	string	false	"Comma-separated, - for descending: created_date"
+//	@Param		country	query	string	false	"Country"
+//	@Param		is_default	query	bool	false	"Default address only, or not"
 //	@Router		/api/users/{user_id}/addresses [get]
-//	@Success	200 {array} dto.Address
+//	@Success	200 {object} page.Page[dto.Address]
 //	@Failure	400	{object}	err_dto.ErrorResponse
 //	@Failure	500	{object}	err_dto.ErrorResponse
-//	@Failure	400	{object}	err_dto.ErrorResponse
+//	@Failure	401	{object}	err_dto.ErrorResponse
+//	@Failure	403	{object}	err_dto.ErrorResponse
 //	@Failure	404	{object}	err_dto.ErrorResponse
 func (h *AddressHandler) GetByUserId(c *gin.Context) {
 	userId, err := helpers.ParseParamToUint(c.Param("user_id"))
@@ -135,14 +261,25 @@ func (h *AddressHandler) GetByUserId(c *gin.Context) {
 		c.JSON(response.Code, response)
 		return
 	}
-	var addresses []*dto.Address
-	addresses, err = h.svc.GetAllByUserId(*userId)
+	opts, err := helpers.ParseQueryOptions(c.Request.URL.Query(), "country", "is_default")
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	var addresses *page.Page[dto.Address]
+	addresses, err = h.svc.GetAllByUserId(c.Request.Context(), *userId, opts)
+	if errors.Is(err, query.ErrInvalid) {
+		response := err_dto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
 	if err != nil {
 		response := err_dto.ErrorResponse{Code: 500, Message: err.Error()}
 		c.JSON(response.Code, response)
 		return
 	}
-	if len(addresses) == 0 {
+	if addresses.Total == 0 {
 		response := err_dto.ErrorResponse{Code: 404, Message: "No addresses found"}
 		c.JSON(response.Code, response)
 		return
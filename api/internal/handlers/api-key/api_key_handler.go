@@ -0,0 +1,213 @@
+package apikey
+
+import (
+	auth "commerce/api/internal/auth"
+	"commerce/api/internal/helpers"
+	apikey "commerce/api/internal/services/api-key"
+	"commerce/internal/shared/repositories/query"
+	"errors"
+	"fmt"
+
+	dto "commerce/api/internal/dto/api-key"
+	err_dto "commerce/api/internal/dto/err"
+	"commerce/api/internal/dto/page"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ApiKeyHandler lets admins manage the keys partners use instead of a token.
+type ApiKeyHandler struct {
+	svc apikey.ApiKeyServiceI
+}
+
+func NewApiKeyHandler(svc apikey.ApiKeyServiceI) *ApiKeyHandler {
+	return &ApiKeyHandler{svc: svc}
+}
+
+func (h *ApiKeyHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.Use(auth.RequireRole(auth.RoleAdmin))
+	rg.GET("/", h.GetAll)
+	rg.GET("/:id", h.GetById)
+	rg.POST("/", h.Create)
+	rg.POST("/:id/rotate", h.Rotate)
+	rg.DELETE("/:id", h.Revoke)
+}
+
+// GetApiKeys godoc
+//
+//	@Summary		Get all api keys
+//	@Description	Admin only. Secrets are never returned.
+//	@Tags			api-key
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			limit	query	int		false	"Page size, up to 200; defaults to 50"
+//	@Param			cursor	query	string	false	"next_cursor of the previous page"
+//	@Param			offset	query	int		false	"Items to skip, instead of a cursor"
+//	@Param			sort	query	string	false	"Comma-separated, - for descending: name, owner, created_date"
+//	@Param			owner	query	string	false	"Owner"
+//	@Router			/api/api-keys [get]
+//	@Success		200 {object} page.Page[dto.ApiKey]
+//	@Failure		400 {object} err_dto.ErrorResponse
+//	@Failure		401 {object} err_dto.ErrorResponse
+//	@Failure		403 {object} err_dto.ErrorResponse
+//	@Failure		500 {object} err_dto.ErrorResponse
+func (h *ApiKeyHandler) GetAll(c *gin.Context) {
+	opts, err := helpers.ParseQueryOptions(c.Request.URL.Query(), "owner")
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	var apiKeys *page.Page[dto.ApiKey]
+	apiKeys, err = h.svc.GetAll(c.Request.Context(), opts)
+	if errors.Is(err, query.ErrInvalid) {
+		response := err_dto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 500, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	c.JSON(200, apiKeys)
+}
+
+// GetApiKey godoc
+//
+//	@Summary		Get an api key by id
+//	@Description	Admin only. Secrets are never returned.
+//	@Tags			api-key
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Router			/api/api-keys/{id} [get]
+//	@Param			id	path	int	true	"Api Key Id"
+//	@Success		200 {object} dto.ApiKey
+//	@Failure		400 {object} err_dto.ErrorResponse
+//	@Failure		401 {object} err_dto.ErrorResponse
+//	@Failure		403 {object} err_dto.ErrorResponse
+//	@Failure		404 {object} err_dto.ErrorResponse
+func (h *ApiKeyHandler) GetById(c *gin.Context) {
+	id, err := helpers.ParseParamToUint(c.Param("id"))
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	apiKey, err := h.svc.GetById(c.Request.Context(), *id)
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 404, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	c.JSON(200, apiKey)
+}
+
+// CreateApiKey godoc
+//
+//	@Summary		Issue an api key
+//	@Description	Admin only. The key is in the response and can't be shown again. Send it in the X-Api-Key header.
+//	@Tags			api-key
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Router			/api/api-keys [post]
+//	@Param			api_key	body	dto.CreateApiKey	true	"Provide the key's name, owner and scopes"
+//	@Success		201 {object} dto.IssuedApiKey
+//	@Failure		400 {object} err_dto.ErrorResponse
+//	@Failure		401 {object} err_dto.ErrorResponse
+//	@Failure		403 {object} err_dto.ErrorResponse
+//	@Failure		500 {object} err_dto.ErrorResponse
+func (h *ApiKeyHandler) Create(c *gin.Context) {
+	var request dto.CreateApiKey
+	if err := c.ShouldBindJSON(&request); err != nil {
+		response := err_dto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	for _, s := range request.Scopes {
+		if !auth.IsScope(s) {
+			response := err_dto.ErrorResponse{Code: 400, Message: fmt.Sprintf("unknown scope: %s", s)}
+			c.JSON(response.Code, response)
+			return
+		}
+	}
+	issued, err := h.svc.Create(c.Request.Context(), request)
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 500, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	c.JSON(201, issued)
+}
+
+// RotateApiKey godoc
+//
+//	@Summary		Rotate an api key
+//	@Description	Admin only. Issues a new key with the same name, owner and scopes; the old key stops working at once.
+//	@Tags			api-key
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Router			/api/api-keys/{id}/rotate [post]
+//	@Param			id	path	int	true	"Api Key Id"
+//	@Success		200 {object} dto.IssuedApiKey
+//	@Failure		400 {object} err_dto.ErrorResponse
+//	@Failure		401 {object} err_dto.ErrorResponse
+//	@Failure		403 {object} err_dto.ErrorResponse
+//	@Failure		404 {object} err_dto.ErrorResponse
+//	@Failure		409 {object} err_dto.ErrorResponse
+//	@Failure		500 {object} err_dto.ErrorResponse
+func (h *ApiKeyHandler) Rotate(c *gin.Context) {
+	id, err := helpers.ParseParamToUint(c.Param("id"))
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	issued, err := h.svc.Rotate(c.Request.Context(), *id)
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 500, Message: err.Error()}
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			response.Code = 404
+		case errors.Is(err, apikey.ErrInvalidApiKey):
+			response = err_dto.ErrorResponse{Code: 409, Message: "a revoked key can't be rotated"}
+		}
+		c.JSON(response.Code, response)
+		return
+	}
+	c.JSON(200, issued)
+}
+
+// RevokeApiKey godoc
+//
+//	@Summary		Revoke an api key
+//	@Description	Admin only. The key stops working at once and is kept for the record.
+//	@Tags			api-key
+//	@Security		BearerAuth
+//	@Router			/api/api-keys/{id} [delete]
+//	@Param			id	path	int	true	"Api Key Id"
+//	@Success		204
+//	@Failure		400 {object} err_dto.ErrorResponse
+//	@Failure		401 {object} err_dto.ErrorResponse
+//	@Failure		403 {object} err_dto.ErrorResponse
+//	@Failure		404 {object} err_dto.ErrorResponse
+//	@Failure		500 {object} err_dto.ErrorResponse
+func (h *ApiKeyHandler) Revoke(c *gin.Context) {
+	id, err := helpers.ParseParamToUint(c.Param("id"))
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	if err := h.svc.Revoke(c.Request.Context(), *id); err != nil {
+		response := err_dto.ErrorResponse{Code: 500, Message: err.Error()}
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			response.Code = 404
+		}
+		c.JSON(response.Code, response)
+		return
+	}
+	c.Status(204)
+}
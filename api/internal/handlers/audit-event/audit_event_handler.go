@@ -0,0 +1,81 @@
+package auditevent
+
+import (
+	auth "commerce/api/internal/auth"
+	"commerce/api/internal/helpers"
+	auditevent "commerce/api/internal/services/audit-event"
+	"commerce/internal/shared/repositories/query"
+	"errors"
+
+	dto "commerce/api/internal/dto/audit-event"
+	err_dto "commerce/api/internal/dto/err"
+	"commerce/api/internal/dto/page"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuditEventHandler lets admins search the audit log. Events are written by
+// the database layer, so there is nothing here to create or change them.
+type AuditEventHandler struct {
+	svc auditevent.AuditEventServiceI
+}
+
+func NewAuditEventHandler(svc auditevent.AuditEventServiceI) *AuditEventHandler {
+	return &AuditEventHandler{svc: svc}
+}
+
+func (h *AuditEventHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.Use(auth.RequireRole(auth.RoleAdmin))
+	rg.GET("/", h.GetAll)
+}
+
+// GetAuditEvents godoc
+//
+//	@Summary		Search the audit log
+//	@Description	Admin only. Newest first. Before and after hold only the columns that changed; secrets are redacted.
+//	@Tags			audit
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Router			/api/audit-events [get]
+//	@Param			subject		query	string	false	"Actor subject"
+//	@Param			user_id		query	int		false	"Actor user id"
+//	@Param			action		query	string	false	"create, update or delete"
+//	@Param			entity_type	query	string	false	"Table name, such as orders"
+//	@Param			entity_id	query	int		false	"Entity id"
+//	@Param			from		query	string	false	"From date, RFC 3339, inclusive"
+//	@Param			to			query	string	false	"To date, RFC 3339, exclusive"
+//	@Param			limit		query	int		false	"Page size, up to 200; defaults to 50"
+//	@Param			cursor		query	string	false	"next_cursor of the previous page"
+//	@Param			offset		query	int		false	"Items to skip, instead of a cursor"
+//	@Success		200 {object} page.Page[dto.AuditEvent]
+//	@Failure		400 {object} err_dto.ErrorResponse
+//	@Failure		401 {object} err_dto.ErrorResponse
+//	@Failure		403 {object} err_dto.ErrorResponse
+//	@Failure		500 {object} err_dto.ErrorResponse
+func (h *AuditEventHandler) GetAll(c *gin.Context) {
+	var search dto.AuditEventQuery
+	if err := c.ShouldBindQuery(&search); err != nil {
+		response := err_dto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	opts, err := helpers.ParseQueryOptions(c.Request.URL.Query())
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	var events *page.Page[dto.AuditEvent]
+	events, err = h.svc.GetAll(c.Request.Context(), search, opts)
+	if errors.Is(err, auditevent.ErrInvalidRange) || errors.Is(err, query.ErrInvalid) {
+		response := err_dto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 500, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	c.JSON(200, events)
+}
@@ -4,12 +4,16 @@ import (
 	auth "commerce/api/internal/auth"
 	dto "commerce/api/internal/dto/category"
 	errdto "commerce/api/internal/dto/err"
+	"commerce/api/internal/dto/page"
 	product_dto "commerce/api/internal/dto/product"
 	"commerce/api/internal/helpers"
 	category_svc "commerce/api/internal/services/category"
 	product_svc "commerce/api/internal/services/product"
+	"commerce/internal/shared/repositories/query"
+	"errors"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 type CategoryHandler struct {
@@ -31,6 +35,8 @@ func (h *CategoryHandler) RegisterRoutes(rg *gin.RouterGroup) {
 	rg.GET("/:id/children", auth.RequireScope(auth.Scopes.Category.Read), h.GetAllByParentId)
 	rg.GET("/", auth.RequireScope(auth.Scopes.Category.Read), h.GetAll)
 	rg.POST("/", auth.RequireScope(auth.Scopes.Category.Write), h.Save)
+	rg.PUT("/:id", auth.RequireScope(auth.Scopes.Category.Write), h.Update)
+	rg.PATCH("/:id", auth.RequireScope(auth.Scopes.Category.Write), h.Patch)
 	rg.DELETE("/:id", auth.RequireScope(auth.Scopes.Category.Write), h.Delete)
 }
 
@@ -54,7 +60,7 @@ func (h *CategoryHandler) Delete(c *gin.Context) {
 		c.JSON(400, errorResponse)
 		return
 	}
-	err = h.svc.Delete(*id, false)
+	err = h.svc.Delete(c.Request.Context(), *id, false)
 	if err != nil {
 		errorResponse := errdto.ErrorResponse{Code: 500, Message: err.Error()}
 		c.JSON(500, errorResponse)
@@ -70,8 +76,15 @@ func (h *CategoryHandler) Delete(c *gin.Context) {
 //	@Produce	json
 //	@Security	BearerAuth
 //	@Param		id	path		int	true	"Category ID"
+//	@Param		limit	query	int		false	"Page size, up to 200; defaults to 50"
+//	@Param		cursor	query	string	false	"next_cursor of the previous page"
+//	@Param		offset	query	int		false	"Items to skip, instead of a cursor"
+//	@Param		sort	query	string	false	"Comma-separated, - for descending: name, price, stock, sku, created_date"
+//	@Param		name	query	string	false	"Name contains"
+//	@Param		min_price	query	number	false	"Minimum price"
+//	@Param		max_price	query	number	false	"Maximum price"
 //	@Router		/api/category/{id}/products [get]
-//	@Success	200	{array}		product_dto.Product
+//	@Success	200	{object}	page.Page[product_dto.Product]
 //	@Failure	400	{object}	errdto.ErrorResponse
 //	@Failure	500	{object}	errdto.ErrorResponse
 //	@Failure	401 {object}	errdto.ErrorResponse
@@ -83,8 +96,19 @@ func (h *CategoryHandler) GetAllProductsByCategory(c *gin.Context) {
 		c.JSON(400, errorResponse)
 		return
 	}
-	var products []*product_dto.Product
-	products, err = h.productSvc.GetAllByCategory(*id)
+	opts, err := helpers.ParseQueryOptions(c.Request.URL.Query(), "name", "sku", "min_price", "max_price", "is_active", "is_featured")
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(400, errorResponse)
+		return
+	}
+	var products *page.Page[product_dto.Product]
+	products, err = h.productSvc.GetAllByCategory(c.Request.Context(), *id, opts)
+	if errors.Is(err, query.ErrInvalid) {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(400, errorResponse)
+		return
+	}
 	if err != nil {
 		errorResponse := errdto.ErrorResponse{Code: 404, Message: err.Error()}
 		c.JSON(errorResponse.Code, errorResponse)
@@ -99,14 +123,32 @@ func (h *CategoryHandler) GetAllProductsByCategory(c *gin.Context) {
 //	@Tags		category
 //	@Produce	json
 //	@Security	BearerAuth
+//	@Param		limit	query	int		false	"Page size, up to 200; defaults to 50"
+//	@Param		cursor	query	string	false	"next_cursor of the previous page"
+//	@Param		offset	query	int		false	"Items to skip, instead of a cursor"
+//	@Param		sort	query	string	false	"Comma-separated, - for descending: name, slug, created_date"
+//	@Param		name	query	string	false	"Name contains"
+//	@Param		is_active	query	bool	false	"Active categories only, or inactive"
 //	@Router		/api/category [get]
-//	@Success	200	{array}		dto.Category
+//	@Success	200	{object}	page.Page[dto.Category]
+//	@Failure	400	{object}	errdto.ErrorResponse
 //	@Failure	500	{object}	errdto.ErrorResponse
 //	@Failure	401 {object}	errdto.ErrorResponse
 //	@Failure	403 {object}	errdto.ErrorResponse
 func (h *CategoryHandler) GetAll(c *gin.Context) {
-	var categories []*dto.Category
-	categories, err := h.svc.GetAll()
+	opts, err := helpers.ParseQueryOptions(c.Request.URL.Query(), "name", "is_active")
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(400, errorResponse)
+		return
+	}
+	var categories *page.Page[dto.Category]
+	categories, err = h.svc.GetAll(c.Request.Context(), opts)
+	if errors.Is(err, query.ErrInvalid) {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(400, errorResponse)
+		return
+	}
 	if err != nil {
 		errorResponse := errdto.ErrorResponse{Code: 500, Message: err.Error()}
 		c.JSON(500, errorResponse)
@@ -122,8 +164,14 @@ func (h *CategoryHandler) GetAll(c *gin.Context) {
 //	@Produce	json
 //	@Security	BearerAuth
 //	@Param		id	path		int	true	"Category ID"
+//	@Param		limit	query	int		false	"Page size, up to 200; defaults to 50"
+//	@Param		cursor	query	string	false	"next_cursor of the previous page"
+//	@Param		offset	query	int		false	"Items to skip, instead of a cursor"
+//	@Param		sort	query	string	false	"Comma-separated, - for descending: name, slug, created_date"
+//	@Param		name	query	string	false	"Name contains"
+//	@Param		is_active	query	bool	false	"Active categories only, or inactive"
 //	@Router		/api/category/{id}/children [get]
-//	@Success	200	{array}		dto.Category
+//	@Success	200	{object}	page.Page[dto.Category]
 //	@Failure	400	{object}	errdto.ErrorResponse
 //	@Failure	500	{object}	errdto.ErrorResponse
 //	@Failure	401 {object}	errdto.ErrorResponse
@@ -135,8 +183,19 @@ func (h *CategoryHandler) GetAllByParentId(c *gin.Context) {
 		c.JSON(400, errorResponse)
 		return
 	}
-	var categories []*dto.Category
-	categories, err = h.svc.GetAllByParentId(*id)
+	opts, err := helpers.ParseQueryOptions(c.Request.URL.Query(), "name", "is_active")
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(400, errorResponse)
+		return
+	}
+	var categories *page.Page[dto.Category]
+	categories, err = h.svc.GetAllByParentId(c.Request.Context(), *id, opts)
+	if errors.Is(err, query.ErrInvalid) {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(400, errorResponse)
+		return
+	}
 	if err != nil {
 		errorResponse := errdto.ErrorResponse{Code: 500, Message: err.Error()}
 		c.JSON(500, errorResponse)
@@ -167,7 +226,7 @@ func (h *CategoryHandler) GetById(c *gin.Context) {
 	}
 
 	var category *dto.Category
-	category, err = h.svc.GetById(*id)
+	category, err = h.svc.GetById(c.Request.Context(), *id)
 	if err != nil {
 		errorResponse := errdto.ErrorResponse{Code: 500, Message: err.Error()}
 		c.JSON(errorResponse.Code, errorResponse)
@@ -196,7 +255,7 @@ func (h *CategoryHandler) Save(c *gin.Context) {
 		c.JSON(400, errorResponse)
 		return
 	}
-	err := h.svc.Save(category)
+	err := h.svc.Save(c.Request.Context(), category)
 	if err != nil {
 		errorResponse := errdto.ErrorResponse{Code: 500, Message: err.Error()}
 		c.JSON(500, errorResponse)
@@ -204,3 +263,90 @@ func (h *CategoryHandler) Save(c *gin.Context) {
 	}
 	c.JSON(201, category)
 }
+
+// UpdateCategory godoc
+//
+//	@Summary		Replace the category
+//	@Description	Fields left out are reset. A category can't be moved under itself or one of its subcategories.
+//	@Tags			category
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Router			/api/category/{id} [put]
+//	@Param			id			path	int				true	"Category ID"
+//	@Param			category	body	dto.Category	true	"Provide category object"
+//	@Success		200 {object}	dto.Category
+//	@Failure		400 {object}	errdto.ErrorResponse
+//	@Failure		404 {object}	errdto.ErrorResponse
+//	@Failure		500 {object}	errdto.ErrorResponse
+//	@Failure		401 {object}	errdto.ErrorResponse
+//	@Failure		403 {object}	errdto.ErrorResponse
+func (h *CategoryHandler) Update(c *gin.Context) {
+	id, err := helpers.ParseParamToUint(c.Param("id"))
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: "invalid id"}
+		c.JSON(400, errorResponse)
+		return
+	}
+	var category dto.Category
+	if err := c.ShouldBindJSON(&category); err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(400, errorResponse)
+		return
+	}
+	h.update(c, *id, &category)
+}
+
+// PatchCategory godoc
+//
+//	@Summary		Update the category
+//	@Description	Applies a JSON merge patch (RFC 7396): fields given replace the category's, null resets one and fields left out are kept.
+//	@Tags			category
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Router			/api/category/{id} [patch]
+//	@Param			id		path	int				true	"Category ID"
+//	@Param			patch	body	dto.Category	true	"Fields to change"
+//	@Success		200 {object}	dto.Category
+//	@Failure		400 {object}	errdto.ErrorResponse
+//	@Failure		404 {object}	errdto.ErrorResponse
+//	@Failure		500 {object}	errdto.ErrorResponse
+//	@Failure		401 {object}	errdto.ErrorResponse
+//	@Failure		403 {object}	errdto.ErrorResponse
+func (h *CategoryHandler) Patch(c *gin.Context) {
+	id, err := helpers.ParseParamToUint(c.Param("id"))
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: "invalid id"}
+		c.JSON(400, errorResponse)
+		return
+	}
+	category, err := h.svc.GetById(c.Request.Context(), *id)
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 404, Message: err.Error()}
+		c.JSON(404, errorResponse)
+		return
+	}
+	if err := helpers.BindMergePatch(c, category); err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(400, errorResponse)
+		return
+	}
+	h.update(c, *id, category)
+}
+
+func (h *CategoryHandler) update(c *gin.Context, id uint, category *dto.Category) {
+	err := h.svc.Update(c.Request.Context(), id, category)
+	if err != nil {
+		code := 500
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			code = 404
+		case errors.Is(err, category_svc.ErrInvalidParent):
+			code = 400
+		}
+		errorResponse := errdto.ErrorResponse{Code: code, Message: err.Error()}
+		c.JSON(code, errorResponse)
+		return
+	}
+	c.JSON(200, category)
+}
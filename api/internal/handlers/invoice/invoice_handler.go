@@ -0,0 +1,166 @@
+package invoice
+
+import (
+	"bytes"
+	auth "commerce/api/internal/auth"
+	"commerce/api/internal/helpers"
+	invoice_service "commerce/api/internal/services/invoice"
+	order_service "commerce/api/internal/services/order"
+	"errors"
+	"fmt"
+
+	err_dto "commerce/api/internal/dto/err"
+	dto "commerce/api/internal/dto/invoice"
+
+	"github.com/gin-gonic/gin"
+)
+
+type InvoiceHandler struct {
+	svc      invoice_service.InvoiceServiceI
+	orderSvc order_service.OrderServiceI
+}
+
+func NewInvoiceHandler(svc invoice_service.InvoiceServiceI, orderSvc order_service.OrderServiceI) *InvoiceHandler {
+	return &InvoiceHandler{svc: svc, orderSvc: orderSvc}
+}
+
+func (h *InvoiceHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.GET("/:id", auth.RequireScope(auth.Scopes.Orders.Read), h.GetById)
+}
+
+// GetInvoice godoc
+//
+//	@Summary		Get an invoice or credit note
+//	@Description	Rendered as HTML by default; format=pdf returns a PDF and format=json the document itself.
+//	@Tags			invoice
+//	@Produce		html
+//	@Produce		application/pdf
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Router			/api/invoices/{id} [get]
+//	@Param			id		path	int		true	"Invoice Id"
+//	@Param			format	query	string	false	"html, pdf or json"	Enums(html, pdf, json)
+//	@Success		200 {object} dto.Invoice
+//	@Failure		400 {object} err_dto.ErrorResponse
+//	@Failure		401 {object} err_dto.ErrorResponse
+//	@Failure		403 {object} err_dto.ErrorResponse
+//	@Failure		404 {object} err_dto.ErrorResponse
+func (h *InvoiceHandler) GetById(c *gin.Context) {
+	id, err := helpers.ParseParamToUint(c.Param("id"))
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+
+	var invoice *dto.Invoice
+	invoice, err = h.svc.GetById(c.Request.Context(), *id)
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 404, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	if !auth.AuthorizeReadOwnerOf(c, invoice.OrderId, h.orderSvc.GetOwnerId) {
+		return
+	}
+	render(c, invoice)
+}
+
+// GetOrderInvoice godoc
+//
+//	@Summary		Get the invoice of an order
+//	@Description	Orders are invoiced once their payments cover the total. Rendered as HTML by default;
+//	@Description	format=pdf returns a PDF and format=json the document itself.
+//	@Tags			invoice
+//	@Produce		html
+//	@Produce		application/pdf
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Router			/api/orders/{id}/invoice [get]
+//	@Param			id		path	int		true	"Order Id"
+//	@Param			format	query	string	false	"html, pdf or json"	Enums(html, pdf, json)
+//	@Success		200 {object} dto.Invoice
+//	@Failure		400 {object} err_dto.ErrorResponse
+//	@Failure		401 {object} err_dto.ErrorResponse
+//	@Failure		403 {object} err_dto.ErrorResponse
+//	@Failure		404 {object} err_dto.ErrorResponse
+//	@Failure		500 {object} err_dto.ErrorResponse
+func (h *InvoiceHandler) GetByOrder(c *gin.Context) {
+	orderId, err := helpers.ParseParamToUint(c.Param("id"))
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+
+	invoice, err := h.svc.GetInvoice(c.Request.Context(), *orderId)
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 500, Message: err.Error()}
+		if errors.Is(err, invoice_service.ErrNotInvoiced) {
+			response.Code = 404
+		}
+		c.JSON(response.Code, response)
+		return
+	}
+	render(c, invoice)
+}
+
+// GetOrderInvoices godoc
+//
+//	@Summary	Get the invoice and credit notes of an order
+//	@Tags		invoice
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Router		/api/orders/{id}/invoices [get]
+//	@Param		id	path	int	true	"Order Id"
+//	@Success	200 {array} dto.Invoice
+//	@Failure	400 {object} err_dto.ErrorResponse
+//	@Failure	401 {object} err_dto.ErrorResponse
+//	@Failure	403 {object} err_dto.ErrorResponse
+//	@Failure	500 {object} err_dto.ErrorResponse
+func (h *InvoiceHandler) GetAllByOrder(c *gin.Context) {
+	orderId, err := helpers.ParseParamToUint(c.Param("id"))
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+
+	var invoices []*dto.Invoice
+	invoices, err = h.svc.GetByOrderId(c.Request.Context(), *orderId)
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 500, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	c.JSON(200, invoices)
+}
+
+func render(c *gin.Context, invoice *dto.Invoice) {
+	var buf bytes.Buffer
+	var err error
+	contentType := "text/html; charset=utf-8"
+	switch format := c.DefaultQuery("format", "html"); format {
+	case "json":
+		c.JSON(200, invoice)
+		return
+	case "html":
+		err = invoice_service.RenderHTML(&buf, invoice)
+	case "pdf":
+		contentType = "application/pdf"
+		err = invoice_service.RenderPDF(&buf, invoice)
+	default:
+		response := err_dto.ErrorResponse{Code: 400, Message: fmt.Sprintf("unsupported format %q", format)}
+		c.JSON(response.Code, response)
+		return
+	}
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 500, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	if contentType == "application/pdf" {
+		c.Header("Content-Disposition", fmt.Sprintf(`inline; filename="%s.pdf"`, invoice.InvoiceNumber))
+	}
+	c.Data(200, contentType, buf.Bytes())
+}
@@ -0,0 +1,276 @@
+package me
+
+import (
+	"bytes"
+	auth "commerce/api/internal/auth"
+	"commerce/api/internal/helpers"
+	"commerce/api/internal/services/address"
+	"commerce/api/internal/services/order"
+	"commerce/api/internal/services/privacy"
+	"commerce/api/internal/services/review"
+	"commerce/api/internal/services/user"
+	"commerce/internal/shared/repositories/query"
+	"errors"
+	"fmt"
+
+	address_dto "commerce/api/internal/dto/address"
+	err_dto "commerce/api/internal/dto/err"
+	order_dto "commerce/api/internal/dto/order"
+	"commerce/api/internal/dto/page"
+	privacy_dto "commerce/api/internal/dto/privacy"
+	review_dto "commerce/api/internal/dto/review"
+	dto "commerce/api/internal/dto/user"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MeHandler serves the caller's own account. The user comes from the token,
+// so clients don't need to know their numeric user id.
+type MeHandler struct {
+	userSvc    user.UserServiceI
+	orderSvc   order.OrderServiceI
+	addressSvc address.AddressServiceI
+	reviewSvc  review.ReviewServiceI
+	privacySvc privacy.PrivacyServiceI
+}
+
+func NewMeHandler(userSvc user.UserServiceI,
+	orderSvc order.OrderServiceI,
+	addressSvc address.AddressServiceI,
+	reviewSvc review.ReviewServiceI,
+	privacySvc privacy.PrivacyServiceI) *MeHandler {
+	return &MeHandler{userSvc: userSvc, orderSvc: orderSvc, addressSvc: addressSvc, reviewSvc: reviewSvc, privacySvc: privacySvc}
+}
+
+func (h *MeHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.Use(auth.RequireUser())
+	rg.GET("", auth.RequireScope(auth.Scopes.Users.Read), h.Get)
+	rg.PATCH("", auth.RequireScope(auth.Scopes.Users.Write), h.Update)
+	rg.GET("/orders", auth.RequireScope(auth.Scopes.Orders.Read), h.GetOrders)
+	rg.GET("/addresses", auth.RequireScope(auth.Scopes.Users.Read), h.GetAddresses)
+	rg.GET("/reviews", auth.RequireScope(auth.Scopes.Reviews.Read), h.GetReviews)
+	rg.GET("/export", auth.RequireScope(auth.Scopes.Users.Read), h.Export)
+}
+
+// GetMe godoc
+//
+//	@Summary		Get the caller's user
+//	@Description	Only user tokens have a user; M2M tokens get 403.
+//	@Tags			me
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Router			/api/me [get]
+//	@Success		200 {object} dto.User
+//	@Failure		401 {object} err_dto.ErrorResponse
+//	@Failure		403 {object} err_dto.ErrorResponse
+//	@Failure		404 {object} err_dto.ErrorResponse
+func (h *MeHandler) Get(c *gin.Context) {
+	usr, err := h.userSvc.GetById(c.Request.Context(), userId(c))
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 404, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	c.JSON(200, usr)
+}
+
+// UpdateMe godoc
+//
+//	@Summary		Update the caller's profile
+//	@Description	Only the name can be changed; fields left out are kept as they are.
+//	@Tags			me
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Router			/api/me [patch]
+//	@Param			profile	body	dto.Profile	true	"Provide the fields to change"
+//	@Success		200 {object} dto.User
+//	@Failure		400 {object} err_dto.ErrorResponse
+//	@Failure		401 {object} err_dto.ErrorResponse
+//	@Failure		403 {object} err_dto.ErrorResponse
+//	@Failure		500 {object} err_dto.ErrorResponse
+func (h *MeHandler) Update(c *gin.Context) {
+	var profile dto.Profile
+	if err := c.ShouldBindJSON(&profile); err != nil {
+		response := err_dto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	usr, err := h.userSvc.UpdateProfile(c.Request.Context(), userId(c), profile)
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 500, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	c.JSON(200, usr)
+}
+
+// GetMyOrders godoc
+//
+//	@Summary	Get the caller's orders
+//	@Tags		me
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		limit	query	int		false	"Page size, up to 200; defaults to 50"
+//	@Param		cursor	query	string	false	"next_cursor of the previous page"
+//	@Param		offset	query	int		false	"Items to skip, instead of a cursor"
+//	@Param		sort	query	string	false	"Comma-separated, - for descending: order_number, total_amount, status, created_date"
+//	@Param		status	query	string	false	"Order status"
+//	@Param		from	query	string	false	"Placed on or after, RFC 3339"
+//	@Param		to		query	string	false	"Placed before, RFC 3339"
+//	@Router		/api/me/orders [get]
+//	@Success	200 {object} page.Page[order_dto.Order]
+//	@Failure	400 {object} err_dto.ErrorResponse
+//	@Failure	401 {object} err_dto.ErrorResponse
+//	@Failure	403 {object} err_dto.ErrorResponse
+//	@Failure	500 {object} err_dto.ErrorResponse
+func (h *MeHandler) GetOrders(c *gin.Context) {
+	opts, err := helpers.ParseQueryOptions(c.Request.URL.Query(), "status", "from", "to")
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	var orders *page.Page[order_dto.Order]
+	orders, err = h.orderSvc.GetByUserId(c.Request.Context(), userId(c), opts)
+	if errors.Is(err, query.ErrInvalid) {
+		response := err_dto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 500, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	c.JSON(200, orders)
+}
+
+// GetMyAddresses godoc
+//
+//	@Summary	Get the caller's address book
+//	@Tags		me
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		limit	query	int		false	"Page size, up to 200; defaults to 50"
+//	@Param		cursor	query	string	false	"next_cursor of the previous page"
+//	@Param		offset	query	int		false	"Items to skip, instead of a cursor"
+//	@Param		sort	query	string	false	"Comma-separated, - for descending: created_date"
+//	@Param		country	query	string	false	"Country"
+//	@Param		is_default	query	bool	false	"Default address only, or not"
+//	@Router		/api/me/addresses [get]
+//	@Success	200 {object} page.Page[address_dto.Address]
+//	@Failure	400 {object} err_dto.ErrorResponse
+//	@Failure	401 {object} err_dto.ErrorResponse
+//	@Failure	403 {object} err_dto.ErrorResponse
+//	@Failure	500 {object} err_dto.ErrorResponse
+func (h *MeHandler) GetAddresses(c *gin.Context) {
+	opts, err := helpers.ParseQueryOptions(c.Request.URL.Query(), "country", "is_default")
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	var addresses *page.Page[address_dto.Address]
+	addresses, err = h.addressSvc.GetAllByUserId(c.Request.Context(), userId(c), opts)
+	if errors.Is(err, query.ErrInvalid) {
+		response := err_dto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 500, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	c.JSON(200, addresses)
+}
+
+// GetMyReviews godoc
+//
+//	@Summary	Get the caller's reviews
+//	@Tags		me
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		limit	query	int		false	"Page size, up to 200; defaults to 50"
+//	@Param		cursor	query	string	false	"next_cursor of the previous page"
+//	@Param		offset	query	int		false	"Items to skip, instead of a cursor"
+//	@Param		sort	query	string	false	"Comma-separated, - for descending: rating, created_date"
+//	@Param		rating	query	int		false	"Rating"
+//	@Param		min_rating	query	int	false	"Minimum rating"
+//	@Router		/api/me/reviews [get]
+//	@Success	200 {object} page.Page[review_dto.Review]
+//	@Failure	400 {object} err_dto.ErrorResponse
+//	@Failure	401 {object} err_dto.ErrorResponse
+//	@Failure	403 {object} err_dto.ErrorResponse
+//	@Failure	500 {object} err_dto.ErrorResponse
+func (h *MeHandler) GetReviews(c *gin.Context) {
+	opts, err := helpers.ParseQueryOptions(c.Request.URL.Query(), "rating", "min_rating")
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	var reviews *page.Page[review_dto.Review]
+	reviews, err = h.reviewSvc.GetAllByUser(c.Request.Context(), userId(c), opts)
+	if errors.Is(err, query.ErrInvalid) {
+		response := err_dto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 500, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	c.JSON(200, reviews)
+}
+
+// ExportMe godoc
+//
+//	@Summary		Export everything held about the caller
+//	@Description	Profile, addresses, orders, payments and reviews. format=zip returns a ZIP with one JSON file per section.
+//	@Tags			me
+//	@Produce		json
+//	@Produce		application/zip
+//	@Security		BearerAuth
+//	@Router			/api/me/export [get]
+//	@Param			format	query	string	false	"json or zip"	Enums(json, zip)
+//	@Success		200 {object} privacy_dto.Export
+//	@Failure		400 {object} err_dto.ErrorResponse
+//	@Failure		401 {object} err_dto.ErrorResponse
+//	@Failure		403 {object} err_dto.ErrorResponse
+//	@Failure		500 {object} err_dto.ErrorResponse
+func (h *MeHandler) Export(c *gin.Context) {
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "zip" {
+		response := err_dto.ErrorResponse{Code: 400, Message: fmt.Sprintf("unknown format: %s", format)}
+		c.JSON(response.Code, response)
+		return
+	}
+	var export *privacy_dto.Export
+	export, err := h.privacySvc.Export(c.Request.Context(), userId(c))
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 500, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	if format == "json" {
+		c.JSON(200, export)
+		return
+	}
+	var buf bytes.Buffer
+	if err := privacy.WriteZip(&buf, export); err != nil {
+		response := err_dto.ErrorResponse{Code: 500, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="export-%d.zip"`, export.Profile.Id))
+	c.Data(200, "application/zip", buf.Bytes())
+}
+
+// userId is the caller's user id. Every route is behind [auth.RequireUser],
+// so it is always set.
+func userId(c *gin.Context) uint {
+	return *auth.GetIdentity(c).UserId
+}
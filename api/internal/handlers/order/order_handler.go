@@ -3,28 +3,35 @@ package order
 import (
 	auth "commerce/api/internal/auth"
 	"commerce/api/internal/helpers"
-	"commerce/api/internal/services/order"
+	order_service "commerce/api/internal/services/order"
+	"commerce/internal/shared/repositories/query"
+	"errors"
 
 	err_dto "commerce/api/internal/dto/err"
 	dto "commerce/api/internal/dto/order"
+	"commerce/api/internal/dto/page"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 type OrderHandler struct {
-	svc order.OrderServiceI
+	svc order_service.OrderServiceI
 }
 
-func NewOrderHandler(svc order.OrderServiceI) *OrderHandler {
+func NewOrderHandler(svc order_service.OrderServiceI) *OrderHandler {
 	return &OrderHandler{svc: svc}
 }
 
 func (h *OrderHandler) RegisterRoutes(rg *gin.RouterGroup) {
 	rg.GET("/:id", auth.RequireScope(auth.Scopes.Orders.Read), h.GetById)
 	rg.GET("/statuses", auth.RequireScope(auth.Scopes.Orders.Read), h.GetStatuses)
+	rg.GET("/by-number/:number", auth.RequireScope(auth.Scopes.Orders.Read), h.GetByOrderNumber)
 	rg.POST("/", auth.RequireScope(auth.Scopes.Orders.Write), h.Save)
-	rg.PATCH("/:id/status", auth.RequireScope(auth.Scopes.Orders.Write), h.UpdateStatus)
-	rg.DELETE("/:id", auth.RequireScope(auth.Scopes.Orders.Write), h.Delete)
+	rg.PATCH("/:id/status", auth.RequireStaff(), h.UpdateStatus)
+	rg.POST("/:id/cancel", auth.RequireScope(auth.Scopes.Orders.Write), auth.RequireOwnerOf("id", h.svc.GetOwnerId), h.Cancel)
+	rg.DELETE("/:id", auth.RequireScope(auth.Scopes.Orders.Write), auth.RequireOwnerOf("id", h.svc.GetOwnerId), h.Delete)
+	rg.POST("/:id/restore", auth.RequireRole(auth.RoleAdmin), h.Restore)
 }
 
 // GetOrder godoc
@@ -39,7 +46,7 @@ func (h *OrderHandler) RegisterRoutes(rg *gin.RouterGroup) {
 //	@Failure	400 {object} err_dto.ErrorResponse
 //	@Failure	401 {object} err_dto.ErrorResponse
 //	@Failure	403 {object} err_dto.ErrorResponse
-//	@Failure	500 {object} err_dto.ErrorResponse
+//	@Failure	404 {object} err_dto.ErrorResponse
 func (h *OrderHandler) GetById(c *gin.Context) {
 	id, err := helpers.ParseParamToUint(c.Param("id"))
 	if err != nil {
@@ -49,12 +56,47 @@ func (h *OrderHandler) GetById(c *gin.Context) {
 	}
 
 	var order *dto.Order
-	order, err = h.svc.GetById(*id)
+	order, err = h.svc.GetById(c.Request.Context(), *id)
 	if err != nil {
 		response := err_dto.ErrorResponse{Code: 404, Message: err.Error()}
 		c.JSON(response.Code, response)
 		return
 	}
+	if !auth.AuthorizeRead(c, order.UserId) {
+		return
+	}
+	c.JSON(200, order)
+}
+
+// GetOrderByNumber godoc
+//
+//	@Summary		Get the order by its order number
+//	@Description	Order numbers carry a check digit; a mistyped number is rejected with 400 rather than 404.
+//	@Tags			order
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Router			/api/orders/by-number/{number} [get]
+//	@Param			number	path	string	true	"Order number, e.g. ORD-20261019-0004277"
+//	@Success		200 {object} dto.Order
+//	@Failure		400 {object} err_dto.ErrorResponse
+//	@Failure		401 {object} err_dto.ErrorResponse
+//	@Failure		403 {object} err_dto.ErrorResponse
+//	@Failure		404 {object} err_dto.ErrorResponse
+func (h *OrderHandler) GetByOrderNumber(c *gin.Context) {
+	order, err := h.svc.GetByOrderNumber(c.Request.Context(), c.Param("number"))
+	if errors.Is(err, order_service.ErrInvalidOrderNumber) {
+		response := err_dto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 404, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	if !auth.AuthorizeRead(c, order.UserId) {
+		return
+	}
 	c.JSON(200, order)
 }
 
@@ -70,24 +112,26 @@ func (h *OrderHandler) GetById(c *gin.Context) {
 //	@Failure	403	{object}	err_dto.ErrorResponse
 //	@Failure	404	{object}	err_dto.ErrorResponse
 func (h *OrderHandler) GetStatuses(c *gin.Context) {
-	statuses := h.svc.GetStatuses()
+	statuses := h.svc.GetStatuses(c.Request.Context())
 	c.JSON(200, statuses)
 }
 
 // UpdateStatus godoc
 //
-//	@Summary	update order status
-//	@Tags		order
-//	@Produce	json
-//	@Security	BearerAuth
-//	@Router		/api/orders/{id}/status [patch]
-//	@Param		id		path		int		true	"Order ID"
-//	@Param   order_status  body      dto.OrderStatus  true  "Provide order status object"
-//	@Success	204
-//	@Failure	400 {object} err_dto.ErrorResponse
-//	@Failure	401 {object} err_dto.ErrorResponse
-//	@Failure	403 {object} err_dto.ErrorResponse
-//	@Failure	500 {object} err_dto.ErrorResponse
+//	@Summary		update order status
+//	@Description	Staff only: the admin or support role. Customers cancel with POST /api/orders/{id}/cancel.
+//	@Tags			order
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Router			/api/orders/{id}/status [patch]
+//	@Param			id				path	int				true	"Order ID"
+//	@Param			order_status	body	dto.OrderStatus	true	"Provide order status object"
+//	@Success		204
+//	@Failure		400 {object} err_dto.ErrorResponse
+//	@Failure		401 {object} err_dto.ErrorResponse
+//	@Failure		403 {object} err_dto.ErrorResponse
+//	@Failure		404 {object} err_dto.ErrorResponse
+//	@Failure		500 {object} err_dto.ErrorResponse
 func (h *OrderHandler) UpdateStatus(c *gin.Context) {
 	id, err := helpers.ParseParamToUint(c.Param("id"))
 	if err != nil {
@@ -101,7 +145,7 @@ func (h *OrderHandler) UpdateStatus(c *gin.Context) {
 		c.JSON(errorResponse.Code, errorResponse)
 		return
 	}
-	err = h.svc.UpdateStatus(*id, status.Status)
+	err = h.svc.UpdateStatus(c.Request.Context(), *id, status.Status)
 	if err != nil {
 		errorResponse := err_dto.ErrorResponse{Code: 500, Message: err.Error()}
 		c.JSON(500, errorResponse)
@@ -110,6 +154,47 @@ func (h *OrderHandler) UpdateStatus(c *gin.Context) {
 	c.JSON(204, nil)
 }
 
+// CancelOrder godoc
+//
+//	@Summary		Cancel the order
+//	@Description	Cancels an order that hasn't shipped. Authorized payments are voided, captured ones refunded,
+//	@Description	and the items go back in stock. Only the order's owner, an admin or a back-office (M2M) client may cancel.
+//	@Tags			order
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Router			/api/orders/{id}/cancel [post]
+//	@Param			id		path	int				true	"Order ID"
+//	@Param			cancel	body	dto.CancelOrder	true	"Provide cancellation reason"
+//	@Success		200 {object} dto.Order
+//	@Failure		400 {object} err_dto.ErrorResponse
+//	@Failure		401 {object} err_dto.ErrorResponse
+//	@Failure		403 {object} err_dto.ErrorResponse
+//	@Failure		404 {object} err_dto.ErrorResponse
+//	@Failure		422 {object} err_dto.ErrorResponse
+func (h *OrderHandler) Cancel(c *gin.Context) {
+	id, err := helpers.ParseParamToUint(c.Param("id"))
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	var request dto.CancelOrder
+	if err := c.ShouldBindJSON(&request); err != nil {
+		response := err_dto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+
+	cancelled, err := h.svc.Cancel(c.Request.Context(), *id, request.Reason)
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 422, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	c.JSON(200, cancelled)
+}
+
 // DeleteOrder godoc
 //
 //	@Summary	Delete the order
@@ -123,6 +208,7 @@ func (h *OrderHandler) UpdateStatus(c *gin.Context) {
 //	@Failure	400	{object}	err_dto.ErrorResponse
 //	@Failure	401	{object}	err_dto.ErrorResponse
 //	@Failure	403	{object}	err_dto.ErrorResponse
+//	@Failure	404	{object}	err_dto.ErrorResponse
 //	@Failure	500	{object}	err_dto.ErrorResponse
 func (h *OrderHandler) Delete(c *gin.Context) {
 	id, err := helpers.ParseParamToUint(c.Param("id"))
@@ -132,7 +218,7 @@ func (h *OrderHandler) Delete(c *gin.Context) {
 		return
 	}
 	hard := c.DefaultQuery("hard", "false") == "true"
-	err = h.svc.Delete(*id, hard)
+	err = h.svc.Delete(c.Request.Context(), *id, hard)
 	if err != nil {
 		errorResponse := err_dto.ErrorResponse{Code: 500, Message: err.Error()}
 		c.JSON(errorResponse.Code, errorResponse)
@@ -143,17 +229,21 @@ func (h *OrderHandler) Delete(c *gin.Context) {
 
 // Saveorder godoc
 //
-//	@Summary	Save the order
-//	@Tags		order
-//	@Produce	json
-//	@Security	BearerAuth
-//	@Router		/api/orders [post]
-//	@Param   order  body      dto.Order  true  "Provide order object"
-//	@Success	201 {object} dto.Order
-//	@Failure	400 {object} err_dto.ErrorResponse
-//	@Failure	401 {object} err_dto.ErrorResponse
-//	@Failure	403 {object} err_dto.ErrorResponse
-//	@Failure	500 {object} err_dto.ErrorResponse
+//	@Summary		Save the order
+//	@Description	billing_address and shipping_address take either an address_id from the user's address book or an inline address.
+//	@Description	Either way the order keeps its own copy, so later changes to the address book don't alter it.
+//	@Description	This always creates a pending order: id and status are ignored. Items are charged the active price of their product or variant; unit_price is ignored.
+//	@Description	Responds with the created order, including its id, order_number and computed amounts.
+//	@Tags			order
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Router			/api/orders [post]
+//	@Param			order	body	dto.Order	true	"Provide order object"
+//	@Success		201 {object} dto.Order
+//	@Failure		400 {object} err_dto.ErrorResponse
+//	@Failure		401 {object} err_dto.ErrorResponse
+//	@Failure		403 {object} err_dto.ErrorResponse
+//	@Failure		500 {object} err_dto.ErrorResponse
 func (h *OrderHandler) Save(c *gin.Context) {
 	var order *dto.Order
 	if err := c.ShouldBindJSON(&order); err != nil {
@@ -161,13 +251,20 @@ func (h *OrderHandler) Save(c *gin.Context) {
 		c.JSON(errorResponse.Code, errorResponse)
 		return
 	}
-	err := h.svc.Save(*order)
+	if !auth.Authorize(c, order.UserId) {
+		return
+	}
+	created, err := h.svc.Save(c.Request.Context(), *order)
 	if err != nil {
 		errorResponse := err_dto.ErrorResponse{Code: 500, Message: err.Error()}
-		c.JSON(500, errorResponse)
+		if errors.Is(err, order_service.ErrInvalidAddress) || errors.Is(err, order_service.ErrInvalidVariant) ||
+			errors.Is(err, order_service.ErrInvalidProduct) || errors.Is(err, order_service.ErrInvalidQuantity) {
+			errorResponse.Code = 400
+		}
+		c.JSON(errorResponse.Code, errorResponse)
 		return
 	}
-	c.JSON(201, order)
+	c.JSON(201, created)
 }
 
 // GetOrders godoc
@@ -177,10 +274,20 @@ func (h *OrderHandler) Save(c *gin.Context) {
 //	@Produce	json
 //	@Security	BearerAuth
 //	@Router		/api/users/{user_id}/orders [get]
-//	@Param		user_id	path	int	true	"User Id"
-//	@Success	200 {array} dto.Order
+//	@Param		user_id			path	int		true	"User Id"
+//	@Param		include_deleted	query	bool	false	"Include deleted orders, admins only"
+//	@Param		limit	query	int		false	"Page size, up to 200; defaults to 50"
+//	@Param		cursor	query	string	false	"next_cursor of the previous page"
+//	@Param		offset	query	int		false	"Items to skip, instead of a cursor"
+//	@Param		sort	query	string	false	"Comma-separated, - for descending: order_number, total_amount, status, created_date"
+//	@Param		status	query	string	false	"Order status"
+//	@Param		from	query	string	false	"Placed on or after, RFC 3339"
+//	@Param		to		query	string	false	"Placed before, RFC 3339"
+//	@Success	200 {object} page.Page[dto.Order]
 //	@Failure	400 {object} err_dto.ErrorResponse
-//	@Failure	500 {object} err_dto.ErrorResponse
+//	@Failure	401 {object} err_dto.ErrorResponse
+//	@Failure	403 {object} err_dto.ErrorResponse
+//	@Failure	404 {object} err_dto.ErrorResponse
 func (h *OrderHandler) GetByUser(c *gin.Context) {
 	userId, err := helpers.ParseParamToUint(c.Param("user_id"))
 	if err != nil {
@@ -188,8 +295,24 @@ func (h *OrderHandler) GetByUser(c *gin.Context) {
 		c.JSON(response.Code, response)
 		return
 	}
-	var orders []*dto.Order
-	orders, err = h.svc.GetByUserId(*userId)
+	includeDeleted, ok := auth.IncludeDeleted(c)
+	if !ok {
+		return
+	}
+	opts, err := helpers.ParseQueryOptions(c.Request.URL.Query(), "status", "from", "to")
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	opts.IncludeDeleted = includeDeleted
+	var orders *page.Page[dto.Order]
+	orders, err = h.svc.GetByUserId(c.Request.Context(), *userId, opts)
+	if errors.Is(err, query.ErrInvalid) {
+		response := err_dto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
 	if err != nil {
 		response := err_dto.ErrorResponse{Code: 404, Message: err.Error()}
 		c.JSON(response.Code, response)
@@ -197,3 +320,39 @@ func (h *OrderHandler) GetByUser(c *gin.Context) {
 	}
 	c.JSON(200, orders)
 }
+
+// RestoreOrder godoc
+//
+//	@Summary		Restore a deleted order
+//	@Description	Admin only. Undoes a soft delete.
+//	@Tags			order
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Router			/api/orders/{id}/restore [post]
+//	@Param			id	path	int	true	"Order ID"
+//	@Success		204
+//	@Failure		400	{object}	err_dto.ErrorResponse
+//	@Failure		401	{object}	err_dto.ErrorResponse
+//	@Failure		403	{object}	err_dto.ErrorResponse
+//	@Failure		404	{object}	err_dto.ErrorResponse
+//	@Failure		500	{object}	err_dto.ErrorResponse
+func (h *OrderHandler) Restore(c *gin.Context) {
+	id, err := helpers.ParseParamToUint(c.Param("id"))
+	if err != nil {
+		errorResponse := err_dto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(errorResponse.Code, errorResponse)
+		return
+	}
+	err = h.svc.Restore(c.Request.Context(), *id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		errorResponse := err_dto.ErrorResponse{Code: 404, Message: "no deleted order with that id"}
+		c.JSON(errorResponse.Code, errorResponse)
+		return
+	}
+	if err != nil {
+		errorResponse := err_dto.ErrorResponse{Code: 500, Message: err.Error()}
+		c.JSON(errorResponse.Code, errorResponse)
+		return
+	}
+	c.JSON(204, nil)
+}
@@ -3,7 +3,12 @@ package payment
 import (
 	auth "commerce/api/internal/auth"
 	"commerce/api/internal/helpers"
+	invoice_service "commerce/api/internal/services/invoice"
+	order_service "commerce/api/internal/services/order"
 	"commerce/api/internal/services/payment"
+	"context"
+	"errors"
+	"log/slog"
 
 	err_dto "commerce/api/internal/dto/err"
 	dto "commerce/api/internal/dto/payment"
@@ -12,19 +17,23 @@ import (
 )
 
 type PaymentHandler struct {
-	svc payment.PaymentServiceI
+	svc        payment.PaymentServiceI
+	invoiceSvc invoice_service.InvoiceServiceI
+	orderSvc   order_service.OrderServiceI
 }
 
-func NewPaymentHandler(svc payment.PaymentServiceI) *PaymentHandler {
-	return &PaymentHandler{svc: svc}
+func NewPaymentHandler(svc payment.PaymentServiceI,
+	invoiceSvc invoice_service.InvoiceServiceI,
+	orderSvc order_service.OrderServiceI) *PaymentHandler {
+	return &PaymentHandler{svc: svc, invoiceSvc: invoiceSvc, orderSvc: orderSvc}
 }
 
 func (h *PaymentHandler) RegisterRoutes(rg *gin.RouterGroup) {
 	rg.GET("/:id", auth.RequireScope(auth.Scopes.Payment.Read), h.GetById)
 	rg.GET("/statuses", auth.RequireScope(auth.Scopes.Payment.Read), h.GetStatuses)
 	rg.POST("/", auth.RequireScope(auth.Scopes.Payment.Write), h.Save)
-	rg.PATCH("/:id/status", auth.RequireScope(auth.Scopes.Payment.Write), h.UpdateStatus)
-	rg.DELETE("/:id", auth.RequireScope(auth.Scopes.Payment.Write), h.Delete)
+	rg.PATCH("/:id/status", auth.RequireStaff(), h.UpdateStatus)
+	rg.DELETE("/:id", auth.RequireStaff(), h.Delete)
 }
 
 // GetPayment godoc
@@ -40,6 +49,7 @@ func (h *PaymentHandler) RegisterRoutes(rg *gin.RouterGroup) {
 //	@Failure	500 {object} 	err_dto.ErrorResponse
 //	@Failure	401 {object}	err_dto.ErrorResponse
 //	@Failure	403 {object}	err_dto.ErrorResponse
+//	@Failure	404 {object}	err_dto.ErrorResponse
 func (h *PaymentHandler) GetById(c *gin.Context) {
 	id, err := helpers.ParseParamToUint(c.Param("id"))
 	if err != nil {
@@ -48,12 +58,15 @@ func (h *PaymentHandler) GetById(c *gin.Context) {
 		return
 	}
 	var payment *dto.Payment
-	payment, err = h.svc.GetById(*id)
+	payment, err = h.svc.GetById(c.Request.Context(), *id)
 	if err != nil {
 		response := err_dto.ErrorResponse{Code: 404, Message: err.Error()}
 		c.JSON(response.Code, response)
 		return
 	}
+	if !auth.AuthorizeReadOwnerOf(c, payment.OrderId, h.orderSvc.GetOwnerId) {
+		return
+	}
 	c.JSON(200, payment)
 }
 
@@ -78,7 +91,7 @@ func (h *PaymentHandler) GetByOrder(c *gin.Context) {
 		return
 	}
 	var payments []*dto.Payment
-	payments, err = h.svc.GetByOrder(*orderId)
+	payments, err = h.svc.GetByOrder(c.Request.Context(), *orderId)
 	if err != nil {
 		response := err_dto.ErrorResponse{Code: 404, Message: err.Error()}
 		c.JSON(response.Code, response)
@@ -89,17 +102,19 @@ func (h *PaymentHandler) GetByOrder(c *gin.Context) {
 
 // Savepayment godoc
 //
-//	@Summary	Save the payment
-//	@Tags		payment
-//	@Produce	json
-//	@Security	BearerAuth
-//	@Router		/api/payment [post]
-//	@Param   payment  body      dto.Payment  true  "Provide payment object"
-//	@Success	201 {object} dto.Payment
-//	@Failure	400 {object} err_dto.ErrorResponse
-//	@Failure	500 {object} err_dto.ErrorResponse
-//	@Failure	401 {object}	err_dto.ErrorResponse
-//	@Failure	403 {object}	err_dto.ErrorResponse
+//	@Summary		Save the payment
+//	@Description	Only staff set the status. Payments recorded by anyone else are saved pending, whatever status is sent.
+//	@Tags			payment
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Router			/api/payment [post]
+//	@Param			payment	body	dto.Payment	true	"Provide payment object"
+//	@Success		201	{object}	dto.Payment
+//	@Failure		400	{object}	err_dto.ErrorResponse
+//	@Failure		500	{object}	err_dto.ErrorResponse
+//	@Failure		401	{object}	err_dto.ErrorResponse
+//	@Failure		403	{object}	err_dto.ErrorResponse
+//	@Failure		404	{object}	err_dto.ErrorResponse
 func (h *PaymentHandler) Save(c *gin.Context) {
 	var payment *dto.Payment
 	if err := c.ShouldBindJSON(&payment); err != nil {
@@ -107,30 +122,37 @@ func (h *PaymentHandler) Save(c *gin.Context) {
 		c.JSON(errorResponse.Code, errorResponse)
 		return
 	}
-	err := h.svc.Save(payment)
+	if !auth.AuthorizeOwnerOf(c, payment.OrderId, h.orderSvc.GetOwnerId) {
+		return
+	}
+	staff := auth.GetIdentity(c).IsStaff()
+	err := h.svc.Save(c.Request.Context(), payment, staff)
 	if err != nil {
 		errorResponse := err_dto.ErrorResponse{Code: 500, Message: err.Error()}
 		c.JSON(500, errorResponse)
 		return
 	}
+	h.issueInvoice(c.Request.Context(), payment.OrderId)
 	c.JSON(201, payment)
 
 }
 
 // DeletePayment godoc
 //
-//	@Summary	Delete the payment
-//	@Tags		payment
-//	@Produce	json
-//	@Security	BearerAuth
-//	@Param		id		path		int		true	"Payment ID"
-//	@Param		hard	query		bool	false	"Hard delete"
-//	@Router		/api/payment/{id} [delete]
-//	@Success	204
-//	@Failure	400	{object}	err_dto.ErrorResponse
-//	@Failure	500	{object}	err_dto.ErrorResponse
-//	@Failure	401 {object}	err_dto.ErrorResponse
-//	@Failure	403 {object}	err_dto.ErrorResponse
+//	@Summary		Delete the payment
+//	@Description	Staff only: the admin or support role.
+//	@Tags			payment
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path	int	true	"Payment ID"
+//	@Param			hard	query	bool	false	"Hard delete"
+//	@Router			/api/payment/{id} [delete]
+//	@Success		204
+//	@Failure		400	{object}	err_dto.ErrorResponse
+//	@Failure		500	{object}	err_dto.ErrorResponse
+//	@Failure		401	{object}	err_dto.ErrorResponse
+//	@Failure		403	{object}	err_dto.ErrorResponse
+//	@Failure		404	{object}	err_dto.ErrorResponse
 func (h *PaymentHandler) Delete(c *gin.Context) {
 	id, err := helpers.ParseParamToUint(c.Param("id"))
 	if err != nil {
@@ -139,7 +161,7 @@ func (h *PaymentHandler) Delete(c *gin.Context) {
 		return
 	}
 	hard := c.DefaultQuery("hard", "false") == "true"
-	err = h.svc.Delete(*id, hard)
+	err = h.svc.Delete(c.Request.Context(), *id, hard)
 	if err != nil {
 		errorResponse := err_dto.ErrorResponse{Code: 500, Message: err.Error()}
 		c.JSON(errorResponse.Code, errorResponse)
@@ -160,7 +182,7 @@ func (h *PaymentHandler) Delete(c *gin.Context) {
 //	@Failure	401 {object}	err_dto.ErrorResponse
 //	@Failure	403 {object}	err_dto.ErrorResponse
 func (h *PaymentHandler) GetStatuses(c *gin.Context) {
-	statuses := h.svc.GetStatuses()
+	statuses := h.svc.GetStatuses(c.Request.Context())
 	if len(statuses) == 0 {
 		response := err_dto.ErrorResponse{Code: 404, Message: "No payment statuses were found"}
 		c.JSON(response.Code, response)
@@ -171,18 +193,20 @@ func (h *PaymentHandler) GetStatuses(c *gin.Context) {
 
 // UpdateStatus godoc
 //
-//	@Summary	update payment status
-//	@Tags		payment
-//	@Produce	json
-//	@Security	BearerAuth
-//	@Router		/api/payment/{id}/status [patch]
-//	@Param		id		path		int		true	"Payment ID"
-//	@Param   payment_status  body      dto.PaymentStatus  true  "Provide payment status object"
-//	@Success	204
-//	@Failure	400 {object} err_dto.ErrorResponse
-//	@Failure	500 {object} err_dto.ErrorResponse
-//	@Failure	401 {object}	err_dto.ErrorResponse
-//	@Failure	403 {object}	err_dto.ErrorResponse
+//	@Summary		update payment status
+//	@Description	Staff only: the admin or support role.
+//	@Tags			payment
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Router			/api/payment/{id}/status [patch]
+//	@Param			id	path	int	true	"Payment ID"
+//	@Param			payment_status	body	dto.PaymentStatus	true	"Provide payment status object"
+//	@Success		204
+//	@Failure		400	{object}	err_dto.ErrorResponse
+//	@Failure		500	{object}	err_dto.ErrorResponse
+//	@Failure		401	{object}	err_dto.ErrorResponse
+//	@Failure		403	{object}	err_dto.ErrorResponse
+//	@Failure		404	{object}	err_dto.ErrorResponse
 func (h *PaymentHandler) UpdateStatus(c *gin.Context) {
 	id, err := helpers.ParseParamToUint(c.Param("id"))
 	if err != nil {
@@ -196,11 +220,23 @@ func (h *PaymentHandler) UpdateStatus(c *gin.Context) {
 		c.JSON(errorResponse.Code, errorResponse)
 		return
 	}
-	err = h.svc.UpdateStatus(*id, status.Status)
+	err = h.svc.UpdateStatus(c.Request.Context(), *id, status.Status)
 	if err != nil {
 		errorResponse := err_dto.ErrorResponse{Code: 500, Message: err.Error()}
 		c.JSON(500, errorResponse)
 		return
 	}
+	if payment, err := h.svc.GetById(c.Request.Context(), *id); err == nil {
+		h.issueInvoice(c.Request.Context(), payment.OrderId)
+	}
 	c.JSON(204, nil)
 }
+
+// issueInvoice invoices the order once its payments cover the total. The
+// payment has already been recorded, so a failure here is logged rather than
+// returned.
+func (h *PaymentHandler) issueInvoice(ctx context.Context, orderId uint) {
+	if _, err := h.invoiceSvc.IssueIfPaid(ctx, orderId); err != nil && !errors.Is(err, invoice_service.ErrNotInvoiced) {
+		slog.Error("Exception occurred invoicing order after payment.", "order-id", orderId, "error", err)
+	}
+}
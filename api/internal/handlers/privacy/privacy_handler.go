@@ -0,0 +1,90 @@
+package privacy
+
+import (
+	auth "commerce/api/internal/auth"
+	"commerce/api/internal/helpers"
+	"commerce/api/internal/services/privacy"
+
+	err_dto "commerce/api/internal/dto/err"
+	dto "commerce/api/internal/dto/privacy"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PrivacyHandler takes erasure requests. ADR-011 still rules out deleting a
+// user, so erasure anonymizes them instead; the export lives on /api/me.
+type PrivacyHandler struct {
+	svc privacy.PrivacyServiceI
+}
+
+func NewPrivacyHandler(svc privacy.PrivacyServiceI) *PrivacyHandler {
+	return &PrivacyHandler{svc: svc}
+}
+
+// RegisterRoutes expects a group with a :user_id parameter.
+func (h *PrivacyHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	owner := auth.RequireOwner("user_id")
+	rg.GET("/erasure", auth.RequireScope(auth.Scopes.Users.Read), owner, h.GetErasures)
+	rg.POST("/erasure", auth.RequireScope(auth.Scopes.Users.Delete), owner, h.RequestErasure)
+}
+
+// GetErasures godoc
+//
+//	@Summary	Get a user's erasure requests
+//	@Tags		privacy
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Router		/api/users/{user_id}/erasure [get]
+//	@Param		user_id	path	int	true	"User Id"
+//	@Success	200 {array} dto.ErasureRequest
+//	@Failure	400 {object} err_dto.ErrorResponse
+//	@Failure	401 {object} err_dto.ErrorResponse
+//	@Failure	403 {object} err_dto.ErrorResponse
+//	@Failure	500 {object} err_dto.ErrorResponse
+func (h *PrivacyHandler) GetErasures(c *gin.Context) {
+	userId, err := helpers.ParseParamToUint(c.Param("user_id"))
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	var erasures []*dto.ErasureRequest
+	erasures, err = h.svc.GetErasures(c.Request.Context(), *userId)
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 500, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	c.JSON(200, erasures)
+}
+
+// RequestErasure godoc
+//
+//	@Summary		Request erasure of a user's personal data
+//	@Description	The user's name, email, address book and review text are anonymized by a background job.
+//	@Description	Orders, payments and invoices are kept. Asking again while a request is pending returns that request.
+//	@Tags			privacy
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Router			/api/users/{user_id}/erasure [post]
+//	@Param			user_id	path	int	true	"User Id"
+//	@Success		202 {object} dto.ErasureRequest
+//	@Failure		400 {object} err_dto.ErrorResponse
+//	@Failure		401 {object} err_dto.ErrorResponse
+//	@Failure		403 {object} err_dto.ErrorResponse
+//	@Failure		404 {object} err_dto.ErrorResponse
+func (h *PrivacyHandler) RequestErasure(c *gin.Context) {
+	userId, err := helpers.ParseParamToUint(c.Param("user_id"))
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	request, err := h.svc.RequestErasure(c.Request.Context(), *userId, auth.GetIdentity(c).Subject)
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 404, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	c.JSON(202, request)
+}
@@ -0,0 +1,233 @@
+package productimage
+
+import (
+	auth "commerce/api/internal/auth"
+	errdto "commerce/api/internal/dto/err"
+	dto "commerce/api/internal/dto/product"
+	"commerce/api/internal/helpers"
+	"commerce/api/internal/imaging"
+	svc "commerce/api/internal/services/product-image"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type ProductImageHandler struct {
+	svc            svc.ProductImageServiceI
+	maxUploadBytes int64
+}
+
+// NewProductImageHandler makes the handler. Uploads larger than
+// maxUploadBytes are refused.
+func NewProductImageHandler(svc svc.ProductImageServiceI, maxUploadBytes int64) *ProductImageHandler {
+	return &ProductImageHandler{svc: svc, maxUploadBytes: maxUploadBytes}
+}
+
+// RegisterRoutes mounts the routes under a product, /products/:id.
+func (h *ProductImageHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.GET("/images", auth.RequireScope(auth.Scopes.Products.Read), h.GetAll)
+	rg.POST("/images", auth.RequireScope(auth.Scopes.Products.Write), h.Upload)
+	rg.PUT("/images/:image_id", auth.RequireScope(auth.Scopes.Products.Write), h.Update)
+	rg.DELETE("/images/:image_id", auth.RequireScope(auth.Scopes.Products.Write), h.Delete)
+}
+
+// GetProductImages godoc
+//
+//	@Summary	Get the product's images
+//	@Tags		product
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Router		/api/products/{id}/images [get]
+//	@Param		id	path	int	true	"Product ID"
+//	@Success	200 {array}		dto.Image
+//	@Failure	400 {object}	errdto.ErrorResponse
+//	@Failure	401 {object}	errdto.ErrorResponse
+//	@Failure	403 {object}	errdto.ErrorResponse
+//	@Failure	404 {object}	errdto.ErrorResponse
+func (h *ProductImageHandler) GetAll(c *gin.Context) {
+	id, err := helpers.ParseParamToUint(c.Param("id"))
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: "invalid id"}
+		c.JSON(400, errorResponse)
+		return
+	}
+	var images []dto.Image
+	images, err = h.svc.GetAll(c.Request.Context(), *id)
+	if err != nil {
+		respond(c, err)
+		return
+	}
+	c.JSON(200, images)
+}
+
+// UploadProductImage godoc
+//
+//	@Summary		Upload a product image
+//	@Description	Takes a JPEG, PNG or GIF, up to MEDIA_MAX_UPLOAD_BYTES. Thumbnails are made of it, and it goes after the product's other images. A product's first image is its primary one.
+//	@Tags			product
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Router			/api/products/{id}/images [post]
+//	@Param			id			path		int		true	"Product ID"
+//	@Param			file		formData	file	true	"Image file"
+//	@Param			alt_text	formData	string	false	"Alt text"
+//	@Param			is_primary	formData	bool	false	"Make it the primary image"
+//	@Success		201 {object}	dto.Image
+//	@Failure		400 {object}	errdto.ErrorResponse
+//	@Failure		401 {object}	errdto.ErrorResponse
+//	@Failure		403 {object}	errdto.ErrorResponse
+//	@Failure		404 {object}	errdto.ErrorResponse
+//	@Failure		413 {object}	errdto.ErrorResponse
+//	@Failure		500 {object}	errdto.ErrorResponse
+func (h *ProductImageHandler) Upload(c *gin.Context) {
+	id, err := helpers.ParseParamToUint(c.Param("id"))
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: "invalid id"}
+		c.JSON(400, errorResponse)
+		return
+	}
+	// The limit covers the whole form, which is a little more than the file.
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxUploadBytes+1<<16)
+	var upload dto.ImageUpload
+	if err := c.ShouldBind(&upload); err != nil {
+		uploadError(c, err)
+		return
+	}
+	header, err := c.FormFile("file")
+	if err != nil {
+		uploadError(c, err)
+		return
+	}
+	if header.Size > h.maxUploadBytes {
+		errorResponse := errdto.ErrorResponse{Code: 413, Message: "file is too large"}
+		c.JSON(413, errorResponse)
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(400, errorResponse)
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(400, errorResponse)
+		return
+	}
+	image, err := h.svc.Upload(c.Request.Context(), *id, data, upload)
+	if err != nil {
+		respond(c, err)
+		return
+	}
+	c.JSON(201, image)
+}
+
+// UpdateProductImage godoc
+//
+//	@Summary		Update a product image
+//	@Description	Changes the alt text, moves the image to position and can make it the primary image. Unmarking the primary image makes the first of the others primary. url, thumbnails and the dimensions are ignored.
+//	@Tags			product
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Router			/api/products/{id}/images/{image_id} [put]
+//	@Param			id			path	int			true	"Product ID"
+//	@Param			image_id	path	int			true	"Image ID"
+//	@Param			image		body	dto.Image	true	"Image"
+//	@Success		200 {object}	dto.Image
+//	@Failure		400 {object}	errdto.ErrorResponse
+//	@Failure		401 {object}	errdto.ErrorResponse
+//	@Failure		403 {object}	errdto.ErrorResponse
+//	@Failure		404 {object}	errdto.ErrorResponse
+//	@Failure		500 {object}	errdto.ErrorResponse
+func (h *ProductImageHandler) Update(c *gin.Context) {
+	id, err := helpers.ParseParamToUint(c.Param("id"))
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: "invalid id"}
+		c.JSON(400, errorResponse)
+		return
+	}
+	imageId, err := helpers.ParseParamToUint(c.Param("image_id"))
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: "invalid image id"}
+		c.JSON(400, errorResponse)
+		return
+	}
+	var image dto.Image
+	if err := c.ShouldBindJSON(&image); err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(400, errorResponse)
+		return
+	}
+	if err := h.svc.Update(c.Request.Context(), *id, *imageId, &image); err != nil {
+		respond(c, err)
+		return
+	}
+	c.JSON(200, image)
+}
+
+// DeleteProductImage godoc
+//
+//	@Summary		Delete a product image
+//	@Description	Deletes the image and its files. Deleting the primary image makes the first of the rest primary.
+//	@Tags			product
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Router			/api/products/{id}/images/{image_id} [delete]
+//	@Param			id			path	int	true	"Product ID"
+//	@Param			image_id	path	int	true	"Image ID"
+//	@Success		204
+//	@Failure		400 {object}	errdto.ErrorResponse
+//	@Failure		401 {object}	errdto.ErrorResponse
+//	@Failure		403 {object}	errdto.ErrorResponse
+//	@Failure		404 {object}	errdto.ErrorResponse
+//	@Failure		500 {object}	errdto.ErrorResponse
+func (h *ProductImageHandler) Delete(c *gin.Context) {
+	id, err := helpers.ParseParamToUint(c.Param("id"))
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: "invalid id"}
+		c.JSON(400, errorResponse)
+		return
+	}
+	imageId, err := helpers.ParseParamToUint(c.Param("image_id"))
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: "invalid image id"}
+		c.JSON(400, errorResponse)
+		return
+	}
+	if err := h.svc.Delete(c.Request.Context(), *id, *imageId); err != nil {
+		respond(c, err)
+		return
+	}
+	c.JSON(204, nil)
+}
+
+// uploadError answers a request whose form couldn't be read: 413 if it was
+// cut off at the size limit.
+func uploadError(c *gin.Context, err error) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		errorResponse := errdto.ErrorResponse{Code: 413, Message: "file is too large"}
+		c.JSON(413, errorResponse)
+		return
+	}
+	errorResponse := errdto.ErrorResponse{Code: 400, Message: err.Error()}
+	c.JSON(400, errorResponse)
+}
+
+func respond(c *gin.Context, err error) {
+	code := 500
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		code = 404
+	case errors.Is(err, imaging.ErrUnsupported):
+		code = 400
+	}
+	errorResponse := errdto.ErrorResponse{Code: code, Message: err.Error()}
+	c.JSON(code, errorResponse)
+}
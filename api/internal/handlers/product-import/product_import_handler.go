@@ -0,0 +1,194 @@
+package productimport
+
+import (
+	auth "commerce/api/internal/auth"
+	errdto "commerce/api/internal/dto/err"
+	dto "commerce/api/internal/dto/product"
+	"commerce/api/internal/helpers"
+	svc "commerce/api/internal/services/product-import"
+	"commerce/internal/shared/catalog"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type ProductImportHandler struct {
+	svc            svc.ProductImportServiceI
+	maxUploadBytes int64
+}
+
+// NewProductImportHandler makes the handler. Files larger than
+// maxUploadBytes are refused.
+func NewProductImportHandler(svc svc.ProductImportServiceI, maxUploadBytes int64) *ProductImportHandler {
+	return &ProductImportHandler{svc: svc, maxUploadBytes: maxUploadBytes}
+}
+
+// RegisterRoutes mounts the routes under /products.
+func (h *ProductImportHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.POST("/import", auth.RequireScope(auth.Scopes.Products.Write), h.Import)
+	rg.GET("/import/jobs/:job_id", auth.RequireScope(auth.Scopes.Products.Write), h.GetJob)
+	rg.GET("/export", auth.RequireScope(auth.Scopes.Products.Read), h.Export)
+}
+
+// ImportProducts godoc
+//
+//	@Summary		Import products from CSV
+//	@Description	Creates and updates products by SKU. The header names the columns: sku, and any of name, price, stock, is_active, is_featured and categories, which holds category slugs separated by |. Empty cells leave a product's value as it is, except categories, which an empty cell clears. New products need a name. If any row has an error nothing is written, and the result lists every error with its row; it comes back as 422. A dry run checks the file and counts the changes without writing. With async the import is queued and 202 comes back with the job, whose status is at /api/products/import/jobs/{id}.
+//	@Tags			product
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Router			/api/products/import [post]
+//	@Param			file	formData	file	true	"CSV file"
+//	@Param			dry_run	query		bool	false	"Check the file without writing anything"
+//	@Param			async	query		bool	false	"Run the import as a background job"
+//	@Success		200 {object}	dto.ImportResult
+//	@Success		202 {object}	dto.ImportJob
+//	@Failure		400 {object}	errdto.ErrorResponse
+//	@Failure		401 {object}	errdto.ErrorResponse
+//	@Failure		403 {object}	errdto.ErrorResponse
+//	@Failure		413 {object}	errdto.ErrorResponse
+//	@Failure		422 {object}	dto.ImportResult
+//	@Failure		500 {object}	errdto.ErrorResponse
+func (h *ProductImportHandler) Import(c *gin.Context) {
+	var opts dto.ImportOptions
+	if err := c.ShouldBindQuery(&opts); err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(400, errorResponse)
+		return
+	}
+	data, ok := h.readFile(c)
+	if !ok {
+		return
+	}
+
+	if opts.Async {
+		job, err := h.svc.Enqueue(c.Request.Context(), data, opts.DryRun)
+		if err != nil {
+			respond(c, err)
+			return
+		}
+		c.Header("Location", fmt.Sprintf("/api/products/import/jobs/%d", job.Id))
+		c.JSON(202, job)
+		return
+	}
+	result, err := h.svc.Import(c.Request.Context(), data, opts.DryRun)
+	if err != nil {
+		respond(c, err)
+		return
+	}
+	if len(result.Errors) > 0 && !result.DryRun {
+		c.JSON(422, result)
+		return
+	}
+	c.JSON(200, result)
+}
+
+// GetImportJob godoc
+//
+//	@Summary		Get an import job
+//	@Description	pending and running jobs have no result yet. A completed job's result says what was imported, or for a dry run what would be; a job fails when its rows have errors, which are in the result, or when the file couldn't be read, which is in error.
+//	@Tags			product
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Router			/api/products/import/jobs/{job_id} [get]
+//	@Param			job_id	path	int	true	"Job ID"
+//	@Success		200 {object}	dto.ImportJob
+//	@Failure		400 {object}	errdto.ErrorResponse
+//	@Failure		401 {object}	errdto.ErrorResponse
+//	@Failure		403 {object}	errdto.ErrorResponse
+//	@Failure		404 {object}	errdto.ErrorResponse
+//	@Failure		500 {object}	errdto.ErrorResponse
+func (h *ProductImportHandler) GetJob(c *gin.Context) {
+	id, err := helpers.ParseParamToUint(c.Param("job_id"))
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: "invalid job id"}
+		c.JSON(400, errorResponse)
+		return
+	}
+	var job *dto.ImportJob
+	job, err = h.svc.GetJob(c.Request.Context(), *id)
+	if err != nil {
+		respond(c, err)
+		return
+	}
+	c.JSON(200, job)
+}
+
+// ExportProducts godoc
+//
+//	@Summary		Export products as CSV
+//	@Description	Every product that isn't deleted, in the columns the import takes, so the file can be edited and imported again. Categories without a slug are left out.
+//	@Tags			product
+//	@Produce		text/csv
+//	@Security		BearerAuth
+//	@Router			/api/products/export [get]
+//	@Success		200 {file}		file
+//	@Failure		401 {object}	errdto.ErrorResponse
+//	@Failure		403 {object}	errdto.ErrorResponse
+func (h *ProductImportHandler) Export(c *gin.Context) {
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", `attachment; filename="products.csv"`)
+	c.Status(200)
+	// The file is streamed, so by the time an error comes up the status has
+	// gone out; the response is cut short instead.
+	if err := h.svc.Export(c.Request.Context(), c.Writer); err != nil {
+		slog.Error("Export cut short.", "error", err)
+		c.Abort()
+	}
+}
+
+// readFile reads the uploaded file, answering the request itself when it
+// can't.
+func (h *ProductImportHandler) readFile(c *gin.Context) ([]byte, bool) {
+	// The limit covers the whole form, which is a little more than the file.
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxUploadBytes+1<<16)
+	header, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			errorResponse := errdto.ErrorResponse{Code: 413, Message: "file is too large"}
+			c.JSON(413, errorResponse)
+			return nil, false
+		}
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(400, errorResponse)
+		return nil, false
+	}
+	if header.Size > h.maxUploadBytes {
+		errorResponse := errdto.ErrorResponse{Code: 413, Message: "file is too large"}
+		c.JSON(413, errorResponse)
+		return nil, false
+	}
+	file, err := header.Open()
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(400, errorResponse)
+		return nil, false
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(400, errorResponse)
+		return nil, false
+	}
+	return data, true
+}
+
+func respond(c *gin.Context, err error) {
+	code := 500
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		code = 404
+	case errors.Is(err, catalog.ErrInvalidFile):
+		code = 400
+	}
+	errorResponse := errdto.ErrorResponse{Code: code, Message: err.Error()}
+	c.JSON(code, errorResponse)
+}
@@ -0,0 +1,192 @@
+package productprice
+
+import (
+	auth "commerce/api/internal/auth"
+	errdto "commerce/api/internal/dto/err"
+	dto "commerce/api/internal/dto/product"
+	"commerce/api/internal/helpers"
+	svc "commerce/api/internal/services/product-price"
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type ProductPriceHandler struct {
+	svc svc.ProductPriceServiceI
+}
+
+func NewProductPriceHandler(svc svc.ProductPriceServiceI) *ProductPriceHandler {
+	return &ProductPriceHandler{svc: svc}
+}
+
+// RegisterRoutes mounts the routes under a product, /products/:id. Shoppers
+// see prices on the product itself; these are for staff scheduling them.
+func (h *ProductPriceHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.GET("/prices", auth.RequireScope(auth.Scopes.Products.Write), h.GetAll)
+	rg.POST("/prices", auth.RequireScope(auth.Scopes.Products.Write), h.Create)
+	rg.PUT("/prices/:price_id", auth.RequireScope(auth.Scopes.Products.Write), h.Update)
+	rg.DELETE("/prices/:price_id", auth.RequireScope(auth.Scopes.Products.Write), h.Delete)
+}
+
+// GetProductPrices godoc
+//
+//	@Summary		Get the product's price history
+//	@Description	Lists the prices of the product and its variants, latest start first: scheduled ones, current ones and ended ones.
+//	@Tags			product
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Router			/api/products/{id}/prices [get]
+//	@Param			id	path	int	true	"Product ID"
+//	@Success		200 {array}		dto.Price
+//	@Failure		400 {object}	errdto.ErrorResponse
+//	@Failure		401 {object}	errdto.ErrorResponse
+//	@Failure		403 {object}	errdto.ErrorResponse
+//	@Failure		404 {object}	errdto.ErrorResponse
+func (h *ProductPriceHandler) GetAll(c *gin.Context) {
+	id, err := helpers.ParseParamToUint(c.Param("id"))
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: "invalid id"}
+		c.JSON(400, errorResponse)
+		return
+	}
+	prices, err := h.svc.GetAll(c.Request.Context(), *id)
+	if err != nil {
+		respond(c, err)
+		return
+	}
+	c.JSON(200, prices)
+}
+
+// CreateProductPrice godoc
+//
+//	@Summary		Schedule a product price
+//	@Description	Sets what the product, or one of its variants with variant_id, sells for from effective_from, now when left out, until effective_to.
+//	@Description	Without effective_to it is a price change that lasts until a later one starts; with one it is a promotion, which runs over a price change.
+//	@Description	Where two apply the one that started later wins.
+//	@Tags			product
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Router			/api/products/{id}/prices [post]
+//	@Param			id		path	int			true	"Product ID"
+//	@Param			price	body	dto.Price	true	"Price"
+//	@Success		201 {object}	dto.Price
+//	@Failure		400 {object}	errdto.ErrorResponse
+//	@Failure		401 {object}	errdto.ErrorResponse
+//	@Failure		403 {object}	errdto.ErrorResponse
+//	@Failure		404 {object}	errdto.ErrorResponse
+//	@Failure		500 {object}	errdto.ErrorResponse
+func (h *ProductPriceHandler) Create(c *gin.Context) {
+	id, err := helpers.ParseParamToUint(c.Param("id"))
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: "invalid id"}
+		c.JSON(400, errorResponse)
+		return
+	}
+	var price dto.Price
+	if err := c.ShouldBindJSON(&price); err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(400, errorResponse)
+		return
+	}
+	if err := h.svc.Create(c.Request.Context(), *id, &price); err != nil {
+		respond(c, err)
+		return
+	}
+	c.JSON(201, price)
+}
+
+// UpdateProductPrice godoc
+//
+//	@Summary		Update a product price
+//	@Description	A scheduled price can be changed entirely. A current one can only have its effective_to moved, to now at the earliest, or taken away; an ended one can't be changed.
+//	@Tags			product
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Router			/api/products/{id}/prices/{price_id} [put]
+//	@Param			id			path	int			true	"Product ID"
+//	@Param			price_id	path	int			true	"Price ID"
+//	@Param			price		body	dto.Price	true	"Price"
+//	@Success		200 {object}	dto.Price
+//	@Failure		400 {object}	errdto.ErrorResponse
+//	@Failure		401 {object}	errdto.ErrorResponse
+//	@Failure		403 {object}	errdto.ErrorResponse
+//	@Failure		404 {object}	errdto.ErrorResponse
+//	@Failure		409 {object}	errdto.ErrorResponse
+//	@Failure		500 {object}	errdto.ErrorResponse
+func (h *ProductPriceHandler) Update(c *gin.Context) {
+	id, err := helpers.ParseParamToUint(c.Param("id"))
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: "invalid id"}
+		c.JSON(400, errorResponse)
+		return
+	}
+	priceId, err := helpers.ParseParamToUint(c.Param("price_id"))
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: "invalid price id"}
+		c.JSON(400, errorResponse)
+		return
+	}
+	var price dto.Price
+	if err := c.ShouldBindJSON(&price); err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(400, errorResponse)
+		return
+	}
+	if err := h.svc.Update(c.Request.Context(), *id, *priceId, &price); err != nil {
+		respond(c, err)
+		return
+	}
+	c.JSON(200, price)
+}
+
+// DeleteProductPrice godoc
+//
+//	@Summary		Delete a scheduled product price
+//	@Description	Only prices that haven't started can be deleted. Current ones are ended by setting effective_to, so they stay in the history.
+//	@Tags			product
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Router			/api/products/{id}/prices/{price_id} [delete]
+//	@Param			id			path	int	true	"Product ID"
+//	@Param			price_id	path	int	true	"Price ID"
+//	@Success		204
+//	@Failure		400 {object}	errdto.ErrorResponse
+//	@Failure		401 {object}	errdto.ErrorResponse
+//	@Failure		403 {object}	errdto.ErrorResponse
+//	@Failure		404 {object}	errdto.ErrorResponse
+//	@Failure		409 {object}	errdto.ErrorResponse
+//	@Failure		500 {object}	errdto.ErrorResponse
+func (h *ProductPriceHandler) Delete(c *gin.Context) {
+	id, err := helpers.ParseParamToUint(c.Param("id"))
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: "invalid id"}
+		c.JSON(400, errorResponse)
+		return
+	}
+	priceId, err := helpers.ParseParamToUint(c.Param("price_id"))
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: "invalid price id"}
+		c.JSON(400, errorResponse)
+		return
+	}
+	if err := h.svc.Delete(c.Request.Context(), *id, *priceId); err != nil {
+		respond(c, err)
+		return
+	}
+	c.JSON(204, nil)
+}
+
+func respond(c *gin.Context, err error) {
+	code := 500
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		code = 404
+	case errors.Is(err, svc.ErrInvalidPrice):
+		code = 400
+	case errors.Is(err, svc.ErrPriceStarted):
+		code = 409
+	}
+	errorResponse := errdto.ErrorResponse{Code: code, Message: err.Error()}
+	c.JSON(code, errorResponse)
+}
@@ -0,0 +1,251 @@
+package productvariant
+
+import (
+	auth "commerce/api/internal/auth"
+	errdto "commerce/api/internal/dto/err"
+	dto "commerce/api/internal/dto/product"
+	"commerce/api/internal/helpers"
+	svc "commerce/api/internal/services/product-variant"
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type ProductVariantHandler struct {
+	svc svc.ProductVariantServiceI
+}
+
+func NewProductVariantHandler(svc svc.ProductVariantServiceI) *ProductVariantHandler {
+	return &ProductVariantHandler{svc: svc}
+}
+
+// RegisterRoutes mounts the routes under a product, /products/:id.
+func (h *ProductVariantHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.GET("/options", auth.RequireScope(auth.Scopes.Products.Read), h.GetOptions)
+	rg.POST("/options", auth.RequireScope(auth.Scopes.Products.Write), h.SaveOption)
+	rg.DELETE("/options/:option_id", auth.RequireScope(auth.Scopes.Products.Write), h.DeleteOption)
+	rg.GET("/variants", auth.RequireScope(auth.Scopes.Products.Read), h.GetVariants)
+	rg.POST("/variants", auth.RequireScope(auth.Scopes.Products.Write), h.SaveVariant)
+	rg.DELETE("/variants/:variant_id", auth.RequireScope(auth.Scopes.Products.Write), h.DeleteVariant)
+}
+
+// GetProductOptions godoc
+//
+//	@Summary	Get the product's options
+//	@Tags		product
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Router		/api/products/{id}/options [get]
+//	@Param		id	path	int	true	"Product ID"
+//	@Success	200 {array}		dto.Option
+//	@Failure	400 {object}	errdto.ErrorResponse
+//	@Failure	401 {object}	errdto.ErrorResponse
+//	@Failure	403 {object}	errdto.ErrorResponse
+//	@Failure	404 {object}	errdto.ErrorResponse
+func (h *ProductVariantHandler) GetOptions(c *gin.Context) {
+	id, err := helpers.ParseParamToUint(c.Param("id"))
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: "invalid id"}
+		c.JSON(400, errorResponse)
+		return
+	}
+	var options []dto.Option
+	options, err = h.svc.GetOptions(c.Request.Context(), *id)
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 404, Message: err.Error()}
+		c.JSON(404, errorResponse)
+		return
+	}
+	c.JSON(200, options)
+}
+
+// SaveProductOption godoc
+//
+//	@Summary		Save a product option
+//	@Description	Creates the option, or updates it when it has an id. Values left out are deleted unless variants use them. Options can't be added to a product that already has variants.
+//	@Tags			product
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Router			/api/products/{id}/options [post]
+//	@Param			id		path	int			true	"Product ID"
+//	@Param			option	body	dto.Option	true	"Option with its values"
+//	@Success		201 {object}	dto.Option
+//	@Failure		400 {object}	errdto.ErrorResponse
+//	@Failure		401 {object}	errdto.ErrorResponse
+//	@Failure		403 {object}	errdto.ErrorResponse
+//	@Failure		404 {object}	errdto.ErrorResponse
+//	@Failure		409 {object}	errdto.ErrorResponse
+//	@Failure		500 {object}	errdto.ErrorResponse
+func (h *ProductVariantHandler) SaveOption(c *gin.Context) {
+	id, err := helpers.ParseParamToUint(c.Param("id"))
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: "invalid id"}
+		c.JSON(400, errorResponse)
+		return
+	}
+	var option dto.Option
+	if err := c.ShouldBindJSON(&option); err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(400, errorResponse)
+		return
+	}
+	if err := h.svc.SaveOption(c.Request.Context(), *id, &option); err != nil {
+		respond(c, err)
+		return
+	}
+	c.JSON(201, option)
+}
+
+// DeleteProductOption godoc
+//
+//	@Summary		Delete a product option
+//	@Description	Only options of products without variants can be deleted.
+//	@Tags			product
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Router			/api/products/{id}/options/{option_id} [delete]
+//	@Param			id			path	int	true	"Product ID"
+//	@Param			option_id	path	int	true	"Option ID"
+//	@Success		204
+//	@Failure		400 {object}	errdto.ErrorResponse
+//	@Failure		401 {object}	errdto.ErrorResponse
+//	@Failure		403 {object}	errdto.ErrorResponse
+//	@Failure		404 {object}	errdto.ErrorResponse
+//	@Failure		409 {object}	errdto.ErrorResponse
+//	@Failure		500 {object}	errdto.ErrorResponse
+func (h *ProductVariantHandler) DeleteOption(c *gin.Context) {
+	id, err := helpers.ParseParamToUint(c.Param("id"))
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: "invalid id"}
+		c.JSON(400, errorResponse)
+		return
+	}
+	optionId, err := helpers.ParseParamToUint(c.Param("option_id"))
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: "invalid option id"}
+		c.JSON(400, errorResponse)
+		return
+	}
+	if err := h.svc.DeleteOption(c.Request.Context(), *id, *optionId); err != nil {
+		respond(c, err)
+		return
+	}
+	c.JSON(204, nil)
+}
+
+// GetProductVariants godoc
+//
+//	@Summary	Get the product's variants
+//	@Tags		product
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Router		/api/products/{id}/variants [get]
+//	@Param		id	path	int	true	"Product ID"
+//	@Success	200 {array}		dto.Variant
+//	@Failure	400 {object}	errdto.ErrorResponse
+//	@Failure	401 {object}	errdto.ErrorResponse
+//	@Failure	403 {object}	errdto.ErrorResponse
+//	@Failure	404 {object}	errdto.ErrorResponse
+func (h *ProductVariantHandler) GetVariants(c *gin.Context) {
+	id, err := helpers.ParseParamToUint(c.Param("id"))
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: "invalid id"}
+		c.JSON(400, errorResponse)
+		return
+	}
+	var variants []dto.Variant
+	variants, err = h.svc.GetVariants(c.Request.Context(), *id)
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 404, Message: err.Error()}
+		c.JSON(404, errorResponse)
+		return
+	}
+	c.JSON(200, variants)
+}
+
+// SaveProductVariant godoc
+//
+//	@Summary		Save a product variant
+//	@Description	Creates the variant, or updates it when it has an id. option_value_ids must hold one value of each of the product's options, in a combination no other variant has.
+//	@Tags			product
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Router			/api/products/{id}/variants [post]
+//	@Param			id		path	int			true	"Product ID"
+//	@Param			variant	body	dto.Variant	true	"Variant; price and options are ignored"
+//	@Success		201 {object}	dto.Variant
+//	@Failure		400 {object}	errdto.ErrorResponse
+//	@Failure		401 {object}	errdto.ErrorResponse
+//	@Failure		403 {object}	errdto.ErrorResponse
+//	@Failure		404 {object}	errdto.ErrorResponse
+//	@Failure		500 {object}	errdto.ErrorResponse
+func (h *ProductVariantHandler) SaveVariant(c *gin.Context) {
+	id, err := helpers.ParseParamToUint(c.Param("id"))
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: "invalid id"}
+		c.JSON(400, errorResponse)
+		return
+	}
+	var variant dto.Variant
+	if err := c.ShouldBindJSON(&variant); err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(400, errorResponse)
+		return
+	}
+	if err := h.svc.SaveVariant(c.Request.Context(), *id, &variant); err != nil {
+		respond(c, err)
+		return
+	}
+	c.JSON(201, variant)
+}
+
+// DeleteProductVariant godoc
+//
+//	@Summary		Delete a product variant
+//	@Description	Soft deletes the variant; orders for it keep pointing at it.
+//	@Tags			product
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Router			/api/products/{id}/variants/{variant_id} [delete]
+//	@Param			id			path	int	true	"Product ID"
+//	@Param			variant_id	path	int	true	"Variant ID"
+//	@Success		204
+//	@Failure		400 {object}	errdto.ErrorResponse
+//	@Failure		401 {object}	errdto.ErrorResponse
+//	@Failure		403 {object}	errdto.ErrorResponse
+//	@Failure		404 {object}	errdto.ErrorResponse
+//	@Failure		500 {object}	errdto.ErrorResponse
+func (h *ProductVariantHandler) DeleteVariant(c *gin.Context) {
+	id, err := helpers.ParseParamToUint(c.Param("id"))
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: "invalid id"}
+		c.JSON(400, errorResponse)
+		return
+	}
+	variantId, err := helpers.ParseParamToUint(c.Param("variant_id"))
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: "invalid variant id"}
+		c.JSON(400, errorResponse)
+		return
+	}
+	if err := h.svc.DeleteVariant(c.Request.Context(), *id, *variantId); err != nil {
+		respond(c, err)
+		return
+	}
+	c.JSON(204, nil)
+}
+
+func respond(c *gin.Context, err error) {
+	code := 500
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		code = 404
+	case errors.Is(err, svc.ErrInvalidVariant):
+		code = 400
+	case errors.Is(err, svc.ErrOptionInUse):
+		code = 409
+	}
+	errorResponse := errdto.ErrorResponse{Code: code, Message: err.Error()}
+	c.JSON(code, errorResponse)
+}
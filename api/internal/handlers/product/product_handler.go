@@ -2,12 +2,19 @@ package product
 
 import (
 	auth "commerce/api/internal/auth"
+	category_dto "commerce/api/internal/dto/category"
 	errdto "commerce/api/internal/dto/err"
+	"commerce/api/internal/dto/page"
 	dto "commerce/api/internal/dto/product"
 	"commerce/api/internal/helpers"
 	svc "commerce/api/internal/services/product"
+	product_repo "commerce/internal/shared/repositories/product"
+	"commerce/internal/shared/repositories/query"
+	"errors"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"gorm.io/gorm"
 )
 
 type ProductHandler struct {
@@ -20,9 +27,17 @@ func NewProductHandler(svc svc.ProductServiceI) *ProductHandler {
 
 func (h *ProductHandler) RegisterRoutes(rg *gin.RouterGroup) {
 	rg.GET("/", auth.RequireScope(auth.Scopes.Products.Read), h.GetAll)
+	rg.GET("/search", auth.RequireScope(auth.Scopes.Products.Read), h.Search)
 	rg.GET("/:id", auth.RequireScope(auth.Scopes.Products.Read), h.GetById)
 	rg.POST("/", auth.RequireScope(auth.Scopes.Products.Write), h.Save)
+	rg.PUT("/:id", auth.RequireScope(auth.Scopes.Products.Write), h.Update)
+	rg.PATCH("/:id", auth.RequireScope(auth.Scopes.Products.Write), h.Patch)
 	rg.DELETE("/:id", auth.RequireScope(auth.Scopes.Products.Write), h.Delete)
+	rg.POST("/:id/restore", auth.RequireRole(auth.RoleAdmin), h.Restore)
+	rg.GET("/:id/categories", auth.RequireScope(auth.Scopes.Products.Read), h.GetCategories)
+	rg.PUT("/:id/categories", auth.RequireScope(auth.Scopes.Products.Write), h.SetCategories)
+	rg.POST("/:id/categories/:category_id", auth.RequireScope(auth.Scopes.Products.Write), h.AddCategory)
+	rg.DELETE("/:id/categories/:category_id", auth.RequireScope(auth.Scopes.Products.Write), h.RemoveCategory)
 }
 
 // GetProducts godoc
@@ -32,12 +47,40 @@ func (h *ProductHandler) RegisterRoutes(rg *gin.RouterGroup) {
 //	@Produce	json
 //	@Security	BearerAuth
 //	@Router		/api/products [get]
-//	@Success	200 {array} dto.Product
+//	@Param		include_deleted	query	bool	false	"Include deleted products, admins only"
+//	@Param		limit	query	int		false	"Page size, up to 200; defaults to 50"
+//	@Param		cursor	query	string	false	"next_cursor of the previous page"
+//	@Param		offset	query	int		false	"Items to skip, instead of a cursor"
+//	@Param		sort	query	string	false	"Comma-separated, - for descending: name, price, stock, sku, created_date"
+//	@Param		name	query	string	false	"Name contains"
+//	@Param		sku	query	string	false	"SKU"
+//	@Param		min_price	query	number	false	"Minimum price"
+//	@Param		max_price	query	number	false	"Maximum price"
+//	@Param		is_active	query	bool	false	"Active products only, or inactive"
+//	@Param		is_featured	query	bool	false	"Featured products only, or not"
+//	@Success	200 {object} page.Page[dto.Product]
+//	@Failure	400 {object}	errdto.ErrorResponse
 //	@Failure	401 {object}	errdto.ErrorResponse
 //	@Failure	403 {object}	errdto.ErrorResponse
 func (h *ProductHandler) GetAll(c *gin.Context) {
-	var products []*dto.Product
-	products, err := h.svc.GetAll()
+	includeDeleted, ok := auth.IncludeDeleted(c)
+	if !ok {
+		return
+	}
+	opts, err := helpers.ParseQueryOptions(c.Request.URL.Query(), "name", "sku", "min_price", "max_price", "is_active", "is_featured")
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(400, errorResponse)
+		return
+	}
+	opts.IncludeDeleted = includeDeleted
+	var products *page.Page[dto.Product]
+	products, err = h.svc.GetAll(c.Request.Context(), opts)
+	if errors.Is(err, query.ErrInvalid) {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(400, errorResponse)
+		return
+	}
 	if err != nil {
 		errorResponse := errdto.ErrorResponse{Code: 500, Message: err.Error()}
 		c.JSON(500, errorResponse)
@@ -46,6 +89,57 @@ func (h *ProductHandler) GetAll(c *gin.Context) {
 	c.JSON(200, products)
 }
 
+// SearchProducts godoc
+//
+//	@Summary		Search the catalogue
+//	@Description	Full-text search over active products' names, SKUs and descriptions, with facet counts by category and price.
+//	@Tags			product
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Router			/api/products/search [get]
+//	@Param			q			query	string	false	"Search text; supports "quoted phrases", or and -word"
+//	@Param			min_price	query	number	false	"Minimum price"
+//	@Param			max_price	query	number	false	"Maximum price"
+//	@Param			category_id	query	int		false	"Category, including its subcategories"
+//	@Param			in_stock	query	bool	false	"In stock only"
+//	@Param			featured	query	bool	false	"Featured only"
+//	@Param			min_rating	query	number	false	"Minimum average rating, 1 to 5"
+//	@Param			limit		query	int		false	"Page size, up to 200; defaults to 50"
+//	@Param			cursor		query	string	false	"next_cursor of the previous page"
+//	@Param			offset		query	int		false	"Items to skip, instead of a cursor"
+//	@Param			sort		query	string	false	"Comma-separated, - for descending: relevance, name, price, created_date. Defaults to -relevance with q, else -created_date"
+//	@Success		200 {object}	dto.SearchResult
+//	@Failure		400 {object}	errdto.ErrorResponse
+//	@Failure		401 {object}	errdto.ErrorResponse
+//	@Failure		403 {object}	errdto.ErrorResponse
+//	@Failure		500 {object}	errdto.ErrorResponse
+func (h *ProductHandler) Search(c *gin.Context) {
+	var search dto.SearchQuery
+	if err := c.ShouldBindQuery(&search); err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(400, errorResponse)
+		return
+	}
+	opts, err := helpers.ParseQueryOptions(c.Request.URL.Query())
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(400, errorResponse)
+		return
+	}
+	result, err := h.svc.Search(c.Request.Context(), search, opts)
+	if errors.Is(err, query.ErrInvalid) || errors.Is(err, svc.ErrInvalidPriceRange) {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(400, errorResponse)
+		return
+	}
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 500, Message: err.Error()}
+		c.JSON(500, errorResponse)
+		return
+	}
+	c.JSON(200, result)
+}
+
 // GetProduct godoc
 //
 //	@Summary	Get the product
@@ -66,7 +160,7 @@ func (h *ProductHandler) GetById(c *gin.Context) {
 	}
 
 	var product *dto.Product
-	product, err = h.svc.GetById(*id)
+	product, err = h.svc.GetById(c.Request.Context(), *id)
 	if err != nil {
 		errorResponse := errdto.ErrorResponse{Code: 404, Message: err.Error()}
 		c.JSON(404, errorResponse)
@@ -95,7 +189,12 @@ func (h *ProductHandler) Save(c *gin.Context) {
 		c.JSON(400, errorResponse)
 		return
 	}
-	err := h.svc.Save(product)
+	err := h.svc.Save(c.Request.Context(), product)
+	if errors.Is(err, product_repo.ErrUnknownCategory) {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(400, errorResponse)
+		return
+	}
 	if err != nil {
 		errorResponse := errdto.ErrorResponse{Code: 500, Message: err.Error()}
 		c.JSON(500, errorResponse)
@@ -104,6 +203,108 @@ func (h *ProductHandler) Save(c *gin.Context) {
 	c.JSON(201, product)
 }
 
+// UpdateProduct godoc
+//
+//	@Summary		Replace the product
+//	@Description	Fields left out are reset, except stock and category_ids. stock, when given, sets the stock; without it the stock is kept, since orders move it in the meantime. category_ids, when given, replaces the product's categories; without it they're kept.
+//	@Tags			product
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Router			/api/products/{id} [put]
+//	@Param			id		path	int			true	"Product ID"
+//	@Param			product	body	dto.Product	true	"Provide product object"
+//	@Success		200 {object} dto.Product
+//	@Failure		400 {object} errdto.ErrorResponse
+//	@Failure		404 {object} errdto.ErrorResponse
+//	@Failure		500 {object} errdto.ErrorResponse
+//	@Failure		401 {object} errdto.ErrorResponse
+//	@Failure		403 {object} errdto.ErrorResponse
+func (h *ProductHandler) Update(c *gin.Context) {
+	id, err := helpers.ParseParamToUint(c.Param("id"))
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: "invalid id"}
+		c.JSON(400, errorResponse)
+		return
+	}
+	body, err := c.GetRawData()
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(400, errorResponse)
+		return
+	}
+	var product dto.Product
+	if err := binding.JSON.BindBody(body, &product); err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(400, errorResponse)
+		return
+	}
+	h.update(c, *id, &product, helpers.HasMember(body, "stock"))
+}
+
+// PatchProduct godoc
+//
+//	@Summary		Update the product
+//	@Description	Applies a JSON merge patch (RFC 7396): fields given replace the product's, null resets one and fields left out are kept. stock is only written when the patch has it. category_ids, when given, replaces the product's categories.
+//	@Tags			product
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Router			/api/products/{id} [patch]
+//	@Param			id		path	int			true	"Product ID"
+//	@Param			patch	body	dto.Product	true	"Fields to change"
+//	@Success		200 {object} dto.Product
+//	@Failure		400 {object} errdto.ErrorResponse
+//	@Failure		404 {object} errdto.ErrorResponse
+//	@Failure		500 {object} errdto.ErrorResponse
+//	@Failure		401 {object} errdto.ErrorResponse
+//	@Failure		403 {object} errdto.ErrorResponse
+func (h *ProductHandler) Patch(c *gin.Context) {
+	id, err := helpers.ParseParamToUint(c.Param("id"))
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: "invalid id"}
+		c.JSON(400, errorResponse)
+		return
+	}
+	product, err := h.svc.GetById(c.Request.Context(), *id)
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 404, Message: err.Error()}
+		c.JSON(404, errorResponse)
+		return
+	}
+	patch, err := c.GetRawData()
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(400, errorResponse)
+		return
+	}
+	if err := helpers.ApplyMergePatch(product, patch); err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(400, errorResponse)
+		return
+	}
+	h.update(c, *id, product, helpers.HasMember(patch, "stock"))
+}
+
+func (h *ProductHandler) update(c *gin.Context, id uint, product *dto.Product, setStock bool) {
+	err := h.svc.Update(c.Request.Context(), id, product, setStock)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		errorResponse := errdto.ErrorResponse{Code: 404, Message: err.Error()}
+		c.JSON(404, errorResponse)
+		return
+	}
+	if errors.Is(err, product_repo.ErrUnknownCategory) {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(400, errorResponse)
+		return
+	}
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 500, Message: err.Error()}
+		c.JSON(500, errorResponse)
+		return
+	}
+	c.JSON(200, product)
+}
+
 // DeleteProduct godoc
 //
 //	@Summary	Delete the product
@@ -124,7 +325,7 @@ func (h *ProductHandler) Delete(c *gin.Context) {
 		c.JSON(400, errorResponse)
 		return
 	}
-	err = h.svc.Delete(*id, false)
+	err = h.svc.Delete(c.Request.Context(), *id, false)
 	if err != nil {
 		errorResponse := errdto.ErrorResponse{Code: 500, Message: err.Error()}
 		c.JSON(500, errorResponse)
@@ -132,3 +333,192 @@ func (h *ProductHandler) Delete(c *gin.Context) {
 	}
 	c.JSON(204, nil)
 }
+
+// RestoreProduct godoc
+//
+//	@Summary		Restore a deleted product
+//	@Description	Admin only. Undoes a soft delete.
+//	@Tags			product
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Router			/api/products/{id}/restore [post]
+//	@Param			id	path	int	true	"Product ID"
+//	@Success		204
+//	@Failure		400 {object} errdto.ErrorResponse
+//	@Failure		401 {object} errdto.ErrorResponse
+//	@Failure		403 {object} errdto.ErrorResponse
+//	@Failure		404 {object} errdto.ErrorResponse
+//	@Failure		500 {object} errdto.ErrorResponse
+func (h *ProductHandler) Restore(c *gin.Context) {
+	id, err := helpers.ParseParamToUint(c.Param("id"))
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: "invalid id"}
+		c.JSON(400, errorResponse)
+		return
+	}
+	err = h.svc.Restore(c.Request.Context(), *id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		errorResponse := errdto.ErrorResponse{Code: 404, Message: "no deleted product with that id"}
+		c.JSON(404, errorResponse)
+		return
+	}
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 500, Message: err.Error()}
+		c.JSON(500, errorResponse)
+		return
+	}
+	c.JSON(204, nil)
+}
+
+// GetProductCategories godoc
+//
+//	@Summary	Get the categories the product is in
+//	@Tags		product
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Router		/api/products/{id}/categories [get]
+//	@Param		id	path	int	true	"Product ID"
+//	@Success	200 {array}		category_dto.Category
+//	@Failure	400 {object}	errdto.ErrorResponse
+//	@Failure	401 {object}	errdto.ErrorResponse
+//	@Failure	403 {object}	errdto.ErrorResponse
+//	@Failure	404 {object}	errdto.ErrorResponse
+func (h *ProductHandler) GetCategories(c *gin.Context) {
+	id, err := helpers.ParseParamToUint(c.Param("id"))
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: "invalid id"}
+		c.JSON(400, errorResponse)
+		return
+	}
+	var categories []category_dto.Category
+	categories, err = h.svc.GetCategories(c.Request.Context(), *id)
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 404, Message: err.Error()}
+		c.JSON(404, errorResponse)
+		return
+	}
+	c.JSON(200, categories)
+}
+
+// SetProductCategories godoc
+//
+//	@Summary		Set the categories the product is in
+//	@Description	Replaces the product's categories with category_ids. An empty list takes the product out of every category.
+//	@Tags			product
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Router			/api/products/{id}/categories [put]
+//	@Param			id			path	int				true	"Product ID"
+//	@Param			categories	body	dto.Categories	true	"Category ids"
+//	@Success		200 {array}		category_dto.Category
+//	@Failure		400 {object}	errdto.ErrorResponse
+//	@Failure		401 {object}	errdto.ErrorResponse
+//	@Failure		403 {object}	errdto.ErrorResponse
+//	@Failure		404 {object}	errdto.ErrorResponse
+//	@Failure		500 {object}	errdto.ErrorResponse
+func (h *ProductHandler) SetCategories(c *gin.Context) {
+	id, err := helpers.ParseParamToUint(c.Param("id"))
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: "invalid id"}
+		c.JSON(400, errorResponse)
+		return
+	}
+	var body dto.Categories
+	if err := c.ShouldBindJSON(&body); err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(400, errorResponse)
+		return
+	}
+	categories, err := h.svc.SetCategories(c.Request.Context(), *id, body.CategoryIds)
+	if err != nil {
+		respondCategories(c, err)
+		return
+	}
+	c.JSON(200, categories)
+}
+
+// AddProductCategory godoc
+//
+//	@Summary	Add the product to a category
+//	@Tags		product
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Router		/api/products/{id}/categories/{category_id} [post]
+//	@Param		id			path	int	true	"Product ID"
+//	@Param		category_id	path	int	true	"Category ID"
+//	@Success	201 {array}		category_dto.Category
+//	@Failure	400 {object}	errdto.ErrorResponse
+//	@Failure	401 {object}	errdto.ErrorResponse
+//	@Failure	403 {object}	errdto.ErrorResponse
+//	@Failure	404 {object}	errdto.ErrorResponse
+//	@Failure	409 {object}	errdto.ErrorResponse
+//	@Failure	500 {object}	errdto.ErrorResponse
+func (h *ProductHandler) AddCategory(c *gin.Context) {
+	id, err := helpers.ParseParamToUint(c.Param("id"))
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: "invalid id"}
+		c.JSON(400, errorResponse)
+		return
+	}
+	categoryId, err := helpers.ParseParamToUint(c.Param("category_id"))
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: "invalid category id"}
+		c.JSON(400, errorResponse)
+		return
+	}
+	categories, err := h.svc.AddCategory(c.Request.Context(), *id, *categoryId)
+	if err != nil {
+		respondCategories(c, err)
+		return
+	}
+	c.JSON(201, categories)
+}
+
+// RemoveProductCategory godoc
+//
+//	@Summary	Take the product out of a category
+//	@Tags		product
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Router		/api/products/{id}/categories/{category_id} [delete]
+//	@Param		id			path	int	true	"Product ID"
+//	@Param		category_id	path	int	true	"Category ID"
+//	@Success	204
+//	@Failure	400 {object}	errdto.ErrorResponse
+//	@Failure	401 {object}	errdto.ErrorResponse
+//	@Failure	403 {object}	errdto.ErrorResponse
+//	@Failure	404 {object}	errdto.ErrorResponse
+//	@Failure	500 {object}	errdto.ErrorResponse
+func (h *ProductHandler) RemoveCategory(c *gin.Context) {
+	id, err := helpers.ParseParamToUint(c.Param("id"))
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: "invalid id"}
+		c.JSON(400, errorResponse)
+		return
+	}
+	categoryId, err := helpers.ParseParamToUint(c.Param("category_id"))
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: "invalid category id"}
+		c.JSON(400, errorResponse)
+		return
+	}
+	if err := h.svc.RemoveCategory(c.Request.Context(), *id, *categoryId); err != nil {
+		respondCategories(c, err)
+		return
+	}
+	c.JSON(204, nil)
+}
+
+func respondCategories(c *gin.Context, err error) {
+	code := 500
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		code = 404
+	case errors.Is(err, product_repo.ErrUnknownCategory):
+		code = 400
+	case errors.Is(err, product_repo.ErrDuplicateCategory):
+		code = 409
+	}
+	errorResponse := errdto.ErrorResponse{Code: code, Message: err.Error()}
+	c.JSON(code, errorResponse)
+}
@@ -0,0 +1,216 @@
+package returnrequest
+
+import (
+	auth "commerce/api/internal/auth"
+	"commerce/api/internal/helpers"
+	order_service "commerce/api/internal/services/order"
+	returnrequest "commerce/api/internal/services/return-request"
+	"context"
+
+	err_dto "commerce/api/internal/dto/err"
+	dto "commerce/api/internal/dto/return-request"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReturnRequestHandler struct {
+	svc      returnrequest.ReturnRequestServiceI
+	orderSvc order_service.OrderServiceI
+}
+
+func NewReturnRequestHandler(svc returnrequest.ReturnRequestServiceI, orderSvc order_service.OrderServiceI) *ReturnRequestHandler {
+	return &ReturnRequestHandler{svc: svc, orderSvc: orderSvc}
+}
+
+func (h *ReturnRequestHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.GET("/:id", auth.RequireScope(auth.Scopes.Orders.Read), h.GetById)
+	rg.PATCH("/:id/approve", auth.RequireScope(auth.Scopes.Returns.Write), h.Approve)
+	rg.PATCH("/:id/reject", auth.RequireScope(auth.Scopes.Returns.Write), h.Reject)
+	rg.PATCH("/:id/receive", auth.RequireScope(auth.Scopes.Returns.Write), h.Receive)
+}
+
+// GetReturn godoc
+//
+//	@Summary	Get the return
+//	@Tags		return
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Router		/api/returns/{id} [get]
+//	@Param		id	path	int	true	"Return Id"
+//	@Success	200 {object} dto.ReturnRequest
+//	@Failure	400 {object} err_dto.ErrorResponse
+//	@Failure	401 {object} err_dto.ErrorResponse
+//	@Failure	403 {object} err_dto.ErrorResponse
+//	@Failure	404 {object} err_dto.ErrorResponse
+func (h *ReturnRequestHandler) GetById(c *gin.Context) {
+	id, err := helpers.ParseParamToUint(c.Param("id"))
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+
+	var returnRequest *dto.ReturnRequest
+	returnRequest, err = h.svc.GetById(c.Request.Context(), *id)
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 404, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	if !auth.AuthorizeReadOwnerOf(c, returnRequest.OrderId, h.orderSvc.GetOwnerId) {
+		return
+	}
+	c.JSON(200, returnRequest)
+}
+
+// GetReturns godoc
+//
+//	@Summary	Get the returns of an order
+//	@Tags		return
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Router		/api/orders/{id}/returns [get]
+//	@Param		id	path	int	true	"Order Id"
+//	@Success	200 {array} dto.ReturnRequest
+//	@Failure	400 {object} err_dto.ErrorResponse
+//	@Failure	401 {object} err_dto.ErrorResponse
+//	@Failure	403 {object} err_dto.ErrorResponse
+//	@Failure	500 {object} err_dto.ErrorResponse
+func (h *ReturnRequestHandler) GetByOrder(c *gin.Context) {
+	orderId, err := helpers.ParseParamToUint(c.Param("id"))
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+
+	var returnRequests []*dto.ReturnRequest
+	returnRequests, err = h.svc.GetByOrderId(c.Request.Context(), *orderId)
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 500, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	c.JSON(200, returnRequests)
+}
+
+// CreateReturn godoc
+//
+//	@Summary		Request a return
+//	@Description	Requests the return of shipped order items. Staff approve or reject the request.
+//	@Tags			return
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Router			/api/orders/{id}/returns [post]
+//	@Param			id		path	int					true	"Order Id"
+//	@Param			return	body	dto.ReturnRequest	true	"Provide return object"
+//	@Success		201 {object} dto.ReturnRequest
+//	@Failure		400 {object} err_dto.ErrorResponse
+//	@Failure		401 {object} err_dto.ErrorResponse
+//	@Failure		403 {object} err_dto.ErrorResponse
+//	@Failure		422 {object} err_dto.ErrorResponse
+func (h *ReturnRequestHandler) Create(c *gin.Context) {
+	orderId, err := helpers.ParseParamToUint(c.Param("id"))
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	var returnRequest dto.ReturnRequest
+	if err := c.ShouldBindJSON(&returnRequest); err != nil {
+		response := err_dto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	returnRequest.OrderId = *orderId
+
+	created, err := h.svc.Create(c.Request.Context(), returnRequest)
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 422, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	c.JSON(201, created)
+}
+
+// ApproveReturn godoc
+//
+//	@Summary	Approve a requested return
+//	@Tags		return
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Router		/api/returns/{id}/approve [patch]
+//	@Param		id	path	int	true	"Return Id"
+//	@Success	204
+//	@Failure	400 {object} err_dto.ErrorResponse
+//	@Failure	401 {object} err_dto.ErrorResponse
+//	@Failure	403 {object} err_dto.ErrorResponse
+//	@Failure	422 {object} err_dto.ErrorResponse
+func (h *ReturnRequestHandler) Approve(c *gin.Context) {
+	h.transition(c, h.svc.Approve)
+}
+
+// RejectReturn godoc
+//
+//	@Summary	Reject a requested return
+//	@Tags		return
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Router		/api/returns/{id}/reject [patch]
+//	@Param		id	path	int	true	"Return Id"
+//	@Success	204
+//	@Failure	400 {object} err_dto.ErrorResponse
+//	@Failure	401 {object} err_dto.ErrorResponse
+//	@Failure	403 {object} err_dto.ErrorResponse
+//	@Failure	422 {object} err_dto.ErrorResponse
+func (h *ReturnRequestHandler) Reject(c *gin.Context) {
+	h.transition(c, h.svc.Reject)
+}
+
+// ReceiveReturn godoc
+//
+//	@Summary		Record receipt of an approved return
+//	@Description	Restocks the returned items and refunds them, with their share of tax, against the order's payment.
+//	@Tags			return
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Router			/api/returns/{id}/receive [patch]
+//	@Param			id	path	int	true	"Return Id"
+//	@Success		200 {object} dto.ReturnRequest
+//	@Failure		400 {object} err_dto.ErrorResponse
+//	@Failure		401 {object} err_dto.ErrorResponse
+//	@Failure		403 {object} err_dto.ErrorResponse
+//	@Failure		422 {object} err_dto.ErrorResponse
+func (h *ReturnRequestHandler) Receive(c *gin.Context) {
+	id, err := helpers.ParseParamToUint(c.Param("id"))
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+
+	var returnRequest *dto.ReturnRequest
+	returnRequest, err = h.svc.Receive(c.Request.Context(), *id)
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 422, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	c.JSON(200, returnRequest)
+}
+
+func (h *ReturnRequestHandler) transition(c *gin.Context, fn func(ctx context.Context, id uint) error) {
+	id, err := helpers.ParseParamToUint(c.Param("id"))
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	if err := fn(c.Request.Context(), *id); err != nil {
+		response := err_dto.ErrorResponse{Code: 422, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	c.JSON(204, nil)
+}
@@ -3,11 +3,15 @@ package review
 import (
 	auth "commerce/api/internal/auth"
 	errdto "commerce/api/internal/dto/err"
+	"commerce/api/internal/dto/page"
 	dto "commerce/api/internal/dto/review"
 	"commerce/api/internal/helpers"
 	"commerce/api/internal/services/review"
+	"commerce/internal/shared/repositories/query"
+	"errors"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 type ReviewHandler struct {
@@ -21,6 +25,8 @@ func NewReviewHandler(svc review.ReviewServiceI) *ReviewHandler {
 func (h *ReviewHandler) RegisterRoutes(rg *gin.RouterGroup) {
 	rg.GET("/:id", auth.RequireScope(auth.Scopes.Reviews.Read), h.GetById)
 	rg.POST("/", auth.RequireScope(auth.Scopes.Reviews.Write), h.Save)
+	rg.PUT("/:id", auth.RequireScope(auth.Scopes.Reviews.Write), h.Update)
+	rg.PATCH("/:id", auth.RequireScope(auth.Scopes.Reviews.Write), h.Patch)
 	rg.DELETE("/:id", auth.RequireScope(auth.Scopes.Reviews.Write), h.Delete)
 }
 
@@ -38,6 +44,7 @@ func (h *ReviewHandler) RegisterRoutes(rg *gin.RouterGroup) {
 //	@Failure	500	{object}	errdto.ErrorResponse
 //	@Failure	401 {object}	errdto.ErrorResponse
 //	@Failure	403 {object}	errdto.ErrorResponse
+//	@Failure	404 {object}	errdto.ErrorResponse
 func (h *ReviewHandler) Delete(c *gin.Context) {
 	id, err := helpers.ParseParamToUint(c.Param("id"))
 	if err != nil {
@@ -45,8 +52,17 @@ func (h *ReviewHandler) Delete(c *gin.Context) {
 		c.JSON(400, errorResponse)
 		return
 	}
+	review, err := h.svc.GetById(c.Request.Context(), *id)
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 404, Message: err.Error()}
+		c.JSON(errorResponse.Code, errorResponse)
+		return
+	}
+	if !auth.Authorize(c, review.UserId) {
+		return
+	}
 	hard := c.DefaultQuery("hard", "false") == "true"
-	err = h.svc.Delete(*id, hard)
+	err = h.svc.Delete(c.Request.Context(), *id, hard)
 
 	if err != nil {
 		errorResponse := errdto.ErrorResponse{Code: 500, Message: err.Error()}
@@ -63,8 +79,14 @@ func (h *ReviewHandler) Delete(c *gin.Context) {
 //	@Produce	json
 //	@Security	BearerAuth
 //	@Param		id	path		int	true	"product id"
+//	@Param		limit	query	int		false	"Page size, up to 200; defaults to 50"
+//	@Param		cursor	query	string	false	"next_cursor of the previous page"
+//	@Param		offset	query	int		false	"Items to skip, instead of a cursor"
+//	@Param		sort	query	string	false	"Comma-separated, - for descending: rating, created_date"
+//	@Param		rating	query	int		false	"Rating"
+//	@Param		min_rating	query	int	false	"Minimum rating"
 //	@Router		/api/products/{id}/reviews [get]
-//	@Success	200	{array}		dto.Review
+//	@Success	200	{object}	page.Page[dto.Review]
 //	@Failure	400	{object}	errdto.ErrorResponse
 //	@Failure	500	{object}	errdto.ErrorResponse
 //	@Failure	401 {object}	errdto.ErrorResponse
@@ -76,8 +98,19 @@ func (h *ReviewHandler) GetAllByProduct(c *gin.Context) {
 		c.JSON(400, errorResponse)
 		return
 	}
-	var reviews []*dto.Review
-	reviews, err = h.svc.GetAllByProduct(*id)
+	opts, err := helpers.ParseQueryOptions(c.Request.URL.Query(), "rating", "min_rating")
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(400, errorResponse)
+		return
+	}
+	var reviews *page.Page[dto.Review]
+	reviews, err = h.svc.GetAllByProduct(c.Request.Context(), *id, opts)
+	if errors.Is(err, query.ErrInvalid) {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(400, errorResponse)
+		return
+	}
 	if err != nil {
 		errorResponse := errdto.ErrorResponse{Code: 500, Message: err.Error()}
 		c.JSON(500, errorResponse)
@@ -108,7 +141,7 @@ func (h *ReviewHandler) GetById(c *gin.Context) {
 	}
 
 	var review *dto.Review
-	review, err = h.svc.GetById(*id)
+	review, err = h.svc.GetById(c.Request.Context(), *id)
 	if err != nil {
 		errorResponse := errdto.ErrorResponse{Code: 500, Message: err.Error()}
 		c.JSON(errorResponse.Code, errorResponse)
@@ -137,7 +170,10 @@ func (h *ReviewHandler) Save(c *gin.Context) {
 		c.JSON(400, errorResponse)
 		return
 	}
-	err := h.svc.Save(review)
+	if !auth.Authorize(c, review.UserId) {
+		return
+	}
+	err := h.svc.Save(c.Request.Context(), review)
 	if err != nil {
 		errorResponse := errdto.ErrorResponse{Code: 500, Message: err.Error()}
 		c.JSON(500, errorResponse)
@@ -145,3 +181,100 @@ func (h *ReviewHandler) Save(c *gin.Context) {
 	}
 	c.JSON(201, review)
 }
+
+// Updatereview godoc
+//
+//	@Summary		Replace the review
+//	@Description	Fields left out are reset. The review stays with its product and user.
+//	@Tags			review
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Router			/api/review/{id} [put]
+//	@Param			id		path	int			true	"review ID"
+//	@Param			review	body	dto.Review	true	"Provide review object"
+//	@Success		200 {object}	dto.Review
+//	@Failure		400 {object}	errdto.ErrorResponse
+//	@Failure		404 {object}	errdto.ErrorResponse
+//	@Failure		500 {object}	errdto.ErrorResponse
+//	@Failure		401 {object}	errdto.ErrorResponse
+//	@Failure		403 {object}	errdto.ErrorResponse
+func (h *ReviewHandler) Update(c *gin.Context) {
+	id, err := helpers.ParseParamToUint(c.Param("id"))
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: "invalid id"}
+		c.JSON(400, errorResponse)
+		return
+	}
+	var review dto.Review
+	if err := c.ShouldBindJSON(&review); err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(400, errorResponse)
+		return
+	}
+	existing, err := h.svc.GetById(c.Request.Context(), *id)
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 404, Message: err.Error()}
+		c.JSON(errorResponse.Code, errorResponse)
+		return
+	}
+	if !auth.Authorize(c, existing.UserId) {
+		return
+	}
+	h.update(c, *id, &review)
+}
+
+// Patchreview godoc
+//
+//	@Summary		Update the review
+//	@Description	Applies a JSON merge patch (RFC 7396): fields given replace the review's, null resets one and fields left out are kept.
+//	@Tags			review
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Router			/api/review/{id} [patch]
+//	@Param			id		path	int			true	"review ID"
+//	@Param			patch	body	dto.Review	true	"Fields to change"
+//	@Success		200 {object}	dto.Review
+//	@Failure		400 {object}	errdto.ErrorResponse
+//	@Failure		404 {object}	errdto.ErrorResponse
+//	@Failure		500 {object}	errdto.ErrorResponse
+//	@Failure		401 {object}	errdto.ErrorResponse
+//	@Failure		403 {object}	errdto.ErrorResponse
+func (h *ReviewHandler) Patch(c *gin.Context) {
+	id, err := helpers.ParseParamToUint(c.Param("id"))
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: "invalid id"}
+		c.JSON(400, errorResponse)
+		return
+	}
+	review, err := h.svc.GetById(c.Request.Context(), *id)
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 404, Message: err.Error()}
+		c.JSON(errorResponse.Code, errorResponse)
+		return
+	}
+	if !auth.Authorize(c, review.UserId) {
+		return
+	}
+	if err := helpers.BindMergePatch(c, review); err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(400, errorResponse)
+		return
+	}
+	h.update(c, *id, review)
+}
+
+func (h *ReviewHandler) update(c *gin.Context, id uint, review *dto.Review) {
+	err := h.svc.Update(c.Request.Context(), id, review)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		errorResponse := errdto.ErrorResponse{Code: 404, Message: err.Error()}
+		c.JSON(404, errorResponse)
+		return
+	}
+	if err != nil {
+		errorResponse := errdto.ErrorResponse{Code: 500, Message: err.Error()}
+		c.JSON(500, errorResponse)
+		return
+	}
+	c.JSON(200, review)
+}
@@ -0,0 +1,121 @@
+package role
+
+import (
+	auth "commerce/api/internal/auth"
+	"commerce/api/internal/helpers"
+	"commerce/api/internal/services/role"
+	"fmt"
+	"sort"
+
+	err_dto "commerce/api/internal/dto/err"
+	dto "commerce/api/internal/dto/role"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RoleHandler struct {
+	svc role.RoleServiceI
+}
+
+func NewRoleHandler(svc role.RoleServiceI) *RoleHandler {
+	return &RoleHandler{svc: svc}
+}
+
+func (h *RoleHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.GET("/", auth.RequireRole(auth.RoleAdmin), h.GetAll)
+}
+
+// GetRoles godoc
+//
+//	@Summary		Get the roles and the scopes they grant
+//	@Description	Admin only.
+//	@Tags			role
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Router			/api/roles [get]
+//	@Success		200 {array} dto.Role
+//	@Failure		401 {object} err_dto.ErrorResponse
+//	@Failure		403 {object} err_dto.ErrorResponse
+func (h *RoleHandler) GetAll(c *gin.Context) {
+	roles := make([]dto.Role, 0, len(auth.Permissions))
+	for name, permissions := range auth.Permissions {
+		roles = append(roles, dto.Role{Name: name, Permissions: permissions})
+	}
+	sort.Slice(roles, func(i, j int) bool { return roles[i].Name < roles[j].Name })
+	c.JSON(200, roles)
+}
+
+// GetUserRoles godoc
+//
+//	@Summary		Get the roles assigned to a user
+//	@Description	Admin only. Roles carried in the user's token aren't included.
+//	@Tags			role
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Router			/api/users/{user_id}/roles [get]
+//	@Param			user_id	path	int	true	"User Id"
+//	@Success		200 {object} dto.UserRoles
+//	@Failure		400 {object} err_dto.ErrorResponse
+//	@Failure		401 {object} err_dto.ErrorResponse
+//	@Failure		403 {object} err_dto.ErrorResponse
+//	@Failure		500 {object} err_dto.ErrorResponse
+func (h *RoleHandler) GetByUser(c *gin.Context) {
+	userId, err := helpers.ParseParamToUint(c.Param("user_id"))
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	roles, err := h.svc.GetByUserId(c.Request.Context(), *userId)
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 500, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	c.JSON(200, dto.UserRoles{UserId: *userId, Roles: roles})
+}
+
+// AssignUserRoles godoc
+//
+//	@Summary		Assign roles to a user
+//	@Description	Admin only. Replaces the user's assigned roles; an empty list revokes them all.
+//	@Tags			role
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Router			/api/users/{user_id}/roles [put]
+//	@Param			user_id	path	int				true	"User Id"
+//	@Param			roles	body	dto.AssignRoles	true	"Provide the roles"
+//	@Success		200 {object} dto.UserRoles
+//	@Failure		400 {object} err_dto.ErrorResponse
+//	@Failure		401 {object} err_dto.ErrorResponse
+//	@Failure		403 {object} err_dto.ErrorResponse
+//	@Failure		500 {object} err_dto.ErrorResponse
+func (h *RoleHandler) Assign(c *gin.Context) {
+	userId, err := helpers.ParseParamToUint(c.Param("user_id"))
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	var request dto.AssignRoles
+	if err := c.ShouldBindJSON(&request); err != nil {
+		response := err_dto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	for _, r := range request.Roles {
+		if !auth.IsRole(r) {
+			response := err_dto.ErrorResponse{Code: 400, Message: fmt.Sprintf("unknown role: %s", r)}
+			c.JSON(response.Code, response)
+			return
+		}
+	}
+	roles, err := h.svc.Assign(c.Request.Context(), *userId, request.Roles)
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 500, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	c.JSON(200, dto.UserRoles{UserId: *userId, Roles: roles})
+}
@@ -0,0 +1,134 @@
+package shipment
+
+import (
+	auth "commerce/api/internal/auth"
+	"commerce/api/internal/helpers"
+	order_service "commerce/api/internal/services/order"
+	"commerce/api/internal/services/shipment"
+
+	err_dto "commerce/api/internal/dto/err"
+	dto "commerce/api/internal/dto/shipment"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ShipmentHandler struct {
+	svc      shipment.ShipmentServiceI
+	orderSvc order_service.OrderServiceI
+}
+
+func NewShipmentHandler(svc shipment.ShipmentServiceI, orderSvc order_service.OrderServiceI) *ShipmentHandler {
+	return &ShipmentHandler{svc: svc, orderSvc: orderSvc}
+}
+
+func (h *ShipmentHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.GET("/:id", auth.RequireScope(auth.Scopes.Orders.Read), h.GetById)
+}
+
+// GetShipment godoc
+//
+//	@Summary	Get the shipment
+//	@Tags		shipment
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Router		/api/shipments/{id} [get]
+//	@Param		id	path	int	true	"Shipment Id"
+//	@Success	200 {object} dto.Shipment
+//	@Failure	400 {object} err_dto.ErrorResponse
+//	@Failure	401 {object} err_dto.ErrorResponse
+//	@Failure	403 {object} err_dto.ErrorResponse
+//	@Failure	404 {object} err_dto.ErrorResponse
+func (h *ShipmentHandler) GetById(c *gin.Context) {
+	id, err := helpers.ParseParamToUint(c.Param("id"))
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+
+	var shipment *dto.Shipment
+	shipment, err = h.svc.GetById(c.Request.Context(), *id)
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 404, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	if !auth.AuthorizeReadOwnerOf(c, shipment.OrderId, h.orderSvc.GetOwnerId) {
+		return
+	}
+	c.JSON(200, shipment)
+}
+
+// GetShipments godoc
+//
+//	@Summary	Get the shipments of an order
+//	@Tags		shipment
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Router		/api/orders/{id}/shipments [get]
+//	@Param		id	path	int	true	"Order Id"
+//	@Success	200 {array} dto.Shipment
+//	@Failure	400 {object} err_dto.ErrorResponse
+//	@Failure	401 {object} err_dto.ErrorResponse
+//	@Failure	403 {object} err_dto.ErrorResponse
+//	@Failure	500 {object} err_dto.ErrorResponse
+func (h *ShipmentHandler) GetByOrder(c *gin.Context) {
+	orderId, err := helpers.ParseParamToUint(c.Param("id"))
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+
+	var shipments []*dto.Shipment
+	shipments, err = h.svc.GetByOrderId(c.Request.Context(), *orderId)
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 500, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	c.JSON(200, shipments)
+}
+
+// CreateShipment godoc
+//
+//	@Summary		Ship some or all of an order
+//	@Description	Records a shipment for the given order items and quantities. The order moves to
+//	@Description	shipped once every item has shipped in full, or partially_shipped otherwise.
+//	@Description	Leave tracking_number empty to buy a label from a supported carrier (e.g. simulator).
+//	@Description	Staff only: the admin or support role.
+//	@Tags			shipment
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Router			/api/orders/{id}/shipments [post]
+//	@Param			id			path	int				true	"Order Id"
+//	@Param			shipment	body	dto.Shipment	true	"Provide shipment object"
+//	@Success		201 {object} dto.Shipment
+//	@Failure		400 {object} err_dto.ErrorResponse
+//	@Failure		401 {object} err_dto.ErrorResponse
+//	@Failure		403 {object} err_dto.ErrorResponse
+//	@Failure		422 {object} err_dto.ErrorResponse
+func (h *ShipmentHandler) Create(c *gin.Context) {
+	orderId, err := helpers.ParseParamToUint(c.Param("id"))
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	var shipment dto.Shipment
+	if err := c.ShouldBindJSON(&shipment); err != nil {
+		response := err_dto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	shipment.OrderId = *orderId
+
+	created, err := h.svc.Create(c.Request.Context(), shipment)
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 422, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	c.JSON(201, created)
+}
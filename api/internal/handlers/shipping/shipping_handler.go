@@ -0,0 +1,124 @@
+package shipping
+
+import (
+	auth "commerce/api/internal/auth"
+	"commerce/api/internal/services/order"
+	"commerce/api/internal/services/shipping"
+
+	err_dto "commerce/api/internal/dto/err"
+	dto "commerce/api/internal/dto/shipping"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ShippingHandler struct {
+	svc      shipping.ShippingServiceI
+	orderSvc order.OrderServiceI
+}
+
+func NewShippingHandler(svc shipping.ShippingServiceI, orderSvc order.OrderServiceI) *ShippingHandler {
+	return &ShippingHandler{svc: svc, orderSvc: orderSvc}
+}
+
+func (h *ShippingHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.GET("/methods", auth.RequireScope(auth.Scopes.Orders.Read), h.GetMethods)
+	rg.GET("/zones", auth.RequireScope(auth.Scopes.Orders.Read), h.GetZones)
+	rg.POST("/quote", auth.RequireScope(auth.Scopes.Orders.Read), h.Quote)
+}
+
+// GetMethods godoc
+//
+//	@Summary	Get the shipping methods and their rate tables
+//	@Tags		shipping
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Router		/api/shipping/methods [get]
+//	@Success	200 {array} dto.ShippingMethod
+//	@Failure	401 {object} err_dto.ErrorResponse
+//	@Failure	403 {object} err_dto.ErrorResponse
+func (h *ShippingHandler) GetMethods(c *gin.Context) {
+	c.JSON(200, h.svc.GetMethods())
+}
+
+// GetZones godoc
+//
+//	@Summary	Get the shipping zones
+//	@Tags		shipping
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Router		/api/shipping/zones [get]
+//	@Success	200 {array} dto.ShippingZone
+//	@Failure	401 {object} err_dto.ErrorResponse
+//	@Failure	403 {object} err_dto.ErrorResponse
+func (h *ShippingHandler) GetZones(c *gin.Context) {
+	c.JSON(200, h.svc.GetZones())
+}
+
+// Quote godoc
+//
+//	@Summary		Quote shipping for a cart or an existing order
+//	@Description	Quotes every method available in the destination's zone, or only the requested method.
+//	@Description	When order_id is given the order's items are quoted, shipping to the order's address unless one is provided. The order must be the caller's, unless they are staff.
+//	@Tags			shipping
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Router			/api/shipping/quote [post]
+//	@Param			quote	body	dto.QuoteRequest	true	"Provide quote request object"
+//	@Success		200 {array} dto.Quote
+//	@Failure		400 {object} err_dto.ErrorResponse
+//	@Failure		401 {object} err_dto.ErrorResponse
+//	@Failure		403 {object} err_dto.ErrorResponse
+//	@Failure		404 {object} err_dto.ErrorResponse
+func (h *ShippingHandler) Quote(c *gin.Context) {
+	var request dto.QuoteRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		response := err_dto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+
+	items, state := request.Items, request.ShippingAddress.State
+	if request.OrderId != nil {
+		o, err := h.orderSvc.GetById(c.Request.Context(), *request.OrderId)
+		if err != nil {
+			response := err_dto.ErrorResponse{Code: 404, Message: err.Error()}
+			c.JSON(response.Code, response)
+			return
+		}
+		if !auth.AuthorizeRead(c, o.UserId) {
+			return
+		}
+		items = make([]dto.QuoteItem, 0, len(o.OrderItems))
+		for _, item := range o.OrderItems {
+			items = append(items, dto.QuoteItem{ProductId: item.ProductId, VariantId: item.VariantId, Quantity: item.Quantity, UnitPrice: item.UnitPrice})
+		}
+		if state == "" {
+			state = o.ShippingAddress.State
+		}
+	}
+	if len(items) == 0 {
+		response := err_dto.ErrorResponse{Code: 400, Message: "items or order_id is required"}
+		c.JSON(response.Code, response)
+		return
+	}
+
+	if request.Method != "" {
+		quote, err := h.svc.Calculate(c.Request.Context(), items, state, request.Method)
+		if err != nil {
+			response := err_dto.ErrorResponse{Code: 400, Message: err.Error()}
+			c.JSON(response.Code, response)
+			return
+		}
+		c.JSON(200, []dto.Quote{*quote})
+		return
+	}
+
+	quotes, err := h.svc.Quote(c.Request.Context(), items, state)
+	if err != nil {
+		response := err_dto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(response.Code, response)
+		return
+	}
+	c.JSON(200, quotes)
+}
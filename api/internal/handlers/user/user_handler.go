@@ -4,8 +4,11 @@ import (
 	auth "commerce/api/internal/auth"
 	"commerce/api/internal/helpers"
 	"commerce/api/internal/services/user"
+	"commerce/internal/shared/repositories/query"
+	"errors"
 
 	err_dto "commerce/api/internal/dto/err"
+	"commerce/api/internal/dto/page"
 	dto "commerce/api/internal/dto/user"
 
 	"github.com/gin-gonic/gin"
@@ -22,7 +25,6 @@ func NewUserHandler(svc user.UserServiceI) *UserHandler {
 func (h *UserHandler) RegisterRoutes(rg *gin.RouterGroup) {
 	rg.GET("/:id", auth.RequireScope(auth.Scopes.Users.Read), h.GetById)
 	rg.GET("/", auth.RequireScope(auth.Scopes.Users.Read), h.GetAll)
-	rg.POST("/authenticate", auth.RequireScope(auth.Scopes.Users.Write), h.Authenticate)
 	rg.GET("/email/:email", auth.RequireScope(auth.Scopes.Users.Read), h.GetByEmail)
 	rg.DELETE("/:id", auth.RequireScope(auth.Scopes.Users.Delete), h.Delete)
 	rg.POST("/", auth.RequireScope(auth.Scopes.Users.Write), h.Save)
@@ -49,7 +51,7 @@ func (h *UserHandler) GetById(c *gin.Context) {
 		return
 	}
 	var usr *dto.User
-	usr, err = h.svc.GetById(*id)
+	usr, err = h.svc.GetById(c.Request.Context(), *id)
 	if err != nil {
 		response := err_dto.ErrorResponse{Code: 404, Message: err.Error()}
 		c.JSON(response.Code, response)
@@ -64,51 +66,38 @@ func (h *UserHandler) GetById(c *gin.Context) {
 //	@Tags		user
 //	@Produce	json
 //	@Security	BearerAuth
+//	@Param		limit	query	int		false	"Page size, up to 200; defaults to 50"
+//	@Param		cursor	query	string	false	"next_cursor of the previous page"
+//	@Param		offset	query	int		false	"Items to skip, instead of a cursor"
+//	@Param		sort	query	string	false	"Comma-separated, - for descending: email, last_name, created_date"
+//	@Param		email	query	string	false	"Email contains"
+//	@Param		name	query	string	false	"Full name contains"
 //	@Router		/api/user [get]
-//	@Success	200 {array} dto.User
+//	@Success	200 {object} page.Page[dto.User]
 //	@Failure	400 {object} err_dto.ErrorResponse
 //	@Failure	500 {object} err_dto.ErrorResponse
 //	@Failure	401 {object}	err_dto.ErrorResponse
 //	@Failure	403 {object}	err_dto.ErrorResponse
 func (h *UserHandler) GetAll(c *gin.Context) {
-	var users []*dto.User
-
-	users, err := h.svc.GetAll()
+	opts, err := helpers.ParseQueryOptions(c.Request.URL.Query(), "email", "name")
 	if err != nil {
 		response := err_dto.ErrorResponse{Code: 400, Message: err.Error()}
 		c.JSON(response.Code, response)
 		return
 	}
-	c.JSON(200, users)
-}
-
-// GetUser godoc
-//
-//	@Summary	Get the user
-//	@Tags		user
-//	@Produce	json
-//	@Security	BearerAuth
-//	@Router		/api/user/authenticate [post]
-//	@Param	authenticate  body      dto.Authenticate  true  "Provide authenticate object"
-//	@Success	204 {object} nil
-//	@Failure	400 {object} err_dto.ErrorResponse
-//	@Failure	500 {object} err_dto.ErrorResponse
-//	@Failure	401 {object}	err_dto.ErrorResponse
-//	@Failure	403 {object}	err_dto.ErrorResponse
-func (h *UserHandler) Authenticate(c *gin.Context) {
-	var auth *dto.Authenticate
-	if err := c.ShouldBindJSON(&auth); err != nil {
-		errorResponse := err_dto.ErrorResponse{Code: 400, Message: err.Error()}
-		c.JSON(400, errorResponse)
+	var users *page.Page[dto.User]
+	users, err = h.svc.GetAll(c.Request.Context(), opts)
+	if errors.Is(err, query.ErrInvalid) {
+		response := err_dto.ErrorResponse{Code: 400, Message: err.Error()}
+		c.JSON(response.Code, response)
 		return
 	}
-	_, err := h.svc.Authenticate(auth.Email, auth.Password)
 	if err != nil {
-		errorResponse := err_dto.ErrorResponse{Code: 500, Message: err.Error()}
-		c.JSON(errorResponse.Code, errorResponse)
+		response := err_dto.ErrorResponse{Code: 500, Message: err.Error()}
+		c.JSON(response.Code, response)
 		return
 	}
-	c.JSON(204, nil)
+	c.JSON(200, users)
 }
 
 // GetUser godoc
@@ -126,7 +115,7 @@ func (h *UserHandler) Authenticate(c *gin.Context) {
 //	@Failure	403 {object}	err_dto.ErrorResponse
 func (h *UserHandler) GetByEmail(c *gin.Context) {
 	email := c.Param("email")
-	_, err := h.svc.GetByEmail(email)
+	_, err := h.svc.GetByEmail(c.Request.Context(), email)
 	if err != nil {
 		errorResponse := err_dto.ErrorResponse{Code: 404, Message: err.Error()}
 		c.JSON(errorResponse.Code, errorResponse)
@@ -155,7 +144,7 @@ func (h *UserHandler) Delete(c *gin.Context) {
 		c.JSON(errorResponse.Code, errorResponse)
 		return
 	}
-	err = h.svc.Delete(*id)
+	err = h.svc.Delete(c.Request.Context(), *id)
 	if err != nil {
 		errorResponse := err_dto.ErrorResponse{Code: 500, Message: err.Error()}
 		c.JSON(500, errorResponse)
@@ -184,7 +173,7 @@ func (h *UserHandler) Save(c *gin.Context) {
 		c.JSON(400, errorResponse)
 		return
 	}
-	err := h.svc.Save(user)
+	err := h.svc.Save(c.Request.Context(), user)
 	if err != nil {
 		errorResponse := err_dto.ErrorResponse{Code: 500, Message: err.Error()}
 		c.JSON(500, errorResponse)
@@ -1,7 +1,11 @@
 package helpers
 
 import (
+	"commerce/internal/shared/repositories/query"
+	"fmt"
+	"net/url"
 	"strconv"
+	"strings"
 )
 
 func ParseParamToUint(param string) (*uint, error) {
@@ -22,3 +26,47 @@ func ParseParamToBool(param string) bool {
 	}
 	return bool(p)
 }
+
+// ParseQueryOptions reads the paging and sorting parameters of a list
+// endpoint, and the named filters when they are given:
+//
+//	?limit=20&cursor=...&sort=-price,name&min_price=10
+//
+// Whether the sort and filter fields are allowed is up to the repository.
+func ParseQueryOptions(values url.Values, filters ...string) (query.Options, error) {
+	opts := query.Options{Cursor: values.Get("cursor")}
+	var err error
+	if opts.Limit, err = parseInt(values, "limit"); err != nil {
+		return opts, err
+	}
+	if opts.Offset, err = parseInt(values, "offset"); err != nil {
+		return opts, err
+	}
+	if sort := values.Get("sort"); sort != "" {
+		for _, field := range strings.Split(sort, ",") {
+			desc := strings.HasPrefix(field, "-")
+			opts.Sort = append(opts.Sort, query.Sort{Field: strings.TrimPrefix(field, "-"), Desc: desc})
+		}
+	}
+	for _, filter := range filters {
+		if value := values.Get(filter); value != "" {
+			if opts.Filters == nil {
+				opts.Filters = map[string]string{}
+			}
+			opts.Filters[filter] = value
+		}
+	}
+	return opts, nil
+}
+
+func parseInt(values url.Values, key string) (int, error) {
+	value := values.Get(key)
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%s must be a non-negative integer", key)
+	}
+	return n, nil
+}
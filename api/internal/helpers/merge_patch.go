@@ -0,0 +1,89 @@
+package helpers
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// ErrInvalidPatch is returned for a merge patch that isn't a JSON object.
+var ErrInvalidPatch = errors.New("patch must be a JSON object")
+
+// MergePatch applies an RFC 7396 JSON merge patch to target, a pointer to a
+// DTO. Members of the patch replace the target's, objects are merged member
+// by member and null resets a field to its zero value. Fields the patch
+// leaves out keep their values.
+func MergePatch(target any, patch []byte) error {
+	var changes map[string]any
+	if err := json.Unmarshal(patch, &changes); err != nil || changes == nil {
+		return ErrInvalidPatch
+	}
+	b, err := json.Marshal(target)
+	if err != nil {
+		return err
+	}
+	var document map[string]any
+	if err := json.Unmarshal(b, &document); err != nil {
+		return err
+	}
+	if b, err = json.Marshal(merge(document, changes)); err != nil {
+		return err
+	}
+	// Unmarshal into a zero value, or members the patch removed would keep
+	// their old values.
+	v := reflect.ValueOf(target).Elem()
+	v.Set(reflect.Zero(v.Type()))
+	return json.Unmarshal(b, target)
+}
+
+// BindMergePatch applies the request body, a JSON merge patch, to target and
+// validates the result against its binding tags, as ShouldBindJSON would a
+// full body.
+func BindMergePatch(c *gin.Context, target any) error {
+	patch, err := c.GetRawData()
+	if err != nil {
+		return err
+	}
+	return ApplyMergePatch(target, patch)
+}
+
+// ApplyMergePatch is [BindMergePatch] for handlers that have read the patch
+// themselves, to look at which members it has.
+func ApplyMergePatch(target any, patch []byte) error {
+	if err := MergePatch(target, patch); err != nil {
+		return err
+	}
+	return binding.Validator.ValidateStruct(target)
+}
+
+// HasMember reports whether body is a JSON object with a member called name,
+// null included.
+func HasMember(body []byte, name string) bool {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(body, &members); err != nil {
+		return false
+	}
+	_, ok := members[name]
+	return ok
+}
+
+func merge(document map[string]any, patch map[string]any) map[string]any {
+	if document == nil {
+		document = map[string]any{}
+	}
+	for key, value := range patch {
+		switch value := value.(type) {
+		case nil:
+			delete(document, key)
+		case map[string]any:
+			existing, _ := document[key].(map[string]any)
+			document[key] = merge(existing, value)
+		default:
+			document[key] = value
+		}
+	}
+	return document
+}
@@ -0,0 +1,80 @@
+package helpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type patchAddress struct {
+	Street string `json:"street"`
+	City   string `json:"city"`
+}
+
+type patchTarget struct {
+	Name    string       `json:"name" binding:"required"`
+	Stock   int          `json:"stock"`
+	Parent  *uint        `json:"parent_id"`
+	Address patchAddress `json:"address"`
+}
+
+func TestMergePatch(t *testing.T) {
+	parent := uint(4)
+	tests := []struct {
+		name    string
+		patch   string
+		want    patchTarget
+		wantErr error
+	}{
+		{
+			name:  "omitted fields are kept",
+			patch: `{"name": "Cups"}`,
+			want:  patchTarget{Name: "Cups", Stock: 7, Parent: &parent, Address: patchAddress{Street: "1 Main St", City: "Baltimore"}},
+		},
+		{
+			name:  "null resets a field",
+			patch: `{"parent_id": null, "stock": null}`,
+			want:  patchTarget{Name: "Mugs", Address: patchAddress{Street: "1 Main St", City: "Baltimore"}},
+		},
+		{
+			name:  "nested objects merge",
+			patch: `{"address": {"city": "Annapolis"}}`,
+			want:  patchTarget{Name: "Mugs", Stock: 7, Parent: &parent, Address: patchAddress{Street: "1 Main St", City: "Annapolis"}},
+		},
+		{
+			name:  "null in a nested object resets its field",
+			patch: `{"address": {"street": null}}`,
+			want:  patchTarget{Name: "Mugs", Stock: 7, Parent: &parent, Address: patchAddress{City: "Baltimore"}},
+		},
+		{name: "an array is rejected", patch: `[{"name": "Cups"}]`, wantErr: ErrInvalidPatch},
+		{name: "a string is rejected", patch: `"Cups"`, wantErr: ErrInvalidPatch},
+		{name: "null is rejected", patch: `null`, wantErr: ErrInvalidPatch},
+		{name: "invalid JSON is rejected", patch: `{"name":`, wantErr: ErrInvalidPatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := patchTarget{Name: "Mugs", Stock: 7, Parent: &parent, Address: patchAddress{Street: "1 Main St", City: "Baltimore"}}
+			err := MergePatch(&target, []byte(tt.patch))
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, target)
+		})
+	}
+}
+
+func TestApplyMergePatchValidates(t *testing.T) {
+	target := patchTarget{Name: "Mugs"}
+	assert.Error(t, ApplyMergePatch(&target, []byte(`{"name": null}`)), "a patch can't leave a required field empty")
+	assert.NoError(t, ApplyMergePatch(&target, []byte(`{"name": "Cups"}`)))
+}
+
+func TestHasMember(t *testing.T) {
+	assert.True(t, HasMember([]byte(`{"stock": 3}`), "stock"))
+	assert.True(t, HasMember([]byte(`{"stock": null}`), "stock"))
+	assert.False(t, HasMember([]byte(`{"name": "Mugs"}`), "stock"))
+	assert.False(t, HasMember([]byte(`[1, 2]`), "stock"))
+}
@@ -0,0 +1,128 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+)
+
+// ErrUnsupported is returned by Decode for data that isn't a JPEG, PNG or GIF
+// image, or is one too large to process.
+var ErrUnsupported = errors.New("unsupported image")
+
+// MaxPixels bounds the images Decode accepts. A small file can declare huge
+// dimensions, and decoding it would take width × height × 4 bytes.
+const MaxPixels = 30_000_000
+
+// formats maps the formats Decode accepts to their content type and file
+// extension.
+var formats = map[string]struct{ contentType, extension string }{
+	"jpeg": {"image/jpeg", ".jpg"},
+	"png":  {"image/png", ".png"},
+	"gif":  {"image/gif", ".gif"},
+}
+
+// Decode decodes a JPEG, PNG or GIF image and returns its format: jpeg, png
+// or gif. The dimensions are checked against MaxPixels before the pixels are
+// decoded.
+func Decode(data []byte) (image.Image, string, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %v", ErrUnsupported, err)
+	}
+	if _, ok := formats[format]; !ok {
+		return nil, "", fmt.Errorf("%w: %s", ErrUnsupported, format)
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > MaxPixels {
+		return nil, "", fmt.Errorf("%w: %dx%d is over %d pixels", ErrUnsupported, config.Width, config.Height, MaxPixels)
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %v", ErrUnsupported, err)
+	}
+	return img, format, nil
+}
+
+// ContentType is the MIME type of a format Decode returns.
+func ContentType(format string) string {
+	return formats[format].contentType
+}
+
+// Extension is the file extension, with its dot, of a format Decode returns.
+func Extension(format string) string {
+	return formats[format].extension
+}
+
+// Encode writes img in format: jpeg, png or gif.
+func Encode(w io.Writer, img image.Image, format string) error {
+	switch format {
+	case "jpeg":
+		return jpeg.Encode(w, img, &jpeg.Options{Quality: 85})
+	case "png":
+		return png.Encode(w, img)
+	case "gif":
+		return gif.Encode(w, img, nil)
+	}
+	return fmt.Errorf("%w: %s", ErrUnsupported, format)
+}
+
+// Fit scales img down to fit in a size × size square, keeping its aspect
+// ratio. Images that already fit are returned unchanged; Fit never enlarges.
+func Fit(img image.Image, size int) image.Image {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w <= size && h <= size {
+		return img
+	}
+	if w >= h {
+		w, h = size, max(1, h*size/w)
+	} else {
+		w, h = max(1, w*size/h), size
+	}
+	return resize(img, w, h)
+}
+
+// resize scales img to w × h by averaging the source pixels each destination
+// pixel covers. That's the right filter for shrinking, which is all Fit does:
+// every source pixel counts, so detail isn't dropped or aliased as it is by
+// sampling.
+func resize(img image.Image, w int, h int) *image.RGBA {
+	b := img.Bounds()
+	sw, sh := b.Dx(), b.Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := range h {
+		y0, y1 := span(y, h, sh)
+		for x := range w {
+			x0, x1 := span(x, w, sw)
+			var r, g, bl, a uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := img.At(b.Min.X+sx, b.Min.Y+sy).RGBA()
+					r, g, bl, a = r+uint64(cr), g+uint64(cg), bl+uint64(cb), a+uint64(ca)
+				}
+			}
+			n := uint64((x1 - x0) * (y1 - y0))
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8(r / n >> 8),
+				G: uint8(g / n >> 8),
+				B: uint8(bl / n >> 8),
+				A: uint8(a / n >> 8),
+			})
+		}
+	}
+	return dst
+}
+
+// span is the range of the n source pixels covered by destination pixel i of
+// size. Every destination pixel covers at least one source pixel.
+func span(i int, size int, n int) (int, int) {
+	from := i * n / size
+	to := (i + 1) * n / size
+	return from, max(to, from+1)
+}
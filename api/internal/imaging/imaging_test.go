@@ -0,0 +1,62 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFitKeepsAspectRatio(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 400, 100))
+
+	thumb := Fit(img, 200)
+
+	assert.Equal(t, image.Rect(0, 0, 200, 50), thumb.Bounds())
+}
+
+func TestFitDoesNotEnlarge(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 40, 30))
+
+	assert.Same(t, img, Fit(img, 200))
+}
+
+func TestFitAveragesPixels(t *testing.T) {
+	// Alternating black and white columns average to grey rather than
+	// sampling to all black or all white.
+	img := image.NewGray(image.Rect(0, 0, 4, 4))
+	for x := range 4 {
+		for y := range 4 {
+			img.SetGray(x, y, color.Gray{Y: uint8(255 * (x % 2))})
+		}
+	}
+
+	thumb := Fit(img, 2)
+
+	r, g, b, _ := thumb.At(0, 0).RGBA()
+	assert.InDelta(t, 127, r>>8, 1)
+	assert.InDelta(t, 127, g>>8, 1)
+	assert.InDelta(t, 127, b>>8, 1)
+}
+
+func TestDecode(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 3, 2))))
+
+	img, format, err := Decode(buf.Bytes())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "png", format)
+	assert.Equal(t, image.Rect(0, 0, 3, 2), img.Bounds())
+	assert.Equal(t, "image/png", ContentType(format))
+	assert.Equal(t, ".png", Extension(format))
+}
+
+func TestDecodeRejectsOtherData(t *testing.T) {
+	_, _, err := Decode([]byte("not an image"))
+
+	assert.ErrorIs(t, err, ErrUnsupported)
+}
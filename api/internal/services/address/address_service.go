@@ -2,15 +2,19 @@ package address
 
 import (
 	dto "commerce/api/internal/dto/address"
+	"commerce/api/internal/dto/page"
 	addressrepo "commerce/internal/shared/repositories/address"
+	"commerce/internal/shared/repositories/query"
+	"context"
 	"log/slog"
 )
 
 type AddressServiceI interface {
-	GetById(id uint) (*dto.Address, error)
-	GetAllByUserId(userId uint) ([]*dto.Address, error)
-	Save(address *dto.Address) error
-	Delete(id uint, hard bool) error
+	GetById(ctx context.Context, id uint) (*dto.Address, error)
+	GetAllByUserId(ctx context.Context, userId uint, opts query.Options) (*page.Page[dto.Address], error)
+	Save(ctx context.Context, address *dto.Address) error
+	Update(ctx context.Context, id uint, address *dto.Address) error
+	Delete(ctx context.Context, id uint, hard bool) error
 }
 
 type AddressService struct {
@@ -22,27 +26,23 @@ func NewAddressService(repo addressrepo.AddressRepositoryI) AddressServiceI {
 }
 
 // Delete implements [AddressServiceI].
-func (a *AddressService) Delete(id uint, hard bool) error {
-	return a.repo.Delete(id, hard)
+func (a *AddressService) Delete(ctx context.Context, id uint, hard bool) error {
+	return a.repo.Delete(ctx, id, hard)
 }
 
 // GetAllByUserId implements [AddressServiceI].
-func (a *AddressService) GetAllByUserId(userId uint) ([]*dto.Address, error) {
-	models, err := a.repo.GetByUserId(userId)
+func (a *AddressService) GetAllByUserId(ctx context.Context, userId uint, opts query.Options) (*page.Page[dto.Address], error) {
+	models, err := a.repo.GetByUserId(ctx, userId, opts)
 	if err != nil {
 		slog.Error("Error occured getting addresses by user.", "error", err)
 		return nil, err
 	}
-	addresses := []*dto.Address{}
-	for _, addr := range models {
-		addresses = append(addresses, dto.FromModel(addr))
-	}
-	return addresses, nil
+	return page.FromPage(models, dto.FromModel), nil
 }
 
 // GetById implements [AddressServiceI].
-func (a *AddressService) GetById(id uint) (*dto.Address, error) {
-	model, err := a.repo.GetById(id)
+func (a *AddressService) GetById(ctx context.Context, id uint) (*dto.Address, error) {
+	model, err := a.repo.GetById(ctx, id)
 	if err != nil {
 		slog.Error("Error occured getting addresses by id.", "error", err)
 		return nil, err
@@ -50,8 +50,31 @@ func (a *AddressService) GetById(id uint) (*dto.Address, error) {
 	return dto.FromModel(model), nil
 }
 
-// Save implements [AddressServiceI].
-func (a *AddressService) Save(address *dto.Address) error {
+// Save implements [AddressServiceI]. It always creates an address; see Update.
+func (a *AddressService) Save(ctx context.Context, address *dto.Address) error {
+	model := dto.ToModel(address)
+	if err := a.repo.Save(ctx, model); err != nil {
+		return err
+	}
+	address.Id = model.Id
+	return nil
+}
+
+// Update implements [AddressServiceI]. The address stays with its user
+// whatever the request says.
+func (a *AddressService) Update(ctx context.Context, id uint, address *dto.Address) error {
+	existing, err := a.repo.GetById(ctx, id)
+	if err != nil {
+		slog.Error("Error occured getting address to update.", "id", id, "error", err)
+		return err
+	}
 	model := dto.ToModel(address)
-	return a.repo.Save(model)
+	model.Base = existing.Base
+	model.UserId = existing.UserId
+	if err := a.repo.Save(ctx, model); err != nil {
+		slog.Error("Error occured updating address.", "id", id, "error", err)
+		return err
+	}
+	*address = *dto.FromModel(model)
+	return nil
 }
@@ -0,0 +1,53 @@
+package address
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	dto "commerce/api/internal/dto/address"
+	"commerce/internal/shared/models"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func setup(t *testing.T) (*MockAddressRepositoryI, AddressServiceI) {
+	t.Helper()
+	ctl := gomock.NewController(t)
+	t.Cleanup(ctl.Finish)
+	repo := NewMockAddressRepositoryI(ctl)
+	return repo, NewAddressService(repo)
+}
+
+func TestUpdateKeepsOwnerAndBase(t *testing.T) {
+	repo, svc := setup(t)
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	repo.EXPECT().GetById(gomock.Any(), uint(4)).Return(&models.Address{
+		Base:   models.Base{Id: 4, CreatedDate: created},
+		UserId: 7,
+		Street: "1 Old St",
+	}, nil)
+	repo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, a *models.Address) error {
+		assert.Equal(t, uint(4), a.Id)
+		assert.Equal(t, created, a.CreatedDate)
+		assert.Equal(t, uint(7), a.UserId, "the owner comes from the stored address")
+		assert.Equal(t, "2 New St", a.Street)
+		return nil
+	})
+	address := &dto.Address{Id: 99, UserId: 8, Street: "2 New St", City: "Baltimore", State: "MD", PostalCode: "21201", Country: "US"}
+
+	err := svc.Update(context.Background(), 4, address)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(4), address.Id)
+	assert.Equal(t, uint(7), address.UserId)
+}
+
+func TestUpdateMissingAddress(t *testing.T) {
+	repo, svc := setup(t)
+	repo.EXPECT().GetById(gomock.Any(), uint(4)).Return(nil, gorm.ErrRecordNotFound)
+
+	err := svc.Update(context.Background(), 4, &dto.Address{})
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
@@ -0,0 +1,156 @@
+package apikey
+
+import (
+	dto "commerce/api/internal/dto/api-key"
+	"commerce/internal/shared/models"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var now = time.Date(2026, 10, 19, 9, 30, 0, 0, time.UTC)
+
+func setup(t *testing.T) (*MockApiKeyRepositoryI, ApiKeyServiceI) {
+	t.Helper()
+	ctl := gomock.NewController(t)
+	t.Cleanup(ctl.Finish)
+	mockRepo := NewMockApiKeyRepositoryI(ctl)
+	return mockRepo, NewApiKeyService(mockRepo, func() time.Time { return now })
+}
+
+// issueKey creates a key through the service and returns it with the row the
+// repository was asked to save.
+func issueKey(t *testing.T, mockRepo *MockApiKeyRepositoryI, svc ApiKeyServiceI) (*dto.IssuedApiKey, *models.ApiKey) {
+	t.Helper()
+	var saved *models.ApiKey
+	mockRepo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, apiKey *models.ApiKey) error {
+		apiKey.Id = 1
+		saved = apiKey
+		return nil
+	})
+	issued, err := svc.Create(context.Background(), dto.CreateApiKey{
+		Name:   "ERP sync",
+		Owner:  "Acme ERP",
+		Scopes: []string{"orders:read", "products:write"},
+	})
+	require.NoError(t, err)
+	return issued, saved
+}
+
+func TestCreate(t *testing.T) {
+	mockRepo, svc := setup(t)
+	issued, saved := issueKey(t, mockRepo, svc)
+
+	assert.True(t, strings.HasPrefix(issued.Key, "ck_"+saved.Prefix+"_"))
+	assert.Equal(t, []string{"orders:read", "products:write"}, issued.Scopes)
+	assert.Equal(t, "orders:read products:write", saved.Scopes)
+	assert.NotContains(t, saved.SecretHash, strings.Split(issued.Key, "_")[2], "only a hash of the secret is stored")
+}
+
+func TestAuthenticate(t *testing.T) {
+	mockRepo, svc := setup(t)
+	issued, saved := issueKey(t, mockRepo, svc)
+	mockRepo.EXPECT().GetByPrefix(gomock.Any(), saved.Prefix).Return(saved, nil)
+	mockRepo.EXPECT().Touch(gomock.Any(), uint(1), now).Return(nil)
+
+	apiKey, err := svc.Authenticate(context.Background(), issued.Key)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Acme ERP", apiKey.Owner)
+	assert.Equal(t, &now, apiKey.LastUsedDate)
+}
+
+func TestAuthenticate_RecentlyUsed_SkipsTouch(t *testing.T) {
+	mockRepo, svc := setup(t)
+	issued, saved := issueKey(t, mockRepo, svc)
+	lastUsed := now.Add(-10 * time.Second)
+	saved.LastUsedDate = &lastUsed
+	mockRepo.EXPECT().GetByPrefix(gomock.Any(), saved.Prefix).Return(saved, nil)
+	// no Touch expected — gomock fails the test if it is called
+
+	_, err := svc.Authenticate(context.Background(), issued.Key)
+	require.NoError(t, err)
+}
+
+func TestAuthenticate_WrongSecret(t *testing.T) {
+	mockRepo, svc := setup(t)
+	_, saved := issueKey(t, mockRepo, svc)
+	mockRepo.EXPECT().GetByPrefix(gomock.Any(), saved.Prefix).Return(saved, nil)
+
+	_, err := svc.Authenticate(context.Background(), "ck_"+saved.Prefix+"_not-the-secret")
+	assert.ErrorIs(t, err, ErrInvalidApiKey)
+}
+
+func TestAuthenticate_Revoked(t *testing.T) {
+	mockRepo, svc := setup(t)
+	issued, saved := issueKey(t, mockRepo, svc)
+	revoked := now.Add(-time.Hour)
+	saved.RevokedDate = &revoked
+	mockRepo.EXPECT().GetByPrefix(gomock.Any(), saved.Prefix).Return(saved, nil)
+
+	_, err := svc.Authenticate(context.Background(), issued.Key)
+	assert.ErrorIs(t, err, ErrInvalidApiKey)
+}
+
+func TestAuthenticate_Expired(t *testing.T) {
+	mockRepo, svc := setup(t)
+	issued, saved := issueKey(t, mockRepo, svc)
+	saved.ExpiresDate = &now
+	mockRepo.EXPECT().GetByPrefix(gomock.Any(), saved.Prefix).Return(saved, nil)
+
+	_, err := svc.Authenticate(context.Background(), issued.Key)
+	assert.ErrorIs(t, err, ErrInvalidApiKey)
+}
+
+func TestAuthenticate_Malformed(t *testing.T) {
+	_, svc := setup(t)
+	for _, key := range []string{"", "ck_", "ck_abc", "sk_abc_def", "ck__secret"} {
+		_, err := svc.Authenticate(context.Background(), key)
+		assert.ErrorIs(t, err, ErrInvalidApiKey, key)
+	}
+}
+
+func TestAuthenticate_UnknownPrefix(t *testing.T) {
+	mockRepo, svc := setup(t)
+	mockRepo.EXPECT().GetByPrefix(gomock.Any(), "abcdef").Return(nil, errors.New("record not found"))
+
+	_, err := svc.Authenticate(context.Background(), "ck_abcdef_secret")
+	assert.ErrorIs(t, err, ErrInvalidApiKey)
+}
+
+func TestRotate(t *testing.T) {
+	mockRepo, svc := setup(t)
+	issued, saved := issueKey(t, mockRepo, svc)
+	oldPrefix, oldHash := saved.Prefix, saved.SecretHash
+	mockRepo.EXPECT().GetById(gomock.Any(), uint(1)).Return(saved, nil)
+	mockRepo.EXPECT().Save(gomock.Any(), saved).Return(nil)
+
+	rotated, err := svc.Rotate(context.Background(), 1)
+
+	require.NoError(t, err)
+	assert.NotEqual(t, issued.Key, rotated.Key)
+	assert.NotEqual(t, oldPrefix, saved.Prefix)
+	assert.NotEqual(t, oldHash, saved.SecretHash)
+	assert.Equal(t, uint(1), rotated.Id)
+}
+
+func TestRotate_Revoked(t *testing.T) {
+	mockRepo, svc := setup(t)
+	mockRepo.EXPECT().GetById(gomock.Any(), uint(1)).Return(&models.ApiKey{Base: models.Base{Id: 1}, RevokedDate: &now}, nil)
+
+	_, err := svc.Rotate(context.Background(), 1)
+	assert.ErrorIs(t, err, ErrInvalidApiKey)
+}
+
+func TestRevoke(t *testing.T) {
+	mockRepo, svc := setup(t)
+	mockRepo.EXPECT().Revoke(gomock.Any(), uint(1), now).Return(nil)
+
+	assert.NoError(t, svc.Revoke(context.Background(), 1))
+}
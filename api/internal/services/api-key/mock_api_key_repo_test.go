@@ -0,0 +1,131 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../../../../internal/shared/repositories/api-key/api_key_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=../../../../internal/shared/repositories/api-key/api_key_repository.go -destination=mock_api_key_repo_test.go -package=apikey
+//
+
+// Package apikey is a generated GoMock package.
+package apikey
+
+import (
+	models "commerce/internal/shared/models"
+	query "commerce/internal/shared/repositories/query"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockApiKeyRepositoryI is a mock of ApiKeyRepositoryI interface.
+type MockApiKeyRepositoryI struct {
+	ctrl     *gomock.Controller
+	recorder *MockApiKeyRepositoryIMockRecorder
+	isgomock struct{}
+}
+
+// MockApiKeyRepositoryIMockRecorder is the mock recorder for MockApiKeyRepositoryI.
+type MockApiKeyRepositoryIMockRecorder struct {
+	mock *MockApiKeyRepositoryI
+}
+
+// NewMockApiKeyRepositoryI creates a new mock instance.
+func NewMockApiKeyRepositoryI(ctrl *gomock.Controller) *MockApiKeyRepositoryI {
+	mock := &MockApiKeyRepositoryI{ctrl: ctrl}
+	mock.recorder = &MockApiKeyRepositoryIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApiKeyRepositoryI) EXPECT() *MockApiKeyRepositoryIMockRecorder {
+	return m.recorder
+}
+
+// GetAll mocks base method.
+func (m *MockApiKeyRepositoryI) GetAll(ctx context.Context, opts query.Options) (*query.Page[models.ApiKey], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, opts)
+	ret0, _ := ret[0].(*query.Page[models.ApiKey])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockApiKeyRepositoryIMockRecorder) GetAll(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockApiKeyRepositoryI)(nil).GetAll), ctx, opts)
+}
+
+// GetById mocks base method.
+func (m *MockApiKeyRepositoryI) GetById(ctx context.Context, id uint) (*models.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(*models.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockApiKeyRepositoryIMockRecorder) GetById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockApiKeyRepositoryI)(nil).GetById), ctx, id)
+}
+
+// GetByPrefix mocks base method.
+func (m *MockApiKeyRepositoryI) GetByPrefix(ctx context.Context, prefix string) (*models.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByPrefix", ctx, prefix)
+	ret0, _ := ret[0].(*models.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByPrefix indicates an expected call of GetByPrefix.
+func (mr *MockApiKeyRepositoryIMockRecorder) GetByPrefix(ctx, prefix any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByPrefix", reflect.TypeOf((*MockApiKeyRepositoryI)(nil).GetByPrefix), ctx, prefix)
+}
+
+// Revoke mocks base method.
+func (m *MockApiKeyRepositoryI) Revoke(ctx context.Context, id uint, revokedDate time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, id, revokedDate)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockApiKeyRepositoryIMockRecorder) Revoke(ctx, id, revokedDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockApiKeyRepositoryI)(nil).Revoke), ctx, id, revokedDate)
+}
+
+// Save mocks base method.
+func (m *MockApiKeyRepositoryI) Save(ctx context.Context, apiKey *models.ApiKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, apiKey)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockApiKeyRepositoryIMockRecorder) Save(ctx, apiKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockApiKeyRepositoryI)(nil).Save), ctx, apiKey)
+}
+
+// Touch mocks base method.
+func (m *MockApiKeyRepositoryI) Touch(ctx context.Context, id uint, lastUsedDate time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Touch", ctx, id, lastUsedDate)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Touch indicates an expected call of Touch.
+func (mr *MockApiKeyRepositoryIMockRecorder) Touch(ctx, id, lastUsedDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Touch", reflect.TypeOf((*MockApiKeyRepositoryI)(nil).Touch), ctx, id, lastUsedDate)
+}
@@ -0,0 +1,49 @@
+package auditevent
+
+import (
+	dto "commerce/api/internal/dto/audit-event"
+	"commerce/api/internal/dto/page"
+	"commerce/internal/shared/models"
+	repo "commerce/internal/shared/repositories/audit-event"
+	"commerce/internal/shared/repositories/query"
+	"context"
+	"errors"
+	"log/slog"
+)
+
+// ErrInvalidRange is returned when a query's from date isn't before its to date.
+var ErrInvalidRange = errors.New("from must be before to")
+
+type AuditEventServiceI interface {
+	GetAll(ctx context.Context, search dto.AuditEventQuery, opts query.Options) (*page.Page[dto.AuditEvent], error)
+}
+
+type AuditEventService struct {
+	repo repo.AuditEventRepositoryI
+}
+
+func NewAuditEventService(repo repo.AuditEventRepositoryI) AuditEventServiceI {
+	return &AuditEventService{repo: repo}
+}
+
+// GetAll implements [AuditEventServiceI].
+func (a *AuditEventService) GetAll(ctx context.Context, search dto.AuditEventQuery, opts query.Options) (*page.Page[dto.AuditEvent], error) {
+	if search.From != nil && search.To != nil && !search.From.Before(*search.To) {
+		return nil, ErrInvalidRange
+	}
+	filter := repo.Filter{
+		Subject:    search.Subject,
+		UserId:     search.UserId,
+		Action:     models.AuditAction(search.Action),
+		EntityType: search.EntityType,
+		EntityId:   search.EntityId,
+		From:       search.From,
+		To:         search.To,
+	}
+	models, err := a.repo.GetAll(ctx, filter, opts)
+	if err != nil {
+		slog.Error("Exception occurred getting audit events.", "error", err)
+		return nil, err
+	}
+	return page.FromPage(models, dto.FromModel), nil
+}
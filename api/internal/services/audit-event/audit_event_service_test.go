@@ -0,0 +1,81 @@
+package auditevent
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	dto "commerce/api/internal/dto/audit-event"
+	"commerce/internal/shared/models"
+	repo "commerce/internal/shared/repositories/audit-event"
+	"commerce/internal/shared/repositories/query"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func setup(t *testing.T) (*MockAuditEventRepositoryI, AuditEventServiceI) {
+	t.Helper()
+	ctl := gomock.NewController(t)
+	t.Cleanup(ctl.Finish)
+	mockRepo := NewMockAuditEventRepositoryI(ctl)
+	return mockRepo, NewAuditEventService(mockRepo)
+}
+
+func TestGetAll(t *testing.T) {
+	mockRepo, svc := setup(t)
+	entityId, userId := uint(3), uint(7)
+	before, after := `{"status":"pending"}`, `{"status":"shipped"}`
+	mockRepo.EXPECT().GetAll(gomock.Any(), repo.Filter{
+		Action:     models.AuditActionUpdate,
+		EntityType: "orders",
+		EntityId:   &entityId,
+	}, query.Options{Limit: 10}).Return(&query.Page[models.AuditEvent]{Total: 1, Items: []*models.AuditEvent{{
+		Base:       models.Base{Id: 1},
+		Subject:    "auth0|abc123",
+		UserId:     &userId,
+		Action:     models.AuditActionUpdate,
+		EntityType: "orders",
+		EntityId:   entityId,
+		Before:     &before,
+		After:      &after,
+	}}}, nil)
+
+	events, err := svc.GetAll(context.Background(), dto.AuditEventQuery{Action: "update", EntityType: "orders", EntityId: &entityId}, query.Options{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, events.Items, 1)
+	assert.Equal(t, int64(1), events.Total)
+	assert.Equal(t, "auth0|abc123", events.Items[0].Subject)
+	assert.JSONEq(t, before, string(events.Items[0].Before))
+	assert.JSONEq(t, after, string(events.Items[0].After))
+}
+
+func TestGetAll_CreateHasNoBefore(t *testing.T) {
+	mockRepo, svc := setup(t)
+	after := `{"id":1}`
+	mockRepo.EXPECT().GetAll(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&query.Page[models.AuditEvent]{Items: []*models.AuditEvent{{Action: models.AuditActionCreate, After: &after}}}, nil)
+
+	events, err := svc.GetAll(context.Background(), dto.AuditEventQuery{}, query.Options{})
+	require.NoError(t, err)
+	assert.Nil(t, events.Items[0].Before)
+}
+
+func TestGetAll_InvalidRange(t *testing.T) {
+	_, svc := setup(t)
+	from := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	to := from.Add(-time.Hour)
+
+	_, err := svc.GetAll(context.Background(), dto.AuditEventQuery{From: &from, To: &to}, query.Options{})
+	assert.ErrorIs(t, err, ErrInvalidRange)
+}
+
+func TestGetAll_RepoError(t *testing.T) {
+	mockRepo, svc := setup(t)
+	mockRepo.EXPECT().GetAll(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+
+	_, err := svc.GetAll(context.Background(), dto.AuditEventQuery{}, query.Options{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,59 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../../../../internal/shared/repositories/audit-event/audit_event_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=../../../../internal/shared/repositories/audit-event/audit_event_repository.go -destination=mock_audit_event_repo_test.go -package=auditevent
+//
+
+// Package auditevent is a generated GoMock package.
+package auditevent
+
+import (
+	models "commerce/internal/shared/models"
+	auditevent "commerce/internal/shared/repositories/audit-event"
+	query "commerce/internal/shared/repositories/query"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAuditEventRepositoryI is a mock of AuditEventRepositoryI interface.
+type MockAuditEventRepositoryI struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditEventRepositoryIMockRecorder
+	isgomock struct{}
+}
+
+// MockAuditEventRepositoryIMockRecorder is the mock recorder for MockAuditEventRepositoryI.
+type MockAuditEventRepositoryIMockRecorder struct {
+	mock *MockAuditEventRepositoryI
+}
+
+// NewMockAuditEventRepositoryI creates a new mock instance.
+func NewMockAuditEventRepositoryI(ctrl *gomock.Controller) *MockAuditEventRepositoryI {
+	mock := &MockAuditEventRepositoryI{ctrl: ctrl}
+	mock.recorder = &MockAuditEventRepositoryIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditEventRepositoryI) EXPECT() *MockAuditEventRepositoryIMockRecorder {
+	return m.recorder
+}
+
+// GetAll mocks base method.
+func (m *MockAuditEventRepositoryI) GetAll(ctx context.Context, filter auditevent.Filter, opts query.Options) (*query.Page[models.AuditEvent], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, filter, opts)
+	ret0, _ := ret[0].(*query.Page[models.AuditEvent])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockAuditEventRepositoryIMockRecorder) GetAll(ctx, filter, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockAuditEventRepositoryI)(nil).GetAll), ctx, filter, opts)
+}
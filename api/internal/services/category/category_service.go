@@ -2,16 +2,26 @@ package category
 
 import (
 	dto "commerce/api/internal/dto/category"
+	"commerce/api/internal/dto/page"
 	repo "commerce/internal/shared/repositories/category"
+	"commerce/internal/shared/repositories/query"
+	"context"
+	"errors"
+	"fmt"
 	"log/slog"
 )
 
+// ErrInvalidParent is returned when a category would become its own parent or
+// the child of one of its descendants.
+var ErrInvalidParent = errors.New("invalid parent category")
+
 type CategoryServiceI interface {
-	GetById(id uint) (*dto.Category, error)
-	GetAll() ([]*dto.Category, error)
-	GetAllByParentId(parentId uint) ([]*dto.Category, error)
-	Save(category *dto.Category) error
-	Delete(id uint, hard bool) error
+	GetById(ctx context.Context, id uint) (*dto.Category, error)
+	GetAll(ctx context.Context, opts query.Options) (*page.Page[dto.Category], error)
+	GetAllByParentId(ctx context.Context, parentId uint, opts query.Options) (*page.Page[dto.Category], error)
+	Save(ctx context.Context, category *dto.Category) error
+	Update(ctx context.Context, id uint, category *dto.Category) error
+	Delete(ctx context.Context, id uint, hard bool) error
 }
 
 type CategoryService struct {
@@ -23,34 +33,33 @@ func NewCategoryService(repo repo.CategoryRepositoryI) CategoryServiceI {
 }
 
 // Delete implements [CategoryServiceI].
-func (c *CategoryService) Delete(id uint, hard bool) error {
-	return c.repo.Delete(id, hard)
+func (c *CategoryService) Delete(ctx context.Context, id uint, hard bool) error {
+	return c.repo.Delete(ctx, id, hard)
 }
 
 // GetAll implements [CategoryServiceI].
-func (c *CategoryService) GetAll() ([]*dto.Category, error) {
-	models, err := c.repo.GetAll()
+func (c *CategoryService) GetAll(ctx context.Context, opts query.Options) (*page.Page[dto.Category], error) {
+	models, err := c.repo.GetAll(ctx, opts)
 	if err != nil {
 		slog.Error("Exception occured while getting all categories.", "error", err)
 		return nil, err
 	}
-	return dto.FromAllModels(models), nil
+	return page.FromPage(models, dto.FromModel), nil
 }
 
 // GetAllByParentId implements [CategoryServiceI].
-func (c *CategoryService) GetAllByParentId(parentId uint) ([]*dto.Category, error) {
-	models, err := c.repo.GetByParentId(parentId)
+func (c *CategoryService) GetAllByParentId(ctx context.Context, parentId uint, opts query.Options) (*page.Page[dto.Category], error) {
+	models, err := c.repo.GetByParentId(ctx, parentId, opts)
 	if err != nil {
 		slog.Error("Exception occured while getting all categories by parent.", "error", err)
 		return nil, err
 	}
-
-	return dto.FromAllModels(models), nil
+	return page.FromPage(models, dto.FromModel), nil
 }
 
 // GetById implements [CategoryServiceI].
-func (c *CategoryService) GetById(id uint) (*dto.Category, error) {
-	model, err := c.repo.GetById(id)
+func (c *CategoryService) GetById(ctx context.Context, id uint) (*dto.Category, error) {
+	model, err := c.repo.GetById(ctx, id)
 	if err != nil {
 		slog.Error("Exception occured while getting category.", "error", err)
 		return nil, err
@@ -58,8 +67,49 @@ func (c *CategoryService) GetById(id uint) (*dto.Category, error) {
 	return dto.FromModel(model), nil
 }
 
-// Save implements [CategoryServiceI].
-func (c *CategoryService) Save(category *dto.Category) error {
+// Save implements [CategoryServiceI]. It always creates a category; see
+// Update.
+func (c *CategoryService) Save(ctx context.Context, category *dto.Category) error {
 	model := dto.ToModel(category)
-	return c.repo.Save(model)
+	if err := c.repo.Save(ctx, model); err != nil {
+		return err
+	}
+	category.Id = model.Id
+	return nil
+}
+
+// Update implements [CategoryServiceI]. A category can't be moved under
+// itself or one of its descendants.
+func (c *CategoryService) Update(ctx context.Context, id uint, category *dto.Category) error {
+	existing, err := c.repo.GetById(ctx, id)
+	if err != nil {
+		slog.Error("Exception occured while getting category to update.", "id", id, "error", err)
+		return err
+	}
+	if err := c.checkParent(ctx, id, category.ParentId); err != nil {
+		return err
+	}
+	model := dto.ToModel(category)
+	model.Base = existing.Base
+	if err := c.repo.Save(ctx, model); err != nil {
+		slog.Error("Exception occured while updating category.", "id", id, "error", err)
+		return err
+	}
+	*category = *dto.FromModel(model)
+	return nil
+}
+
+// checkParent walks up from parentId and fails if it reaches id.
+func (c *CategoryService) checkParent(ctx context.Context, id uint, parentId *uint) error {
+	for parentId != nil {
+		if *parentId == id {
+			return fmt.Errorf("%w: %d is %d or one of its subcategories", ErrInvalidParent, *parentId, id)
+		}
+		parent, err := c.repo.GetById(ctx, *parentId)
+		if err != nil {
+			return fmt.Errorf("%w: %d", ErrInvalidParent, *parentId)
+		}
+		parentId = parent.ParentId
+	}
+	return nil
 }
@@ -0,0 +1,55 @@
+package category
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	dto "commerce/api/internal/dto/category"
+	"commerce/internal/shared/models"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func setup(t *testing.T) (*MockCategoryRepositoryI, CategoryServiceI) {
+	t.Helper()
+	ctl := gomock.NewController(t)
+	t.Cleanup(ctl.Finish)
+	repo := NewMockCategoryRepositoryI(ctl)
+	return repo, NewCategoryService(repo)
+}
+
+func TestUpdateKeepsBase(t *testing.T) {
+	repo, svc := setup(t)
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	repo.EXPECT().GetById(gomock.Any(), uint(2)).Return(&models.Category{
+		Base: models.Base{Id: 2, CreatedDate: created},
+		Name: "Mugs",
+	}, nil)
+	repo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, c *models.Category) error {
+		assert.Equal(t, uint(2), c.Id)
+		assert.Equal(t, created, c.CreatedDate)
+		assert.Equal(t, "Cups", c.Name)
+		return nil
+	})
+	category := &dto.Category{Id: 99, Name: "Cups"}
+
+	err := svc.Update(context.Background(), 2, category)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), category.Id)
+}
+
+func TestUpdateUnderOwnSubcategory(t *testing.T) {
+	repo, svc := setup(t)
+	parent := uint(3)
+	repo.EXPECT().GetById(gomock.Any(), uint(2)).Return(&models.Category{Base: models.Base{Id: 2}}, nil)
+	repo.EXPECT().GetById(gomock.Any(), uint(3)).Return(&models.Category{Base: models.Base{Id: 3}, ParentId: ptr(uint(2))}, nil)
+
+	err := svc.Update(context.Background(), 2, &dto.Category{Name: "Cups", ParentId: &parent})
+	assert.ErrorIs(t, err, ErrInvalidParent)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
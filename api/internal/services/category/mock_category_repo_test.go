@@ -0,0 +1,116 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../../../../internal/shared/repositories/category/category_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=../../../../internal/shared/repositories/category/category_repository.go -destination=mock_category_repo_test.go -package=category
+//
+
+// Package category is a generated GoMock package.
+package category
+
+import (
+	models "commerce/internal/shared/models"
+	query "commerce/internal/shared/repositories/query"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCategoryRepositoryI is a mock of CategoryRepositoryI interface.
+type MockCategoryRepositoryI struct {
+	ctrl     *gomock.Controller
+	recorder *MockCategoryRepositoryIMockRecorder
+	isgomock struct{}
+}
+
+// MockCategoryRepositoryIMockRecorder is the mock recorder for MockCategoryRepositoryI.
+type MockCategoryRepositoryIMockRecorder struct {
+	mock *MockCategoryRepositoryI
+}
+
+// NewMockCategoryRepositoryI creates a new mock instance.
+func NewMockCategoryRepositoryI(ctrl *gomock.Controller) *MockCategoryRepositoryI {
+	mock := &MockCategoryRepositoryI{ctrl: ctrl}
+	mock.recorder = &MockCategoryRepositoryIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCategoryRepositoryI) EXPECT() *MockCategoryRepositoryIMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockCategoryRepositoryI) Delete(ctx context.Context, id uint, hard bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, hard)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCategoryRepositoryIMockRecorder) Delete(ctx, id, hard any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCategoryRepositoryI)(nil).Delete), ctx, id, hard)
+}
+
+// GetAll mocks base method.
+func (m *MockCategoryRepositoryI) GetAll(ctx context.Context, opts query.Options) (*query.Page[models.Category], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, opts)
+	ret0, _ := ret[0].(*query.Page[models.Category])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockCategoryRepositoryIMockRecorder) GetAll(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockCategoryRepositoryI)(nil).GetAll), ctx, opts)
+}
+
+// GetById mocks base method.
+func (m *MockCategoryRepositoryI) GetById(ctx context.Context, id uint) (*models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(*models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockCategoryRepositoryIMockRecorder) GetById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockCategoryRepositoryI)(nil).GetById), ctx, id)
+}
+
+// GetByParentId mocks base method.
+func (m *MockCategoryRepositoryI) GetByParentId(ctx context.Context, parentId uint, opts query.Options) (*query.Page[models.Category], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByParentId", ctx, parentId, opts)
+	ret0, _ := ret[0].(*query.Page[models.Category])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByParentId indicates an expected call of GetByParentId.
+func (mr *MockCategoryRepositoryIMockRecorder) GetByParentId(ctx, parentId, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByParentId", reflect.TypeOf((*MockCategoryRepositoryI)(nil).GetByParentId), ctx, parentId, opts)
+}
+
+// Save mocks base method.
+func (m *MockCategoryRepositoryI) Save(ctx context.Context, category *models.Category) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, category)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockCategoryRepositoryIMockRecorder) Save(ctx, category any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockCategoryRepositoryI)(nil).Save), ctx, category)
+}
@@ -0,0 +1,330 @@
+package invoice
+
+import (
+	dto "commerce/api/internal/dto/invoice"
+	tax_service "commerce/api/internal/services/tax"
+	"commerce/internal/shared/models"
+	repo "commerce/internal/shared/repositories/invoice"
+	order_repo "commerce/internal/shared/repositories/order"
+	payment_repo "commerce/internal/shared/repositories/payment"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrNotInvoiced is returned when an order has no invoice yet, either because
+// it hasn't been paid in full or because it was paid before invoicing existed.
+var ErrNotInvoiced = errors.New("order has not been invoiced")
+
+type InvoiceServiceI interface {
+	GetById(ctx context.Context, id uint) (*dto.Invoice, error)
+	GetByOrderId(ctx context.Context, orderId uint) ([]*dto.Invoice, error)
+	GetInvoice(ctx context.Context, orderId uint) (*dto.Invoice, error)
+	IssueIfPaid(ctx context.Context, orderId uint) (*dto.Invoice, error)
+	IssueCreditNote(ctx context.Context, orderId uint, amount float64, reason string) (*dto.Invoice, error)
+	CreditRemaining(ctx context.Context, orderId uint, reason string) (*dto.Invoice, error)
+}
+
+type InvoiceService struct {
+	repo        repo.InvoiceRepositoryI
+	orderRepo   order_repo.OrderRepositoryI
+	paymentRepo payment_repo.PaymentRepositoryI
+	taxService  tax_service.TaxServiceI
+}
+
+func NewInvoiceService(repo repo.InvoiceRepositoryI,
+	orderRepo order_repo.OrderRepositoryI,
+	paymentRepo payment_repo.PaymentRepositoryI,
+	taxService tax_service.TaxServiceI) InvoiceServiceI {
+	return &InvoiceService{
+		repo:        repo,
+		orderRepo:   orderRepo,
+		paymentRepo: paymentRepo,
+		taxService:  taxService,
+	}
+}
+
+// GetById implements [InvoiceServiceI].
+func (s *InvoiceService) GetById(ctx context.Context, id uint) (*dto.Invoice, error) {
+	model, err := s.repo.GetById(ctx, id)
+	if err != nil {
+		slog.Error("Exception occurred getting invoice by id.", "id", id, "error", err)
+		return nil, err
+	}
+	return dto.FromModel(model), nil
+}
+
+// GetByOrderId implements [InvoiceServiceI].
+func (s *InvoiceService) GetByOrderId(ctx context.Context, orderId uint) ([]*dto.Invoice, error) {
+	models, err := s.repo.GetAllByOrderId(ctx, orderId)
+	if err != nil {
+		slog.Error("Exception occurred getting invoices by order.", "order-id", orderId, "error", err)
+		return nil, err
+	}
+	invoices := make([]*dto.Invoice, 0, len(models))
+	for _, model := range models {
+		invoices = append(invoices, dto.FromModel(model))
+	}
+	return invoices, nil
+}
+
+// GetInvoice implements [InvoiceServiceI].
+func (s *InvoiceService) GetInvoice(ctx context.Context, orderId uint) (*dto.Invoice, error) {
+	invoice, _, err := s.invoiceAndCredits(ctx, orderId)
+	if err != nil {
+		return nil, err
+	}
+	if invoice == nil {
+		return nil, ErrNotInvoiced
+	}
+	return dto.FromModel(invoice), nil
+}
+
+// IssueIfPaid implements [InvoiceServiceI]. An order gets one invoice, issued
+// the first time this is called after its payments cover the total. Until then
+// it returns ErrNotInvoiced; afterwards it returns the invoice already issued,
+// including when a concurrent call issues it first.
+func (s *InvoiceService) IssueIfPaid(ctx context.Context, orderId uint) (*dto.Invoice, error) {
+	existing, _, err := s.invoiceAndCredits(ctx, orderId)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return dto.FromModel(existing), nil
+	}
+
+	order, err := s.orderRepo.GetById(ctx, orderId)
+	if err != nil {
+		slog.Error("Exception occurred getting order by id.", "id", orderId, "error", err)
+		return nil, err
+	}
+	payments, err := s.paymentRepo.GetByOrder(ctx, orderId)
+	if err != nil {
+		slog.Error("Exception occurred getting payments by order.", "order-id", orderId, "error", err)
+		return nil, err
+	}
+	if order.Status == models.OrderStatusCancelled || paidAmount(payments) < round(order.TotalAmount) {
+		return nil, ErrNotInvoiced
+	}
+
+	invoice, err := s.invoiceFromOrder(order)
+	if err != nil {
+		return nil, err
+	}
+	err = s.create(ctx, invoice)
+	if errors.Is(err, repo.ErrAlreadyIssued) {
+		invoice, _, err = s.invoiceAndCredits(ctx, orderId)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return dto.FromModel(invoice), nil
+}
+
+// IssueCreditNote implements [InvoiceServiceI]. The amount is the gross
+// refund; its tax is apportioned at the invoice's ratio of tax to total so
+// that crediting the whole invoice reverses its tax exactly. Run it in a
+// transaction; see lockedInvoice.
+func (s *InvoiceService) IssueCreditNote(ctx context.Context, orderId uint, amount float64, reason string) (*dto.Invoice, error) {
+	invoice, credits, err := s.lockedInvoice(ctx, orderId)
+	if err != nil {
+		return nil, err
+	}
+	return s.creditNote(ctx, invoice, credits, amount, reason)
+}
+
+// CreditRemaining implements [InvoiceServiceI]. It credits whatever part of
+// the invoice earlier credit notes haven't, as when an order is cancelled, and
+// returns nil when the invoice is already fully credited. Run it in a
+// transaction; see lockedInvoice.
+func (s *InvoiceService) CreditRemaining(ctx context.Context, orderId uint, reason string) (*dto.Invoice, error) {
+	invoice, credits, err := s.lockedInvoice(ctx, orderId)
+	if err != nil {
+		return nil, err
+	}
+	remaining := uncredited(invoice, credits)
+	if remaining == 0 {
+		return nil, nil
+	}
+	return s.creditNote(ctx, invoice, credits, remaining, reason)
+}
+
+func (s *InvoiceService) creditNote(ctx context.Context, invoice *models.Invoice, credits []*models.Invoice, amount float64, reason string) (*dto.Invoice, error) {
+	remaining := uncredited(invoice, credits)
+	if amount <= 0 || amount > remaining {
+		return nil, fmt.Errorf("cannot credit %.2f of invoice %s, %.2f not yet credited", amount, invoice.InvoiceNumber, remaining)
+	}
+
+	share := amount / invoice.TotalAmount
+	tax := round(invoice.TaxAmount * share)
+	net := round(amount - tax)
+	creditNote := &models.Invoice{
+		Type:              models.InvoiceTypeCreditNote,
+		OrderId:           invoice.OrderId,
+		CreditedInvoiceId: &invoice.Id,
+		OrderNumber:       invoice.OrderNumber,
+		Reason:            reason,
+		IssuedDate:        time.Now(),
+		BillingAddress:    invoice.BillingAddress,
+		ShippingAddress:   invoice.ShippingAddress,
+		Currency:          invoice.Currency,
+		SubTotalAmount:    net,
+		TaxState:          invoice.TaxState,
+		TaxRate:           invoice.TaxRate,
+		TaxableAmount:     round(invoice.TaxableAmount * share),
+		TaxAmount:         tax,
+		TotalAmount:       amount,
+		Lines: []models.InvoiceLine{
+			{Description: reason, Quantity: 1, UnitPrice: net, Amount: net},
+		},
+	}
+	if err := s.create(ctx, creditNote); err != nil {
+		return nil, err
+	}
+	return dto.FromModel(creditNote), nil
+}
+
+func (s *InvoiceService) create(ctx context.Context, invoice *models.Invoice) error {
+	next, err := s.repo.NextNumber(ctx, invoice.Type)
+	if err != nil {
+		slog.Error("Exception occurred numbering invoice.", "order-id", invoice.OrderId, "type", invoice.Type, "error", err)
+		return err
+	}
+	invoice.InvoiceNumber = fmt.Sprintf("%s-%06d", numberPrefixes[invoice.Type], next)
+	if err := s.repo.Create(ctx, invoice); err != nil {
+		if !errors.Is(err, repo.ErrAlreadyIssued) {
+			slog.Error("Exception occurred issuing invoice.", "order-id", invoice.OrderId, "type", invoice.Type, "error", err)
+		}
+		return err
+	}
+	return nil
+}
+
+// lockedInvoice locks the order's invoice and only then reads it and its
+// credit notes, so that within a transaction two credit notes against the
+// same invoice are checked one after the other and can't together credit more
+// than it totals.
+func (s *InvoiceService) lockedInvoice(ctx context.Context, orderId uint) (*models.Invoice, []*models.Invoice, error) {
+	if err := s.repo.LockInvoice(ctx, orderId); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrNotInvoiced
+		}
+		slog.Error("Exception occurred locking invoice.", "order-id", orderId, "error", err)
+		return nil, nil, err
+	}
+	invoice, credits, err := s.invoiceAndCredits(ctx, orderId)
+	if err != nil {
+		return nil, nil, err
+	}
+	if invoice == nil {
+		return nil, nil, ErrNotInvoiced
+	}
+	return invoice, credits, nil
+}
+
+// invoiceAndCredits splits an order's documents into its invoice, nil when
+// there isn't one, and the credit notes issued against it.
+func (s *InvoiceService) invoiceAndCredits(ctx context.Context, orderId uint) (*models.Invoice, []*models.Invoice, error) {
+	documents, err := s.repo.GetAllByOrderId(ctx, orderId)
+	if err != nil {
+		slog.Error("Exception occurred getting invoices by order.", "order-id", orderId, "error", err)
+		return nil, nil, err
+	}
+	var invoice *models.Invoice
+	credits := []*models.Invoice{}
+	for _, document := range documents {
+		if document.Type == models.InvoiceTypeInvoice {
+			invoice = document
+		} else {
+			credits = append(credits, document)
+		}
+	}
+	return invoice, credits, nil
+}
+
+func uncredited(invoice *models.Invoice, credits []*models.Invoice) float64 {
+	credited := 0.0
+	for _, credit := range credits {
+		credited += credit.TotalAmount
+	}
+	return round(invoice.TotalAmount - credited)
+}
+
+func (s *InvoiceService) invoiceFromOrder(order *models.Order) (*models.Invoice, error) {
+	state := order.BillingAddress.State
+	rate, err := s.taxService.Calculate(1, state)
+	if err != nil {
+		slog.Error("Exception occurred getting invoice tax rate.", "order-id", order.Id, "state", state, "error", err)
+		return nil, err
+	}
+	taxable := order.SubTotalAmount
+	if s.taxService.IsShippingTaxable(state) {
+		taxable += order.ShippingAmount
+	}
+
+	lines := make([]models.InvoiceLine, len(order.OrderItems))
+	for i, item := range order.OrderItems {
+		description := item.Product.Name
+		if description == "" {
+			description = fmt.Sprintf("Product #%d", item.ProductId)
+		}
+		lines[i] = models.InvoiceLine{
+			ProductId:   &item.ProductId,
+			Description: description,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+			Amount:      round(item.UnitPrice * float64(item.Quantity)),
+		}
+	}
+
+	return &models.Invoice{
+		Type:            models.InvoiceTypeInvoice,
+		OrderId:         order.Id,
+		OrderNumber:     order.OrderNumber,
+		IssuedDate:      time.Now(),
+		BillingAddress:  order.BillingAddress,
+		ShippingAddress: order.ShippingAddress,
+		Currency:        "USD",
+		SubTotalAmount:  order.SubTotalAmount,
+		ShippingAmount:  order.ShippingAmount,
+		TaxState:        state,
+		TaxRate:         *rate,
+		TaxableAmount:   round(taxable),
+		TaxAmount:       round(order.TaxAmount),
+		TotalAmount:     round(order.TotalAmount),
+		Lines:           lines,
+	}, nil
+}
+
+var numberPrefixes = map[models.InvoiceType]string{
+	models.InvoiceTypeInvoice:    "INV",
+	models.InvoiceTypeCreditNote: "CN",
+}
+
+// paidStatuses are the payment statuses in which the money has been taken,
+// including payments that have since been refunded in part or in full.
+var paidStatuses = map[models.PaymentStatus]struct{}{
+	models.PaymentStatusCompleted:         {},
+	models.PaymentStatusCaptured:          {},
+	models.PaymentStatusPartiallyRefunded: {},
+	models.PaymentStatusRefunded:          {},
+}
+
+func paidAmount(payments []*models.Payment) float64 {
+	paid := 0.0
+	for _, payment := range payments {
+		if _, ok := paidStatuses[payment.Status]; ok {
+			paid += payment.Amount
+		}
+	}
+	return round(paid)
+}
+
+func round(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,183 @@
+package invoice
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	tax_service "commerce/api/internal/services/tax"
+	"commerce/internal/shared/models"
+	repo "commerce/internal/shared/repositories/invoice"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+type mocks struct {
+	repo        *MockInvoiceRepositoryI
+	orderRepo   *MockOrderRepositoryI
+	paymentRepo *MockPaymentRepositoryI
+}
+
+func setup(t *testing.T) (*mocks, InvoiceServiceI) {
+	t.Helper()
+	ctl := gomock.NewController(t)
+	t.Cleanup(ctl.Finish)
+	m := &mocks{
+		repo:        NewMockInvoiceRepositoryI(ctl),
+		orderRepo:   NewMockOrderRepositoryI(ctl),
+		paymentRepo: NewMockPaymentRepositoryI(ctl),
+	}
+	return m, NewInvoiceService(m.repo, m.orderRepo, m.paymentRepo, tax_service.NewTaxService())
+}
+
+func paidOrder() *models.Order {
+	return &models.Order{
+		Base:           models.Base{Id: 1},
+		OrderNumber:    "ORD-20261019-0000017",
+		Status:         models.OrderStatusPending,
+		SubTotalAmount: 40,
+		ShippingAmount: 8.99,
+		TaxAmount:      (40 + 8.99) * 0.06625,
+		TotalAmount:    40 + 8.99 + (40+8.99)*0.06625,
+		BillingAddress: models.AddressSnapshot{Street: "1 Main St", City: "Trenton", State: "NJ", PostalCode: "08608"},
+		OrderItems: []models.OrderItem{
+			{ProductId: 1, Quantity: 2, UnitPrice: 5, Product: models.Product{Name: "Widget"}},
+			{ProductId: 2, Quantity: 3, UnitPrice: 10},
+		},
+	}
+}
+
+func invoice() *models.Invoice {
+	return &models.Invoice{
+		Base:          models.Base{Id: 9},
+		InvoiceNumber: "INV-000009",
+		OrderId:       1,
+		Type:          models.InvoiceTypeInvoice,
+		TaxableAmount: 50,
+		TaxAmount:     3,
+		TotalAmount:   53,
+	}
+}
+
+func TestIssueIfPaid(t *testing.T) {
+	m, svc := setup(t)
+	m.repo.EXPECT().GetAllByOrderId(gomock.Any(), uint(1)).Return([]*models.Invoice{}, nil)
+	m.orderRepo.EXPECT().GetById(gomock.Any(), uint(1)).Return(paidOrder(), nil)
+	m.paymentRepo.EXPECT().GetByOrder(gomock.Any(), uint(1)).Return([]*models.Payment{
+		{Amount: 20, Status: models.PaymentStatusFailed},
+		{Amount: 52.24, Status: models.PaymentStatusCaptured},
+	}, nil)
+	m.repo.EXPECT().NextNumber(gomock.Any(), models.InvoiceTypeInvoice).Return(int64(17), nil)
+	m.repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+	invoice, err := svc.IssueIfPaid(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "INV-000017", invoice.InvoiceNumber)
+	assert.Equal(t, "Widget", invoice.Lines[0].Description)
+	assert.Equal(t, "Product #2", invoice.Lines[1].Description, "lines fall back to the product id")
+	assert.Equal(t, 30.0, invoice.Lines[1].Amount)
+	assert.Equal(t, "NJ", invoice.Tax.State)
+	assert.Equal(t, 0.06625, invoice.Tax.Rate)
+	assert.Equal(t, 48.99, invoice.Tax.TaxableAmount, "shipping is taxable in NJ")
+	assert.Equal(t, 3.25, invoice.Tax.Amount)
+	assert.Equal(t, 52.24, invoice.TotalAmount)
+	assert.Equal(t, "Trenton", invoice.BillingAddress.City)
+}
+
+func TestIssueIfPaidUnderpaid(t *testing.T) {
+	m, svc := setup(t)
+	m.repo.EXPECT().GetAllByOrderId(gomock.Any(), uint(1)).Return([]*models.Invoice{}, nil)
+	m.orderRepo.EXPECT().GetById(gomock.Any(), uint(1)).Return(paidOrder(), nil)
+	m.paymentRepo.EXPECT().GetByOrder(gomock.Any(), uint(1)).Return([]*models.Payment{
+		{Amount: 52.24, Status: models.PaymentStatusAuthorized},
+	}, nil)
+
+	_, err := svc.IssueIfPaid(context.Background(), 1)
+	assert.ErrorIs(t, err, ErrNotInvoiced)
+}
+
+func TestIssueIfPaidAlreadyInvoiced(t *testing.T) {
+	m, svc := setup(t)
+	m.repo.EXPECT().GetAllByOrderId(gomock.Any(), uint(1)).Return([]*models.Invoice{invoice()}, nil)
+
+	invoice, err := svc.IssueIfPaid(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "INV-000009", invoice.InvoiceNumber)
+}
+
+func TestIssueIfPaidIssuedConcurrently(t *testing.T) {
+	m, svc := setup(t)
+	gomock.InOrder(
+		m.repo.EXPECT().GetAllByOrderId(gomock.Any(), uint(1)).Return([]*models.Invoice{}, nil),
+		m.repo.EXPECT().GetAllByOrderId(gomock.Any(), uint(1)).Return([]*models.Invoice{invoice()}, nil),
+	)
+	m.orderRepo.EXPECT().GetById(gomock.Any(), uint(1)).Return(paidOrder(), nil)
+	m.paymentRepo.EXPECT().GetByOrder(gomock.Any(), uint(1)).Return([]*models.Payment{{Amount: 52.24, Status: models.PaymentStatusCaptured}}, nil)
+	m.repo.EXPECT().NextNumber(gomock.Any(), models.InvoiceTypeInvoice).Return(int64(17), nil)
+	m.repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(repo.ErrAlreadyIssued)
+
+	invoice, err := svc.IssueIfPaid(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "INV-000009", invoice.InvoiceNumber, "the invoice issued first is returned")
+}
+
+func TestIssueCreditNoteOverCredits(t *testing.T) {
+	m, svc := setup(t)
+	m.repo.EXPECT().LockInvoice(gomock.Any(), uint(1)).Return(nil)
+	m.repo.EXPECT().GetAllByOrderId(gomock.Any(), uint(1)).Return([]*models.Invoice{
+		invoice(),
+		{Type: models.InvoiceTypeCreditNote, TotalAmount: 40},
+	}, nil)
+
+	_, err := svc.IssueCreditNote(context.Background(), 1, 13.01, "return")
+	assert.Error(t, err)
+}
+
+func TestIssueCreditNoteWithoutInvoice(t *testing.T) {
+	m, svc := setup(t)
+	m.repo.EXPECT().LockInvoice(gomock.Any(), uint(1)).Return(gorm.ErrRecordNotFound)
+
+	_, err := svc.IssueCreditNote(context.Background(), 1, 10, "return")
+	assert.ErrorIs(t, err, ErrNotInvoiced)
+}
+
+func TestCreditRemaining(t *testing.T) {
+	m, svc := setup(t)
+	m.repo.EXPECT().LockInvoice(gomock.Any(), uint(1)).Return(nil)
+	m.repo.EXPECT().GetAllByOrderId(gomock.Any(), uint(1)).Return([]*models.Invoice{
+		invoice(),
+		{Type: models.InvoiceTypeCreditNote, TaxAmount: 1.2, TotalAmount: 21.2},
+	}, nil)
+	m.repo.EXPECT().NextNumber(gomock.Any(), models.InvoiceTypeCreditNote).Return(int64(2), nil)
+	m.repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+	creditNote, err := svc.CreditRemaining(context.Background(), 1, "cancelled")
+	assert.NoError(t, err)
+	assert.Equal(t, "CN-000002", creditNote.InvoiceNumber)
+	assert.Equal(t, 31.8, creditNote.TotalAmount)
+	assert.Equal(t, 1.8, creditNote.Tax.Amount, "both credit notes together reverse the invoice's tax")
+	assert.Equal(t, uint(9), *creditNote.CreditedInvoiceId)
+}
+
+func TestRender(t *testing.T) {
+	m, svc := setup(t)
+	m.repo.EXPECT().GetAllByOrderId(gomock.Any(), uint(1)).Return([]*models.Invoice{}, nil)
+	m.orderRepo.EXPECT().GetById(gomock.Any(), uint(1)).Return(paidOrder(), nil)
+	m.paymentRepo.EXPECT().GetByOrder(gomock.Any(), uint(1)).Return([]*models.Payment{{Amount: 52.24, Status: models.PaymentStatusCompleted}}, nil)
+	m.repo.EXPECT().NextNumber(gomock.Any(), models.InvoiceTypeInvoice).Return(int64(17), nil)
+	m.repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	invoice, err := svc.IssueIfPaid(context.Background(), 1)
+	assert.NoError(t, err)
+
+	var html bytes.Buffer
+	assert.NoError(t, RenderHTML(&html, invoice))
+	assert.Contains(t, html.String(), "Invoice INV-000017")
+	assert.Contains(t, html.String(), "Tax (NJ 6.62% of 48.99)")
+	assert.Contains(t, html.String(), "USD 52.24")
+
+	var pdf bytes.Buffer
+	assert.NoError(t, RenderPDF(&pdf, invoice))
+	assert.True(t, bytes.HasPrefix(pdf.Bytes(), []byte("%PDF-")))
+}
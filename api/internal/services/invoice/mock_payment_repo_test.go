@@ -0,0 +1,130 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../../../../internal/shared/repositories/payment/payment_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=../../../../internal/shared/repositories/payment/payment_repository.go -destination=mock_payment_repo_test.go -package=invoice
+//
+
+// Package invoice is a generated GoMock package.
+package invoice
+
+import (
+	models "commerce/internal/shared/models"
+	query "commerce/internal/shared/repositories/query"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPaymentRepositoryI is a mock of PaymentRepositoryI interface.
+type MockPaymentRepositoryI struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentRepositoryIMockRecorder
+	isgomock struct{}
+}
+
+// MockPaymentRepositoryIMockRecorder is the mock recorder for MockPaymentRepositoryI.
+type MockPaymentRepositoryIMockRecorder struct {
+	mock *MockPaymentRepositoryI
+}
+
+// NewMockPaymentRepositoryI creates a new mock instance.
+func NewMockPaymentRepositoryI(ctrl *gomock.Controller) *MockPaymentRepositoryI {
+	mock := &MockPaymentRepositoryI{ctrl: ctrl}
+	mock.recorder = &MockPaymentRepositoryIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentRepositoryI) EXPECT() *MockPaymentRepositoryIMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockPaymentRepositoryI) Delete(ctx context.Context, id uint, hard bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, hard)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPaymentRepositoryIMockRecorder) Delete(ctx, id, hard any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPaymentRepositoryI)(nil).Delete), ctx, id, hard)
+}
+
+// GetAll mocks base method.
+func (m *MockPaymentRepositoryI) GetAll(ctx context.Context, opts query.Options) (*query.Page[models.Payment], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, opts)
+	ret0, _ := ret[0].(*query.Page[models.Payment])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockPaymentRepositoryIMockRecorder) GetAll(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockPaymentRepositoryI)(nil).GetAll), ctx, opts)
+}
+
+// GetById mocks base method.
+func (m *MockPaymentRepositoryI) GetById(ctx context.Context, id uint) (*models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(*models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockPaymentRepositoryIMockRecorder) GetById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockPaymentRepositoryI)(nil).GetById), ctx, id)
+}
+
+// GetByOrder mocks base method.
+func (m *MockPaymentRepositoryI) GetByOrder(ctx context.Context, orderId uint) ([]*models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByOrder", ctx, orderId)
+	ret0, _ := ret[0].([]*models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByOrder indicates an expected call of GetByOrder.
+func (mr *MockPaymentRepositoryIMockRecorder) GetByOrder(ctx, orderId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOrder", reflect.TypeOf((*MockPaymentRepositoryI)(nil).GetByOrder), ctx, orderId)
+}
+
+// Save mocks base method.
+func (m *MockPaymentRepositoryI) Save(ctx context.Context, payment *models.Payment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, payment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockPaymentRepositoryIMockRecorder) Save(ctx, payment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockPaymentRepositoryI)(nil).Save), ctx, payment)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockPaymentRepositoryI) UpdateStatus(ctx context.Context, id uint, status string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, id, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockPaymentRepositoryIMockRecorder) UpdateStatus(ctx, id, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockPaymentRepositoryI)(nil).UpdateStatus), ctx, id, status)
+}
@@ -0,0 +1,145 @@
+package invoice
+
+import (
+	dto "commerce/api/internal/dto/invoice"
+	"commerce/api/internal/dto/order"
+	_ "embed"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+
+	"github.com/go-pdf/fpdf"
+)
+
+//go:embed templates/invoice.html
+var invoiceTemplate string
+
+var htmlTemplate = template.Must(template.New("invoice").Funcs(template.FuncMap{
+	"title":    title,
+	"money":    money,
+	"taxLabel": taxLabel,
+}).Parse(invoiceTemplate))
+
+// RenderHTML writes the invoice or credit note as a standalone HTML page.
+func RenderHTML(w io.Writer, invoice *dto.Invoice) error {
+	return htmlTemplate.Execute(w, invoice)
+}
+
+// RenderPDF writes the invoice or credit note as a single-font A4 PDF. It
+// carries the same content as RenderHTML.
+func RenderPDF(w io.Writer, invoice *dto.Invoice) error {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(20, 20, 20)
+	pdf.AddPage()
+	// The core fonts are cp1252; this maps text that strays outside ASCII.
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	pdf.SetFont("Helvetica", "B", 18)
+	pdf.CellFormat(0, 10, fmt.Sprintf("%s %s", title(invoice), invoice.InvoiceNumber), "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.CellFormat(0, 6, fmt.Sprintf("Order %s - Issued %s", invoice.OrderNumber, invoice.IssuedDate.Format("January 2, 2006")), "", 1, "L", false, 0, "")
+	if invoice.IsCreditNote() {
+		credits := fmt.Sprintf("Credits invoice #%d", *invoice.CreditedInvoiceId)
+		if invoice.Reason != "" {
+			credits += ": " + invoice.Reason
+		}
+		pdf.MultiCell(0, 6, tr(credits), "", "L", false)
+	}
+	pdf.Ln(6)
+
+	top := pdf.GetY()
+	for i, block := range []struct {
+		label   string
+		address order.OrderAddress
+	}{{"Bill to", invoice.BillingAddress}, {"Ship to", invoice.ShippingAddress}} {
+		pdf.SetXY(20+float64(i)*85, top)
+		pdf.SetFont("Helvetica", "B", 10)
+		pdf.CellFormat(85, 6, block.label, "", 2, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 10)
+		pdf.MultiCell(85, 5, tr(strings.Join(addressLines(block.address), "\n")), "", "L", false)
+	}
+	pdf.SetXY(20, top+30)
+
+	widths := []float64{95, 15, 30, 30}
+	pdf.SetFont("Helvetica", "B", 10)
+	for i, heading := range []string{"Description", "Qty", "Unit price", "Amount"} {
+		align := "R"
+		if i == 0 {
+			align = "L"
+		}
+		pdf.CellFormat(widths[i], 8, heading, "B", 0, align, false, 0, "")
+	}
+	pdf.Ln(-1)
+	pdf.SetFont("Helvetica", "", 10)
+	for _, line := range invoice.Lines {
+		pdf.CellFormat(widths[0], 7, tr(line.Description), "B", 0, "L", false, 0, "")
+		pdf.CellFormat(widths[1], 7, fmt.Sprint(line.Quantity), "B", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[2], 7, money(invoice.Currency, line.UnitPrice), "B", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[3], 7, money(invoice.Currency, line.Amount), "B", 1, "R", false, 0, "")
+	}
+	pdf.Ln(4)
+
+	total := "Total"
+	if invoice.IsCreditNote() {
+		total = "Total credited"
+	}
+	for _, row := range []struct {
+		label  string
+		amount float64
+		bold   bool
+	}{
+		{"Subtotal", invoice.SubTotalAmount, false},
+		{"Shipping", invoice.ShippingAmount, false},
+		{taxLabel(invoice.Tax), invoice.Tax.Amount, false},
+		{total, invoice.TotalAmount, true},
+	} {
+		style := ""
+		if row.bold {
+			style = "B"
+		}
+		pdf.SetFont("Helvetica", style, 10)
+		pdf.SetX(110)
+		pdf.CellFormat(50, 7, row.label, "", 0, "L", false, 0, "")
+		pdf.CellFormat(30, 7, money(invoice.Currency, row.amount), "", 1, "R", false, 0, "")
+	}
+
+	return pdf.Output(w)
+}
+
+func title(invoice *dto.Invoice) string {
+	if invoice.IsCreditNote() {
+		return "Credit note"
+	}
+	return "Invoice"
+}
+
+func money(currency string, amount float64) string {
+	return fmt.Sprintf("%s %.2f", currency, amount)
+}
+
+func taxLabel(tax dto.TaxBreakdown) string {
+	if tax.State == "" {
+		return "Tax"
+	}
+	return fmt.Sprintf("Tax (%s %.3g%% of %.2f)", tax.State, tax.Rate*100, tax.TaxableAmount)
+}
+
+func addressLines(address order.OrderAddress) []string {
+	lines := []string{}
+	if address.Street != "" {
+		lines = append(lines, address.Street)
+	}
+	city := address.City
+	if address.State != "" {
+		city += ", " + address.State
+	}
+	if address.PostalCode != "" {
+		city += " " + address.PostalCode
+	}
+	lines = append(lines, strings.TrimSpace(city))
+	if address.Country != "" {
+		lines = append(lines, address.Country)
+	}
+	return lines
+}
@@ -3,14 +3,15 @@ package orderitem
 import (
 	dto "commerce/api/internal/dto/order-item"
 	repo "commerce/internal/shared/repositories/order-item"
+	"context"
 	"log/slog"
 )
 
 type OrderItemServiceI interface {
-	GetById(id uint) (*dto.OrderItem, error)
-	GetAllByOrder(orderId uint) ([]*dto.OrderItem, error)
-	Save(orderItem dto.OrderItem) error
-	Delete(id uint, hard bool) error
+	GetById(ctx context.Context, id uint) (*dto.OrderItem, error)
+	GetAllByOrder(ctx context.Context, orderId uint) ([]*dto.OrderItem, error)
+	Save(ctx context.Context, orderItem dto.OrderItem) error
+	Delete(ctx context.Context, id uint, hard bool) error
 }
 
 type OrderItemService struct {
@@ -22,13 +23,13 @@ func NewOrderItemService(repo repo.OrderItemRepositoryI) OrderItemServiceI {
 }
 
 // Delete implements [OrderItemServiceI].
-func (o *OrderItemService) Delete(id uint, hard bool) error {
-	return o.repo.Delete(id, hard)
+func (o *OrderItemService) Delete(ctx context.Context, id uint, hard bool) error {
+	return o.repo.Delete(ctx, id, hard)
 }
 
 // GetAllByOrder implements [OrderItemServiceI].
-func (o *OrderItemService) GetAllByOrder(orderId uint) ([]*dto.OrderItem, error) {
-	models, err := o.repo.GetAllByOrder(orderId)
+func (o *OrderItemService) GetAllByOrder(ctx context.Context, orderId uint) ([]*dto.OrderItem, error) {
+	models, err := o.repo.GetAllByOrder(ctx, orderId)
 	if err != nil {
 		slog.Error("Exception occurred retrieving items by order", "order-id", orderId, "error", err)
 		return nil, err
@@ -41,8 +42,8 @@ func (o *OrderItemService) GetAllByOrder(orderId uint) ([]*dto.OrderItem, error)
 }
 
 // GetById implements [OrderItemServiceI].
-func (o *OrderItemService) GetById(id uint) (*dto.OrderItem, error) {
-	model, err := o.repo.GetById(id)
+func (o *OrderItemService) GetById(ctx context.Context, id uint) (*dto.OrderItem, error) {
+	model, err := o.repo.GetById(ctx, id)
 	if err != nil {
 		slog.Error("Exception occurred retrieving order-item by id", "id", id, "error", err)
 		return nil, err
@@ -51,6 +52,6 @@ func (o *OrderItemService) GetById(id uint) (*dto.OrderItem, error) {
 }
 
 // Save implements [OrderItemServiceI].
-func (o *OrderItemService) Save(orderItem dto.OrderItem) error {
-	return o.repo.Save(dto.ToModel(&orderItem))
+func (o *OrderItemService) Save(ctx context.Context, orderItem dto.OrderItem) error {
+	return o.repo.Save(ctx, dto.ToModel(&orderItem))
 }
@@ -0,0 +1,131 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../../../../internal/shared/repositories/address/address_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=../../../../internal/shared/repositories/address/address_repository.go -destination=mock_address_repo_test.go -package=order
+//
+
+// Package order is a generated GoMock package.
+package order
+
+import (
+	models "commerce/internal/shared/models"
+	query "commerce/internal/shared/repositories/query"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAddressRepositoryI is a mock of AddressRepositoryI interface.
+type MockAddressRepositoryI struct {
+	ctrl     *gomock.Controller
+	recorder *MockAddressRepositoryIMockRecorder
+	isgomock struct{}
+}
+
+// MockAddressRepositoryIMockRecorder is the mock recorder for MockAddressRepositoryI.
+type MockAddressRepositoryIMockRecorder struct {
+	mock *MockAddressRepositoryI
+}
+
+// NewMockAddressRepositoryI creates a new mock instance.
+func NewMockAddressRepositoryI(ctrl *gomock.Controller) *MockAddressRepositoryI {
+	mock := &MockAddressRepositoryI{ctrl: ctrl}
+	mock.recorder = &MockAddressRepositoryIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAddressRepositoryI) EXPECT() *MockAddressRepositoryIMockRecorder {
+	return m.recorder
+}
+
+// AnonymizeByUserId mocks base method.
+func (m *MockAddressRepositoryI) AnonymizeByUserId(ctx context.Context, userId uint, erasedDate time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnonymizeByUserId", ctx, userId, erasedDate)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AnonymizeByUserId indicates an expected call of AnonymizeByUserId.
+func (mr *MockAddressRepositoryIMockRecorder) AnonymizeByUserId(ctx, userId, erasedDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnonymizeByUserId", reflect.TypeOf((*MockAddressRepositoryI)(nil).AnonymizeByUserId), ctx, userId, erasedDate)
+}
+
+// Delete mocks base method.
+func (m *MockAddressRepositoryI) Delete(ctx context.Context, id uint, hard bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, hard)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAddressRepositoryIMockRecorder) Delete(ctx, id, hard any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAddressRepositoryI)(nil).Delete), ctx, id, hard)
+}
+
+// GetAll mocks base method.
+func (m *MockAddressRepositoryI) GetAll(ctx context.Context, opts query.Options) (*query.Page[models.Address], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, opts)
+	ret0, _ := ret[0].(*query.Page[models.Address])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockAddressRepositoryIMockRecorder) GetAll(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockAddressRepositoryI)(nil).GetAll), ctx, opts)
+}
+
+// GetById mocks base method.
+func (m *MockAddressRepositoryI) GetById(ctx context.Context, id uint) (*models.Address, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(*models.Address)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockAddressRepositoryIMockRecorder) GetById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockAddressRepositoryI)(nil).GetById), ctx, id)
+}
+
+// GetByUserId mocks base method.
+func (m *MockAddressRepositoryI) GetByUserId(ctx context.Context, userId uint, opts query.Options) (*query.Page[models.Address], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserId", ctx, userId, opts)
+	ret0, _ := ret[0].(*query.Page[models.Address])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserId indicates an expected call of GetByUserId.
+func (mr *MockAddressRepositoryIMockRecorder) GetByUserId(ctx, userId, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserId", reflect.TypeOf((*MockAddressRepositoryI)(nil).GetByUserId), ctx, userId, opts)
+}
+
+// Save mocks base method.
+func (m *MockAddressRepositoryI) Save(ctx context.Context, address *models.Address) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, address)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockAddressRepositoryIMockRecorder) Save(ctx, address any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockAddressRepositoryI)(nil).Save), ctx, address)
+}
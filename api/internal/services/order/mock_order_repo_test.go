@@ -11,7 +11,10 @@ package order
 
 import (
 	models "commerce/internal/shared/models"
+	query "commerce/internal/shared/repositories/query"
+	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -40,89 +43,162 @@ func (m *MockOrderRepositoryI) EXPECT() *MockOrderRepositoryIMockRecorder {
 	return m.recorder
 }
 
+// Cancel mocks base method.
+func (m *MockOrderRepositoryI) Cancel(ctx context.Context, id uint, reason string, cancelledDate time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", ctx, id, reason, cancelledDate)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockOrderRepositoryIMockRecorder) Cancel(ctx, id, reason, cancelledDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockOrderRepositoryI)(nil).Cancel), ctx, id, reason, cancelledDate)
+}
+
 // Delete mocks base method.
-func (m *MockOrderRepositoryI) Delete(id uint, hard bool) error {
+func (m *MockOrderRepositoryI) Delete(ctx context.Context, id uint, hard bool) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", id, hard)
+	ret := m.ctrl.Call(m, "Delete", ctx, id, hard)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockOrderRepositoryIMockRecorder) Delete(id, hard any) *gomock.Call {
+func (mr *MockOrderRepositoryIMockRecorder) Delete(ctx, id, hard any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockOrderRepositoryI)(nil).Delete), id, hard)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockOrderRepositoryI)(nil).Delete), ctx, id, hard)
 }
 
 // GetAll mocks base method.
-func (m *MockOrderRepositoryI) GetAll() ([]*models.Order, error) {
+func (m *MockOrderRepositoryI) GetAll(ctx context.Context, opts query.Options) (*query.Page[models.Order], error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll")
-	ret0, _ := ret[0].([]*models.Order)
+	ret := m.ctrl.Call(m, "GetAll", ctx, opts)
+	ret0, _ := ret[0].(*query.Page[models.Order])
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockOrderRepositoryIMockRecorder) GetAll() *gomock.Call {
+func (mr *MockOrderRepositoryIMockRecorder) GetAll(ctx, opts any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockOrderRepositoryI)(nil).GetAll))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockOrderRepositoryI)(nil).GetAll), ctx, opts)
 }
 
 // GetAllByUserId mocks base method.
-func (m *MockOrderRepositoryI) GetAllByUserId(userId uint) ([]*models.Order, error) {
+func (m *MockOrderRepositoryI) GetAllByUserId(ctx context.Context, userId uint, opts query.Options) (*query.Page[models.Order], error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllByUserId", userId)
-	ret0, _ := ret[0].([]*models.Order)
+	ret := m.ctrl.Call(m, "GetAllByUserId", ctx, userId, opts)
+	ret0, _ := ret[0].(*query.Page[models.Order])
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAllByUserId indicates an expected call of GetAllByUserId.
-func (mr *MockOrderRepositoryIMockRecorder) GetAllByUserId(userId any) *gomock.Call {
+func (mr *MockOrderRepositoryIMockRecorder) GetAllByUserId(ctx, userId, opts any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByUserId", reflect.TypeOf((*MockOrderRepositoryI)(nil).GetAllByUserId), userId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByUserId", reflect.TypeOf((*MockOrderRepositoryI)(nil).GetAllByUserId), ctx, userId, opts)
 }
 
 // GetById mocks base method.
-func (m *MockOrderRepositoryI) GetById(id uint) (*models.Order, error) {
+func (m *MockOrderRepositoryI) GetById(ctx context.Context, id uint) (*models.Order, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetById", id)
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
 	ret0, _ := ret[0].(*models.Order)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetById indicates an expected call of GetById.
-func (mr *MockOrderRepositoryIMockRecorder) GetById(id any) *gomock.Call {
+func (mr *MockOrderRepositoryIMockRecorder) GetById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockOrderRepositoryI)(nil).GetById), ctx, id)
+}
+
+// GetByIdForUpdate mocks base method.
+func (m *MockOrderRepositoryI) GetByIdForUpdate(ctx context.Context, id uint) (*models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIdForUpdate", ctx, id)
+	ret0, _ := ret[0].(*models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIdForUpdate indicates an expected call of GetByIdForUpdate.
+func (mr *MockOrderRepositoryIMockRecorder) GetByIdForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIdForUpdate", reflect.TypeOf((*MockOrderRepositoryI)(nil).GetByIdForUpdate), ctx, id)
+}
+
+// GetByOrderNumber mocks base method.
+func (m *MockOrderRepositoryI) GetByOrderNumber(ctx context.Context, orderNumber string) (*models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByOrderNumber", ctx, orderNumber)
+	ret0, _ := ret[0].(*models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByOrderNumber indicates an expected call of GetByOrderNumber.
+func (mr *MockOrderRepositoryIMockRecorder) GetByOrderNumber(ctx, orderNumber any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOrderNumber", reflect.TypeOf((*MockOrderRepositoryI)(nil).GetByOrderNumber), ctx, orderNumber)
+}
+
+// NextOrderNumberSequence mocks base method.
+func (m *MockOrderRepositoryI) NextOrderNumberSequence(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextOrderNumberSequence", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NextOrderNumberSequence indicates an expected call of NextOrderNumberSequence.
+func (mr *MockOrderRepositoryIMockRecorder) NextOrderNumberSequence(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextOrderNumberSequence", reflect.TypeOf((*MockOrderRepositoryI)(nil).NextOrderNumberSequence), ctx)
+}
+
+// Restore mocks base method.
+func (m *MockOrderRepositoryI) Restore(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockOrderRepositoryIMockRecorder) Restore(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockOrderRepositoryI)(nil).GetById), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockOrderRepositoryI)(nil).Restore), ctx, id)
 }
 
 // Save mocks base method.
-func (m *MockOrderRepositoryI) Save(order *models.Order) error {
+func (m *MockOrderRepositoryI) Save(ctx context.Context, order *models.Order) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Save", order)
+	ret := m.ctrl.Call(m, "Save", ctx, order)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Save indicates an expected call of Save.
-func (mr *MockOrderRepositoryIMockRecorder) Save(order any) *gomock.Call {
+func (mr *MockOrderRepositoryIMockRecorder) Save(ctx, order any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockOrderRepositoryI)(nil).Save), order)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockOrderRepositoryI)(nil).Save), ctx, order)
 }
 
 // UpdateStatus mocks base method.
-func (m *MockOrderRepositoryI) UpdateStatus(id uint, status string) error {
+func (m *MockOrderRepositoryI) UpdateStatus(ctx context.Context, id uint, status string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateStatus", id, status)
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, id, status)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateStatus indicates an expected call of UpdateStatus.
-func (mr *MockOrderRepositoryIMockRecorder) UpdateStatus(id, status any) *gomock.Call {
+func (mr *MockOrderRepositoryIMockRecorder) UpdateStatus(ctx, id, status any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockOrderRepositoryI)(nil).UpdateStatus), id, status)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockOrderRepositoryI)(nil).UpdateStatus), ctx, id, status)
 }
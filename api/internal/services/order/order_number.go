@@ -0,0 +1,87 @@
+package order
+
+import (
+	repo "commerce/internal/shared/repositories/order"
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// OrderNumberGeneratorI hands out human-readable order numbers such as
+// ORD-20261019-0004277: a prefix, the order date, a zero-padded number from a
+// Postgres sequence, and a trailing Luhn check digit so that a mistyped number
+// is caught before it is looked up.
+type OrderNumberGeneratorI interface {
+	Generate(ctx context.Context) (string, error)
+	IsValid(orderNumber string) bool
+}
+
+type OrderNumberGenerator struct {
+	repo   repo.OrderRepositoryI
+	prefix string
+	clock  func() time.Time
+}
+
+// sequenceWidth is the minimum number of sequence digits; numbers past
+// 999999 simply grow wider.
+const sequenceWidth = 6
+
+var (
+	orderNumberPattern = regexp.MustCompile(`^[A-Z0-9]+-(\d{8})-(\d{7,})$`)
+	prefixPattern      = regexp.MustCompile(`^[A-Z0-9]+$`)
+)
+
+// NewOrderNumberGenerator panics when the prefix, upper-cased, isn't made of
+// letters and digits alone: IsValid would reject every number it generated.
+func NewOrderNumberGenerator(repo repo.OrderRepositoryI, prefix string, clock func() time.Time) OrderNumberGeneratorI {
+	prefix = strings.ToUpper(prefix)
+	if !prefixPattern.MatchString(prefix) {
+		panic(fmt.Sprintf("invalid order number prefix: %q", prefix))
+	}
+	return &OrderNumberGenerator{
+		repo:   repo,
+		prefix: prefix,
+		clock:  clock,
+	}
+}
+
+// Generate implements [OrderNumberGeneratorI].
+func (g *OrderNumberGenerator) Generate(ctx context.Context) (string, error) {
+	seq, err := g.repo.NextOrderNumberSequence(ctx)
+	if err != nil {
+		return "", fmt.Errorf("order number sequence: %w", err)
+	}
+	date := g.clock().UTC().Format("20060102")
+	digits := fmt.Sprintf("%0*d", sequenceWidth, seq)
+	return fmt.Sprintf("%s-%s-%s%d", g.prefix, date, digits, luhnCheckDigit(date+digits)), nil
+}
+
+// IsValid implements [OrderNumberGeneratorI]. Any prefix is accepted so that
+// numbers issued before a prefix change still resolve.
+func (g *OrderNumberGenerator) IsValid(orderNumber string) bool {
+	m := orderNumberPattern.FindStringSubmatch(strings.ToUpper(orderNumber))
+	if m == nil {
+		return false
+	}
+	body, check := m[2][:len(m[2])-1], m[2][len(m[2])-1]
+	return luhnCheckDigit(m[1]+body) == int(check-'0')
+}
+
+func luhnCheckDigit(digits string) int {
+	sum := 0
+	double := true
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return (10 - sum%10) % 10
+}
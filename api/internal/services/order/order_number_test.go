@@ -0,0 +1,41 @@
+package order
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestOrderNumberGenerate(t *testing.T) {
+	ctl := gomock.NewController(t)
+	mockRepo := NewMockOrderRepositoryI(ctl)
+	mockRepo.EXPECT().NextOrderNumberSequence(gomock.Any()).Return(int64(1234567), nil)
+	g := NewOrderNumberGenerator(mockRepo, "CA", func() time.Time {
+		return time.Date(2026, 1, 2, 23, 0, 0, 0, time.UTC)
+	})
+
+	number, err := g.Generate(context.Background())
+	assert.NoError(t, err)
+	assert.Regexp(t, `^CA-20260102-1234567\d$`, number, "sequences wider than the padding keep every digit")
+	assert.True(t, g.IsValid(number))
+}
+
+func TestOrderNumberIsValid(t *testing.T) {
+	g := NewOrderNumberGenerator(nil, "ORD", time.Now)
+	assert.True(t, g.IsValid("ORD-20261019-0042731"))
+	assert.True(t, g.IsValid("OLD-20261019-0042731"), "numbers issued under another prefix still validate")
+	assert.False(t, g.IsValid("ORD-20261019-0042734"), "wrong check digit")
+	assert.False(t, g.IsValid("ORD-20261019-0024731"), "transposed digits")
+	assert.False(t, g.IsValid("ORD-2026109-0042731"))
+	assert.False(t, g.IsValid("42"))
+}
+
+func TestOrderNumberPrefix(t *testing.T) {
+	assert.NotPanics(t, func() { NewOrderNumberGenerator(nil, "ord2", time.Now) }, "prefixes are upper-cased")
+	for _, prefix := range []string{"", "ORD-X", "ORD X", "ÖRD"} {
+		assert.Panics(t, func() { NewOrderNumberGenerator(nil, prefix, time.Now) }, "prefix %q", prefix)
+	}
+}
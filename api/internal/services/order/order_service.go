@@ -2,42 +2,106 @@ package order
 
 import (
 	dto "commerce/api/internal/dto/order"
+	"commerce/api/internal/dto/page"
+	shipping_dto "commerce/api/internal/dto/shipping"
+	invoice_service "commerce/api/internal/services/invoice"
+	payment_service "commerce/api/internal/services/payment"
+	shipping_service "commerce/api/internal/services/shipping"
 	tax_service "commerce/api/internal/services/tax"
 	models "commerce/internal/shared/models"
+	address_repo "commerce/internal/shared/repositories/address"
 	repo "commerce/internal/shared/repositories/order"
+	product_repo "commerce/internal/shared/repositories/product"
+	"commerce/internal/shared/repositories/query"
+	"commerce/internal/shared/repositories/uow"
+	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"math"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
 )
 
+// ErrInvalidOrderNumber is returned for order numbers that fail their check digit.
+var ErrInvalidOrderNumber = errors.New("invalid order number")
+
+// ErrInvalidAddress is returned when an order references an address that
+// doesn't exist or belongs to someone else.
+var ErrInvalidAddress = errors.New("invalid address")
+
+// ErrInvalidVariant is returned when an order item's variant doesn't exist,
+// isn't for sale or belongs to another product, and when an item leaves out
+// the variant of a product that has them.
+var ErrInvalidVariant = errors.New("invalid variant")
+
+// ErrInvalidProduct is returned when an order item's product doesn't exist.
+var ErrInvalidProduct = errors.New("invalid product")
+
+// ErrInvalidQuantity is returned when an order item's quantity isn't
+// positive.
+var ErrInvalidQuantity = errors.New("invalid quantity")
+
 type OrderServiceI interface {
-	GetById(id uint) (*dto.Order, error)
-	GetByUserId(userId uint) ([]*dto.Order, error)
-	GetStatuses() []dto.OrderStatus
-	Save(order dto.Order) error
-	Delete(id uint, hard bool) error
-	UpdateStatus(id uint, status string) error
+	GetById(ctx context.Context, id uint) (*dto.Order, error)
+	GetByOrderNumber(ctx context.Context, orderNumber string) (*dto.Order, error)
+	GetByUserId(ctx context.Context, userId uint, opts query.Options) (*page.Page[dto.Order], error)
+	GetOwnerId(ctx context.Context, id uint) (uint, error)
+	GetStatuses(ctx context.Context) []dto.OrderStatus
+	Save(ctx context.Context, order dto.Order) (*dto.Order, error)
+	Delete(ctx context.Context, id uint, hard bool) error
+	Restore(ctx context.Context, id uint) error
+	UpdateStatus(ctx context.Context, id uint, status string) error
+	Cancel(ctx context.Context, id uint, reason string) (*dto.Order, error)
 }
 
 type OrderService struct {
-	repo       repo.OrderRepositoryI
-	taxService tax_service.TaxServiceI
+	repo            repo.OrderRepositoryI
+	addressRepo     address_repo.AddressRepositoryI
+	productRepo     product_repo.ProductRepositoryI
+	uow             uow.UnitOfWorkI
+	orderNumbers    OrderNumberGeneratorI
+	taxService      tax_service.TaxServiceI
+	shippingService shipping_service.ShippingServiceI
 }
 
-func NewOrderService(repo repo.OrderRepositoryI, taxService tax_service.TaxServiceI) OrderServiceI {
+func NewOrderService(repo repo.OrderRepositoryI,
+	addressRepo address_repo.AddressRepositoryI,
+	productRepo product_repo.ProductRepositoryI,
+	uow uow.UnitOfWorkI,
+	orderNumbers OrderNumberGeneratorI,
+	taxService tax_service.TaxServiceI,
+	shippingService shipping_service.ShippingServiceI) OrderServiceI {
 	return &OrderService{
-		repo:       repo,
-		taxService: taxService,
+		repo:            repo,
+		addressRepo:     addressRepo,
+		productRepo:     productRepo,
+		uow:             uow,
+		orderNumbers:    orderNumbers,
+		taxService:      taxService,
+		shippingService: shippingService,
 	}
 }
 
 // Delete implements [OrderServiceI].
-func (o *OrderService) Delete(id uint, hard bool) error {
-	return o.repo.Delete(id, hard)
+func (o *OrderService) Delete(ctx context.Context, id uint, hard bool) error {
+	return o.repo.Delete(ctx, id, hard)
+}
+
+// Restore implements [OrderServiceI].
+func (o *OrderService) Restore(ctx context.Context, id uint) error {
+	if err := o.repo.Restore(ctx, id); err != nil {
+		slog.Error("Exception occurred restoring order.", "id", id, "error", err)
+		return err
+	}
+	return nil
 }
 
 // GetById implements [OrderServiceI].
-func (o *OrderService) GetById(id uint) (*dto.Order, error) {
-	model, err := o.repo.GetById(id)
+func (o *OrderService) GetById(ctx context.Context, id uint) (*dto.Order, error) {
+	model, err := o.repo.GetById(ctx, id)
 	if err != nil {
 		slog.Error("Exception occurred getting order by id.", "id", id, "error", err)
 		return nil, err
@@ -45,8 +109,31 @@ func (o *OrderService) GetById(id uint) (*dto.Order, error) {
 	return dto.FromModel(model), nil
 }
 
+// GetByOrderNumber implements [OrderServiceI].
+func (o *OrderService) GetByOrderNumber(ctx context.Context, orderNumber string) (*dto.Order, error) {
+	if !o.orderNumbers.IsValid(orderNumber) {
+		return nil, ErrInvalidOrderNumber
+	}
+	model, err := o.repo.GetByOrderNumber(ctx, strings.ToUpper(orderNumber))
+	if err != nil {
+		slog.Error("Exception occurred getting order by number.", "order-number", orderNumber, "error", err)
+		return nil, err
+	}
+	return dto.FromModel(model), nil
+}
+
+// GetOwnerId implements [OrderServiceI].
+func (o *OrderService) GetOwnerId(ctx context.Context, id uint) (uint, error) {
+	model, err := o.repo.GetById(ctx, id)
+	if err != nil {
+		slog.Error("Exception occurred getting order by id.", "id", id, "error", err)
+		return 0, err
+	}
+	return model.UserId, nil
+}
+
 // GetStatuses implements [OrderServiceI].
-func (o *OrderService) GetStatuses() []dto.OrderStatus {
+func (o *OrderService) GetStatuses(ctx context.Context) []dto.OrderStatus {
 	statuses := []dto.OrderStatus{}
 
 	for key := range validStatuses {
@@ -56,46 +143,193 @@ func (o *OrderService) GetStatuses() []dto.OrderStatus {
 }
 
 // GetByUserId implements [OrderServiceI].
-func (o *OrderService) GetByUserId(userId uint) ([]*dto.Order, error) {
-	models, err := o.repo.GetAllByUserId(userId)
+func (o *OrderService) GetByUserId(ctx context.Context, userId uint, opts query.Options) (*page.Page[dto.Order], error) {
+	models, err := o.repo.GetAllByUserId(ctx, userId, opts)
 	if err != nil {
 		slog.Error("Exception occurred getting orders by user", "userId", userId, "error", err)
 		return nil, err
 	}
-	orders := make([]*dto.Order, 0, len(models))
-	for _, model := range models {
-		orders = append(orders, dto.FromModel(model))
-	}
-	return orders, nil
+	return page.FromPage(models, dto.FromModel), nil
 }
 
-// Save implements [OrderServiceI].
-func (o *OrderService) Save(order dto.Order) error {
+// Save implements [OrderServiceI]. It always creates a pending order; an id
+// or status sent with it is ignored. The order takes its items out of stock in the same
+// transaction that creates it, and its items are charged what their products
+// sell for at the time, whatever unit_price was sent. Addresses given by id
+// are copied onto the order, so shipping and tax are worked out from the
+// snapshot it keeps. It returns the order as created, with its id, number
+// and the amounts worked out here.
+func (o *OrderService) Save(ctx context.Context, order dto.Order) (*dto.Order, error) {
+	order.Id = 0
+	order.Status = string(models.OrderStatusPending)
+	if err := o.snapshotAddress(ctx, order.UserId, &order.BillingAddress); err != nil {
+		return nil, err
+	}
+	if err := o.snapshotAddress(ctx, order.UserId, &order.ShippingAddress); err != nil {
+		return nil, err
+	}
+	if err := o.priceItems(ctx, &order); err != nil {
+		return nil, err
+	}
 	order.SubTotalAmount = calculateSubTotalAmount(&order)
+	shipping, err := o.calculateShipping(ctx, &order)
+	if err != nil {
+		return nil, err
+	}
+	order.ShippingAmount = shipping
 	tax, err := o.calculateTax(&order)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	order.TaxAmount = tax
 	order.TotalAmount = calculateTotalAmount(&order)
 	model := dto.ToModel(&order)
-	return o.repo.Save(model)
+	number, err := o.orderNumbers.Generate(ctx)
+	if err != nil {
+		slog.Error("Exception occurred generating order number.", "error", err)
+		return nil, err
+	}
+	model.OrderNumber = number
+	err = o.uow.Do(ctx, func(r *uow.Repositories) error {
+		if err := r.Orders.Save(ctx, model); err != nil {
+			return err
+		}
+		for _, item := range model.OrderItems {
+			if err := checkVariant(ctx, r, item); err != nil {
+				return err
+			}
+			if err := r.AdjustStock(ctx, item, -item.Quantity); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dto.FromModel(model), nil
+}
+
+// priceItems sets each item's unit price to the active price of its product,
+// or of its variant, so a sale applies the moment it starts.
+func (o *OrderService) priceItems(ctx context.Context, order *dto.Order) error {
+	now := time.Now()
+	for i := range order.OrderItems {
+		item := &order.OrderItems[i]
+		if item.Quantity <= 0 {
+			return fmt.Errorf("%w: product %d is ordered %d times", ErrInvalidQuantity, item.ProductId, item.Quantity)
+		}
+		product, err := o.productRepo.GetById(ctx, item.ProductId)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("%w: there is no product %d", ErrInvalidProduct, item.ProductId)
+		}
+		if err != nil {
+			slog.Error("Exception occurred getting product to price order item.", "product-id", item.ProductId, "error", err)
+			return err
+		}
+		price, _ := product.PriceAt(now)
+		if item.VariantId != nil {
+			variant := product.Variant(*item.VariantId)
+			if variant == nil {
+				return fmt.Errorf("%w: %d is not for sale as product %d", ErrInvalidVariant, *item.VariantId, item.ProductId)
+			}
+			price, _ = product.VariantPriceAt(variant, now)
+		}
+		item.UnitPrice = math.Round(float64(price)*100) / 100
+	}
+	return nil
+}
+
+// checkVariant makes sure an item names a variant it can be sold as: one of
+// its product's active variants, or none when the product has no variants.
+func checkVariant(ctx context.Context, r *uow.Repositories, item models.OrderItem) error {
+	if item.VariantId == nil {
+		variants, err := r.Variants.GetAllByProductId(ctx, item.ProductId)
+		if err != nil {
+			return err
+		}
+		if len(variants) > 0 {
+			return fmt.Errorf("%w: product %d is sold by variant and needs a variant_id", ErrInvalidVariant, item.ProductId)
+		}
+		return nil
+	}
+	variant, err := r.Variants.GetById(ctx, *item.VariantId)
+	if err != nil || variant.ProductId != item.ProductId || !variant.IsActive {
+		return fmt.Errorf("%w: %d is not for sale as product %d", ErrInvalidVariant, *item.VariantId, item.ProductId)
+	}
+	return nil
+}
+
+// Cancel implements [OrderServiceI]. Only orders that haven't shipped can be
+// cancelled. Their payments are voided or refunded through the payment
+// service, an invoice already issued is credited in full and the items go
+// back in stock, all in one transaction. The order is marked cancelled
+// first: that update only matches a pending order and holds its row until
+// commit, so a concurrent cancel fails instead of releasing payments and
+// restocking twice.
+func (o *OrderService) Cancel(ctx context.Context, id uint, reason string) (*dto.Order, error) {
+	var cancelled *models.Order
+	err := o.uow.Do(ctx, func(r *uow.Repositories) error {
+		order, err := r.Orders.GetById(ctx, id)
+		if err != nil {
+			return err
+		}
+		if order.Status != models.OrderStatusPending || len(order.Shipments) > 0 {
+			return fmt.Errorf("order %d is %s and can no longer be cancelled", id, order.Status)
+		}
+		now := time.Now()
+		if err := r.Orders.Cancel(ctx, id, reason, now); err != nil {
+			if errors.Is(err, repo.ErrNotPending) {
+				return fmt.Errorf("order %d can no longer be cancelled: %w", id, err)
+			}
+			return err
+		}
+
+		payments := payment_service.NewPaymentService(r.Payments)
+		if _, err := payments.ReleaseOrder(ctx, id); err != nil {
+			return err
+		}
+		invoices := invoice_service.NewInvoiceService(r.Invoices, r.Orders, r.Payments, o.taxService)
+		if _, err := invoices.CreditRemaining(ctx, id, "Order cancelled: "+reason); err != nil && !errors.Is(err, invoice_service.ErrNotInvoiced) {
+			return err
+		}
+		for _, item := range order.OrderItems {
+			if err := r.AdjustStock(ctx, item, item.Quantity); err != nil {
+				return err
+			}
+		}
+
+		order.Status = models.OrderStatusCancelled
+		order.CancelReason = reason
+		order.CancelledDate = &now
+		cancelled = order
+		return nil
+	})
+	if err != nil {
+		slog.Error("Exception occurred cancelling order.", "id", id, "error", err)
+		return nil, err
+	}
+	return dto.FromModel(cancelled), nil
 }
 
 // UpdateStatus implements [OrderServiceI].
-func (o *OrderService) UpdateStatus(id uint, status string) error {
+func (o *OrderService) UpdateStatus(ctx context.Context, id uint, status string) error {
 	if !isOrderStatusValid(status) {
 		slog.Error("Order status doesn't exist.", "status", status)
 		return fmt.Errorf("invalid order status: %s", status)
 	}
-	return o.repo.UpdateStatus(id, status)
+	if models.OrderStatus(status) == models.OrderStatusCancelled {
+		return fmt.Errorf("orders must be cancelled through the cancel endpoint so payments and stock are released")
+	}
+	return o.repo.UpdateStatus(ctx, id, status)
 }
 
 var validStatuses = map[models.OrderStatus]struct{}{
-	models.OrderStatusPending:   {},
-	models.OrderStatusDelivered: {},
-	models.OrderStatusShipped:   {},
-	models.OrderStatusCancelled: {},
+	models.OrderStatusPending:          {},
+	models.OrderStatusPartiallyShipped: {},
+	models.OrderStatusDelivered:        {},
+	models.OrderStatusShipped:          {},
+	models.OrderStatusCancelled:        {},
 }
 
 func isOrderStatusValid(status string) bool {
@@ -103,17 +337,64 @@ func isOrderStatusValid(status string) bool {
 	return ok
 }
 
+// snapshotAddress fills in an address given only by id from the user's
+// address book. Inline addresses are kept as they are.
+func (o *OrderService) snapshotAddress(ctx context.Context, userId uint, address *dto.OrderAddress) error {
+	if address.AddressId == nil {
+		return nil
+	}
+	model, err := o.addressRepo.GetById(ctx, *address.AddressId)
+	if err != nil {
+		slog.Error("Exception occurred getting order address.", "address-id", *address.AddressId, "error", err)
+		return fmt.Errorf("%w: %d", ErrInvalidAddress, *address.AddressId)
+	}
+	if model.UserId != userId {
+		return fmt.Errorf("%w: %d does not belong to user %d", ErrInvalidAddress, model.Id, userId)
+	}
+	address.Street = model.Street
+	address.City = model.City
+	address.State = model.State
+	address.PostalCode = model.PostalCode
+	address.Country = model.Country
+	return nil
+}
+
+func (o *OrderService) calculateShipping(ctx context.Context, order *dto.Order) (float64, error) {
+	if order.ShippingMethod == "" {
+		return 0, nil
+	}
+	items := make([]shipping_dto.QuoteItem, 0, len(order.OrderItems))
+	for _, item := range order.OrderItems {
+		items = append(items, shipping_dto.QuoteItem{
+			ProductId: item.ProductId,
+			VariantId: item.VariantId,
+			Quantity:  item.Quantity,
+			UnitPrice: item.UnitPrice,
+		})
+	}
+	quote, err := o.shippingService.Calculate(ctx, items, order.ShippingAddress.State, order.ShippingMethod)
+	if err != nil {
+		slog.Error("Exception occured when calculating order shipping.", "order-id", order.Id, "method", order.ShippingMethod, "error", err)
+		return 0, err
+	}
+	return quote.Amount, nil
+}
+
 func (o *OrderService) calculateTax(order *dto.Order) (float64, error) {
-	tax, err := o.taxService.Calculate(order.SubTotalAmount, order.BillingState)
+	taxable := order.SubTotalAmount
+	if o.taxService.IsShippingTaxable(order.BillingAddress.State) {
+		taxable += order.ShippingAmount
+	}
+	tax, err := o.taxService.Calculate(taxable, order.BillingAddress.State)
 	if err != nil {
-		slog.Error("Exception occured when calculating order tax.", "order-id", order.Id, "state", order.BillingState)
+		slog.Error("Exception occured when calculating order tax.", "order-id", order.Id, "state", order.BillingAddress.State)
 		return 0, err
 	}
 	return *tax, nil
 }
 
 func calculateTotalAmount(order *dto.Order) float64 {
-	return order.SubTotalAmount + order.TaxAmount
+	return order.SubTotalAmount + order.ShippingAmount + order.TaxAmount
 }
 
 func calculateSubTotalAmount(o *dto.Order) float64 {
@@ -1,33 +1,84 @@
 package order
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
 
+	shipping_service "commerce/api/internal/services/shipping"
 	tax_service "commerce/api/internal/services/tax"
 	"commerce/internal/shared/models"
+	repo "commerce/internal/shared/repositories/order"
+	"commerce/internal/shared/repositories/query"
+	"commerce/internal/shared/repositories/uow"
 
 	dto "commerce/api/internal/dto/order"
 	orderitem "commerce/api/internal/dto/order-item"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
 )
 
+type mocks struct {
+	repo        *MockOrderRepositoryI
+	addressRepo *MockAddressRepositoryI
+	invoiceRepo *MockInvoiceRepositoryI
+	productRepo *MockProductRepositoryI
+	paymentRepo *MockPaymentRepositoryI
+	variantRepo *MockProductVariantRepositoryI
+}
+
 func setup(t *testing.T) (*MockOrderRepositoryI, OrderServiceI) {
+	t.Helper()
+	m, svc := setupMocks(t)
+	return m.repo, svc
+}
+
+func setupMocks(t *testing.T) (*mocks, OrderServiceI) {
 	t.Helper()
 	ctl := gomock.NewController(t)
 	t.Cleanup(ctl.Finish)
-	mockRepo := NewMockOrderRepositoryI(ctl)
+	m := &mocks{
+		repo:        NewMockOrderRepositoryI(ctl),
+		addressRepo: NewMockAddressRepositoryI(ctl),
+		invoiceRepo: NewMockInvoiceRepositoryI(ctl),
+		productRepo: NewMockProductRepositoryI(ctl),
+		paymentRepo: NewMockPaymentRepositoryI(ctl),
+		variantRepo: NewMockProductVariantRepositoryI(ctl),
+	}
+	mockUow := NewMockUnitOfWorkI(ctl)
+	mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, fn func(r *uow.Repositories) error) error {
+		return fn(&uow.Repositories{
+			Invoices: m.invoiceRepo,
+			Orders:   m.repo,
+			Payments: m.paymentRepo,
+			Products: m.productRepo,
+			Variants: m.variantRepo,
+		})
+	}).AnyTimes()
 	taxService := tax_service.NewTaxService()
-	return mockRepo, NewOrderService(mockRepo, taxService)
+	shippingService := shipping_service.NewShippingService(m.productRepo)
+	orderNumbers := NewOrderNumberGenerator(m.repo, "ord", func() time.Time {
+		return time.Date(2026, 10, 19, 9, 30, 0, 0, time.UTC)
+	})
+	return m, NewOrderService(m.repo, m.addressRepo, m.productRepo, mockUow, orderNumbers, taxService, shippingService)
+}
+
+// expectProduct has the product repository return product id, weighing a
+// pound and selling for price, times times.
+func expectProduct(m *mocks, id uint, price float32, times int) {
+	m.productRepo.EXPECT().GetById(gomock.Any(), id).
+		Return(&models.Product{Base: models.Base{Id: id}, Price: price, Weight: 1}, nil).
+		Times(times)
 }
 
 func TestGetbyId(t *testing.T) {
 	id := uint(1)
 	mockRepo, svc := setup(t)
-	mockRepo.EXPECT().GetById(id).Return(&models.Order{
+	mockRepo.EXPECT().GetById(gomock.Any(), id).Return(&models.Order{
 		Base: models.Base{
 			Id:          1,
 			CreatedDate: time.Now(),
@@ -36,30 +87,57 @@ func TestGetbyId(t *testing.T) {
 		UserId:         1,
 		SubTotalAmount: 125.25,
 		TaxAmount:      25.30,
-		ShippingAddress: models.Address{
+		ShippingAddress: models.AddressSnapshot{
 			Street:  "123 foo street",
 			City:    "Foo city",
 			State:   "MD",
 			Country: "USA",
 		},
+		BillingAddress: models.AddressSnapshot{
+			State: "VA",
+		},
 	}, nil)
-	order, err := svc.GetById(id)
+	order, err := svc.GetById(context.Background(), id)
 	assert.NoError(t, err)
 	assert.NotNil(t, order)
+	assert.Equal(t, "123 foo street", order.ShippingAddress.Street)
+	assert.Equal(t, "VA", order.BillingAddress.State)
+}
+
+func TestGetOwnerId(t *testing.T) {
+	mockRepo, svc := setup(t)
+	mockRepo.EXPECT().GetById(gomock.Any(), uint(1)).Return(&models.Order{UserId: 7}, nil)
+	mockRepo.EXPECT().GetById(gomock.Any(), uint(2)).Return(nil, fmt.Errorf("record not found"))
+
+	ownerId, err := svc.GetOwnerId(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(7), ownerId)
+
+	_, err = svc.GetOwnerId(context.Background(), 2)
+	assert.Error(t, err)
 }
 
 func TestDelete(t *testing.T) {
 	id := uint(1)
 	mockRepo, svc := setup(t)
-	mockRepo.EXPECT().Delete(id, false).Return(nil)
-	err := svc.Delete(id, false)
+	mockRepo.EXPECT().Delete(gomock.Any(), id, false).Return(nil)
+	err := svc.Delete(context.Background(), id, false)
 	assert.NoError(t, err)
 }
 
+func TestRestore(t *testing.T) {
+	mockRepo, svc := setup(t)
+	mockRepo.EXPECT().Restore(gomock.Any(), uint(1)).Return(nil)
+	mockRepo.EXPECT().Restore(gomock.Any(), uint(2)).Return(gorm.ErrRecordNotFound)
+
+	assert.NoError(t, svc.Restore(context.Background(), 1))
+	assert.ErrorIs(t, svc.Restore(context.Background(), 2), gorm.ErrRecordNotFound)
+}
+
 func TestGetAllByUser(t *testing.T) {
 	userId := uint(1)
 	mockRepo, svc := setup(t)
-	mockRepo.EXPECT().GetAllByUserId(userId).Return([]*models.Order{
+	mockRepo.EXPECT().GetAllByUserId(gomock.Any(), userId, query.Options{Limit: 2}).Return(&query.Page[models.Order]{Total: 3, NextCursor: "next", Items: []*models.Order{
 		{
 			Base: models.Base{
 				Id:          1,
@@ -77,19 +155,30 @@ func TestGetAllByUser(t *testing.T) {
 			},
 			UserId:         1,
 			SubTotalAmount: 125.55},
-	}, nil)
-	orders, err := svc.GetByUserId(userId)
+	}}, nil)
+	orders, err := svc.GetByUserId(context.Background(), userId, query.Options{Limit: 2})
 	assert.NoError(t, err)
 	assert.NotNil(t, orders)
-	assert.Equal(t, 2, len(orders), "order count must equal two (2)")
+	assert.Equal(t, 2, len(orders.Items), "order count must equal two (2)")
+	assert.Equal(t, int64(3), orders.Total)
+	require.NotNil(t, orders.NextCursor)
+	assert.Equal(t, "next", *orders.NextCursor)
 }
 
 func TestSave(t *testing.T) {
-	mockRepo, svc := setup(t)
-	mockRepo.EXPECT().Save(gomock.Any()).DoAndReturn(func(m *models.Order) error {
+	m, svc := setupMocks(t)
+	expectProduct(m, 1, 5, 1)
+	expectProduct(m, 2, 10, 1)
+	m.repo.EXPECT().NextOrderNumberSequence(gomock.Any()).Return(int64(4273), nil)
+	m.variantRepo.EXPECT().GetAllByProductId(gomock.Any(), gomock.Any()).Return(nil, nil).Times(2)
+	m.productRepo.EXPECT().AdjustStock(gomock.Any(), uint(1), -2).Return(nil)
+	m.productRepo.EXPECT().AdjustStock(gomock.Any(), uint(2), -3).Return(nil)
+	m.repo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, m *models.Order) error {
+		assert.Equal(t, "ORD-20261019-0042731", m.OrderNumber, "order number is not correct.")
 		assert.Equal(t, 40.00, m.SubTotalAmount, "sub total amount is not correct.")
 		assert.InDelta(t, 2.40, m.TaxAmount, 0.001, "tax amount isn't correct.")
 		assert.InDelta(t, 42.40, m.TotalAmount, 0.001, "total amount is not correct.")
+		m.Id = 7
 		return nil
 	})
 	order := dto.Order{
@@ -108,16 +197,316 @@ func TestSave(t *testing.T) {
 				UnitPrice: 10,
 			},
 		},
-		Status:       "Pending",
-		BillingState: "MD",
+		Status:         "Pending",
+		BillingAddress: dto.OrderAddress{State: "MD"},
 	}
 
-	err := svc.Save(order)
+	created, err := svc.Save(context.Background(), order)
 	assert.NoError(t, err)
+	require.NotNil(t, created)
+	assert.Equal(t, uint(7), created.Id)
+	assert.Equal(t, "ORD-20261019-0042731", created.OrderNumber)
+	assert.Equal(t, 40.00, created.SubTotalAmount)
+	assert.InDelta(t, 42.40, created.TotalAmount, 0.001)
+}
+
+func TestSaveWithShipping(t *testing.T) {
+	m, svc := setupMocks(t)
+	expectProduct(m, 1, 5, 2)
+	expectProduct(m, 2, 10, 2)
+	m.variantRepo.EXPECT().GetAllByProductId(gomock.Any(), gomock.Any()).Return(nil, nil).Times(2)
+	m.productRepo.EXPECT().AdjustStock(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	m.repo.EXPECT().NextOrderNumberSequence(gomock.Any()).Return(int64(1), nil)
+	m.repo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, m *models.Order) error {
+		assert.Equal(t, 40.00, m.SubTotalAmount, "sub total amount is not correct.")
+		assert.Equal(t, 8.99, m.ShippingAmount, "shipping amount is not correct.")
+		assert.Equal(t, "standard", m.ShippingMethod)
+		assert.InDelta(t, 2.40, m.TaxAmount, 0.001, "shipping isn't taxable in MD.")
+		assert.InDelta(t, 51.39, m.TotalAmount, 0.001, "total amount is not correct.")
+		return nil
+	})
+	order := dto.Order{
+		OrderItems: []orderitem.OrderItem{
+			{ProductId: 1, Quantity: 2, UnitPrice: 5},
+			{ProductId: 2, Quantity: 3, UnitPrice: 10},
+		},
+		Status:          "Pending",
+		BillingAddress:  dto.OrderAddress{State: "MD"},
+		ShippingAddress: dto.OrderAddress{State: "MD"},
+		ShippingMethod:  "standard",
+	}
+
+	_, err := svc.Save(context.Background(), order)
+	assert.NoError(t, err)
+}
+
+func TestSaveWithTaxableShipping(t *testing.T) {
+	m, svc := setupMocks(t)
+	expectProduct(m, 1, 10, 2)
+	m.variantRepo.EXPECT().GetAllByProductId(gomock.Any(), uint(1)).Return(nil, nil)
+	m.productRepo.EXPECT().AdjustStock(gomock.Any(), uint(1), -1).Return(nil)
+	m.repo.EXPECT().NextOrderNumberSequence(gomock.Any()).Return(int64(2), nil)
+	m.repo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, m *models.Order) error {
+		assert.Equal(t, 5.99, m.ShippingAmount, "shipping amount is not correct.")
+		assert.InDelta(t, (10.00+5.99)*0.06625, m.TaxAmount, 0.001, "shipping is taxable in NJ.")
+		return nil
+	})
+	order := dto.Order{
+		OrderItems: []orderitem.OrderItem{
+			{ProductId: 1, Quantity: 1, UnitPrice: 10},
+		},
+		Status:          "Pending",
+		BillingAddress:  dto.OrderAddress{State: "NJ"},
+		ShippingAddress: dto.OrderAddress{State: "NJ"},
+		ShippingMethod:  "standard",
+	}
+
+	_, err := svc.Save(context.Background(), order)
+	assert.NoError(t, err)
+}
+
+func TestSaveSnapshotsAddressBookEntry(t *testing.T) {
+	m, svc := setupMocks(t)
+	addressId := uint(3)
+	m.addressRepo.EXPECT().GetById(gomock.Any(), addressId).Return(&models.Address{
+		Base:       models.Base{Id: addressId},
+		UserId:     7,
+		Street:     "1 Main St",
+		City:       "Trenton",
+		State:      "NJ",
+		PostalCode: "08608",
+		Country:    "US",
+	}, nil).Times(2)
+	expectProduct(m, 1, 10, 2)
+	m.variantRepo.EXPECT().GetAllByProductId(gomock.Any(), uint(1)).Return(nil, nil)
+	m.productRepo.EXPECT().AdjustStock(gomock.Any(), uint(1), -1).Return(nil)
+	m.repo.EXPECT().NextOrderNumberSequence(gomock.Any()).Return(int64(3), nil)
+	m.repo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, m *models.Order) error {
+		assert.Equal(t, &addressId, m.BillingAddress.AddressId)
+		assert.Equal(t, "1 Main St", m.BillingAddress.Street)
+		assert.Equal(t, "08608", m.ShippingAddress.PostalCode)
+		assert.InDelta(t, (10.00+5.99)*0.06625, m.TaxAmount, 0.001, "tax should follow the address book entry's state.")
+		return nil
+	})
+	order := dto.Order{
+		UserId: 7,
+		OrderItems: []orderitem.OrderItem{
+			{ProductId: 1, Quantity: 1, UnitPrice: 10},
+		},
+		BillingAddress:  dto.OrderAddress{AddressId: &addressId},
+		ShippingAddress: dto.OrderAddress{AddressId: &addressId, State: "AK"},
+		ShippingMethod:  "standard",
+	}
+
+	_, err := svc.Save(context.Background(), order)
+	assert.NoError(t, err)
+}
+
+func TestSaveVariant(t *testing.T) {
+	m, svc := setupMocks(t)
+	variantId := uint(11)
+	variantPrice := float32(7)
+	m.productRepo.EXPECT().GetById(gomock.Any(), uint(1)).Return(&models.Product{
+		Base:     models.Base{Id: 1},
+		Price:    5,
+		Variants: []models.ProductVariant{{Base: models.Base{Id: variantId}, ProductId: 1, Price: &variantPrice, IsActive: true}},
+	}, nil)
+	m.repo.EXPECT().NextOrderNumberSequence(gomock.Any()).Return(int64(5), nil)
+	m.repo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, m *models.Order) error {
+		assert.Equal(t, 7.0, m.OrderItems[0].UnitPrice, "the variant's price override is charged.")
+		assert.Equal(t, 14.0, m.SubTotalAmount)
+		return nil
+	})
+	m.variantRepo.EXPECT().GetById(gomock.Any(), variantId).Return(&models.ProductVariant{
+		Base:      models.Base{Id: variantId},
+		ProductId: 1,
+		IsActive:  true,
+	}, nil)
+	m.variantRepo.EXPECT().AdjustStock(gomock.Any(), variantId, -2).Return(nil)
+	order := dto.Order{
+		OrderItems: []orderitem.OrderItem{
+			{ProductId: 1, VariantId: &variantId, Quantity: 2, UnitPrice: 5},
+		},
+		BillingAddress: dto.OrderAddress{State: "MD"},
+	}
+
+	_, err := svc.Save(context.Background(), order)
+	assert.NoError(t, err)
+}
+
+func TestSaveInvalidVariant(t *testing.T) {
+	variantId := uint(11)
+	tests := []struct {
+		name    string
+		item    orderitem.OrderItem
+		product *models.Product
+		expect  func(m *mocks)
+		message string
+	}{
+		{
+			name:    "product has variants",
+			item:    orderitem.OrderItem{ProductId: 1, Quantity: 1, UnitPrice: 5},
+			product: &models.Product{Base: models.Base{Id: 1}, Price: 5},
+			expect: func(m *mocks) {
+				m.repo.EXPECT().NextOrderNumberSequence(gomock.Any()).Return(int64(6), nil)
+				m.repo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+				m.variantRepo.EXPECT().GetAllByProductId(gomock.Any(), uint(1)).Return([]*models.ProductVariant{{ProductId: 1}}, nil)
+			},
+			message: "needs a variant_id",
+		},
+		{
+			name: "variant of another product",
+			item: orderitem.OrderItem{ProductId: 1, VariantId: &variantId, Quantity: 1, UnitPrice: 5},
+			// The product doesn't have the variant, so it can't be priced and
+			// the order is never saved.
+			product: &models.Product{Base: models.Base{Id: 1}, Price: 5},
+			expect:  func(m *mocks) {},
+			message: "not for sale",
+		},
+		{
+			name: "inactive variant",
+			item: orderitem.OrderItem{ProductId: 1, VariantId: &variantId, Quantity: 1, UnitPrice: 5},
+			product: &models.Product{
+				Base:     models.Base{Id: 1},
+				Price:    5,
+				Variants: []models.ProductVariant{{Base: models.Base{Id: variantId}, ProductId: 1}},
+			},
+			expect: func(m *mocks) {
+				m.repo.EXPECT().NextOrderNumberSequence(gomock.Any()).Return(int64(6), nil)
+				m.repo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+				m.variantRepo.EXPECT().GetById(gomock.Any(), variantId).Return(&models.ProductVariant{ProductId: 1}, nil)
+			},
+			message: "not for sale",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, svc := setupMocks(t)
+			m.productRepo.EXPECT().GetById(gomock.Any(), uint(1)).Return(tt.product, nil)
+			tt.expect(m)
+			order := dto.Order{
+				OrderItems:     []orderitem.OrderItem{tt.item},
+				BillingAddress: dto.OrderAddress{State: "MD"},
+			}
+
+			_, err := svc.Save(context.Background(), order)
+			assert.ErrorIs(t, err, ErrInvalidVariant)
+			assert.ErrorContains(t, err, tt.message)
+		})
+	}
+}
+
+func TestSaveChargesActivePrice(t *testing.T) {
+	m, svc := setupMocks(t)
+	compareAt := float32(10)
+	m.productRepo.EXPECT().GetById(gomock.Any(), uint(1)).Return(&models.Product{
+		Base:  models.Base{Id: 1},
+		Price: 10,
+		Prices: []models.ProductPrice{
+			{Base: models.Base{Id: 1}, ProductId: 1, Price: 7.99, CompareAtPrice: &compareAt, EffectiveFrom: time.Now().Add(-time.Hour)},
+		},
+	}, nil)
+	m.variantRepo.EXPECT().GetAllByProductId(gomock.Any(), uint(1)).Return(nil, nil)
+	m.productRepo.EXPECT().AdjustStock(gomock.Any(), uint(1), -2).Return(nil)
+	m.repo.EXPECT().NextOrderNumberSequence(gomock.Any()).Return(int64(7), nil)
+	m.repo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, m *models.Order) error {
+		assert.Equal(t, 7.99, m.OrderItems[0].UnitPrice, "the sale price is charged, not the one sent.")
+		assert.InDelta(t, 15.98, m.SubTotalAmount, 0.001)
+		return nil
+	})
+	order := dto.Order{
+		OrderItems:     []orderitem.OrderItem{{ProductId: 1, Quantity: 2, UnitPrice: 0.01}},
+		BillingAddress: dto.OrderAddress{State: "MD"},
+	}
+
+	_, err := svc.Save(context.Background(), order)
+	assert.NoError(t, err)
+}
+
+func TestSaveIgnoresIdStatusAndPrice(t *testing.T) {
+	m, svc := setupMocks(t)
+	expectProduct(m, 1, 10, 1)
+	m.variantRepo.EXPECT().GetAllByProductId(gomock.Any(), uint(1)).Return(nil, nil)
+	m.productRepo.EXPECT().AdjustStock(gomock.Any(), uint(1), -1).Return(nil)
+	m.repo.EXPECT().NextOrderNumberSequence(gomock.Any()).Return(int64(8), nil)
+	m.repo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, m *models.Order) error {
+		assert.Zero(t, m.Id, "an id in the body must not make the order an update.")
+		assert.Equal(t, models.OrderStatusPending, m.Status, "a new order is pending whatever status was sent.")
+		assert.Equal(t, 10.0, m.OrderItems[0].UnitPrice, "the product's price is charged, not the one sent.")
+		assert.Equal(t, 10.0, m.SubTotalAmount)
+		return nil
+	})
+	order := dto.Order{
+		Id:             42,
+		Status:         string(models.OrderStatusShipped),
+		OrderItems:     []orderitem.OrderItem{{ProductId: 1, Quantity: 1, UnitPrice: 0.01}},
+		BillingAddress: dto.OrderAddress{State: "MD"},
+	}
+
+	_, err := svc.Save(context.Background(), order)
+	assert.NoError(t, err)
+}
+
+func TestSaveNonPositiveQuantity(t *testing.T) {
+	for _, quantity := range []int{0, -3} {
+		_, svc := setupMocks(t)
+		order := dto.Order{
+			OrderItems:     []orderitem.OrderItem{{ProductId: 1, Quantity: quantity, UnitPrice: 10}},
+			BillingAddress: dto.OrderAddress{State: "MD"},
+		}
+
+		_, err := svc.Save(context.Background(), order)
+		assert.ErrorIs(t, err, ErrInvalidQuantity, "quantity %d", quantity)
+	}
+}
+
+func TestSaveUnknownProduct(t *testing.T) {
+	m, svc := setupMocks(t)
+	m.productRepo.EXPECT().GetById(gomock.Any(), uint(9)).Return(nil, gorm.ErrRecordNotFound)
+	order := dto.Order{
+		OrderItems:     []orderitem.OrderItem{{ProductId: 9, Quantity: 1, UnitPrice: 10}},
+		BillingAddress: dto.OrderAddress{State: "MD"},
+	}
+
+	_, err := svc.Save(context.Background(), order)
+	assert.ErrorIs(t, err, ErrInvalidProduct)
+}
+
+func TestSaveForeignAddress(t *testing.T) {
+	m, svc := setupMocks(t)
+	addressId := uint(3)
+	m.addressRepo.EXPECT().GetById(gomock.Any(), addressId).Return(&models.Address{Base: models.Base{Id: addressId}, UserId: 8, State: "MD"}, nil)
+	order := dto.Order{
+		UserId:         7,
+		OrderItems:     []orderitem.OrderItem{{ProductId: 1, Quantity: 1, UnitPrice: 10}},
+		BillingAddress: dto.OrderAddress{AddressId: &addressId},
+	}
+
+	_, err := svc.Save(context.Background(), order)
+	assert.ErrorIs(t, err, ErrInvalidAddress)
+}
+
+func TestSaveInvalidShippingMethod(t *testing.T) {
+	m, svc := setupMocks(t)
+	expectProduct(m, 1, 10, 1)
+	order := dto.Order{
+		OrderItems: []orderitem.OrderItem{
+			{ProductId: 1, Quantity: 1, UnitPrice: 10},
+		},
+		Status:          "Pending",
+		BillingAddress:  dto.OrderAddress{State: "HI"},
+		ShippingAddress: dto.OrderAddress{State: "HI"},
+		ShippingMethod:  "overnight",
+	}
+
+	_, err := svc.Save(context.Background(), order)
+	assert.Error(t, err)
 }
 
 func TestSaveInvalidState(t *testing.T) {
-	_, svc := setup(t)
+	m, svc := setupMocks(t)
+	expectProduct(m, 1, 5, 1)
+	expectProduct(m, 2, 10, 1)
 	order := dto.Order{
 		Id: 0,
 		OrderItems: []orderitem.OrderItem{
@@ -134,41 +523,174 @@ func TestSaveInvalidState(t *testing.T) {
 				UnitPrice: 10,
 			},
 		},
-		Status:       "Pending",
-		BillingState: "NOTFOUND",
+		Status:         "Pending",
+		BillingAddress: dto.OrderAddress{State: "NOTFOUND"},
 	}
 
-	err := svc.Save(order)
+	_, err := svc.Save(context.Background(), order)
 	assert.Error(t, err)
 }
 
 func TestUpdateStatus(t *testing.T) {
 	id := uint(1)
 	mockRepo, svc := setup(t)
-	mockRepo.EXPECT().UpdateStatus(id, string(models.OrderStatusShipped)).Return(nil)
-	err := svc.UpdateStatus(id, string(models.OrderStatusShipped))
+	mockRepo.EXPECT().UpdateStatus(gomock.Any(), id, string(models.OrderStatusShipped)).Return(nil)
+	err := svc.UpdateStatus(context.Background(), id, string(models.OrderStatusShipped))
 	assert.NoError(t, err)
 }
 
 func TestUpdateStatusInvalid(t *testing.T) {
 	_, svc := setup(t)
-	err := svc.UpdateStatus(uint(1), "INVALID")
+	err := svc.UpdateStatus(context.Background(), uint(1), "INVALID")
 	assert.Error(t, err)
 }
 
 func TestUpdateStatusRepoError(t *testing.T) {
 	id := uint(1)
 	mockRepo, svc := setup(t)
-	mockRepo.EXPECT().UpdateStatus(id, string(models.OrderStatusShipped)).Return(fmt.Errorf("db error"))
-	err := svc.UpdateStatus(id, string(models.OrderStatusShipped))
+	mockRepo.EXPECT().UpdateStatus(gomock.Any(), id, string(models.OrderStatusShipped)).Return(fmt.Errorf("db error"))
+	err := svc.UpdateStatus(context.Background(), id, string(models.OrderStatusShipped))
 	assert.Error(t, err)
 }
 
 func TestGetStatuses(t *testing.T) {
 	_, svc := setup(t)
-	statuses := svc.GetStatuses()
+	statuses := svc.GetStatuses(context.Background())
 	assert.NotEmpty(t, statuses)
 	for i, status := range statuses {
 		t.Logf("status %d %s", i, status.Status)
 	}
 }
+
+func TestUpdateStatusCancelled(t *testing.T) {
+	_, svc := setup(t)
+	err := svc.UpdateStatus(context.Background(), uint(1), string(models.OrderStatusCancelled))
+	assert.Error(t, err, "cancelling must go through Cancel")
+}
+
+func pendingOrder() *models.Order {
+	return &models.Order{
+		Base:   models.Base{Id: 1},
+		UserId: 1,
+		Status: models.OrderStatusPending,
+		OrderItems: []models.OrderItem{
+			{ProductId: 1, Quantity: 2, UnitPrice: 5},
+			{ProductId: 2, Quantity: 3, UnitPrice: 10},
+		},
+	}
+}
+
+func TestCancel(t *testing.T) {
+	m, svc := setupMocks(t)
+	m.repo.EXPECT().GetById(gomock.Any(), uint(1)).Return(pendingOrder(), nil)
+	m.paymentRepo.EXPECT().GetByOrder(gomock.Any(), uint(1)).Return([]*models.Payment{
+		{Base: models.Base{Id: 1}, Amount: 42.4, Status: models.PaymentStatusAuthorized},
+		{Base: models.Base{Id: 2}, Amount: 10, RefundedAmount: 4, Status: models.PaymentStatusPartiallyRefunded},
+		{Base: models.Base{Id: 3}, Amount: 42.4, Status: models.PaymentStatusFailed},
+	}, nil)
+	m.paymentRepo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, p *models.Payment) error {
+		switch p.Id {
+		case 1:
+			assert.Equal(t, models.PaymentStatusVoided, p.Status)
+		case 2:
+			assert.Equal(t, models.PaymentStatusRefunded, p.Status)
+			assert.Equal(t, 10.0, p.RefundedAmount)
+		default:
+			t.Errorf("payment %d should have been left alone", p.Id)
+		}
+		return nil
+	}).Times(2)
+	m.invoiceRepo.EXPECT().LockInvoice(gomock.Any(), uint(1)).Return(gorm.ErrRecordNotFound)
+	m.productRepo.EXPECT().AdjustStock(gomock.Any(), uint(1), 2).Return(nil)
+	m.productRepo.EXPECT().AdjustStock(gomock.Any(), uint(2), 3).Return(nil)
+	m.repo.EXPECT().Cancel(gomock.Any(), uint(1), "changed my mind", gomock.Any()).Return(nil)
+
+	order, err := svc.Cancel(context.Background(), 1, "changed my mind")
+	assert.NoError(t, err)
+	assert.Equal(t, string(models.OrderStatusCancelled), order.Status)
+	assert.Equal(t, "changed my mind", order.CancelReason)
+	assert.NotNil(t, order.CancelledDate)
+}
+
+func TestCancelCreditsInvoice(t *testing.T) {
+	m, svc := setupMocks(t)
+	m.repo.EXPECT().GetById(gomock.Any(), uint(1)).Return(pendingOrder(), nil)
+	m.paymentRepo.EXPECT().GetByOrder(gomock.Any(), uint(1)).Return([]*models.Payment{
+		{Base: models.Base{Id: 1}, Amount: 42.4, Status: models.PaymentStatusCaptured},
+	}, nil)
+	m.paymentRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+	m.invoiceRepo.EXPECT().LockInvoice(gomock.Any(), uint(1)).Return(nil)
+	m.invoiceRepo.EXPECT().GetAllByOrderId(gomock.Any(), uint(1)).Return([]*models.Invoice{
+		{Base: models.Base{Id: 9}, OrderId: 1, Type: models.InvoiceTypeInvoice, TaxAmount: 2.4, TotalAmount: 42.4},
+	}, nil)
+	m.invoiceRepo.EXPECT().NextNumber(gomock.Any(), models.InvoiceTypeCreditNote).Return(int64(5), nil)
+	m.invoiceRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, i *models.Invoice) error {
+		assert.Equal(t, "CN-000005", i.InvoiceNumber)
+		assert.Equal(t, 42.4, i.TotalAmount)
+		assert.Equal(t, 2.4, i.TaxAmount)
+		assert.Equal(t, uint(9), *i.CreditedInvoiceId)
+		return nil
+	})
+	m.productRepo.EXPECT().AdjustStock(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	m.repo.EXPECT().Cancel(gomock.Any(), uint(1), "changed my mind", gomock.Any()).Return(nil)
+
+	_, err := svc.Cancel(context.Background(), 1, "changed my mind")
+	assert.NoError(t, err)
+}
+
+func TestCancelShippedOrder(t *testing.T) {
+	m, svc := setupMocks(t)
+	order := pendingOrder()
+	order.Status = models.OrderStatusPartiallyShipped
+	m.repo.EXPECT().GetById(gomock.Any(), uint(1)).Return(order, nil)
+
+	_, err := svc.Cancel(context.Background(), 1, "too late")
+	assert.Error(t, err)
+}
+
+func TestCancelConcurrentlyCancelled(t *testing.T) {
+	m, svc := setupMocks(t)
+	m.repo.EXPECT().GetById(gomock.Any(), uint(1)).Return(pendingOrder(), nil)
+	m.repo.EXPECT().Cancel(gomock.Any(), uint(1), "changed my mind", gomock.Any()).Return(repo.ErrNotPending)
+
+	_, err := svc.Cancel(context.Background(), 1, "changed my mind")
+	assert.ErrorIs(t, err, repo.ErrNotPending)
+}
+
+func TestCancelPaymentError(t *testing.T) {
+	m, svc := setupMocks(t)
+	m.repo.EXPECT().GetById(gomock.Any(), uint(1)).Return(pendingOrder(), nil)
+	m.repo.EXPECT().Cancel(gomock.Any(), uint(1), "changed my mind", gomock.Any()).Return(nil)
+	m.paymentRepo.EXPECT().GetByOrder(gomock.Any(), uint(1)).Return(nil, fmt.Errorf("db error"))
+
+	_, err := svc.Cancel(context.Background(), 1, "changed my mind")
+	assert.Error(t, err)
+}
+
+func TestSaveSequenceError(t *testing.T) {
+	m, svc := setupMocks(t)
+	expectProduct(m, 1, 10, 1)
+	m.repo.EXPECT().NextOrderNumberSequence(gomock.Any()).Return(int64(0), fmt.Errorf("db error"))
+	_, err := svc.Save(context.Background(), dto.Order{
+		OrderItems:     []orderitem.OrderItem{{ProductId: 1, Quantity: 1, UnitPrice: 10}},
+		BillingAddress: dto.OrderAddress{State: "MD"},
+	})
+	assert.Error(t, err)
+}
+
+func TestGetByOrderNumber(t *testing.T) {
+	mockRepo, svc := setup(t)
+	mockRepo.EXPECT().GetByOrderNumber(gomock.Any(), "ORD-20261019-0042731").Return(&models.Order{
+		Base:        models.Base{Id: 1},
+		OrderNumber: "ORD-20261019-0042731",
+	}, nil)
+	order, err := svc.GetByOrderNumber(context.Background(), "ord-20261019-0042731")
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), order.Id)
+}
+
+func TestGetByOrderNumberTypo(t *testing.T) {
+	_, svc := setup(t)
+	_, err := svc.GetByOrderNumber(context.Background(), "ORD-20261019-0042372")
+	assert.ErrorIs(t, err, ErrInvalidOrderNumber)
+}
@@ -11,6 +11,8 @@ package payment
 
 import (
 	models "commerce/internal/shared/models"
+	query "commerce/internal/shared/repositories/query"
+	context "context"
 	reflect "reflect"
 
 	gomock "go.uber.org/mock/gomock"
@@ -41,88 +43,88 @@ func (m *MockPaymentRepositoryI) EXPECT() *MockPaymentRepositoryIMockRecorder {
 }
 
 // Delete mocks base method.
-func (m *MockPaymentRepositoryI) Delete(id uint, hard bool) error {
+func (m *MockPaymentRepositoryI) Delete(ctx context.Context, id uint, hard bool) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", id, hard)
+	ret := m.ctrl.Call(m, "Delete", ctx, id, hard)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockPaymentRepositoryIMockRecorder) Delete(id, hard any) *gomock.Call {
+func (mr *MockPaymentRepositoryIMockRecorder) Delete(ctx, id, hard any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPaymentRepositoryI)(nil).Delete), id, hard)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPaymentRepositoryI)(nil).Delete), ctx, id, hard)
 }
 
 // GetAll mocks base method.
-func (m *MockPaymentRepositoryI) GetAll() ([]*models.Payment, error) {
+func (m *MockPaymentRepositoryI) GetAll(ctx context.Context, opts query.Options) (*query.Page[models.Payment], error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll")
-	ret0, _ := ret[0].([]*models.Payment)
+	ret := m.ctrl.Call(m, "GetAll", ctx, opts)
+	ret0, _ := ret[0].(*query.Page[models.Payment])
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockPaymentRepositoryIMockRecorder) GetAll() *gomock.Call {
+func (mr *MockPaymentRepositoryIMockRecorder) GetAll(ctx, opts any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockPaymentRepositoryI)(nil).GetAll))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockPaymentRepositoryI)(nil).GetAll), ctx, opts)
 }
 
 // GetById mocks base method.
-func (m *MockPaymentRepositoryI) GetById(id uint) (*models.Payment, error) {
+func (m *MockPaymentRepositoryI) GetById(ctx context.Context, id uint) (*models.Payment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetById", id)
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
 	ret0, _ := ret[0].(*models.Payment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetById indicates an expected call of GetById.
-func (mr *MockPaymentRepositoryIMockRecorder) GetById(id any) *gomock.Call {
+func (mr *MockPaymentRepositoryIMockRecorder) GetById(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockPaymentRepositoryI)(nil).GetById), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockPaymentRepositoryI)(nil).GetById), ctx, id)
 }
 
 // GetByOrder mocks base method.
-func (m *MockPaymentRepositoryI) GetByOrder(orderId uint) ([]*models.Payment, error) {
+func (m *MockPaymentRepositoryI) GetByOrder(ctx context.Context, orderId uint) ([]*models.Payment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByOrder", orderId)
+	ret := m.ctrl.Call(m, "GetByOrder", ctx, orderId)
 	ret0, _ := ret[0].([]*models.Payment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByOrder indicates an expected call of GetByOrder.
-func (mr *MockPaymentRepositoryIMockRecorder) GetByOrder(orderId any) *gomock.Call {
+func (mr *MockPaymentRepositoryIMockRecorder) GetByOrder(ctx, orderId any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOrder", reflect.TypeOf((*MockPaymentRepositoryI)(nil).GetByOrder), orderId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOrder", reflect.TypeOf((*MockPaymentRepositoryI)(nil).GetByOrder), ctx, orderId)
 }
 
 // Save mocks base method.
-func (m *MockPaymentRepositoryI) Save(payment *models.Payment) error {
+func (m *MockPaymentRepositoryI) Save(ctx context.Context, payment *models.Payment) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Save", payment)
+	ret := m.ctrl.Call(m, "Save", ctx, payment)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Save indicates an expected call of Save.
-func (mr *MockPaymentRepositoryIMockRecorder) Save(payment any) *gomock.Call {
+func (mr *MockPaymentRepositoryIMockRecorder) Save(ctx, payment any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockPaymentRepositoryI)(nil).Save), payment)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockPaymentRepositoryI)(nil).Save), ctx, payment)
 }
 
 // UpdateStatus mocks base method.
-func (m *MockPaymentRepositoryI) UpdateStatus(id uint, status string) error {
+func (m *MockPaymentRepositoryI) UpdateStatus(ctx context.Context, id uint, status string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateStatus", id, status)
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, id, status)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateStatus indicates an expected call of UpdateStatus.
-func (mr *MockPaymentRepositoryIMockRecorder) UpdateStatus(id, status any) *gomock.Call {
+func (mr *MockPaymentRepositoryIMockRecorder) UpdateStatus(ctx, id, status any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockPaymentRepositoryI)(nil).UpdateStatus), id, status)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockPaymentRepositoryI)(nil).UpdateStatus), ctx, id, status)
 }
@@ -4,17 +4,28 @@ import (
 	dto "commerce/api/internal/dto/payment"
 	model "commerce/internal/shared/models"
 	repo "commerce/internal/shared/repositories/payment"
+	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"math"
 )
 
+// ErrNoRefundablePayment is returned by RefundOrder when no payment on the
+// order has enough captured, unrefunded funds left.
+var ErrNoRefundablePayment = errors.New("no refundable payment")
+
 type PaymentServiceI interface {
-	GetById(id uint) (*dto.Payment, error)
-	GetByOrder(orderId uint) ([]*dto.Payment, error)
-	GetStatuses() []dto.PaymentStatus
-	Delete(id uint, hard bool) error
-	Save(payment *dto.Payment) error
-	UpdateStatus(id uint, status string) error
+	GetById(ctx context.Context, id uint) (*dto.Payment, error)
+	GetByOrder(ctx context.Context, orderId uint) ([]*dto.Payment, error)
+	GetStatuses(ctx context.Context) []dto.PaymentStatus
+	Delete(ctx context.Context, id uint, hard bool) error
+	Save(ctx context.Context, payment *dto.Payment, staff bool) error
+	UpdateStatus(ctx context.Context, id uint, status string) error
+	Refund(ctx context.Context, id uint, amount float64) (*dto.Payment, error)
+	RefundOrder(ctx context.Context, orderId uint, amount float64) (*dto.Payment, error)
+	Void(ctx context.Context, id uint) (*dto.Payment, error)
+	ReleaseOrder(ctx context.Context, orderId uint) ([]*dto.Payment, error)
 }
 
 type PaymentService struct {
@@ -26,13 +37,13 @@ func NewPaymentService(repo repo.PaymentRepositoryI) PaymentServiceI {
 }
 
 // Delete implements [PaymentServiceI].
-func (p *PaymentService) Delete(id uint, hard bool) error {
-	return p.repo.Delete(id, hard)
+func (p *PaymentService) Delete(ctx context.Context, id uint, hard bool) error {
+	return p.repo.Delete(ctx, id, hard)
 }
 
 // GetById implements [PaymentServiceI].
-func (p *PaymentService) GetById(id uint) (*dto.Payment, error) {
-	model, err := p.repo.GetById(id)
+func (p *PaymentService) GetById(ctx context.Context, id uint) (*dto.Payment, error) {
+	model, err := p.repo.GetById(ctx, id)
 	if err != nil {
 		slog.Error("Exception occured when getting payment by id", "id", id, "error", err)
 		return nil, err
@@ -41,8 +52,8 @@ func (p *PaymentService) GetById(id uint) (*dto.Payment, error) {
 }
 
 // GetByOrder implements [PaymentServiceI].
-func (p *PaymentService) GetByOrder(orderId uint) ([]*dto.Payment, error) {
-	models, err := p.repo.GetByOrder(orderId)
+func (p *PaymentService) GetByOrder(ctx context.Context, orderId uint) ([]*dto.Payment, error) {
+	models, err := p.repo.GetByOrder(ctx, orderId)
 	if err != nil {
 		slog.Error("Exception occured when getting payments by order", "orderId", orderId, "error", err)
 		return nil, err
@@ -55,7 +66,7 @@ func (p *PaymentService) GetByOrder(orderId uint) ([]*dto.Payment, error) {
 }
 
 // GetStatuses implements [PaymentServiceI].
-func (p *PaymentService) GetStatuses() []dto.PaymentStatus {
+func (p *PaymentService) GetStatuses(ctx context.Context) []dto.PaymentStatus {
 	var statuses []dto.PaymentStatus
 	for k := range validStatuses {
 		statuses = append(statuses, dto.PaymentStatus{Status: string(k)})
@@ -63,19 +74,169 @@ func (p *PaymentService) GetStatuses() []dto.PaymentStatus {
 	return statuses
 }
 
-// Save implements [PaymentServiceI].
-func (p *PaymentService) Save(payment *dto.Payment) error {
+// Save implements [PaymentServiceI]. Only staff choose the status of a
+// payment they record; anyone else's payment is saved pending, whatever status
+// was sent, so a customer can't mark their own payment captured.
+func (p *PaymentService) Save(ctx context.Context, payment *dto.Payment, staff bool) error {
+	if !staff || payment.Status == "" {
+		payment.Status = string(model.PaymentStatusPending)
+	}
+	if !isPaymentStatusValid(payment.Status) {
+		slog.Error("Payment status doesn't exist.", "status", payment.Status)
+		return fmt.Errorf("invalid payment status: %s", payment.Status)
+	}
 	dto := dto.ToModel(payment)
-	return p.repo.Save(dto)
+	return p.repo.Save(ctx, dto)
 }
 
 // UpdateStatus implements [PaymentServiceI].
-func (p *PaymentService) UpdateStatus(id uint, status string) error {
+func (p *PaymentService) UpdateStatus(ctx context.Context, id uint, status string) error {
 	if !isPaymentStatusValid(status) {
 		slog.Error("Payment status doesn't exist.", "status", status)
 		return fmt.Errorf("invalid payment status: %s", status)
 	}
-	return p.repo.UpdateStatus(id, status)
+	return p.repo.UpdateStatus(ctx, id, status)
+}
+
+// Refund implements [PaymentServiceI]. It records the refund against the
+// payment; there is no gateway integration to move the money yet.
+func (p *PaymentService) Refund(ctx context.Context, id uint, amount float64) (*dto.Payment, error) {
+	payment, err := p.repo.GetById(ctx, id)
+	if err != nil {
+		slog.Error("Exception occured when getting payment by id", "id", id, "error", err)
+		return nil, err
+	}
+	if err := refund(payment, amount); err != nil {
+		return nil, err
+	}
+	if err := p.repo.Save(ctx, payment); err != nil {
+		slog.Error("Exception occured when refunding payment", "id", id, "amount", amount, "error", err)
+		return nil, err
+	}
+	return dto.FromModel(payment), nil
+}
+
+// RefundOrder implements [PaymentServiceI]. The refund goes to the oldest
+// payment on the order that can cover it in full.
+func (p *PaymentService) RefundOrder(ctx context.Context, orderId uint, amount float64) (*dto.Payment, error) {
+	payments, err := p.repo.GetByOrder(ctx, orderId)
+	if err != nil {
+		slog.Error("Exception occured when getting payments by order", "orderId", orderId, "error", err)
+		return nil, err
+	}
+	for i := len(payments) - 1; i >= 0; i-- {
+		payment := payments[i]
+		if !isRefundable(payment) || refundableAmount(payment) < amount {
+			continue
+		}
+		if err := refund(payment, amount); err != nil {
+			return nil, err
+		}
+		if err := p.repo.Save(ctx, payment); err != nil {
+			slog.Error("Exception occured when refunding payment", "id", payment.Id, "amount", amount, "error", err)
+			return nil, err
+		}
+		return dto.FromModel(payment), nil
+	}
+	return nil, ErrNoRefundablePayment
+}
+
+// Void implements [PaymentServiceI]. Only payments that haven't been captured
+// can be voided.
+func (p *PaymentService) Void(ctx context.Context, id uint) (*dto.Payment, error) {
+	payment, err := p.repo.GetById(ctx, id)
+	if err != nil {
+		slog.Error("Exception occured when getting payment by id", "id", id, "error", err)
+		return nil, err
+	}
+	if err := void(payment); err != nil {
+		return nil, err
+	}
+	if err := p.repo.Save(ctx, payment); err != nil {
+		slog.Error("Exception occured when voiding payment", "id", id, "error", err)
+		return nil, err
+	}
+	return dto.FromModel(payment), nil
+}
+
+// ReleaseOrder implements [PaymentServiceI]. It gives back everything taken
+// for the order: uncaptured payments are voided and whatever is left of
+// captured ones is refunded. Failed, voided and refunded payments are left
+// as they are.
+func (p *PaymentService) ReleaseOrder(ctx context.Context, orderId uint) ([]*dto.Payment, error) {
+	payments, err := p.repo.GetByOrder(ctx, orderId)
+	if err != nil {
+		slog.Error("Exception occured when getting payments by order", "orderId", orderId, "error", err)
+		return nil, err
+	}
+	released := []*dto.Payment{}
+	for _, payment := range payments {
+		switch {
+		case isVoidable(payment):
+			err = void(payment)
+		case isRefundable(payment):
+			err = refund(payment, refundableAmount(payment))
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := p.repo.Save(ctx, payment); err != nil {
+			slog.Error("Exception occured when releasing payment", "id", payment.Id, "error", err)
+			return nil, err
+		}
+		released = append(released, dto.FromModel(payment))
+	}
+	return released, nil
+}
+
+var voidableStatuses = map[model.PaymentStatus]struct{}{
+	model.PaymentStatusPending:    {},
+	model.PaymentStatusAuthorized: {},
+}
+
+func isVoidable(payment *model.Payment) bool {
+	_, ok := voidableStatuses[payment.Status]
+	return ok
+}
+
+func void(payment *model.Payment) error {
+	if !isVoidable(payment) {
+		return fmt.Errorf("payment %d is %s and cannot be voided", payment.Id, payment.Status)
+	}
+	payment.Status = model.PaymentStatusVoided
+	return nil
+}
+
+var refundableStatuses = map[model.PaymentStatus]struct{}{
+	model.PaymentStatusCompleted:         {},
+	model.PaymentStatusCaptured:          {},
+	model.PaymentStatusPartiallyRefunded: {},
+}
+
+func isRefundable(payment *model.Payment) bool {
+	_, ok := refundableStatuses[payment.Status]
+	return ok
+}
+
+func refundableAmount(payment *model.Payment) float64 {
+	return math.Round((payment.Amount-payment.RefundedAmount)*100) / 100
+}
+
+func refund(payment *model.Payment, amount float64) error {
+	if !isRefundable(payment) {
+		return fmt.Errorf("payment %d is %s and cannot be refunded", payment.Id, payment.Status)
+	}
+	if amount <= 0 || amount > refundableAmount(payment) {
+		return fmt.Errorf("cannot refund %.2f of payment %d, %.2f refundable", amount, payment.Id, refundableAmount(payment))
+	}
+	payment.RefundedAmount = math.Round((payment.RefundedAmount+amount)*100) / 100
+	payment.Status = model.PaymentStatusPartiallyRefunded
+	if refundableAmount(payment) == 0 {
+		payment.Status = model.PaymentStatusRefunded
+	}
+	return nil
 }
 
 var validStatuses = map[model.PaymentStatus]struct{}{
@@ -86,6 +247,7 @@ var validStatuses = map[model.PaymentStatus]struct{}{
 	model.PaymentStatusFailed:            {},
 	model.PaymentStatusRefunded:          {},
 	model.PaymentStatusPartiallyRefunded: {},
+	model.PaymentStatusVoided:            {},
 }
 
 func isPaymentStatusValid(status string) bool {
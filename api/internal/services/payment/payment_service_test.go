@@ -2,6 +2,7 @@ package payment
 
 import (
 	"commerce/internal/shared/models"
+	"context"
 	"testing"
 	"time"
 
@@ -24,7 +25,7 @@ func setup(t *testing.T) (*MockPaymentRepositoryI, PaymentServiceI) {
 func TestGetById(t *testing.T) {
 	id, empty := uint(1), uuid.New()
 	mockRepo, svc := setup(t)
-	mockRepo.EXPECT().GetById(id).Return(&models.Payment{
+	mockRepo.EXPECT().GetById(gomock.Any(), id).Return(&models.Payment{
 		Base: models.Base{
 			Id:          id,
 			CreatedDate: time.Now(),
@@ -37,7 +38,7 @@ func TestGetById(t *testing.T) {
 		GatewayResponse:      "correct",
 	}, nil)
 
-	payment, err := svc.GetById(id)
+	payment, err := svc.GetById(context.Background(), id)
 	assert.NoError(t, err)
 	assert.NotNil(t, payment)
 }
@@ -45,7 +46,7 @@ func TestGetById(t *testing.T) {
 func TestGetByOrder(t *testing.T) {
 	orderId := uint(1)
 	mockRepo, svc := setup(t)
-	mockRepo.EXPECT().GetByOrder(orderId).Return([]*models.Payment{
+	mockRepo.EXPECT().GetByOrder(gomock.Any(), orderId).Return([]*models.Payment{
 		{
 			Base: models.Base{
 				Id:          uint(1),
@@ -66,7 +67,7 @@ func TestGetByOrder(t *testing.T) {
 		},
 	}, nil)
 
-	payments, err := svc.GetByOrder(orderId)
+	payments, err := svc.GetByOrder(context.Background(), orderId)
 	assert.NoError(t, err)
 	assert.NotEmpty(t, payments)
 	assert.Equalf(t, 2, len(payments), "Payments count should be two (2)")
@@ -75,36 +76,120 @@ func TestGetByOrder(t *testing.T) {
 func TestDelete(t *testing.T) {
 	id := uint(1)
 	mockRepo, svc := setup(t)
-	mockRepo.EXPECT().Delete(id, false).Return(nil)
-	err := svc.Delete(id, false)
+	mockRepo.EXPECT().Delete(gomock.Any(), id, false).Return(nil)
+	err := svc.Delete(context.Background(), id, false)
 	assert.NoError(t, err)
 }
 
 func TestSave(t *testing.T) {
 	mockRepo, svc := setup(t)
-	mockRepo.EXPECT().Save(gomock.Any()).Return(nil)
-	err := svc.Save(&dto.Payment{
+	mockRepo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, p *models.Payment) error {
+		assert.Equal(t, models.PaymentStatusCompleted, p.Status)
+		return nil
+	})
+	err := svc.Save(context.Background(), &dto.Payment{
 		Id:      0,
 		OrderId: 1,
 		Amount:  125.250,
 		Status:  "completed",
+	}, true)
+	assert.NoError(t, err)
+}
+
+func TestSaveByCustomerIsPending(t *testing.T) {
+	mockRepo, svc := setup(t)
+	mockRepo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, p *models.Payment) error {
+		assert.Equal(t, models.PaymentStatusPending, p.Status)
+		return nil
 	})
+	payment := &dto.Payment{OrderId: 1, Amount: 125.250, Status: "captured"}
+	err := svc.Save(context.Background(), payment, false)
 	assert.NoError(t, err)
+	assert.Equal(t, "pending", payment.Status)
+}
+
+func TestSaveInvalidStatus(t *testing.T) {
+	_, svc := setup(t)
+	err := svc.Save(context.Background(), &dto.Payment{OrderId: 1, Amount: 10, Status: "paid"}, true)
+	assert.Error(t, err)
 }
 
 func TestUpdateStatus(t *testing.T) {
 	mockRepo, svc := setup(t)
-	mockRepo.EXPECT().UpdateStatus(uint(1), "completed").Return(nil)
-	err := svc.UpdateStatus(uint(1), "completed")
+	mockRepo.EXPECT().UpdateStatus(gomock.Any(), uint(1), "completed").Return(nil)
+	err := svc.UpdateStatus(context.Background(), uint(1), "completed")
 	assert.NoError(t, err)
 
 }
 
 func TestGetStatuses(t *testing.T) {
 	_, svc := setup(t)
-	statuses := svc.GetStatuses()
+	statuses := svc.GetStatuses(context.Background())
 	assert.NotEmpty(t, statuses)
 	for i, status := range statuses {
 		t.Logf("status %d %s", i, status.Status)
 	}
 }
+
+func TestRefund(t *testing.T) {
+	mockRepo, svc := setup(t)
+	mockRepo.EXPECT().GetById(gomock.Any(), uint(1)).Return(&models.Payment{
+		Base:           models.Base{Id: 1},
+		Amount:         100,
+		RefundedAmount: 40,
+		Status:         models.PaymentStatusPartiallyRefunded,
+	}, nil)
+	mockRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+	payment, err := svc.Refund(context.Background(), uint(1), 60)
+	assert.NoError(t, err)
+	assert.Equal(t, 100.0, payment.RefundedAmount)
+	assert.Equal(t, string(models.PaymentStatusRefunded), payment.Status)
+}
+
+func TestRefundMoreThanCaptured(t *testing.T) {
+	mockRepo, svc := setup(t)
+	mockRepo.EXPECT().GetById(gomock.Any(), uint(1)).Return(&models.Payment{
+		Base:   models.Base{Id: 1},
+		Amount: 100,
+		Status: models.PaymentStatusCaptured,
+	}, nil)
+
+	payment, err := svc.Refund(context.Background(), uint(1), 100.01)
+	assert.Error(t, err)
+	assert.Nil(t, payment)
+}
+
+func TestRefundPending(t *testing.T) {
+	mockRepo, svc := setup(t)
+	mockRepo.EXPECT().GetById(gomock.Any(), uint(1)).Return(&models.Payment{
+		Base:   models.Base{Id: 1},
+		Amount: 100,
+		Status: models.PaymentStatusPending,
+	}, nil)
+
+	_, err := svc.Refund(context.Background(), uint(1), 10)
+	assert.Error(t, err)
+}
+
+func TestRefundOrderPicksOldestCoveringPayment(t *testing.T) {
+	mockRepo, svc := setup(t)
+	mockRepo.EXPECT().GetByOrder(gomock.Any(), uint(1)).Return([]*models.Payment{
+		{Base: models.Base{Id: 3}, Amount: 50, Status: models.PaymentStatusCaptured},
+		{Base: models.Base{Id: 2}, Amount: 50, Status: models.PaymentStatusCaptured},
+		{Base: models.Base{Id: 1}, Amount: 5, Status: models.PaymentStatusCaptured},
+	}, nil)
+	mockRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+	payment, err := svc.RefundOrder(context.Background(), uint(1), 20)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), payment.Id)
+}
+
+func TestRefundOrderNoPayment(t *testing.T) {
+	mockRepo, svc := setup(t)
+	mockRepo.EXPECT().GetByOrder(gomock.Any(), uint(1)).Return([]*models.Payment{}, nil)
+
+	_, err := svc.RefundOrder(context.Background(), uint(1), 20)
+	assert.ErrorIs(t, err, ErrNoRefundablePayment)
+}
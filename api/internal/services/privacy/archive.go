@@ -0,0 +1,38 @@
+package privacy
+
+import (
+	"archive/zip"
+	dto "commerce/api/internal/dto/privacy"
+	"encoding/json"
+	"io"
+)
+
+// WriteZip writes the export as a ZIP archive with one JSON file per section.
+func WriteZip(w io.Writer, export *dto.Export) error {
+	archive := zip.NewWriter(w)
+	for _, file := range []struct {
+		name string
+		data any
+	}{
+		{"profile.json", export.Profile},
+		{"addresses.json", export.Addresses},
+		{"orders.json", export.Orders},
+		{"payments.json", export.Payments},
+		{"reviews.json", export.Reviews},
+	} {
+		f, err := archive.CreateHeader(&zip.FileHeader{
+			Name:     file.name,
+			Method:   zip.Deflate,
+			Modified: export.ExportedDate,
+		})
+		if err != nil {
+			return err
+		}
+		encoder := json.NewEncoder(f)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(file.data); err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
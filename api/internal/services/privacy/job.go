@@ -0,0 +1,35 @@
+package privacy
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// ErasureJob carries out pending erasure requests on a fixed interval until
+// its context is cancelled.
+type ErasureJob struct {
+	svc      PrivacyServiceI
+	interval time.Duration
+}
+
+func NewErasureJob(svc PrivacyServiceI, interval time.Duration) *ErasureJob {
+	return &ErasureJob{svc: svc, interval: interval}
+}
+
+func (j *ErasureJob) Start(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+	slog.Info("Erasure job started.", "interval", j.interval)
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("Erasure job stopped.")
+			return
+		case <-ticker.C:
+			if err := j.svc.ProcessErasures(ctx); err != nil {
+				slog.Error("Exception occurred processing erasures.", "error", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../../../../internal/shared/repositories/erasure-request/erasure_request_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=../../../../internal/shared/repositories/erasure-request/erasure_request_repository.go -destination=mock_erasure_request_repo_test.go -package=privacy
+//
+
+// Package privacy is a generated GoMock package.
+package privacy
+
+import (
+	models "commerce/internal/shared/models"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockErasureRequestRepositoryI is a mock of ErasureRequestRepositoryI interface.
+type MockErasureRequestRepositoryI struct {
+	ctrl     *gomock.Controller
+	recorder *MockErasureRequestRepositoryIMockRecorder
+	isgomock struct{}
+}
+
+// MockErasureRequestRepositoryIMockRecorder is the mock recorder for MockErasureRequestRepositoryI.
+type MockErasureRequestRepositoryIMockRecorder struct {
+	mock *MockErasureRequestRepositoryI
+}
+
+// NewMockErasureRequestRepositoryI creates a new mock instance.
+func NewMockErasureRequestRepositoryI(ctrl *gomock.Controller) *MockErasureRequestRepositoryI {
+	mock := &MockErasureRequestRepositoryI{ctrl: ctrl}
+	mock.recorder = &MockErasureRequestRepositoryIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockErasureRequestRepositoryI) EXPECT() *MockErasureRequestRepositoryIMockRecorder {
+	return m.recorder
+}
+
+// GetByUserId mocks base method.
+func (m *MockErasureRequestRepositoryI) GetByUserId(ctx context.Context, userId uint) ([]*models.ErasureRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserId", ctx, userId)
+	ret0, _ := ret[0].([]*models.ErasureRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserId indicates an expected call of GetByUserId.
+func (mr *MockErasureRequestRepositoryIMockRecorder) GetByUserId(ctx, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserId", reflect.TypeOf((*MockErasureRequestRepositoryI)(nil).GetByUserId), ctx, userId)
+}
+
+// GetPending mocks base method.
+func (m *MockErasureRequestRepositoryI) GetPending(ctx context.Context) ([]*models.ErasureRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPending", ctx)
+	ret0, _ := ret[0].([]*models.ErasureRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPending indicates an expected call of GetPending.
+func (mr *MockErasureRequestRepositoryIMockRecorder) GetPending(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPending", reflect.TypeOf((*MockErasureRequestRepositoryI)(nil).GetPending), ctx)
+}
+
+// Save mocks base method.
+func (m *MockErasureRequestRepositoryI) Save(ctx context.Context, request *models.ErasureRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, request)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockErasureRequestRepositoryIMockRecorder) Save(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockErasureRequestRepositoryI)(nil).Save), ctx, request)
+}
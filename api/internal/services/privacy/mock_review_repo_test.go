@@ -0,0 +1,130 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../../../../internal/shared/repositories/review/review_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=../../../../internal/shared/repositories/review/review_repository.go -destination=mock_review_repo_test.go -package=privacy
+//
+
+// Package privacy is a generated GoMock package.
+package privacy
+
+import (
+	models "commerce/internal/shared/models"
+	query "commerce/internal/shared/repositories/query"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReviewRepositoryI is a mock of ReviewRepositoryI interface.
+type MockReviewRepositoryI struct {
+	ctrl     *gomock.Controller
+	recorder *MockReviewRepositoryIMockRecorder
+	isgomock struct{}
+}
+
+// MockReviewRepositoryIMockRecorder is the mock recorder for MockReviewRepositoryI.
+type MockReviewRepositoryIMockRecorder struct {
+	mock *MockReviewRepositoryI
+}
+
+// NewMockReviewRepositoryI creates a new mock instance.
+func NewMockReviewRepositoryI(ctrl *gomock.Controller) *MockReviewRepositoryI {
+	mock := &MockReviewRepositoryI{ctrl: ctrl}
+	mock.recorder = &MockReviewRepositoryIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReviewRepositoryI) EXPECT() *MockReviewRepositoryIMockRecorder {
+	return m.recorder
+}
+
+// AnonymizeByUserId mocks base method.
+func (m *MockReviewRepositoryI) AnonymizeByUserId(ctx context.Context, userId uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnonymizeByUserId", ctx, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AnonymizeByUserId indicates an expected call of AnonymizeByUserId.
+func (mr *MockReviewRepositoryIMockRecorder) AnonymizeByUserId(ctx, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnonymizeByUserId", reflect.TypeOf((*MockReviewRepositoryI)(nil).AnonymizeByUserId), ctx, userId)
+}
+
+// Delete mocks base method.
+func (m *MockReviewRepositoryI) Delete(ctx context.Context, id uint, hard bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, hard)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockReviewRepositoryIMockRecorder) Delete(ctx, id, hard any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockReviewRepositoryI)(nil).Delete), ctx, id, hard)
+}
+
+// GetAllByUserId mocks base method.
+func (m *MockReviewRepositoryI) GetAllByUserId(ctx context.Context, userId uint, opts query.Options) (*query.Page[models.Review], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllByUserId", ctx, userId, opts)
+	ret0, _ := ret[0].(*query.Page[models.Review])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllByUserId indicates an expected call of GetAllByUserId.
+func (mr *MockReviewRepositoryIMockRecorder) GetAllByUserId(ctx, userId, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByUserId", reflect.TypeOf((*MockReviewRepositoryI)(nil).GetAllByUserId), ctx, userId, opts)
+}
+
+// GetById mocks base method.
+func (m *MockReviewRepositoryI) GetById(ctx context.Context, id uint) (*models.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(*models.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockReviewRepositoryIMockRecorder) GetById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockReviewRepositoryI)(nil).GetById), ctx, id)
+}
+
+// GetByProductId mocks base method.
+func (m *MockReviewRepositoryI) GetByProductId(ctx context.Context, productId uint, opts query.Options) (*query.Page[models.Review], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByProductId", ctx, productId, opts)
+	ret0, _ := ret[0].(*query.Page[models.Review])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByProductId indicates an expected call of GetByProductId.
+func (mr *MockReviewRepositoryIMockRecorder) GetByProductId(ctx, productId, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByProductId", reflect.TypeOf((*MockReviewRepositoryI)(nil).GetByProductId), ctx, productId, opts)
+}
+
+// Save mocks base method.
+func (m *MockReviewRepositoryI) Save(ctx context.Context, review *models.Review) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, review)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockReviewRepositoryIMockRecorder) Save(ctx, review any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockReviewRepositoryI)(nil).Save), ctx, review)
+}
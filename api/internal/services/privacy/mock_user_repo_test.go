@@ -0,0 +1,146 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../../../../internal/shared/repositories/user/user_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=../../../../internal/shared/repositories/user/user_repository.go -destination=mock_user_repo_test.go -package=privacy
+//
+
+// Package privacy is a generated GoMock package.
+package privacy
+
+import (
+	models "commerce/internal/shared/models"
+	query "commerce/internal/shared/repositories/query"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUserRepositoryI is a mock of UserRepositoryI interface.
+type MockUserRepositoryI struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserRepositoryIMockRecorder
+	isgomock struct{}
+}
+
+// MockUserRepositoryIMockRecorder is the mock recorder for MockUserRepositoryI.
+type MockUserRepositoryIMockRecorder struct {
+	mock *MockUserRepositoryI
+}
+
+// NewMockUserRepositoryI creates a new mock instance.
+func NewMockUserRepositoryI(ctrl *gomock.Controller) *MockUserRepositoryI {
+	mock := &MockUserRepositoryI{ctrl: ctrl}
+	mock.recorder = &MockUserRepositoryIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserRepositoryI) EXPECT() *MockUserRepositoryIMockRecorder {
+	return m.recorder
+}
+
+// Anonymize mocks base method.
+func (m *MockUserRepositoryI) Anonymize(ctx context.Context, id uint, erasedDate time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Anonymize", ctx, id, erasedDate)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Anonymize indicates an expected call of Anonymize.
+func (mr *MockUserRepositoryIMockRecorder) Anonymize(ctx, id, erasedDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Anonymize", reflect.TypeOf((*MockUserRepositoryI)(nil).Anonymize), ctx, id, erasedDate)
+}
+
+// Delete mocks base method.
+func (m *MockUserRepositoryI) Delete(ctx context.Context, id uint, hard bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, hard)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockUserRepositoryIMockRecorder) Delete(ctx, id, hard any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUserRepositoryI)(nil).Delete), ctx, id, hard)
+}
+
+// GetAll mocks base method.
+func (m *MockUserRepositoryI) GetAll(ctx context.Context, opts query.Options) (*query.Page[models.User], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, opts)
+	ret0, _ := ret[0].(*query.Page[models.User])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockUserRepositoryIMockRecorder) GetAll(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockUserRepositoryI)(nil).GetAll), ctx, opts)
+}
+
+// GetByAuthSub mocks base method.
+func (m *MockUserRepositoryI) GetByAuthSub(ctx context.Context, sub string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByAuthSub", ctx, sub)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByAuthSub indicates an expected call of GetByAuthSub.
+func (mr *MockUserRepositoryIMockRecorder) GetByAuthSub(ctx, sub any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByAuthSub", reflect.TypeOf((*MockUserRepositoryI)(nil).GetByAuthSub), ctx, sub)
+}
+
+// GetByEmail mocks base method.
+func (m *MockUserRepositoryI) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByEmail", ctx, email)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByEmail indicates an expected call of GetByEmail.
+func (mr *MockUserRepositoryIMockRecorder) GetByEmail(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEmail", reflect.TypeOf((*MockUserRepositoryI)(nil).GetByEmail), ctx, email)
+}
+
+// GetById mocks base method.
+func (m *MockUserRepositoryI) GetById(ctx context.Context, id uint) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockUserRepositoryIMockRecorder) GetById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockUserRepositoryI)(nil).GetById), ctx, id)
+}
+
+// Save mocks base method.
+func (m *MockUserRepositoryI) Save(ctx context.Context, user *models.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockUserRepositoryIMockRecorder) Save(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockUserRepositoryI)(nil).Save), ctx, user)
+}
@@ -0,0 +1,221 @@
+package privacy
+
+import (
+	address_dto "commerce/api/internal/dto/address"
+	order_dto "commerce/api/internal/dto/order"
+	payment_dto "commerce/api/internal/dto/payment"
+	dto "commerce/api/internal/dto/privacy"
+	review_dto "commerce/api/internal/dto/review"
+	user_dto "commerce/api/internal/dto/user"
+	"commerce/internal/shared/models"
+	address_repo "commerce/internal/shared/repositories/address"
+	erasure_repo "commerce/internal/shared/repositories/erasure-request"
+	order_repo "commerce/internal/shared/repositories/order"
+	payment_repo "commerce/internal/shared/repositories/payment"
+	"commerce/internal/shared/repositories/query"
+	review_repo "commerce/internal/shared/repositories/review"
+	"commerce/internal/shared/repositories/uow"
+	user_repo "commerce/internal/shared/repositories/user"
+	"context"
+	"log/slog"
+	"time"
+)
+
+type PrivacyServiceI interface {
+	Export(ctx context.Context, userId uint) (*dto.Export, error)
+	RequestErasure(ctx context.Context, userId uint, requestedBy string) (*dto.ErasureRequest, error)
+	GetErasures(ctx context.Context, userId uint) ([]*dto.ErasureRequest, error)
+	ProcessErasures(ctx context.Context) error
+}
+
+type PrivacyService struct {
+	userRepo    user_repo.UserRepositoryI
+	addressRepo address_repo.AddressRepositoryI
+	orderRepo   order_repo.OrderRepositoryI
+	paymentRepo payment_repo.PaymentRepositoryI
+	reviewRepo  review_repo.ReviewRepositoryI
+	erasureRepo erasure_repo.ErasureRequestRepositoryI
+	uow         uow.UnitOfWorkI
+	now         func() time.Time
+}
+
+func NewPrivacyService(userRepo user_repo.UserRepositoryI,
+	addressRepo address_repo.AddressRepositoryI,
+	orderRepo order_repo.OrderRepositoryI,
+	paymentRepo payment_repo.PaymentRepositoryI,
+	reviewRepo review_repo.ReviewRepositoryI,
+	erasureRepo erasure_repo.ErasureRequestRepositoryI,
+	uow uow.UnitOfWorkI,
+	now func() time.Time) PrivacyServiceI {
+	return &PrivacyService{
+		userRepo:    userRepo,
+		addressRepo: addressRepo,
+		orderRepo:   orderRepo,
+		paymentRepo: paymentRepo,
+		reviewRepo:  reviewRepo,
+		erasureRepo: erasureRepo,
+		uow:         uow,
+		now:         now,
+	}
+}
+
+// Export implements [PrivacyServiceI].
+func (p *PrivacyService) Export(ctx context.Context, userId uint) (*dto.Export, error) {
+	user, err := p.userRepo.GetById(ctx, userId)
+	if err != nil {
+		slog.Error("Exception occurred getting user for export.", "user-id", userId, "error", err)
+		return nil, err
+	}
+	addresses, err := all(func(opts query.Options) (*query.Page[models.Address], error) {
+		return p.addressRepo.GetByUserId(ctx, userId, opts)
+	})
+	if err != nil {
+		slog.Error("Exception occurred getting addresses for export.", "user-id", userId, "error", err)
+		return nil, err
+	}
+	// Deleted orders are still data held about the user.
+	orders, err := all(func(opts query.Options) (*query.Page[models.Order], error) {
+		opts.IncludeDeleted = true
+		return p.orderRepo.GetAllByUserId(ctx, userId, opts)
+	})
+	if err != nil {
+		slog.Error("Exception occurred getting orders for export.", "user-id", userId, "error", err)
+		return nil, err
+	}
+	reviews, err := all(func(opts query.Options) (*query.Page[models.Review], error) {
+		return p.reviewRepo.GetAllByUserId(ctx, userId, opts)
+	})
+	if err != nil {
+		slog.Error("Exception occurred getting reviews for export.", "user-id", userId, "error", err)
+		return nil, err
+	}
+
+	export := &dto.Export{
+		ExportedDate: p.now(),
+		Profile:      user_dto.FromModel(user),
+		Addresses:    make([]*address_dto.Address, 0, len(addresses)),
+		Orders:       make([]*order_dto.Order, 0, len(orders)),
+		Payments:     []*payment_dto.Payment{},
+		Reviews:      review_dto.FromAllModels(reviews),
+	}
+	for _, address := range addresses {
+		export.Addresses = append(export.Addresses, address_dto.FromModel(address))
+	}
+	for _, order := range orders {
+		export.Orders = append(export.Orders, order_dto.FromModel(order))
+		payments, err := p.paymentRepo.GetByOrder(ctx, order.Id)
+		if err != nil {
+			slog.Error("Exception occurred getting payments for export.", "order-id", order.Id, "error", err)
+			return nil, err
+		}
+		for _, payment := range payments {
+			export.Payments = append(export.Payments, payment_dto.FromModel(payment))
+		}
+	}
+	return export, nil
+}
+
+// all reads every page of a list, for an export that must hold all of it.
+func all[M any](list func(opts query.Options) (*query.Page[M], error)) ([]*M, error) {
+	opts := query.Options{Limit: query.MaxLimit}
+	var items []*M
+	for {
+		page, err := list(opts)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, page.Items...)
+		if page.NextCursor == "" {
+			return items, nil
+		}
+		opts.Cursor = page.NextCursor
+	}
+}
+
+// RequestErasure implements [PrivacyServiceI]. Asking again while a request
+// is pending returns that request rather than queueing another.
+func (p *PrivacyService) RequestErasure(ctx context.Context, userId uint, requestedBy string) (*dto.ErasureRequest, error) {
+	if _, err := p.userRepo.GetById(ctx, userId); err != nil {
+		slog.Error("Exception occurred getting user for erasure.", "user-id", userId, "error", err)
+		return nil, err
+	}
+	existing, err := p.erasureRepo.GetByUserId(ctx, userId)
+	if err != nil {
+		slog.Error("Exception occurred getting erasure requests.", "user-id", userId, "error", err)
+		return nil, err
+	}
+	for _, request := range existing {
+		if request.Status == models.ErasureStatusPending {
+			return dto.FromModel(request), nil
+		}
+	}
+	request := &models.ErasureRequest{
+		UserId:      userId,
+		RequestedBy: requestedBy,
+		Status:      models.ErasureStatusPending,
+	}
+	if err := p.erasureRepo.Save(ctx, request); err != nil {
+		slog.Error("Exception occurred saving erasure request.", "user-id", userId, "error", err)
+		return nil, err
+	}
+	slog.Info("Erasure requested.", "request-id", request.Id, "user-id", userId, "requested-by", requestedBy)
+	return dto.FromModel(request), nil
+}
+
+// GetErasures implements [PrivacyServiceI].
+func (p *PrivacyService) GetErasures(ctx context.Context, userId uint) ([]*dto.ErasureRequest, error) {
+	requests, err := p.erasureRepo.GetByUserId(ctx, userId)
+	if err != nil {
+		slog.Error("Exception occurred getting erasure requests.", "user-id", userId, "error", err)
+		return nil, err
+	}
+	erasures := make([]*dto.ErasureRequest, len(requests))
+	for i, request := range requests {
+		erasures[i] = dto.FromModel(request)
+	}
+	return erasures, nil
+}
+
+// ProcessErasures implements [PrivacyServiceI]. Each user is anonymized in
+// its own transaction together with marking the request completed, so a
+// request is never recorded as done unless the data is gone. A failure is
+// recorded on the request and doesn't stop the others.
+func (p *PrivacyService) ProcessErasures(ctx context.Context) error {
+	pending, err := p.erasureRepo.GetPending(ctx)
+	if err != nil {
+		slog.Error("Exception occurred getting pending erasures.", "error", err)
+		return err
+	}
+	for _, request := range pending {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		now := p.now()
+		err := p.uow.Do(ctx, func(r *uow.Repositories) error {
+			if err := r.Users.Anonymize(ctx, request.UserId, now); err != nil {
+				return err
+			}
+			if err := r.Addresses.AnonymizeByUserId(ctx, request.UserId, now); err != nil {
+				return err
+			}
+			if err := r.Reviews.AnonymizeByUserId(ctx, request.UserId); err != nil {
+				return err
+			}
+			request.Status = models.ErasureStatusCompleted
+			request.CompletedDate = &now
+			return r.ErasureRequests.Save(ctx, request)
+		})
+		if err != nil {
+			slog.Error("Exception occurred erasing user.", "request-id", request.Id, "user-id", request.UserId, "error", err)
+			request.Status = models.ErasureStatusFailed
+			request.CompletedDate = nil
+			request.Error = err.Error()
+			if saveErr := p.erasureRepo.Save(ctx, request); saveErr != nil {
+				slog.Error("Exception occurred recording failed erasure.", "request-id", request.Id, "error", saveErr)
+			}
+			continue
+		}
+		slog.Info("User erased.", "request-id", request.Id, "user-id", request.UserId, "requested-by", request.RequestedBy, "completed-date", now)
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package privacy
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"commerce/internal/shared/models"
+	"commerce/internal/shared/repositories/query"
+	"commerce/internal/shared/repositories/uow"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var now = time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+type mocks struct {
+	userRepo    *MockUserRepositoryI
+	addressRepo *MockAddressRepositoryI
+	orderRepo   *MockOrderRepositoryI
+	paymentRepo *MockPaymentRepositoryI
+	reviewRepo  *MockReviewRepositoryI
+	erasureRepo *MockErasureRequestRepositoryI
+}
+
+func setup(t *testing.T) (*mocks, PrivacyServiceI) {
+	t.Helper()
+	ctl := gomock.NewController(t)
+	t.Cleanup(ctl.Finish)
+	m := &mocks{
+		userRepo:    NewMockUserRepositoryI(ctl),
+		addressRepo: NewMockAddressRepositoryI(ctl),
+		orderRepo:   NewMockOrderRepositoryI(ctl),
+		paymentRepo: NewMockPaymentRepositoryI(ctl),
+		reviewRepo:  NewMockReviewRepositoryI(ctl),
+		erasureRepo: NewMockErasureRequestRepositoryI(ctl),
+	}
+	mockUow := NewMockUnitOfWorkI(ctl)
+	mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, fn func(r *uow.Repositories) error) error {
+		return fn(&uow.Repositories{
+			Addresses:       m.addressRepo,
+			ErasureRequests: m.erasureRepo,
+			Reviews:         m.reviewRepo,
+			Users:           m.userRepo,
+		})
+	}).AnyTimes()
+	svc := NewPrivacyService(m.userRepo, m.addressRepo, m.orderRepo, m.paymentRepo, m.reviewRepo, m.erasureRepo, mockUow,
+		func() time.Time { return now })
+	return m, svc
+}
+
+func TestExport(t *testing.T) {
+	m, svc := setup(t)
+	m.userRepo.EXPECT().GetById(gomock.Any(), uint(7)).Return(&models.User{Base: models.Base{Id: 7}, Email: "jon.doe@example.com"}, nil)
+	m.addressRepo.EXPECT().GetByUserId(gomock.Any(), uint(7), gomock.Any()).Return(&query.Page[models.Address]{Items: []*models.Address{{Base: models.Base{Id: 3}, UserId: 7, City: "Denver"}}, Total: 1}, nil)
+	// Orders come back a page at a time; the export follows the cursor to the end.
+	m.orderRepo.EXPECT().GetAllByUserId(gomock.Any(), uint(7), query.Options{Limit: query.MaxLimit, IncludeDeleted: true}).
+		Return(&query.Page[models.Order]{Items: []*models.Order{{Base: models.Base{Id: 1}, UserId: 7}}, NextCursor: "next", Total: 2}, nil)
+	m.orderRepo.EXPECT().GetAllByUserId(gomock.Any(), uint(7), query.Options{Limit: query.MaxLimit, Cursor: "next", IncludeDeleted: true}).
+		Return(&query.Page[models.Order]{Items: []*models.Order{{Base: models.Base{Id: 2}, UserId: 7}}, Total: 2}, nil)
+	m.paymentRepo.EXPECT().GetByOrder(gomock.Any(), uint(1)).Return([]*models.Payment{{Base: models.Base{Id: 10}, OrderId: 1}}, nil)
+	m.paymentRepo.EXPECT().GetByOrder(gomock.Any(), uint(2)).Return([]*models.Payment{}, nil)
+	m.reviewRepo.EXPECT().GetAllByUserId(gomock.Any(), uint(7), gomock.Any()).Return(&query.Page[models.Review]{Items: []*models.Review{{Base: models.Base{Id: 5}, UserId: 7, Rating: 4}}, Total: 1}, nil)
+
+	export, err := svc.Export(context.Background(), 7)
+
+	require.NoError(t, err)
+	assert.Equal(t, now, export.ExportedDate)
+	assert.Equal(t, "jon.doe@example.com", export.Profile.Email)
+	assert.Len(t, export.Addresses, 1)
+	assert.Len(t, export.Orders, 2)
+	require.Len(t, export.Payments, 1)
+	assert.Equal(t, uint(10), export.Payments[0].Id)
+	assert.Len(t, export.Reviews, 1)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteZip(&buf, export))
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	var names []string
+	for _, f := range archive.File {
+		names = append(names, f.Name)
+	}
+	assert.Equal(t, []string{"profile.json", "addresses.json", "orders.json", "payments.json", "reviews.json"}, names)
+}
+
+func TestExport_UnknownUser_ReturnsError(t *testing.T) {
+	m, svc := setup(t)
+	m.userRepo.EXPECT().GetById(gomock.Any(), uint(7)).Return(nil, errors.New("record not found"))
+
+	export, err := svc.Export(context.Background(), 7)
+
+	assert.Error(t, err)
+	assert.Nil(t, export)
+}
+
+func TestRequestErasure_QueuesRequest(t *testing.T) {
+	m, svc := setup(t)
+	m.userRepo.EXPECT().GetById(gomock.Any(), uint(7)).Return(&models.User{Base: models.Base{Id: 7}}, nil)
+	m.erasureRepo.EXPECT().GetByUserId(gomock.Any(), uint(7)).Return(nil, nil)
+	m.erasureRepo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, r *models.ErasureRequest) error {
+		assert.Equal(t, models.ErasureStatusPending, r.Status)
+		assert.Equal(t, "auth0|abc", r.RequestedBy)
+		r.Id = 1
+		return nil
+	})
+
+	request, err := svc.RequestErasure(context.Background(), 7, "auth0|abc")
+
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), request.Id)
+	assert.Equal(t, "pending", request.Status)
+}
+
+func TestRequestErasure_AlreadyPending_ReturnsExisting(t *testing.T) {
+	m, svc := setup(t)
+	m.userRepo.EXPECT().GetById(gomock.Any(), uint(7)).Return(&models.User{Base: models.Base{Id: 7}}, nil)
+	m.erasureRepo.EXPECT().GetByUserId(gomock.Any(), uint(7)).Return([]*models.ErasureRequest{
+		{Base: models.Base{Id: 4}, UserId: 7, Status: models.ErasureStatusPending},
+	}, nil)
+	// Save must NOT be called.
+
+	request, err := svc.RequestErasure(context.Background(), 7, "auth0|abc")
+
+	require.NoError(t, err)
+	assert.Equal(t, uint(4), request.Id)
+}
+
+func TestProcessErasures_AnonymizesAndCompletes(t *testing.T) {
+	m, svc := setup(t)
+	request := &models.ErasureRequest{Base: models.Base{Id: 4}, UserId: 7, Status: models.ErasureStatusPending}
+	m.erasureRepo.EXPECT().GetPending(gomock.Any()).Return([]*models.ErasureRequest{request}, nil)
+	gomock.InOrder(
+		m.userRepo.EXPECT().Anonymize(gomock.Any(), uint(7), now).Return(nil),
+		m.addressRepo.EXPECT().AnonymizeByUserId(gomock.Any(), uint(7), now).Return(nil),
+		m.reviewRepo.EXPECT().AnonymizeByUserId(gomock.Any(), uint(7)).Return(nil),
+		m.erasureRepo.EXPECT().Save(gomock.Any(), request).Return(nil),
+	)
+
+	require.NoError(t, svc.ProcessErasures(context.Background()))
+
+	assert.Equal(t, models.ErasureStatusCompleted, request.Status)
+	require.NotNil(t, request.CompletedDate)
+	assert.Equal(t, now, *request.CompletedDate)
+}
+
+func TestProcessErasures_Failure_RecordedAndOthersContinue(t *testing.T) {
+	m, svc := setup(t)
+	failing := &models.ErasureRequest{Base: models.Base{Id: 4}, UserId: 7, Status: models.ErasureStatusPending}
+	next := &models.ErasureRequest{Base: models.Base{Id: 5}, UserId: 8, Status: models.ErasureStatusPending}
+	m.erasureRepo.EXPECT().GetPending(gomock.Any()).Return([]*models.ErasureRequest{failing, next}, nil)
+	m.userRepo.EXPECT().Anonymize(gomock.Any(), uint(7), now).Return(errors.New("record not found"))
+	m.erasureRepo.EXPECT().Save(gomock.Any(), failing).Return(nil)
+	m.userRepo.EXPECT().Anonymize(gomock.Any(), uint(8), now).Return(nil)
+	m.addressRepo.EXPECT().AnonymizeByUserId(gomock.Any(), uint(8), now).Return(nil)
+	m.reviewRepo.EXPECT().AnonymizeByUserId(gomock.Any(), uint(8)).Return(nil)
+	m.erasureRepo.EXPECT().Save(gomock.Any(), next).Return(nil)
+
+	require.NoError(t, svc.ProcessErasures(context.Background()))
+
+	assert.Equal(t, models.ErasureStatusFailed, failing.Status)
+	assert.Equal(t, "record not found", failing.Error)
+	assert.Nil(t, failing.CompletedDate)
+	assert.Equal(t, models.ErasureStatusCompleted, next.Status)
+}
@@ -0,0 +1,114 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../../../../internal/shared/repositories/product-image/product_image_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=../../../../internal/shared/repositories/product-image/product_image_repository.go -destination=mock_product_image_repo_test.go -package=productimage
+//
+
+// Package productimage is a generated GoMock package.
+package productimage
+
+import (
+	models "commerce/internal/shared/models"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockProductImageRepositoryI is a mock of ProductImageRepositoryI interface.
+type MockProductImageRepositoryI struct {
+	ctrl     *gomock.Controller
+	recorder *MockProductImageRepositoryIMockRecorder
+	isgomock struct{}
+}
+
+// MockProductImageRepositoryIMockRecorder is the mock recorder for MockProductImageRepositoryI.
+type MockProductImageRepositoryIMockRecorder struct {
+	mock *MockProductImageRepositoryI
+}
+
+// NewMockProductImageRepositoryI creates a new mock instance.
+func NewMockProductImageRepositoryI(ctrl *gomock.Controller) *MockProductImageRepositoryI {
+	mock := &MockProductImageRepositoryI{ctrl: ctrl}
+	mock.recorder = &MockProductImageRepositoryIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProductImageRepositoryI) EXPECT() *MockProductImageRepositoryIMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockProductImageRepositoryI) Delete(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockProductImageRepositoryIMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockProductImageRepositoryI)(nil).Delete), ctx, id)
+}
+
+// GetAllByProductId mocks base method.
+func (m *MockProductImageRepositoryI) GetAllByProductId(ctx context.Context, productId uint) ([]*models.ProductImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllByProductId", ctx, productId)
+	ret0, _ := ret[0].([]*models.ProductImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllByProductId indicates an expected call of GetAllByProductId.
+func (mr *MockProductImageRepositoryIMockRecorder) GetAllByProductId(ctx, productId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByProductId", reflect.TypeOf((*MockProductImageRepositoryI)(nil).GetAllByProductId), ctx, productId)
+}
+
+// GetById mocks base method.
+func (m *MockProductImageRepositoryI) GetById(ctx context.Context, id uint) (*models.ProductImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(*models.ProductImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockProductImageRepositoryIMockRecorder) GetById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockProductImageRepositoryI)(nil).GetById), ctx, id)
+}
+
+// Save mocks base method.
+func (m *MockProductImageRepositoryI) Save(ctx context.Context, image *models.ProductImage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, image)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockProductImageRepositoryIMockRecorder) Save(ctx, image any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockProductImageRepositoryI)(nil).Save), ctx, image)
+}
+
+// SaveAll mocks base method.
+func (m *MockProductImageRepositoryI) SaveAll(ctx context.Context, images []*models.ProductImage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAll", ctx, images)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveAll indicates an expected call of SaveAll.
+func (mr *MockProductImageRepositoryIMockRecorder) SaveAll(ctx, images any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAll", reflect.TypeOf((*MockProductImageRepositoryI)(nil).SaveAll), ctx, images)
+}
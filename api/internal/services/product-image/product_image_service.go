@@ -0,0 +1,221 @@
+package productimage
+
+import (
+	"bytes"
+	dto "commerce/api/internal/dto/product"
+	"commerce/api/internal/imaging"
+	"commerce/api/internal/storage"
+	"commerce/internal/shared/models"
+	product_repo "commerce/internal/shared/repositories/product"
+	repo "commerce/internal/shared/repositories/product-image"
+	"context"
+	"fmt"
+	"image"
+	"log/slog"
+	"slices"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ProductImageServiceI interface {
+	GetAll(ctx context.Context, productId uint) ([]dto.Image, error)
+	Upload(ctx context.Context, productId uint, data []byte, upload dto.ImageUpload) (*dto.Image, error)
+	Update(ctx context.Context, productId uint, imageId uint, image *dto.Image) error
+	Delete(ctx context.Context, productId uint, imageId uint) error
+}
+
+type ProductImageService struct {
+	productRepo product_repo.ProductRepositoryI
+	repo        repo.ProductImageRepositoryI
+	store       storage.BlobStore
+}
+
+func NewProductImageService(productRepo product_repo.ProductRepositoryI,
+	repo repo.ProductImageRepositoryI,
+	store storage.BlobStore) ProductImageServiceI {
+	return &ProductImageService{productRepo: productRepo, repo: repo, store: store}
+}
+
+// GetAll implements [ProductImageServiceI].
+func (s *ProductImageService) GetAll(ctx context.Context, productId uint) ([]dto.Image, error) {
+	if _, err := s.productRepo.GetById(ctx, productId); err != nil {
+		return nil, err
+	}
+	images, err := s.repo.GetAllByProductId(ctx, productId)
+	if err != nil {
+		slog.Error("Exception occurred getting product images.", "product-id", productId, "error", err)
+		return nil, err
+	}
+	dtos := make([]dto.Image, len(images))
+	for i, image := range images {
+		dtos[i] = *dto.ImageFromModel(image, s.store.URL)
+	}
+	return dtos, nil
+}
+
+// Upload implements [ProductImageServiceI]. The image is stored with a
+// thumbnail of each of [models.ThumbnailSizes] and added after the product's
+// other images. A product's first image is its primary one. Data that isn't
+// a JPEG, PNG or GIF fails with [imaging.ErrUnsupported].
+func (s *ProductImageService) Upload(ctx context.Context, productId uint, data []byte, upload dto.ImageUpload) (*dto.Image, error) {
+	if _, err := s.productRepo.GetById(ctx, productId); err != nil {
+		return nil, err
+	}
+	img, format, err := imaging.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	images, err := s.repo.GetAllByProductId(ctx, productId)
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := img.Bounds()
+	model := &models.ProductImage{
+		ProductId:   productId,
+		Path:        fmt.Sprintf("products/%d/%s%s", productId, uuid.NewString(), imaging.Extension(format)),
+		AltText:     upload.AltText,
+		Position:    len(images),
+		IsPrimary:   upload.IsPrimary || len(images) == 0,
+		ContentType: imaging.ContentType(format),
+		Width:       bounds.Dx(),
+		Height:      bounds.Dy(),
+	}
+	if err := s.putFiles(ctx, model, data, img); err != nil {
+		slog.Error("Exception occurred storing product image.", "product-id", productId, "path", model.Path, "error", err)
+		s.deleteFiles(ctx, model)
+		return nil, err
+	}
+	if err := s.repo.Save(ctx, model); err != nil {
+		slog.Error("Exception occurred saving product image.", "product-id", productId, "error", err)
+		s.deleteFiles(ctx, model)
+		return nil, err
+	}
+	if model.IsPrimary {
+		var demoted []*models.ProductImage
+		for _, other := range images {
+			if other.IsPrimary {
+				other.IsPrimary = false
+				demoted = append(demoted, other)
+			}
+		}
+		if len(demoted) > 0 {
+			if err := s.repo.SaveAll(ctx, demoted); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return dto.ImageFromModel(model, s.store.URL), nil
+}
+
+// Update implements [ProductImageServiceI]. It changes the image's alt text,
+// moves it to its position among the product's images and can make it the
+// primary image. Unmarking the primary image passes that on to the first of
+// the others, so a product with images always has one primary image.
+func (s *ProductImageService) Update(ctx context.Context, productId uint, imageId uint, image *dto.Image) error {
+	images, err := s.repo.GetAllByProductId(ctx, productId)
+	if err != nil {
+		return err
+	}
+	i := slices.IndexFunc(images, func(m *models.ProductImage) bool { return m.Id == imageId })
+	if i < 0 {
+		return gorm.ErrRecordNotFound
+	}
+	target := images[i]
+	target.AltText = image.AltText
+
+	images = slices.Delete(images, i, i+1)
+	images = slices.Insert(images, min(image.Position, len(images)), target)
+	if image.IsPrimary {
+		for _, other := range images {
+			other.IsPrimary = other == target
+		}
+	} else if target.IsPrimary && len(images) > 1 {
+		target.IsPrimary = false
+		next := images[0]
+		if next == target {
+			next = images[1]
+		}
+		next.IsPrimary = true
+	}
+	renumber(images)
+
+	if err := s.repo.SaveAll(ctx, images); err != nil {
+		slog.Error("Exception occurred updating product image.", "product-id", productId, "image-id", imageId, "error", err)
+		return err
+	}
+	*image = *dto.ImageFromModel(target, s.store.URL)
+	return nil
+}
+
+// Delete implements [ProductImageServiceI]. The image's files are deleted
+// with it, and the images after it move up. Deleting the primary image makes
+// the first of the rest primary.
+func (s *ProductImageService) Delete(ctx context.Context, productId uint, imageId uint) error {
+	images, err := s.repo.GetAllByProductId(ctx, productId)
+	if err != nil {
+		return err
+	}
+	i := slices.IndexFunc(images, func(m *models.ProductImage) bool { return m.Id == imageId })
+	if i < 0 {
+		return gorm.ErrRecordNotFound
+	}
+	target := images[i]
+	if err := s.repo.Delete(ctx, imageId); err != nil {
+		slog.Error("Exception occurred deleting product image.", "product-id", productId, "image-id", imageId, "error", err)
+		return err
+	}
+	s.deleteFiles(ctx, target)
+
+	rest := slices.Delete(images, i, i+1)
+	if len(rest) == 0 {
+		return nil
+	}
+	if target.IsPrimary {
+		rest[0].IsPrimary = true
+	}
+	renumber(rest)
+	return s.repo.SaveAll(ctx, rest)
+}
+
+// putFiles stores the uploaded file and its thumbnails.
+func (s *ProductImageService) putFiles(ctx context.Context, model *models.ProductImage, data []byte, img image.Image) error {
+	if err := s.store.Put(ctx, model.Path, bytes.NewReader(data), model.ContentType); err != nil {
+		return err
+	}
+	format := "png"
+	if model.ContentType == "image/jpeg" {
+		format = "jpeg"
+	}
+	for size, pixels := range models.ThumbnailSizes {
+		var buf bytes.Buffer
+		if err := imaging.Encode(&buf, imaging.Fit(img, pixels), format); err != nil {
+			return err
+		}
+		if err := s.store.Put(ctx, model.ThumbnailPath(size), &buf, imaging.ContentType(format)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteFiles removes the image's files from the store. Failures are only
+// logged: the image is gone either way, and a leftover file harms nothing.
+func (s *ProductImageService) deleteFiles(ctx context.Context, model *models.ProductImage) {
+	paths := []string{model.Path}
+	for size := range models.ThumbnailSizes {
+		paths = append(paths, model.ThumbnailPath(size))
+	}
+	for _, path := range paths {
+		if err := s.store.Delete(ctx, path); err != nil {
+			slog.Error("Exception occurred deleting product image file.", "path", path, "error", err)
+		}
+	}
+}
+
+func renumber(images []*models.ProductImage) {
+	for i, image := range images {
+		image.Position = i
+	}
+}
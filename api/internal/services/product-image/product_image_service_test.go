@@ -0,0 +1,211 @@
+package productimage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+
+	dto "commerce/api/internal/dto/product"
+	"commerce/api/internal/imaging"
+	"commerce/internal/shared/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+type mocks struct {
+	productRepo *MockProductRepositoryI
+	repo        *MockProductImageRepositoryI
+	store       *MockBlobStore
+}
+
+func setup(t *testing.T) (*mocks, ProductImageServiceI) {
+	t.Helper()
+	ctl := gomock.NewController(t)
+	t.Cleanup(ctl.Finish)
+	m := &mocks{
+		productRepo: NewMockProductRepositoryI(ctl),
+		repo:        NewMockProductImageRepositoryI(ctl),
+		store:       NewMockBlobStore(ctl),
+	}
+	m.store.EXPECT().URL(gomock.Any()).DoAndReturn(func(key string) string { return "/media/" + key }).AnyTimes()
+	return m, NewProductImageService(m.productRepo, m.repo, m.store)
+}
+
+func pngData(t *testing.T, w int, h int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, w, h))))
+	return buf.Bytes()
+}
+
+// images are a product's three images, the first of them primary.
+func images() []*models.ProductImage {
+	return []*models.ProductImage{
+		{Base: models.Base{Id: 1}, ProductId: 7, Path: "products/7/a.png", ContentType: "image/png", Position: 0, IsPrimary: true},
+		{Base: models.Base{Id: 2}, ProductId: 7, Path: "products/7/b.png", ContentType: "image/png", Position: 1},
+		{Base: models.Base{Id: 3}, ProductId: 7, Path: "products/7/c.png", ContentType: "image/png", Position: 2},
+	}
+}
+
+func ids(images []*models.ProductImage) []uint {
+	result := make([]uint, len(images))
+	for i, image := range images {
+		result[i] = image.Id
+	}
+	return result
+}
+
+func TestUploadStoresFileAndThumbnails(t *testing.T) {
+	m, s := setup(t)
+	ctx := context.Background()
+	m.productRepo.EXPECT().GetById(ctx, uint(7)).Return(&models.Product{Base: models.Base{Id: 7}}, nil)
+	m.repo.EXPECT().GetAllByProductId(ctx, uint(7)).Return(nil, nil)
+	var keys []string
+	m.store.EXPECT().Put(ctx, gomock.Any(), gomock.Any(), "image/png").
+		DoAndReturn(func(_ context.Context, key string, _ any, _ string) error {
+			keys = append(keys, key)
+			return nil
+		}).Times(1 + len(models.ThumbnailSizes))
+	m.repo.EXPECT().Save(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, image *models.ProductImage) error {
+		image.Id = 5
+		return nil
+	})
+
+	image, err := s.Upload(ctx, 7, pngData(t, 800, 400), dto.ImageUpload{AltText: "front"})
+
+	require.NoError(t, err)
+	assert.Equal(t, uint(5), image.Id)
+	assert.True(t, image.IsPrimary, "a product's first image is primary")
+	assert.Equal(t, 0, image.Position)
+	assert.Equal(t, "front", image.AltText)
+	assert.Equal(t, 800, image.Width)
+	assert.True(t, strings.HasPrefix(image.Url, "/media/products/7/"))
+	assert.True(t, strings.HasSuffix(image.Url, ".png"))
+	assert.Len(t, image.Thumbnails, len(models.ThumbnailSizes))
+	assert.Equal(t, "/media/"+keys[0], image.Url)
+	for _, url := range image.Thumbnails {
+		assert.Contains(t, keys, strings.TrimPrefix(url, "/media/"))
+	}
+}
+
+func TestUploadAsPrimaryDemotesTheOthers(t *testing.T) {
+	m, s := setup(t)
+	ctx := context.Background()
+	existing := images()
+	m.productRepo.EXPECT().GetById(ctx, uint(7)).Return(&models.Product{Base: models.Base{Id: 7}}, nil)
+	m.repo.EXPECT().GetAllByProductId(ctx, uint(7)).Return(existing, nil)
+	m.store.EXPECT().Put(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	m.repo.EXPECT().Save(ctx, gomock.Any()).Return(nil)
+	m.repo.EXPECT().SaveAll(ctx, []*models.ProductImage{existing[0]}).Return(nil)
+
+	image, err := s.Upload(ctx, 7, pngData(t, 10, 10), dto.ImageUpload{IsPrimary: true})
+
+	require.NoError(t, err)
+	assert.True(t, image.IsPrimary)
+	assert.Equal(t, 3, image.Position)
+	assert.False(t, existing[0].IsPrimary)
+}
+
+func TestUploadRejectsOtherFiles(t *testing.T) {
+	m, s := setup(t)
+	ctx := context.Background()
+	m.productRepo.EXPECT().GetById(ctx, uint(7)).Return(&models.Product{Base: models.Base{Id: 7}}, nil)
+
+	_, err := s.Upload(ctx, 7, []byte("%PDF-1.7"), dto.ImageUpload{})
+
+	assert.ErrorIs(t, err, imaging.ErrUnsupported)
+}
+
+func TestUploadDeletesFilesWhenSaveFails(t *testing.T) {
+	m, s := setup(t)
+	ctx := context.Background()
+	m.productRepo.EXPECT().GetById(ctx, uint(7)).Return(&models.Product{Base: models.Base{Id: 7}}, nil)
+	m.repo.EXPECT().GetAllByProductId(ctx, uint(7)).Return(nil, nil)
+	m.store.EXPECT().Put(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1 + len(models.ThumbnailSizes))
+	m.repo.EXPECT().Save(ctx, gomock.Any()).Return(errors.New("db down"))
+	m.store.EXPECT().Delete(ctx, gomock.Any()).Return(nil).Times(1 + len(models.ThumbnailSizes))
+
+	_, err := s.Upload(ctx, 7, pngData(t, 10, 10), dto.ImageUpload{})
+
+	assert.Error(t, err)
+}
+
+func TestUpdateMovesImageAndMakesItPrimary(t *testing.T) {
+	m, s := setup(t)
+	ctx := context.Background()
+	var saved []*models.ProductImage
+	m.repo.EXPECT().GetAllByProductId(ctx, uint(7)).Return(images(), nil)
+	m.repo.EXPECT().SaveAll(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, images []*models.ProductImage) error {
+		saved = images
+		return nil
+	})
+
+	image := dto.Image{AltText: "back", Position: 0, IsPrimary: true}
+	require.NoError(t, s.Update(ctx, 7, 3, &image))
+
+	assert.Equal(t, []uint{3, 1, 2}, ids(saved))
+	for i, img := range saved {
+		assert.Equal(t, i, img.Position)
+		assert.Equal(t, img.Id == 3, img.IsPrimary, "image %d", img.Id)
+	}
+	assert.Equal(t, "back", image.AltText)
+	assert.Equal(t, "/media/products/7/c.png", image.Url)
+}
+
+func TestUpdateUnmarkingPrimaryPassesItOn(t *testing.T) {
+	m, s := setup(t)
+	ctx := context.Background()
+	var saved []*models.ProductImage
+	m.repo.EXPECT().GetAllByProductId(ctx, uint(7)).Return(images(), nil)
+	m.repo.EXPECT().SaveAll(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, images []*models.ProductImage) error {
+		saved = images
+		return nil
+	})
+
+	image := dto.Image{Position: 0}
+	require.NoError(t, s.Update(ctx, 7, 1, &image))
+
+	assert.False(t, saved[0].IsPrimary)
+	assert.True(t, saved[1].IsPrimary)
+	assert.False(t, image.IsPrimary)
+}
+
+func TestUpdateUnknownImage(t *testing.T) {
+	m, s := setup(t)
+	ctx := context.Background()
+	m.repo.EXPECT().GetAllByProductId(ctx, uint(7)).Return(images(), nil)
+
+	err := s.Update(ctx, 7, 99, &dto.Image{})
+
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestDeletePrimaryImagePromotesTheNext(t *testing.T) {
+	m, s := setup(t)
+	ctx := context.Background()
+	var saved []*models.ProductImage
+	m.repo.EXPECT().GetAllByProductId(ctx, uint(7)).Return(images(), nil)
+	m.repo.EXPECT().Delete(ctx, uint(1)).Return(nil)
+	m.store.EXPECT().Delete(ctx, "products/7/a.png").Return(nil)
+	for size := range models.ThumbnailSizes {
+		m.store.EXPECT().Delete(ctx, "products/7/a_"+size+".png").Return(nil)
+	}
+	m.repo.EXPECT().SaveAll(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, images []*models.ProductImage) error {
+		saved = images
+		return nil
+	})
+
+	require.NoError(t, s.Delete(ctx, 7, 1))
+
+	assert.Equal(t, []uint{2, 3}, ids(saved))
+	assert.True(t, saved[0].IsPrimary)
+	assert.Equal(t, 0, saved[0].Position)
+	assert.Equal(t, 1, saved[1].Position)
+}
@@ -0,0 +1,35 @@
+package productimport
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// ImportJob runs queued imports on a fixed interval until its context is
+// cancelled.
+type ImportJob struct {
+	svc      ProductImportServiceI
+	interval time.Duration
+}
+
+func NewImportJob(svc ProductImportServiceI, interval time.Duration) *ImportJob {
+	return &ImportJob{svc: svc, interval: interval}
+}
+
+func (j *ImportJob) Start(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+	slog.Info("Import job started.", "interval", j.interval)
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("Import job stopped.")
+			return
+		case <-ticker.C:
+			if err := j.svc.ProcessJobs(ctx); err != nil {
+				slog.Error("Exception occurred processing import jobs.", "error", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../../../../internal/shared/catalog/catalog.go
+//
+// Generated by this command:
+//
+//	mockgen -source=../../../../internal/shared/catalog/catalog.go -destination=mock_catalog_test.go -package=productimport
+//
+
+// Package productimport is a generated GoMock package.
+package productimport
+
+import (
+	catalog "commerce/internal/shared/catalog"
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCatalogI is a mock of CatalogI interface.
+type MockCatalogI struct {
+	ctrl     *gomock.Controller
+	recorder *MockCatalogIMockRecorder
+	isgomock struct{}
+}
+
+// MockCatalogIMockRecorder is the mock recorder for MockCatalogI.
+type MockCatalogIMockRecorder struct {
+	mock *MockCatalogI
+}
+
+// NewMockCatalogI creates a new mock instance.
+func NewMockCatalogI(ctrl *gomock.Controller) *MockCatalogI {
+	mock := &MockCatalogI{ctrl: ctrl}
+	mock.recorder = &MockCatalogIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCatalogI) EXPECT() *MockCatalogIMockRecorder {
+	return m.recorder
+}
+
+// Export mocks base method.
+func (m *MockCatalogI) Export(ctx context.Context, w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockCatalogIMockRecorder) Export(ctx, w any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockCatalogI)(nil).Export), ctx, w)
+}
+
+// Import mocks base method.
+func (m *MockCatalogI) Import(ctx context.Context, r io.Reader, dryRun bool) (*catalog.ImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", ctx, r, dryRun)
+	ret0, _ := ret[0].(*catalog.ImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockCatalogIMockRecorder) Import(ctx, r, dryRun any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockCatalogI)(nil).Import), ctx, r, dryRun)
+}
@@ -0,0 +1,87 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../../../../internal/shared/repositories/import-job/import_job_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=../../../../internal/shared/repositories/import-job/import_job_repository.go -destination=mock_import_job_repo_test.go -package=productimport
+//
+
+// Package productimport is a generated GoMock package.
+package productimport
+
+import (
+	models "commerce/internal/shared/models"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockImportJobRepositoryI is a mock of ImportJobRepositoryI interface.
+type MockImportJobRepositoryI struct {
+	ctrl     *gomock.Controller
+	recorder *MockImportJobRepositoryIMockRecorder
+	isgomock struct{}
+}
+
+// MockImportJobRepositoryIMockRecorder is the mock recorder for MockImportJobRepositoryI.
+type MockImportJobRepositoryIMockRecorder struct {
+	mock *MockImportJobRepositoryI
+}
+
+// NewMockImportJobRepositoryI creates a new mock instance.
+func NewMockImportJobRepositoryI(ctrl *gomock.Controller) *MockImportJobRepositoryI {
+	mock := &MockImportJobRepositoryI{ctrl: ctrl}
+	mock.recorder = &MockImportJobRepositoryIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImportJobRepositoryI) EXPECT() *MockImportJobRepositoryIMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *MockImportJobRepositoryI) Claim(ctx context.Context, now time.Time, lease time.Duration) (*models.ImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", ctx, now, lease)
+	ret0, _ := ret[0].(*models.ImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockImportJobRepositoryIMockRecorder) Claim(ctx, now, lease any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockImportJobRepositoryI)(nil).Claim), ctx, now, lease)
+}
+
+// GetById mocks base method.
+func (m *MockImportJobRepositoryI) GetById(ctx context.Context, id uint) (*models.ImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(*models.ImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockImportJobRepositoryIMockRecorder) GetById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockImportJobRepositoryI)(nil).GetById), ctx, id)
+}
+
+// Save mocks base method.
+func (m *MockImportJobRepositoryI) Save(ctx context.Context, job *models.ImportJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockImportJobRepositoryIMockRecorder) Save(ctx, job any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockImportJobRepositoryI)(nil).Save), ctx, job)
+}
@@ -0,0 +1,153 @@
+package productimport
+
+import (
+	"bytes"
+	dto "commerce/api/internal/dto/product"
+	"commerce/internal/shared/catalog"
+	"commerce/internal/shared/database"
+	"commerce/internal/shared/models"
+	import_job_repo "commerce/internal/shared/repositories/import-job"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// jobLease is how long a job can run before it is taken to have been cut off
+// and is claimed again. The import is one transaction, so a job that was cut
+// off has written nothing.
+const jobLease = time.Hour
+
+type ProductImportServiceI interface {
+	Import(ctx context.Context, data []byte, dryRun bool) (*dto.ImportResult, error)
+	Enqueue(ctx context.Context, data []byte, dryRun bool) (*dto.ImportJob, error)
+	GetJob(ctx context.Context, id uint) (*dto.ImportJob, error)
+	ProcessJobs(ctx context.Context) error
+	Export(ctx context.Context, w io.Writer) error
+}
+
+type ProductImportService struct {
+	catalog catalog.CatalogI
+	jobRepo import_job_repo.ImportJobRepositoryI
+	now     func() time.Time
+}
+
+func NewProductImportService(catalog catalog.CatalogI, jobRepo import_job_repo.ImportJobRepositoryI, now func() time.Time) ProductImportServiceI {
+	return &ProductImportService{catalog: catalog, jobRepo: jobRepo, now: now}
+}
+
+// Import implements [ProductImportServiceI]. Rows with errors come back in
+// the result rather than as an error.
+func (s *ProductImportService) Import(ctx context.Context, data []byte, dryRun bool) (*dto.ImportResult, error) {
+	result, err := s.catalog.Import(ctx, bytes.NewReader(data), dryRun)
+	if err != nil {
+		if !errors.Is(err, catalog.ErrInvalidFile) {
+			slog.Error("Exception occurred importing products.", "dry-run", dryRun, "error", err)
+		}
+		return nil, err
+	}
+	return dto.ImportResultFromCatalog(result), nil
+}
+
+// Enqueue implements [ProductImportServiceI]. Only the header is checked up
+// front; the rows are checked when the job runs. The job runs on behalf of
+// the actor in ctx.
+func (s *ProductImportService) Enqueue(ctx context.Context, data []byte, dryRun bool) (*dto.ImportJob, error) {
+	if err := catalog.CheckHeader(bytes.NewReader(data)); err != nil {
+		return nil, err
+	}
+	actor := database.ActorFrom(ctx)
+	job := &models.ImportJob{
+		Status:            models.ImportStatusPending,
+		DryRun:            dryRun,
+		RequestedBy:       actor.Subject,
+		RequestedByUserId: actor.UserId,
+		File:              data,
+	}
+	if err := s.jobRepo.Save(ctx, job); err != nil {
+		slog.Error("Exception occurred queuing import.", "requested-by", actor.Subject, "error", err)
+		return nil, err
+	}
+	return dto.ImportJobFromModel(job), nil
+}
+
+// GetJob implements [ProductImportServiceI].
+func (s *ProductImportService) GetJob(ctx context.Context, id uint) (*dto.ImportJob, error) {
+	job, err := s.jobRepo.GetById(ctx, id)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			slog.Error("Exception occurred getting import job.", "job-id", id, "error", err)
+		}
+		return nil, err
+	}
+	return dto.ImportJobFromModel(job), nil
+}
+
+// ProcessJobs implements [ProductImportServiceI]. It runs queued jobs one
+// after another until there are none left. The file is dropped once a job
+// has run; a job whose rows have errors fails, unless it was a dry run.
+func (s *ProductImportService) ProcessJobs(ctx context.Context) error {
+	for {
+		job, err := s.jobRepo.Claim(ctx, s.now(), jobLease)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			slog.Error("Exception occurred claiming import job.", "error", err)
+			return err
+		}
+		if err := s.run(ctx, job); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *ProductImportService) run(ctx context.Context, job *models.ImportJob) error {
+	actor := database.Actor{Subject: job.RequestedBy, UserId: job.RequestedByUserId}
+	result, err := s.catalog.Import(database.WithActor(ctx, actor), bytes.NewReader(job.File), job.DryRun)
+	if ctx.Err() != nil {
+		// Shutting down: the job is claimed again once its lease is up.
+		return ctx.Err()
+	}
+
+	now := s.now()
+	job.File = nil
+	job.CompletedDate = &now
+	if err != nil {
+		slog.Error("Import job failed.", "job-id", job.Id, "error", err)
+		job.Status = models.ImportStatusFailed
+		job.Error = err.Error()
+	} else {
+		rowErrors, err := json.Marshal(dto.RowErrorsFromCatalog(result.Errors))
+		if err != nil {
+			return err
+		}
+		errs := string(rowErrors)
+		job.Rows, job.Created, job.Updated, job.Unchanged = result.Rows, result.Created, result.Updated, result.Unchanged
+		job.Errors = &errs
+		job.Status = models.ImportStatusCompleted
+		if !job.DryRun && len(result.Errors) > 0 {
+			job.Status = models.ImportStatusFailed
+		}
+		slog.Info("Import job done.", "job-id", job.Id, "status", job.Status, "dry-run", job.DryRun,
+			"rows", result.Rows, "created", result.Created, "updated", result.Updated, "errors", len(result.Errors))
+	}
+	if err := s.jobRepo.Save(ctx, job); err != nil {
+		slog.Error("Exception occurred saving import job.", "job-id", job.Id, "error", err)
+		return err
+	}
+	return nil
+}
+
+// Export implements [ProductImportServiceI].
+func (s *ProductImportService) Export(ctx context.Context, w io.Writer) error {
+	if err := s.catalog.Export(ctx, w); err != nil {
+		slog.Error("Exception occurred exporting products.", "error", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,235 @@
+package productimport
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"commerce/internal/shared/catalog"
+	"commerce/internal/shared/database"
+	"commerce/internal/shared/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+var now = time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+type mocks struct {
+	catalog *MockCatalogI
+	jobRepo *MockImportJobRepositoryI
+}
+
+func setup(t *testing.T) (*mocks, ProductImportServiceI) {
+	t.Helper()
+	ctl := gomock.NewController(t)
+	t.Cleanup(ctl.Finish)
+	m := &mocks{
+		catalog: NewMockCatalogI(ctl),
+		jobRepo: NewMockImportJobRepositoryI(ctl),
+	}
+	return m, NewProductImportService(m.catalog, m.jobRepo, func() time.Time { return now })
+}
+
+func readAll(t *testing.T, r io.Reader) string {
+	t.Helper()
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestImportReturnsRowErrors(t *testing.T) {
+	m, s := setup(t)
+	ctx := context.Background()
+	m.catalog.EXPECT().Import(ctx, gomock.Any(), false).DoAndReturn(func(_ context.Context, r io.Reader, dryRun bool) (*catalog.ImportResult, error) {
+		assert.Equal(t, "sku\nA-1\n", readAll(t, r))
+		return &catalog.ImportResult{Rows: 1, Errors: []catalog.RowError{{Row: 2, Sku: "A-1", Column: "name", Message: "is required for a new product"}}}, nil
+	})
+
+	result, err := s.Import(ctx, []byte("sku\nA-1\n"), false)
+
+	require.NoError(t, err)
+	assert.False(t, result.Applied)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, 2, result.Errors[0].Row)
+	assert.Equal(t, "name", result.Errors[0].Column)
+}
+
+func TestImportDryRunIsNotApplied(t *testing.T) {
+	m, s := setup(t)
+	ctx := context.Background()
+	m.catalog.EXPECT().Import(ctx, gomock.Any(), true).Return(&catalog.ImportResult{DryRun: true, Rows: 2, Created: 1, Updated: 1}, nil)
+
+	result, err := s.Import(ctx, []byte("sku,name\nA-1,A\nB-2,B\n"), true)
+
+	require.NoError(t, err)
+	assert.True(t, result.DryRun)
+	assert.False(t, result.Applied)
+	assert.Equal(t, 1, result.Created)
+	assert.Empty(t, result.Errors)
+}
+
+func TestEnqueueChecksHeader(t *testing.T) {
+	_, s := setup(t)
+
+	_, err := s.Enqueue(context.Background(), []byte("name,colour\nA,red\n"), false)
+
+	assert.ErrorIs(t, err, catalog.ErrInvalidFile)
+}
+
+func TestEnqueueSavesPendingJobForActor(t *testing.T) {
+	m, s := setup(t)
+	userId := uint(4)
+	ctx := database.WithActor(context.Background(), database.Actor{Subject: "auth0|admin", UserId: &userId})
+	data := []byte("sku,price\nA-1,9.99\n")
+	m.jobRepo.EXPECT().Save(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, job *models.ImportJob) error {
+		assert.Equal(t, models.ImportStatusPending, job.Status)
+		assert.Equal(t, "auth0|admin", job.RequestedBy)
+		assert.Equal(t, &userId, job.RequestedByUserId)
+		assert.Equal(t, data, job.File)
+		assert.True(t, job.DryRun)
+		job.Id = 3
+		return nil
+	})
+
+	job, err := s.Enqueue(ctx, data, true)
+
+	require.NoError(t, err)
+	assert.Equal(t, uint(3), job.Id)
+	assert.Equal(t, "pending", job.Status)
+	assert.Nil(t, job.Result)
+}
+
+func TestProcessJobsRunsEachJobAsItsRequester(t *testing.T) {
+	m, s := setup(t)
+	ctx := context.Background()
+	first := &models.ImportJob{Base: models.Base{Id: 1}, Status: models.ImportStatusRunning, RequestedBy: "auth0|admin", File: []byte("sku,stock\nA-1,5\n")}
+	second := &models.ImportJob{Base: models.Base{Id: 2}, Status: models.ImportStatusRunning, RequestedBy: "m2m@clients", DryRun: true, File: []byte("sku,stock\nB-2,x\n")}
+	gomock.InOrder(
+		m.jobRepo.EXPECT().Claim(ctx, now, jobLease).Return(first, nil),
+		m.jobRepo.EXPECT().Claim(ctx, now, jobLease).Return(second, nil),
+		m.jobRepo.EXPECT().Claim(ctx, now, jobLease).Return(nil, gorm.ErrRecordNotFound),
+	)
+	m.catalog.EXPECT().Import(gomock.Any(), gomock.Any(), false).DoAndReturn(func(ctx context.Context, r io.Reader, _ bool) (*catalog.ImportResult, error) {
+		assert.Equal(t, "auth0|admin", database.ActorFrom(ctx).Subject)
+		assert.Equal(t, "sku,stock\nA-1,5\n", readAll(t, r))
+		return &catalog.ImportResult{Rows: 1, Updated: 1}, nil
+	})
+	m.catalog.EXPECT().Import(gomock.Any(), gomock.Any(), true).DoAndReturn(func(ctx context.Context, _ io.Reader, _ bool) (*catalog.ImportResult, error) {
+		assert.Equal(t, "m2m@clients", database.ActorFrom(ctx).Subject)
+		return &catalog.ImportResult{DryRun: true, Rows: 1, Errors: []catalog.RowError{{Row: 2, Sku: "B-2", Column: "stock", Message: `"x" is not a whole number`}}}, nil
+	})
+	var saved []*models.ImportJob
+	m.jobRepo.EXPECT().Save(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, job *models.ImportJob) error {
+		saved = append(saved, job)
+		return nil
+	}).Times(2)
+
+	require.NoError(t, s.ProcessJobs(ctx))
+
+	require.Len(t, saved, 2)
+	assert.Equal(t, models.ImportStatusCompleted, saved[0].Status)
+	assert.Equal(t, 1, saved[0].Updated)
+	assert.Equal(t, "[]", *saved[0].Errors)
+	assert.Nil(t, saved[0].File)
+	assert.Equal(t, &now, saved[0].CompletedDate)
+	// A dry run with bad rows still completes: the errors are its report.
+	assert.Equal(t, models.ImportStatusCompleted, saved[1].Status)
+	assert.JSONEq(t, `[{"row":2,"sku":"B-2","column":"stock","message":"\"x\" is not a whole number"}]`, *saved[1].Errors)
+}
+
+func TestProcessJobsFailsJobWithRowErrors(t *testing.T) {
+	m, s := setup(t)
+	ctx := context.Background()
+	job := &models.ImportJob{Base: models.Base{Id: 1}, Status: models.ImportStatusRunning, File: []byte("sku\nA-1\n")}
+	m.jobRepo.EXPECT().Claim(ctx, now, jobLease).Return(job, nil)
+	m.jobRepo.EXPECT().Claim(ctx, now, jobLease).Return(nil, gorm.ErrRecordNotFound)
+	m.catalog.EXPECT().Import(gomock.Any(), gomock.Any(), false).
+		Return(&catalog.ImportResult{Rows: 1, Errors: []catalog.RowError{{Row: 2, Sku: "A-1", Column: "name", Message: "is required for a new product"}}}, nil)
+	m.jobRepo.EXPECT().Save(ctx, job).Return(nil)
+
+	require.NoError(t, s.ProcessJobs(ctx))
+
+	assert.Equal(t, models.ImportStatusFailed, job.Status)
+	assert.Empty(t, job.Error)
+	assert.Nil(t, job.File)
+}
+
+func TestProcessJobsRecordsImportError(t *testing.T) {
+	m, s := setup(t)
+	ctx := context.Background()
+	job := &models.ImportJob{Base: models.Base{Id: 1}, Status: models.ImportStatusRunning, File: []byte("sku\n\"A-1\n")}
+	m.jobRepo.EXPECT().Claim(ctx, now, jobLease).Return(job, nil)
+	m.jobRepo.EXPECT().Claim(ctx, now, jobLease).Return(nil, gorm.ErrRecordNotFound)
+	m.catalog.EXPECT().Import(gomock.Any(), gomock.Any(), false).Return(nil, catalog.ErrInvalidFile)
+	m.jobRepo.EXPECT().Save(ctx, job).Return(nil)
+
+	require.NoError(t, s.ProcessJobs(ctx))
+
+	assert.Equal(t, models.ImportStatusFailed, job.Status)
+	assert.Equal(t, catalog.ErrInvalidFile.Error(), job.Error)
+	assert.Nil(t, job.Errors)
+}
+
+func TestProcessJobsLeavesJobClaimedOnShutdown(t *testing.T) {
+	m, s := setup(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	job := &models.ImportJob{Base: models.Base{Id: 1}, Status: models.ImportStatusRunning, File: []byte("sku\nA-1\n")}
+	m.jobRepo.EXPECT().Claim(ctx, now, jobLease).Return(job, nil)
+	m.catalog.EXPECT().Import(gomock.Any(), gomock.Any(), false).DoAndReturn(func(ctx context.Context, _ io.Reader, _ bool) (*catalog.ImportResult, error) {
+		cancel()
+		return nil, ctx.Err()
+	})
+
+	err := s.ProcessJobs(ctx)
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, models.ImportStatusRunning, job.Status)
+}
+
+func TestGetJobIncludesResult(t *testing.T) {
+	m, s := setup(t)
+	ctx := context.Background()
+	errs := `[{"row":3,"sku":"C-3","column":"categories","message":"there is no category \"shoes\""}]`
+	m.jobRepo.EXPECT().GetById(ctx, uint(9)).Return(&models.ImportJob{
+		Base:   models.Base{Id: 9},
+		Status: models.ImportStatusFailed,
+		Rows:   2,
+		Errors: &errs,
+	}, nil)
+
+	job, err := s.GetJob(ctx, 9)
+
+	require.NoError(t, err)
+	require.NotNil(t, job.Result)
+	assert.False(t, job.Result.Applied)
+	require.Len(t, job.Result.Errors, 1)
+	assert.Equal(t, "C-3", job.Result.Errors[0].Sku)
+}
+
+func TestExportWritesCatalog(t *testing.T) {
+	m, s := setup(t)
+	ctx := context.Background()
+	m.catalog.EXPECT().Export(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, w io.Writer) error {
+		_, err := io.WriteString(w, "sku\n")
+		return err
+	})
+	var buf bytes.Buffer
+
+	require.NoError(t, s.Export(ctx, &buf))
+
+	assert.Equal(t, "sku\n", buf.String())
+}
+
+func TestExportReturnsError(t *testing.T) {
+	m, s := setup(t)
+	ctx := context.Background()
+	m.catalog.EXPECT().Export(ctx, gomock.Any()).Return(errors.New("connection reset"))
+
+	assert.Error(t, s.Export(ctx, io.Discard))
+}
@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../../../../internal/shared/repositories/product-price/product_price_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=../../../../internal/shared/repositories/product-price/product_price_repository.go -destination=mock_product_price_repo_test.go -package=productprice
+//
+
+// Package productprice is a generated GoMock package.
+package productprice
+
+import (
+	models "commerce/internal/shared/models"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockProductPriceRepositoryI is a mock of ProductPriceRepositoryI interface.
+type MockProductPriceRepositoryI struct {
+	ctrl     *gomock.Controller
+	recorder *MockProductPriceRepositoryIMockRecorder
+	isgomock struct{}
+}
+
+// MockProductPriceRepositoryIMockRecorder is the mock recorder for MockProductPriceRepositoryI.
+type MockProductPriceRepositoryIMockRecorder struct {
+	mock *MockProductPriceRepositoryI
+}
+
+// NewMockProductPriceRepositoryI creates a new mock instance.
+func NewMockProductPriceRepositoryI(ctrl *gomock.Controller) *MockProductPriceRepositoryI {
+	mock := &MockProductPriceRepositoryI{ctrl: ctrl}
+	mock.recorder = &MockProductPriceRepositoryIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProductPriceRepositoryI) EXPECT() *MockProductPriceRepositoryIMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockProductPriceRepositoryI) Delete(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockProductPriceRepositoryIMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockProductPriceRepositoryI)(nil).Delete), ctx, id)
+}
+
+// GetAllByProductId mocks base method.
+func (m *MockProductPriceRepositoryI) GetAllByProductId(ctx context.Context, productId uint) ([]*models.ProductPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllByProductId", ctx, productId)
+	ret0, _ := ret[0].([]*models.ProductPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllByProductId indicates an expected call of GetAllByProductId.
+func (mr *MockProductPriceRepositoryIMockRecorder) GetAllByProductId(ctx, productId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByProductId", reflect.TypeOf((*MockProductPriceRepositoryI)(nil).GetAllByProductId), ctx, productId)
+}
+
+// GetById mocks base method.
+func (m *MockProductPriceRepositoryI) GetById(ctx context.Context, id uint) (*models.ProductPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(*models.ProductPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockProductPriceRepositoryIMockRecorder) GetById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockProductPriceRepositoryI)(nil).GetById), ctx, id)
+}
+
+// Save mocks base method.
+func (m *MockProductPriceRepositoryI) Save(ctx context.Context, price *models.ProductPrice) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, price)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockProductPriceRepositoryIMockRecorder) Save(ctx, price any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockProductPriceRepositoryI)(nil).Save), ctx, price)
+}
@@ -0,0 +1,186 @@
+package productprice
+
+import (
+	dto "commerce/api/internal/dto/product"
+	"commerce/internal/shared/database"
+	"commerce/internal/shared/models"
+	product_repo "commerce/internal/shared/repositories/product"
+	repo "commerce/internal/shared/repositories/product-price"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrInvalidPrice is returned for prices whose amounts or window don't make
+// sense, or that name a variant of another product.
+var ErrInvalidPrice = errors.New("invalid price")
+
+// ErrPriceStarted is returned for changes that would rewrite a price that has
+// already taken effect.
+var ErrPriceStarted = errors.New("price has taken effect")
+
+type ProductPriceServiceI interface {
+	GetAll(ctx context.Context, productId uint) ([]dto.Price, error)
+	Create(ctx context.Context, productId uint, price *dto.Price) error
+	Update(ctx context.Context, productId uint, priceId uint, price *dto.Price) error
+	Delete(ctx context.Context, productId uint, priceId uint) error
+}
+
+type ProductPriceService struct {
+	productRepo product_repo.ProductRepositoryI
+	repo        repo.ProductPriceRepositoryI
+	now         func() time.Time
+}
+
+func NewProductPriceService(productRepo product_repo.ProductRepositoryI,
+	repo repo.ProductPriceRepositoryI,
+	now func() time.Time) ProductPriceServiceI {
+	return &ProductPriceService{productRepo: productRepo, repo: repo, now: now}
+}
+
+// GetAll implements [ProductPriceServiceI]. The product's prices, its
+// variants' included, come latest start first.
+func (s *ProductPriceService) GetAll(ctx context.Context, productId uint) ([]dto.Price, error) {
+	if _, err := s.productRepo.GetById(ctx, productId); err != nil {
+		return nil, err
+	}
+	prices, err := s.repo.GetAllByProductId(ctx, productId)
+	if err != nil {
+		slog.Error("Exception occurred getting product prices.", "product-id", productId, "error", err)
+		return nil, err
+	}
+	now := s.now()
+	dtos := make([]dto.Price, len(prices))
+	for i, price := range prices {
+		dtos[i] = *dto.PriceFromModel(price, now)
+	}
+	return dtos, nil
+}
+
+// Create implements [ProductPriceServiceI]. The price starts now unless
+// scheduled for later; it can't start in the past.
+func (s *ProductPriceService) Create(ctx context.Context, productId uint, price *dto.Price) error {
+	product, err := s.productRepo.GetById(ctx, productId)
+	if err != nil {
+		return err
+	}
+	now := s.now()
+	model := &models.ProductPrice{ProductId: productId, CreatedBy: database.ActorFrom(ctx).Subject}
+	if err := schedule(model, product, price, now); err != nil {
+		return err
+	}
+	if err := s.repo.Save(ctx, model); err != nil {
+		slog.Error("Exception occurred saving product price.", "product-id", productId, "error", err)
+		return err
+	}
+	*price = *dto.PriceFromModel(model, now)
+	return nil
+}
+
+// Update implements [ProductPriceServiceI]. A price that hasn't started can
+// be changed entirely. One that has can only have its end moved, to now at
+// the earliest, or taken away; the rest is history, as is a price that has
+// ended, and changing it fails with [ErrPriceStarted].
+func (s *ProductPriceService) Update(ctx context.Context, productId uint, priceId uint, price *dto.Price) error {
+	product, err := s.productRepo.GetById(ctx, productId)
+	if err != nil {
+		return err
+	}
+	model, err := s.repo.GetById(ctx, priceId)
+	if err != nil {
+		return err
+	}
+	if model.ProductId != productId {
+		return gorm.ErrRecordNotFound
+	}
+	now := s.now()
+	switch {
+	case model.EffectiveFrom.After(now):
+		if err := schedule(model, product, price, now); err != nil {
+			return err
+		}
+	case !model.ActiveAt(now):
+		return fmt.Errorf("%w: it ended at %s", ErrPriceStarted, model.EffectiveTo.Format(time.RFC3339))
+	default:
+		if err := end(model, price, now); err != nil {
+			return err
+		}
+	}
+	if err := s.repo.Save(ctx, model); err != nil {
+		slog.Error("Exception occurred updating product price.", "product-id", productId, "price-id", priceId, "error", err)
+		return err
+	}
+	*price = *dto.PriceFromModel(model, now)
+	return nil
+}
+
+// Delete implements [ProductPriceServiceI]. Only prices that haven't started
+// can be deleted; the others are ended instead by setting effective_to.
+func (s *ProductPriceService) Delete(ctx context.Context, productId uint, priceId uint) error {
+	model, err := s.repo.GetById(ctx, priceId)
+	if err != nil {
+		return err
+	}
+	if model.ProductId != productId {
+		return gorm.ErrRecordNotFound
+	}
+	if !model.EffectiveFrom.After(s.now()) {
+		return fmt.Errorf("%w: end it by setting effective_to instead", ErrPriceStarted)
+	}
+	if err := s.repo.Delete(ctx, priceId); err != nil {
+		slog.Error("Exception occurred deleting product price.", "product-id", productId, "price-id", priceId, "error", err)
+		return err
+	}
+	return nil
+}
+
+// schedule checks a price that hasn't started yet and copies it onto model.
+func schedule(model *models.ProductPrice, product *models.Product, price *dto.Price, now time.Time) error {
+	from := now
+	if price.EffectiveFrom != nil {
+		from = *price.EffectiveFrom
+	}
+	switch {
+	case from.Before(now):
+		return fmt.Errorf("%w: effective_from can't be in the past", ErrInvalidPrice)
+	case price.EffectiveTo != nil && !price.EffectiveTo.After(from):
+		return fmt.Errorf("%w: effective_to must be after effective_from", ErrInvalidPrice)
+	case price.CompareAtPrice != nil && *price.CompareAtPrice <= price.Price:
+		return fmt.Errorf("%w: compare_at_price must be more than price", ErrInvalidPrice)
+	case price.VariantId != nil && product.Variant(*price.VariantId) == nil:
+		return fmt.Errorf("%w: product %d has no variant %d", ErrInvalidPrice, product.Id, *price.VariantId)
+	}
+	model.VariantId = price.VariantId
+	model.Price = price.Price
+	model.CompareAtPrice = price.CompareAtPrice
+	model.EffectiveFrom = from
+	model.EffectiveTo = price.EffectiveTo
+	return nil
+}
+
+// end moves the end of a price that has started, after checking that nothing
+// else about it is being changed.
+func end(model *models.ProductPrice, price *dto.Price, now time.Time) error {
+	if price.Price != model.Price ||
+		!equal(price.CompareAtPrice, model.CompareAtPrice) ||
+		!equal(price.VariantId, model.VariantId) ||
+		(price.EffectiveFrom != nil && !price.EffectiveFrom.Equal(model.EffectiveFrom)) {
+		return fmt.Errorf("%w: only effective_to can be changed", ErrPriceStarted)
+	}
+	if price.EffectiveTo != nil && price.EffectiveTo.Before(now) {
+		return fmt.Errorf("%w: effective_to can't be in the past", ErrInvalidPrice)
+	}
+	model.EffectiveTo = price.EffectiveTo
+	return nil
+}
+
+func equal[T comparable](a *T, b *T) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
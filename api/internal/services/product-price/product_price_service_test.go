@@ -0,0 +1,239 @@
+package productprice
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	dto "commerce/api/internal/dto/product"
+	"commerce/internal/shared/database"
+	"commerce/internal/shared/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+var now = time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+type mocks struct {
+	productRepo *MockProductRepositoryI
+	repo        *MockProductPriceRepositoryI
+}
+
+func setup(t *testing.T) (*mocks, ProductPriceServiceI) {
+	t.Helper()
+	ctl := gomock.NewController(t)
+	t.Cleanup(ctl.Finish)
+	m := &mocks{
+		productRepo: NewMockProductRepositoryI(ctl),
+		repo:        NewMockProductPriceRepositoryI(ctl),
+	}
+	return m, NewProductPriceService(m.productRepo, m.repo, func() time.Time { return now })
+}
+
+func testProduct() *models.Product {
+	return &models.Product{
+		Base:     models.Base{Id: 1},
+		Price:    20,
+		Variants: []models.ProductVariant{{Base: models.Base{Id: 5}, ProductId: 1}},
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestGetAllHasStatuses(t *testing.T) {
+	m, s := setup(t)
+	ctx := context.Background()
+	m.productRepo.EXPECT().GetById(ctx, uint(1)).Return(testProduct(), nil)
+	m.repo.EXPECT().GetAllByProductId(ctx, uint(1)).Return([]*models.ProductPrice{
+		{Base: models.Base{Id: 3}, ProductId: 1, Price: 15, EffectiveFrom: now.Add(24 * time.Hour)},
+		{Base: models.Base{Id: 2}, ProductId: 1, Price: 18, EffectiveFrom: now.Add(-time.Hour)},
+		{Base: models.Base{Id: 1}, ProductId: 1, Price: 10, EffectiveFrom: now.Add(-48 * time.Hour), EffectiveTo: ptr(now.Add(-24 * time.Hour))},
+	}, nil)
+
+	prices, err := s.GetAll(ctx, 1)
+
+	require.NoError(t, err)
+	require.Len(t, prices, 3)
+	assert.Equal(t, dto.PriceScheduled, prices[0].Status)
+	assert.Equal(t, dto.PriceCurrent, prices[1].Status)
+	assert.Equal(t, dto.PriceEnded, prices[2].Status)
+}
+
+func TestGetAllUnknownProduct(t *testing.T) {
+	m, s := setup(t)
+	ctx := context.Background()
+	m.productRepo.EXPECT().GetById(ctx, uint(9)).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := s.GetAll(ctx, 9)
+
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestCreateSchedulesSale(t *testing.T) {
+	m, s := setup(t)
+	ctx := database.WithActor(context.Background(), database.Actor{Subject: "auth0|staff"})
+	from, to := now.Add(24*time.Hour), now.Add(72*time.Hour)
+	m.productRepo.EXPECT().GetById(ctx, uint(1)).Return(testProduct(), nil)
+	m.repo.EXPECT().Save(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, price *models.ProductPrice) error {
+		assert.Equal(t, uint(1), price.ProductId)
+		assert.Equal(t, float32(15), price.Price)
+		assert.Equal(t, ptr(float32(20)), price.CompareAtPrice)
+		assert.Equal(t, from, price.EffectiveFrom)
+		assert.Equal(t, &to, price.EffectiveTo)
+		assert.Equal(t, "auth0|staff", price.CreatedBy)
+		price.Id = 4
+		return nil
+	})
+	price := &dto.Price{Price: 15, CompareAtPrice: ptr(float32(20)), EffectiveFrom: &from, EffectiveTo: &to}
+
+	require.NoError(t, s.Create(ctx, 1, price))
+
+	assert.Equal(t, uint(4), price.Id)
+	assert.Equal(t, dto.PriceScheduled, price.Status)
+}
+
+func TestCreateStartsNow(t *testing.T) {
+	m, s := setup(t)
+	ctx := context.Background()
+	m.productRepo.EXPECT().GetById(ctx, uint(1)).Return(testProduct(), nil)
+	m.repo.EXPECT().Save(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, price *models.ProductPrice) error {
+		assert.Equal(t, now, price.EffectiveFrom)
+		assert.Equal(t, ptr(uint(5)), price.VariantId)
+		assert.Equal(t, database.SystemSubject, price.CreatedBy)
+		return nil
+	})
+	price := &dto.Price{VariantId: ptr(uint(5)), Price: 25}
+
+	require.NoError(t, s.Create(ctx, 1, price))
+
+	assert.Equal(t, dto.PriceCurrent, price.Status)
+}
+
+func TestCreateInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		price   dto.Price
+		message string
+	}{
+		{"starts in the past", dto.Price{Price: 10, EffectiveFrom: ptr(now.Add(-time.Minute))}, "effective_from"},
+		{"ends before it starts", dto.Price{Price: 10, EffectiveTo: ptr(now)}, "effective_to"},
+		{"compare-at not higher", dto.Price{Price: 10, CompareAtPrice: ptr(float32(10))}, "compare_at_price"},
+		{"variant of another product", dto.Price{Price: 10, VariantId: ptr(uint(6))}, "no variant 6"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, s := setup(t)
+			m.productRepo.EXPECT().GetById(gomock.Any(), uint(1)).Return(testProduct(), nil)
+
+			err := s.Create(context.Background(), 1, &tt.price)
+
+			assert.ErrorIs(t, err, ErrInvalidPrice)
+			assert.ErrorContains(t, err, tt.message)
+		})
+	}
+}
+
+func TestUpdateReschedulesScheduledPrice(t *testing.T) {
+	m, s := setup(t)
+	ctx := context.Background()
+	model := &models.ProductPrice{Base: models.Base{Id: 2}, ProductId: 1, Price: 15, EffectiveFrom: now.Add(time.Hour), CreatedBy: "auth0|staff"}
+	from := now.Add(48 * time.Hour)
+	m.productRepo.EXPECT().GetById(ctx, uint(1)).Return(testProduct(), nil)
+	m.repo.EXPECT().GetById(ctx, uint(2)).Return(model, nil)
+	m.repo.EXPECT().Save(ctx, model).Return(nil)
+	price := &dto.Price{Price: 12, EffectiveFrom: &from}
+
+	require.NoError(t, s.Update(ctx, 1, 2, price))
+
+	assert.Equal(t, float32(12), model.Price)
+	assert.Equal(t, from, model.EffectiveFrom)
+	assert.Equal(t, "auth0|staff", model.CreatedBy)
+}
+
+func TestUpdateEndsCurrentPrice(t *testing.T) {
+	m, s := setup(t)
+	ctx := context.Background()
+	started := now.Add(-time.Hour)
+	model := &models.ProductPrice{Base: models.Base{Id: 2}, ProductId: 1, Price: 15, CompareAtPrice: ptr(float32(20)), EffectiveFrom: started}
+	m.productRepo.EXPECT().GetById(ctx, uint(1)).Return(testProduct(), nil)
+	m.repo.EXPECT().GetById(ctx, uint(2)).Return(model, nil)
+	m.repo.EXPECT().Save(ctx, model).Return(nil)
+	price := &dto.Price{Price: 15, CompareAtPrice: ptr(float32(20)), EffectiveFrom: &started, EffectiveTo: &now}
+
+	require.NoError(t, s.Update(ctx, 1, 2, price))
+
+	assert.Equal(t, &now, model.EffectiveTo)
+	assert.Equal(t, dto.PriceEnded, price.Status)
+}
+
+func TestUpdateCurrentPriceRefusesOtherChanges(t *testing.T) {
+	m, s := setup(t)
+	ctx := context.Background()
+	model := &models.ProductPrice{Base: models.Base{Id: 2}, ProductId: 1, Price: 15, EffectiveFrom: now.Add(-time.Hour)}
+	m.productRepo.EXPECT().GetById(ctx, uint(1)).Return(testProduct(), nil)
+	m.repo.EXPECT().GetById(ctx, uint(2)).Return(model, nil)
+
+	err := s.Update(ctx, 1, 2, &dto.Price{Price: 9})
+
+	assert.ErrorIs(t, err, ErrPriceStarted)
+	assert.Equal(t, float32(15), model.Price)
+}
+
+func TestUpdateCurrentPriceCantEndInThePast(t *testing.T) {
+	m, s := setup(t)
+	ctx := context.Background()
+	model := &models.ProductPrice{Base: models.Base{Id: 2}, ProductId: 1, Price: 15, EffectiveFrom: now.Add(-time.Hour)}
+	m.productRepo.EXPECT().GetById(ctx, uint(1)).Return(testProduct(), nil)
+	m.repo.EXPECT().GetById(ctx, uint(2)).Return(model, nil)
+
+	err := s.Update(ctx, 1, 2, &dto.Price{Price: 15, EffectiveTo: ptr(now.Add(-time.Minute))})
+
+	assert.ErrorIs(t, err, ErrInvalidPrice)
+}
+
+func TestUpdateEndedPrice(t *testing.T) {
+	m, s := setup(t)
+	ctx := context.Background()
+	model := &models.ProductPrice{Base: models.Base{Id: 2}, ProductId: 1, Price: 15, EffectiveFrom: now.Add(-48 * time.Hour), EffectiveTo: ptr(now.Add(-24 * time.Hour))}
+	m.productRepo.EXPECT().GetById(ctx, uint(1)).Return(testProduct(), nil)
+	m.repo.EXPECT().GetById(ctx, uint(2)).Return(model, nil)
+
+	err := s.Update(ctx, 1, 2, &dto.Price{Price: 15})
+
+	assert.ErrorIs(t, err, ErrPriceStarted)
+}
+
+func TestUpdatePriceOfAnotherProduct(t *testing.T) {
+	m, s := setup(t)
+	ctx := context.Background()
+	m.productRepo.EXPECT().GetById(ctx, uint(1)).Return(testProduct(), nil)
+	m.repo.EXPECT().GetById(ctx, uint(2)).Return(&models.ProductPrice{Base: models.Base{Id: 2}, ProductId: 3}, nil)
+
+	err := s.Update(ctx, 1, 2, &dto.Price{Price: 15})
+
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestDeleteScheduledPrice(t *testing.T) {
+	m, s := setup(t)
+	ctx := context.Background()
+	m.repo.EXPECT().GetById(ctx, uint(2)).Return(&models.ProductPrice{Base: models.Base{Id: 2}, ProductId: 1, EffectiveFrom: now.Add(time.Hour)}, nil)
+	m.repo.EXPECT().Delete(ctx, uint(2)).Return(nil)
+
+	assert.NoError(t, s.Delete(ctx, 1, 2))
+}
+
+func TestDeleteStartedPrice(t *testing.T) {
+	m, s := setup(t)
+	ctx := context.Background()
+	m.repo.EXPECT().GetById(ctx, uint(2)).Return(&models.ProductPrice{Base: models.Base{Id: 2}, ProductId: 1, EffectiveFrom: now}, nil)
+
+	err := s.Delete(ctx, 1, 2)
+
+	assert.ErrorIs(t, err, ErrPriceStarted)
+}
@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../../../../internal/shared/repositories/product-option/product_option_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=../../../../internal/shared/repositories/product-option/product_option_repository.go -destination=mock_product_option_repo_test.go -package=productvariant
+//
+
+// Package productvariant is a generated GoMock package.
+package productvariant
+
+import (
+	models "commerce/internal/shared/models"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockProductOptionRepositoryI is a mock of ProductOptionRepositoryI interface.
+type MockProductOptionRepositoryI struct {
+	ctrl     *gomock.Controller
+	recorder *MockProductOptionRepositoryIMockRecorder
+	isgomock struct{}
+}
+
+// MockProductOptionRepositoryIMockRecorder is the mock recorder for MockProductOptionRepositoryI.
+type MockProductOptionRepositoryIMockRecorder struct {
+	mock *MockProductOptionRepositoryI
+}
+
+// NewMockProductOptionRepositoryI creates a new mock instance.
+func NewMockProductOptionRepositoryI(ctrl *gomock.Controller) *MockProductOptionRepositoryI {
+	mock := &MockProductOptionRepositoryI{ctrl: ctrl}
+	mock.recorder = &MockProductOptionRepositoryIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProductOptionRepositoryI) EXPECT() *MockProductOptionRepositoryIMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockProductOptionRepositoryI) Delete(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockProductOptionRepositoryIMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockProductOptionRepositoryI)(nil).Delete), ctx, id)
+}
+
+// GetAllByProductId mocks base method.
+func (m *MockProductOptionRepositoryI) GetAllByProductId(ctx context.Context, productId uint) ([]*models.ProductOption, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllByProductId", ctx, productId)
+	ret0, _ := ret[0].([]*models.ProductOption)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllByProductId indicates an expected call of GetAllByProductId.
+func (mr *MockProductOptionRepositoryIMockRecorder) GetAllByProductId(ctx, productId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByProductId", reflect.TypeOf((*MockProductOptionRepositoryI)(nil).GetAllByProductId), ctx, productId)
+}
+
+// GetById mocks base method.
+func (m *MockProductOptionRepositoryI) GetById(ctx context.Context, id uint) (*models.ProductOption, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(*models.ProductOption)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockProductOptionRepositoryIMockRecorder) GetById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockProductOptionRepositoryI)(nil).GetById), ctx, id)
+}
+
+// Save mocks base method.
+func (m *MockProductOptionRepositoryI) Save(ctx context.Context, option *models.ProductOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, option)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockProductOptionRepositoryIMockRecorder) Save(ctx, option any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockProductOptionRepositoryI)(nil).Save), ctx, option)
+}
@@ -0,0 +1,216 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../../../../internal/shared/repositories/product/product_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=../../../../internal/shared/repositories/product/product_repository.go -destination=mock_product_repo_test.go -package=productvariant
+//
+
+// Package productvariant is a generated GoMock package.
+package productvariant
+
+import (
+	models "commerce/internal/shared/models"
+	product "commerce/internal/shared/repositories/product"
+	query "commerce/internal/shared/repositories/query"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockProductRepositoryI is a mock of ProductRepositoryI interface.
+type MockProductRepositoryI struct {
+	ctrl     *gomock.Controller
+	recorder *MockProductRepositoryIMockRecorder
+	isgomock struct{}
+}
+
+// MockProductRepositoryIMockRecorder is the mock recorder for MockProductRepositoryI.
+type MockProductRepositoryIMockRecorder struct {
+	mock *MockProductRepositoryI
+}
+
+// NewMockProductRepositoryI creates a new mock instance.
+func NewMockProductRepositoryI(ctrl *gomock.Controller) *MockProductRepositoryI {
+	mock := &MockProductRepositoryI{ctrl: ctrl}
+	mock.recorder = &MockProductRepositoryIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProductRepositoryI) EXPECT() *MockProductRepositoryIMockRecorder {
+	return m.recorder
+}
+
+// AddCategory mocks base method.
+func (m *MockProductRepositoryI) AddCategory(ctx context.Context, productId, categoryId uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCategory", ctx, productId, categoryId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddCategory indicates an expected call of AddCategory.
+func (mr *MockProductRepositoryIMockRecorder) AddCategory(ctx, productId, categoryId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCategory", reflect.TypeOf((*MockProductRepositoryI)(nil).AddCategory), ctx, productId, categoryId)
+}
+
+// AdjustStock mocks base method.
+func (m *MockProductRepositoryI) AdjustStock(ctx context.Context, id uint, quantity int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdjustStock", ctx, id, quantity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdjustStock indicates an expected call of AdjustStock.
+func (mr *MockProductRepositoryIMockRecorder) AdjustStock(ctx, id, quantity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdjustStock", reflect.TypeOf((*MockProductRepositoryI)(nil).AdjustStock), ctx, id, quantity)
+}
+
+// Delete mocks base method.
+func (m *MockProductRepositoryI) Delete(ctx context.Context, id uint, hard bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, hard)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockProductRepositoryIMockRecorder) Delete(ctx, id, hard any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockProductRepositoryI)(nil).Delete), ctx, id, hard)
+}
+
+// GetAll mocks base method.
+func (m *MockProductRepositoryI) GetAll(ctx context.Context, opts query.Options) (*query.Page[models.Product], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, opts)
+	ret0, _ := ret[0].(*query.Page[models.Product])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockProductRepositoryIMockRecorder) GetAll(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockProductRepositoryI)(nil).GetAll), ctx, opts)
+}
+
+// GetAllByCategoryId mocks base method.
+func (m *MockProductRepositoryI) GetAllByCategoryId(ctx context.Context, categoryId uint, opts query.Options) (*query.Page[models.Product], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllByCategoryId", ctx, categoryId, opts)
+	ret0, _ := ret[0].(*query.Page[models.Product])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllByCategoryId indicates an expected call of GetAllByCategoryId.
+func (mr *MockProductRepositoryIMockRecorder) GetAllByCategoryId(ctx, categoryId, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByCategoryId", reflect.TypeOf((*MockProductRepositoryI)(nil).GetAllByCategoryId), ctx, categoryId, opts)
+}
+
+// GetById mocks base method.
+func (m *MockProductRepositoryI) GetById(ctx context.Context, id uint) (*models.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(*models.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockProductRepositoryIMockRecorder) GetById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockProductRepositoryI)(nil).GetById), ctx, id)
+}
+
+// RemoveCategory mocks base method.
+func (m *MockProductRepositoryI) RemoveCategory(ctx context.Context, productId, categoryId uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveCategory", ctx, productId, categoryId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveCategory indicates an expected call of RemoveCategory.
+func (mr *MockProductRepositoryIMockRecorder) RemoveCategory(ctx, productId, categoryId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCategory", reflect.TypeOf((*MockProductRepositoryI)(nil).RemoveCategory), ctx, productId, categoryId)
+}
+
+// Restore mocks base method.
+func (m *MockProductRepositoryI) Restore(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockProductRepositoryIMockRecorder) Restore(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockProductRepositoryI)(nil).Restore), ctx, id)
+}
+
+// Save mocks base method.
+func (m *MockProductRepositoryI) Save(ctx context.Context, arg1 *models.Product) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockProductRepositoryIMockRecorder) Save(ctx, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockProductRepositoryI)(nil).Save), ctx, arg1)
+}
+
+// Search mocks base method.
+func (m *MockProductRepositoryI) Search(ctx context.Context, filter product.SearchFilter, opts query.Options) (*product.SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, filter, opts)
+	ret0, _ := ret[0].(*product.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockProductRepositoryIMockRecorder) Search(ctx, filter, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockProductRepositoryI)(nil).Search), ctx, filter, opts)
+}
+
+// SetCategories mocks base method.
+func (m *MockProductRepositoryI) SetCategories(ctx context.Context, productId uint, categoryIds []uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCategories", ctx, productId, categoryIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCategories indicates an expected call of SetCategories.
+func (mr *MockProductRepositoryIMockRecorder) SetCategories(ctx, productId, categoryIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCategories", reflect.TypeOf((*MockProductRepositoryI)(nil).SetCategories), ctx, productId, categoryIds)
+}
+
+// Update mocks base method.
+func (m *MockProductRepositoryI) Update(ctx context.Context, arg1 *models.Product, categoryIds []uint, setStock bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, arg1, categoryIds, setStock)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockProductRepositoryIMockRecorder) Update(ctx, arg1, categoryIds, setStock any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockProductRepositoryI)(nil).Update), ctx, arg1, categoryIds, setStock)
+}
@@ -0,0 +1,114 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../../../../internal/shared/repositories/product-variant/product_variant_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=../../../../internal/shared/repositories/product-variant/product_variant_repository.go -destination=mock_product_variant_repo_test.go -package=productvariant
+//
+
+// Package productvariant is a generated GoMock package.
+package productvariant
+
+import (
+	models "commerce/internal/shared/models"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockProductVariantRepositoryI is a mock of ProductVariantRepositoryI interface.
+type MockProductVariantRepositoryI struct {
+	ctrl     *gomock.Controller
+	recorder *MockProductVariantRepositoryIMockRecorder
+	isgomock struct{}
+}
+
+// MockProductVariantRepositoryIMockRecorder is the mock recorder for MockProductVariantRepositoryI.
+type MockProductVariantRepositoryIMockRecorder struct {
+	mock *MockProductVariantRepositoryI
+}
+
+// NewMockProductVariantRepositoryI creates a new mock instance.
+func NewMockProductVariantRepositoryI(ctrl *gomock.Controller) *MockProductVariantRepositoryI {
+	mock := &MockProductVariantRepositoryI{ctrl: ctrl}
+	mock.recorder = &MockProductVariantRepositoryIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProductVariantRepositoryI) EXPECT() *MockProductVariantRepositoryIMockRecorder {
+	return m.recorder
+}
+
+// AdjustStock mocks base method.
+func (m *MockProductVariantRepositoryI) AdjustStock(ctx context.Context, id uint, quantity int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdjustStock", ctx, id, quantity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdjustStock indicates an expected call of AdjustStock.
+func (mr *MockProductVariantRepositoryIMockRecorder) AdjustStock(ctx, id, quantity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdjustStock", reflect.TypeOf((*MockProductVariantRepositoryI)(nil).AdjustStock), ctx, id, quantity)
+}
+
+// Delete mocks base method.
+func (m *MockProductVariantRepositoryI) Delete(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockProductVariantRepositoryIMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockProductVariantRepositoryI)(nil).Delete), ctx, id)
+}
+
+// GetAllByProductId mocks base method.
+func (m *MockProductVariantRepositoryI) GetAllByProductId(ctx context.Context, productId uint) ([]*models.ProductVariant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllByProductId", ctx, productId)
+	ret0, _ := ret[0].([]*models.ProductVariant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllByProductId indicates an expected call of GetAllByProductId.
+func (mr *MockProductVariantRepositoryIMockRecorder) GetAllByProductId(ctx, productId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByProductId", reflect.TypeOf((*MockProductVariantRepositoryI)(nil).GetAllByProductId), ctx, productId)
+}
+
+// GetById mocks base method.
+func (m *MockProductVariantRepositoryI) GetById(ctx context.Context, id uint) (*models.ProductVariant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(*models.ProductVariant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockProductVariantRepositoryIMockRecorder) GetById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockProductVariantRepositoryI)(nil).GetById), ctx, id)
+}
+
+// Save mocks base method.
+func (m *MockProductVariantRepositoryI) Save(ctx context.Context, variant *models.ProductVariant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, variant)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockProductVariantRepositoryIMockRecorder) Save(ctx, variant any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockProductVariantRepositoryI)(nil).Save), ctx, variant)
+}
@@ -0,0 +1,232 @@
+package productvariant
+
+import (
+	dto "commerce/api/internal/dto/product"
+	"commerce/internal/shared/models"
+	product_repo "commerce/internal/shared/repositories/product"
+	option_repo "commerce/internal/shared/repositories/product-option"
+	repo "commerce/internal/shared/repositories/product-variant"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// ErrInvalidVariant is returned for a variant whose option values aren't one
+// of each of its product's options, or that repeats another variant's.
+var ErrInvalidVariant = errors.New("invalid variant")
+
+// ErrOptionInUse is returned for changes to options that would leave variants
+// without a value for every option: deleting an option or value that variants
+// use, or adding an option to a product that already has variants.
+var ErrOptionInUse = errors.New("option is used by variants")
+
+type ProductVariantServiceI interface {
+	GetOptions(ctx context.Context, productId uint) ([]dto.Option, error)
+	SaveOption(ctx context.Context, productId uint, option *dto.Option) error
+	DeleteOption(ctx context.Context, productId uint, optionId uint) error
+	GetVariants(ctx context.Context, productId uint) ([]dto.Variant, error)
+	SaveVariant(ctx context.Context, productId uint, variant *dto.Variant) error
+	DeleteVariant(ctx context.Context, productId uint, variantId uint) error
+}
+
+type ProductVariantService struct {
+	productRepo product_repo.ProductRepositoryI
+	optionRepo  option_repo.ProductOptionRepositoryI
+	repo        repo.ProductVariantRepositoryI
+}
+
+func NewProductVariantService(productRepo product_repo.ProductRepositoryI,
+	optionRepo option_repo.ProductOptionRepositoryI,
+	repo repo.ProductVariantRepositoryI) ProductVariantServiceI {
+	return &ProductVariantService{productRepo: productRepo, optionRepo: optionRepo, repo: repo}
+}
+
+// GetOptions implements [ProductVariantServiceI].
+func (s *ProductVariantService) GetOptions(ctx context.Context, productId uint) ([]dto.Option, error) {
+	product, err := s.productRepo.GetById(ctx, productId)
+	if err != nil {
+		slog.Error("Exception occurred getting product options.", "product-id", productId, "error", err)
+		return nil, err
+	}
+	options := make([]dto.Option, len(product.Options))
+	for i := range product.Options {
+		options[i] = *dto.OptionFromModel(&product.Options[i])
+	}
+	return options, nil
+}
+
+// SaveOption implements [ProductVariantServiceI].
+func (s *ProductVariantService) SaveOption(ctx context.Context, productId uint, option *dto.Option) error {
+	product, err := s.productRepo.GetById(ctx, productId)
+	if err != nil {
+		return err
+	}
+	model := dto.OptionToModel(productId, option)
+	model.Position = len(product.Options)
+	if option.Id == 0 {
+		if len(product.Variants) > 0 {
+			return fmt.Errorf("%w: delete the product's variants before adding an option", ErrOptionInUse)
+		}
+	} else {
+		existing := findOption(product, option.Id)
+		if existing == nil {
+			return gorm.ErrRecordNotFound
+		}
+		used := usedValues(product)
+		for _, value := range existing.Values {
+			kept := slices.ContainsFunc(option.Values, func(v dto.OptionValue) bool { return v.Id == value.Id })
+			if !kept && used[value.Id] {
+				return fmt.Errorf("%w: %q", ErrOptionInUse, value.Value)
+			}
+		}
+		model.Position = existing.Position
+	}
+	if err := s.optionRepo.Save(ctx, model); err != nil {
+		slog.Error("Exception occurred saving product option.", "product-id", productId, "error", err)
+		return err
+	}
+	*option = *dto.OptionFromModel(model)
+	return nil
+}
+
+// DeleteOption implements [ProductVariantServiceI].
+func (s *ProductVariantService) DeleteOption(ctx context.Context, productId uint, optionId uint) error {
+	product, err := s.productRepo.GetById(ctx, productId)
+	if err != nil {
+		return err
+	}
+	option := findOption(product, optionId)
+	if option == nil {
+		return gorm.ErrRecordNotFound
+	}
+	if len(product.Variants) > 0 {
+		return fmt.Errorf("%w: delete the product's variants before its options", ErrOptionInUse)
+	}
+	return s.optionRepo.Delete(ctx, optionId)
+}
+
+// GetVariants implements [ProductVariantServiceI].
+func (s *ProductVariantService) GetVariants(ctx context.Context, productId uint) ([]dto.Variant, error) {
+	product, err := s.productRepo.GetById(ctx, productId)
+	if err != nil {
+		slog.Error("Exception occurred getting product variants.", "product-id", productId, "error", err)
+		return nil, err
+	}
+	variants := make([]dto.Variant, len(product.Variants))
+	for i := range product.Variants {
+		variants[i] = *dto.VariantFromModel(&product.Variants[i], product)
+	}
+	return variants, nil
+}
+
+// SaveVariant implements [ProductVariantServiceI]. A variant takes exactly one
+// value of each of its product's options, and no two variants of a product
+// take the same ones.
+func (s *ProductVariantService) SaveVariant(ctx context.Context, productId uint, variant *dto.Variant) error {
+	product, err := s.productRepo.GetById(ctx, productId)
+	if err != nil {
+		return err
+	}
+	if variant.Id != 0 && product.Variant(variant.Id) == nil {
+		return gorm.ErrRecordNotFound
+	}
+	if err := checkOptionValues(product, variant); err != nil {
+		return err
+	}
+
+	model := dto.VariantToModel(productId, variant)
+	if err := s.repo.Save(ctx, model); err != nil {
+		slog.Error("Exception occurred saving product variant.", "product-id", productId, "sku", variant.Sku, "error", err)
+		return err
+	}
+	saved, err := s.repo.GetById(ctx, model.Id)
+	if err != nil {
+		return err
+	}
+	*variant = *dto.VariantFromModel(saved, product)
+	return nil
+}
+
+// DeleteVariant implements [ProductVariantServiceI].
+func (s *ProductVariantService) DeleteVariant(ctx context.Context, productId uint, variantId uint) error {
+	variant, err := s.repo.GetById(ctx, variantId)
+	if err != nil {
+		return err
+	}
+	if variant.ProductId != productId {
+		return gorm.ErrRecordNotFound
+	}
+	return s.repo.Delete(ctx, variantId)
+}
+
+func checkOptionValues(product *models.Product, variant *dto.Variant) error {
+	optionOf := map[uint]uint{}
+	for _, option := range product.Options {
+		for _, value := range option.Values {
+			optionOf[value.Id] = option.Id
+		}
+	}
+	picked := map[uint]bool{}
+	for _, id := range variant.OptionValueIds {
+		optionId, ok := optionOf[id]
+		if !ok {
+			return fmt.Errorf("%w: option value %d isn't one of the product's", ErrInvalidVariant, id)
+		}
+		if picked[optionId] {
+			return fmt.Errorf("%w: more than one value for option %d", ErrInvalidVariant, optionId)
+		}
+		picked[optionId] = true
+	}
+	if len(picked) != len(product.Options) {
+		return fmt.Errorf("%w: needs a value for each of the product's %d options", ErrInvalidVariant, len(product.Options))
+	}
+
+	combination := valueKey(variant.OptionValueIds)
+	for _, other := range product.Variants {
+		if other.Id == variant.Id {
+			continue
+		}
+		ids := make([]uint, len(other.OptionValues))
+		for i, v := range other.OptionValues {
+			ids[i] = v.OptionValueId
+		}
+		if valueKey(ids) == combination {
+			return fmt.Errorf("%w: variant %d already has these option values", ErrInvalidVariant, other.Id)
+		}
+	}
+	return nil
+}
+
+func valueKey(ids []uint) string {
+	sorted := slices.Clone(ids)
+	slices.Sort(sorted)
+	parts := make([]string, len(sorted))
+	for i, id := range sorted {
+		parts[i] = fmt.Sprint(id)
+	}
+	return strings.Join(parts, ",")
+}
+
+func usedValues(product *models.Product) map[uint]bool {
+	used := map[uint]bool{}
+	for _, variant := range product.Variants {
+		for _, v := range variant.OptionValues {
+			used[v.OptionValueId] = true
+		}
+	}
+	return used
+}
+
+func findOption(product *models.Product, id uint) *models.ProductOption {
+	for i := range product.Options {
+		if product.Options[i].Id == id {
+			return &product.Options[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package productvariant
+
+import (
+	"context"
+	"testing"
+
+	dto "commerce/api/internal/dto/product"
+	"commerce/internal/shared/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+type mocks struct {
+	productRepo *MockProductRepositoryI
+	optionRepo  *MockProductOptionRepositoryI
+	repo        *MockProductVariantRepositoryI
+}
+
+func setup(t *testing.T) (*mocks, ProductVariantServiceI) {
+	t.Helper()
+	ctl := gomock.NewController(t)
+	t.Cleanup(ctl.Finish)
+	m := &mocks{
+		productRepo: NewMockProductRepositoryI(ctl),
+		optionRepo:  NewMockProductOptionRepositoryI(ctl),
+		repo:        NewMockProductVariantRepositoryI(ctl),
+	}
+	return m, NewProductVariantService(m.productRepo, m.optionRepo, m.repo)
+}
+
+// shirt is sold in sizes S and M and colors red and blue, and already has a
+// small red variant.
+func shirt() *models.Product {
+	price := float32(25)
+	return &models.Product{
+		Base:  models.Base{Id: 1},
+		Price: 20,
+		Options: []models.ProductOption{
+			{Base: models.Base{Id: 1}, ProductId: 1, Name: "size", Values: []models.OptionValue{
+				{Base: models.Base{Id: 10}, OptionId: 1, Value: "S"},
+				{Base: models.Base{Id: 11}, OptionId: 1, Value: "M"},
+			}},
+			{Base: models.Base{Id: 2}, ProductId: 1, Name: "color", Position: 1, Values: []models.OptionValue{
+				{Base: models.Base{Id: 20}, OptionId: 2, Value: "red"},
+				{Base: models.Base{Id: 21}, OptionId: 2, Value: "blue"},
+			}},
+		},
+		Variants: []models.ProductVariant{
+			{Base: models.Base{Id: 100}, ProductId: 1, Sku: "SHIRT-S-RED", Price: &price, IsActive: true, OptionValues: []models.VariantOptionValue{
+				{VariantId: 100, OptionValueId: 10, OptionValue: models.OptionValue{Base: models.Base{Id: 10}, OptionId: 1, Value: "S"}},
+				{VariantId: 100, OptionValueId: 20, OptionValue: models.OptionValue{Base: models.Base{Id: 20}, OptionId: 2, Value: "red"}},
+			}},
+		},
+	}
+}
+
+func TestGetVariants(t *testing.T) {
+	m, svc := setup(t)
+	m.productRepo.EXPECT().GetById(gomock.Any(), uint(1)).Return(shirt(), nil)
+
+	variants, err := svc.GetVariants(context.Background(), 1)
+	require.NoError(t, err)
+	require.Len(t, variants, 1)
+	assert.Equal(t, float32(25), variants[0].Price, "the override should win over the product's price")
+	assert.Equal(t, map[string]string{"size": "S", "color": "red"}, variants[0].Options)
+	assert.ElementsMatch(t, []uint{10, 20}, variants[0].OptionValueIds)
+}
+
+func TestSaveVariant(t *testing.T) {
+	m, svc := setup(t)
+	m.productRepo.EXPECT().GetById(gomock.Any(), uint(1)).Return(shirt(), nil)
+	m.repo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, v *models.ProductVariant) error {
+		assert.Equal(t, uint(1), v.ProductId)
+		assert.Len(t, v.OptionValues, 2)
+		v.Id = 101
+		return nil
+	})
+	m.repo.EXPECT().GetById(gomock.Any(), uint(101)).Return(&models.ProductVariant{
+		Base:      models.Base{Id: 101},
+		ProductId: 1,
+		Sku:       "SHIRT-M-BLUE",
+		IsActive:  true,
+		OptionValues: []models.VariantOptionValue{
+			{OptionValueId: 11, OptionValue: models.OptionValue{OptionId: 1, Value: "M"}},
+			{OptionValueId: 21, OptionValue: models.OptionValue{OptionId: 2, Value: "blue"}},
+		},
+	}, nil)
+
+	variant := dto.Variant{Sku: "SHIRT-M-BLUE", IsActive: true, OptionValueIds: []uint{21, 11}}
+	err := svc.SaveVariant(context.Background(), 1, &variant)
+	require.NoError(t, err)
+	assert.Equal(t, uint(101), variant.Id)
+	assert.Equal(t, float32(20), variant.Price, "without an override the product's price applies")
+	assert.Equal(t, map[string]string{"size": "M", "color": "blue"}, variant.Options)
+}
+
+func TestSaveVariantInvalidOptionValues(t *testing.T) {
+	tests := map[string][]uint{
+		"missing an option":     {11},
+		"two values of one":     {10, 11},
+		"another product's":     {11, 99},
+		"same as another":       {20, 10},
+		"no values for options": {},
+	}
+	for name, ids := range tests {
+		t.Run(name, func(t *testing.T) {
+			m, svc := setup(t)
+			m.productRepo.EXPECT().GetById(gomock.Any(), uint(1)).Return(shirt(), nil)
+
+			err := svc.SaveVariant(context.Background(), 1, &dto.Variant{Sku: "SHIRT", OptionValueIds: ids})
+			assert.ErrorIs(t, err, ErrInvalidVariant)
+		})
+	}
+}
+
+func TestSaveVariantOfAnotherProduct(t *testing.T) {
+	m, svc := setup(t)
+	m.productRepo.EXPECT().GetById(gomock.Any(), uint(1)).Return(shirt(), nil)
+
+	err := svc.SaveVariant(context.Background(), 1, &dto.Variant{Id: 555, Sku: "SHIRT", OptionValueIds: []uint{11, 21}})
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestSaveOptionRemovingUsedValue(t *testing.T) {
+	m, svc := setup(t)
+	m.productRepo.EXPECT().GetById(gomock.Any(), uint(1)).Return(shirt(), nil)
+
+	option := dto.Option{Id: 1, Name: "size", Values: []dto.OptionValue{{Id: 11, Value: "M"}}}
+	err := svc.SaveOption(context.Background(), 1, &option)
+	assert.ErrorIs(t, err, ErrOptionInUse)
+}
+
+func TestSaveOptionRemovingUnusedValue(t *testing.T) {
+	m, svc := setup(t)
+	m.productRepo.EXPECT().GetById(gomock.Any(), uint(1)).Return(shirt(), nil)
+	m.optionRepo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, o *models.ProductOption) error {
+		assert.Equal(t, 0, o.Position, "an option keeps its position")
+		assert.Len(t, o.Values, 2)
+		return nil
+	})
+
+	option := dto.Option{Id: 1, Name: "size", Values: []dto.OptionValue{{Id: 10, Value: "S"}, {Value: "L"}}}
+	err := svc.SaveOption(context.Background(), 1, &option)
+	assert.NoError(t, err)
+}
+
+func TestAddOptionToProductWithVariants(t *testing.T) {
+	m, svc := setup(t)
+	m.productRepo.EXPECT().GetById(gomock.Any(), uint(1)).Return(shirt(), nil)
+
+	option := dto.Option{Name: "fit", Values: []dto.OptionValue{{Value: "slim"}}}
+	err := svc.SaveOption(context.Background(), 1, &option)
+	assert.ErrorIs(t, err, ErrOptionInUse)
+}
+
+func TestDeleteVariantOfAnotherProduct(t *testing.T) {
+	m, svc := setup(t)
+	m.repo.EXPECT().GetById(gomock.Any(), uint(100)).Return(&models.ProductVariant{Base: models.Base{Id: 100}, ProductId: 2}, nil)
+
+	err := svc.DeleteVariant(context.Background(), 1, 100)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
@@ -1,64 +1,186 @@
 package product
 
 import (
+	category_dto "commerce/api/internal/dto/category"
+	"commerce/api/internal/dto/page"
 	dto "commerce/api/internal/dto/product"
+	"commerce/api/internal/storage"
+	"commerce/internal/shared/models"
 	repo "commerce/internal/shared/repositories/product"
+	"commerce/internal/shared/repositories/query"
+	"context"
+	"errors"
 	"log/slog"
 )
 
+// ErrInvalidPriceRange is returned by Search when the minimum price is above
+// the maximum.
+var ErrInvalidPriceRange = errors.New("min_price can't be above max_price")
+
 type ProductServiceI interface {
-	GetById(id uint) (*dto.Product, error)
-	GetAll() ([]*dto.Product, error)
-	GetAllByCategory(categoryId uint) ([]*dto.Product, error)
-	Save(product *dto.Product) error
-	Delete(id uint, hard bool) error
+	GetById(ctx context.Context, id uint) (*dto.Product, error)
+	GetAll(ctx context.Context, opts query.Options) (*page.Page[dto.Product], error)
+	GetAllByCategory(ctx context.Context, categoryId uint, opts query.Options) (*page.Page[dto.Product], error)
+	Save(ctx context.Context, product *dto.Product) error
+	Update(ctx context.Context, id uint, product *dto.Product, setStock bool) error
+	Delete(ctx context.Context, id uint, hard bool) error
+	Restore(ctx context.Context, id uint) error
+	Search(ctx context.Context, search dto.SearchQuery, opts query.Options) (*dto.SearchResult, error)
+	GetCategories(ctx context.Context, id uint) ([]category_dto.Category, error)
+	SetCategories(ctx context.Context, id uint, categoryIds []uint) ([]category_dto.Category, error)
+	AddCategory(ctx context.Context, id uint, categoryId uint) ([]category_dto.Category, error)
+	RemoveCategory(ctx context.Context, id uint, categoryId uint) error
 }
 
 type ProductService struct {
-	repo repo.ProductRepositoryI
+	repo      repo.ProductRepositoryI
+	fromModel func(*models.Product) *dto.Product
 }
 
-func NewProductService(repo repo.ProductRepositoryI) ProductServiceI {
-	return &ProductService{repo: repo}
+// NewProductService makes the service. store is where product images are
+// kept; it resolves their URLs in responses.
+func NewProductService(repo repo.ProductRepositoryI, store storage.BlobStore) ProductServiceI {
+	return &ProductService{repo: repo, fromModel: dto.FromModelWithImages(store.URL)}
 }
 
 // Delete implements [ProductServiceI].
-func (p *ProductService) Delete(id uint, hard bool) error {
-	return p.repo.Delete(id, hard)
+func (p *ProductService) Delete(ctx context.Context, id uint, hard bool) error {
+	return p.repo.Delete(ctx, id, hard)
+}
+
+// Restore implements [ProductServiceI].
+func (p *ProductService) Restore(ctx context.Context, id uint) error {
+	if err := p.repo.Restore(ctx, id); err != nil {
+		slog.Error("Exception occurred restoring product.", "id", id, "error", err)
+		return err
+	}
+	return nil
 }
 
 // GetAll implements [ProductServiceI].
-func (p *ProductService) GetAll() ([]*dto.Product, error) {
-	models, err := p.repo.GetAll()
+func (p *ProductService) GetAll(ctx context.Context, opts query.Options) (*page.Page[dto.Product], error) {
+	models, err := p.repo.GetAll(ctx, opts)
 	if err != nil {
 		slog.Error("Exception thrown when getting all product", "error", err)
 		return nil, err
 	}
-	return dto.FromAllModels(models), nil
+	return page.FromPage(models, p.fromModel), nil
 }
 
 // GetAllByCategory implements [ProductServiceI].
-func (p *ProductService) GetAllByCategory(categoryId uint) ([]*dto.Product, error) {
-	models, err := p.repo.GetAllByCategoryId(categoryId)
+func (p *ProductService) GetAllByCategory(ctx context.Context, categoryId uint, opts query.Options) (*page.Page[dto.Product], error) {
+	models, err := p.repo.GetAllByCategoryId(ctx, categoryId, opts)
 	if err != nil {
 		slog.Error("Exception thrown when getting product by category", "error", err)
 		return nil, err
 	}
-	return dto.FromAllModels(models), nil
+	return page.FromPage(models, p.fromModel), nil
+}
+
+// Search implements [ProductServiceI].
+func (p *ProductService) Search(ctx context.Context, search dto.SearchQuery, opts query.Options) (*dto.SearchResult, error) {
+	if search.MinPrice != nil && search.MaxPrice != nil && *search.MinPrice > *search.MaxPrice {
+		return nil, ErrInvalidPriceRange
+	}
+	result, err := p.repo.Search(ctx, dto.ToSearchFilter(search), opts)
+	if err != nil {
+		slog.Error("Exception thrown when searching products", "query", search.Q, "error", err)
+		return nil, err
+	}
+	return dto.FromSearchResult(result, p.fromModel), nil
 }
 
 // GetById implements [ProductServiceI].
-func (p *ProductService) GetById(id uint) (*dto.Product, error) {
-	model, err := p.repo.GetById(id)
+func (p *ProductService) GetById(ctx context.Context, id uint) (*dto.Product, error) {
+	model, err := p.repo.GetById(ctx, id)
 	if err != nil {
 		slog.Error("Exception thrown when getting product by id", "error", err)
 		return nil, err
 	}
-	return dto.FromModel(model), nil
+	return p.fromModel(model), nil
 }
 
-// Save implements [ProductServiceI].
-func (p *ProductService) Save(product *dto.Product) error {
+// Save implements [ProductServiceI]. It always creates a product; see Update.
+func (p *ProductService) Save(ctx context.Context, product *dto.Product) error {
 	model := dto.ToModel(product)
-	return p.repo.Save(model)
+	var err error
+	if product.CategoryIds == nil {
+		err = p.repo.Save(ctx, model)
+	} else {
+		// Create the product and its category links together, so an unknown
+		// category doesn't leave a product behind.
+		err = p.repo.Update(ctx, model, product.CategoryIds, true)
+	}
+	if err != nil {
+		return err
+	}
+	product.Id = model.Id
+	return nil
+}
+
+// Update implements [ProductServiceI]. The product's categories are replaced
+// when CategoryIds is given and kept otherwise. Its stock is only written when
+// setStock is true, that is when the request names it.
+func (p *ProductService) Update(ctx context.Context, id uint, product *dto.Product, setStock bool) error {
+	existing, err := p.repo.GetById(ctx, id)
+	if err != nil {
+		slog.Error("Exception thrown when getting product to update", "id", id, "error", err)
+		return err
+	}
+	model := dto.ToModel(product)
+	model.Base = existing.Base
+	if err := p.repo.Update(ctx, model, product.CategoryIds, setStock); err != nil {
+		slog.Error("Exception thrown when updating product", "id", id, "error", err)
+		return err
+	}
+	updated, err := p.repo.GetById(ctx, id)
+	if err != nil {
+		return err
+	}
+	*product = *p.fromModel(updated)
+	return nil
+}
+
+// GetCategories implements [ProductServiceI].
+func (p *ProductService) GetCategories(ctx context.Context, id uint) ([]category_dto.Category, error) {
+	product, err := p.repo.GetById(ctx, id)
+	if err != nil {
+		slog.Error("Exception thrown when getting product categories", "id", id, "error", err)
+		return nil, err
+	}
+	return dto.FromModel(product).Categories, nil
+}
+
+// SetCategories implements [ProductServiceI]. The product ends up in exactly
+// the given categories, and they are returned.
+func (p *ProductService) SetCategories(ctx context.Context, id uint, categoryIds []uint) ([]category_dto.Category, error) {
+	if _, err := p.repo.GetById(ctx, id); err != nil {
+		return nil, err
+	}
+	if err := p.repo.SetCategories(ctx, id, categoryIds); err != nil {
+		slog.Error("Exception thrown when setting product categories", "id", id, "error", err)
+		return nil, err
+	}
+	return p.GetCategories(ctx, id)
+}
+
+// AddCategory implements [ProductServiceI]. It returns the product's
+// categories after the addition.
+func (p *ProductService) AddCategory(ctx context.Context, id uint, categoryId uint) ([]category_dto.Category, error) {
+	if _, err := p.repo.GetById(ctx, id); err != nil {
+		return nil, err
+	}
+	if err := p.repo.AddCategory(ctx, id, categoryId); err != nil {
+		slog.Error("Exception thrown when adding product to category", "id", id, "category-id", categoryId, "error", err)
+		return nil, err
+	}
+	return p.GetCategories(ctx, id)
+}
+
+// RemoveCategory implements [ProductServiceI].
+func (p *ProductService) RemoveCategory(ctx context.Context, id uint, categoryId uint) error {
+	if _, err := p.repo.GetById(ctx, id); err != nil {
+		return err
+	}
+	return p.repo.RemoveCategory(ctx, id, categoryId)
 }
@@ -0,0 +1,66 @@
+package product
+
+import (
+	"context"
+	"testing"
+
+	dto "commerce/api/internal/dto/product"
+	"commerce/api/internal/storage"
+	"commerce/internal/shared/models"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func setup(t *testing.T) (*MockProductRepositoryI, ProductServiceI) {
+	t.Helper()
+	ctl := gomock.NewController(t)
+	t.Cleanup(ctl.Finish)
+	repo := NewMockProductRepositoryI(ctl)
+	return repo, NewProductService(repo, storage.NewLocalStore(t.TempDir(), "/media"))
+}
+
+func existingProduct() *models.Product {
+	return &models.Product{Base: models.Base{Id: 3}, Name: "Mug", Sku: "MUG-1", Price: 12, Stock: 7}
+}
+
+func TestUpdateKeepsStockUnlessSet(t *testing.T) {
+	for _, setStock := range []bool{false, true} {
+		repo, svc := setup(t)
+		repo.EXPECT().GetById(gomock.Any(), uint(3)).Return(existingProduct(), nil).Times(2)
+		repo.EXPECT().Update(gomock.Any(), gomock.Any(), []uint(nil), setStock).
+			DoAndReturn(func(_ context.Context, p *models.Product, _ []uint, _ bool) error {
+				assert.Equal(t, uint(3), p.Id, "the product keeps its id")
+				assert.Equal(t, "Big mug", p.Name)
+				return nil
+			})
+
+		err := svc.Update(context.Background(), 3, &dto.Product{Name: "Big mug", Sku: "MUG-1", Stock: 7}, setStock)
+		assert.NoError(t, err)
+	}
+}
+
+func TestUpdateMissingProduct(t *testing.T) {
+	repo, svc := setup(t)
+	repo.EXPECT().GetById(gomock.Any(), uint(3)).Return(nil, gorm.ErrRecordNotFound)
+
+	err := svc.Update(context.Background(), 3, &dto.Product{Name: "Big mug", Sku: "MUG-1"}, false)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestSaveCreatesWithStock(t *testing.T) {
+	repo, svc := setup(t)
+	repo.EXPECT().Update(gomock.Any(), gomock.Any(), []uint{1}, true).
+		DoAndReturn(func(_ context.Context, p *models.Product, _ []uint, _ bool) error {
+			assert.Zero(t, p.Id)
+			assert.Equal(t, 5, p.Stock)
+			p.Id = 9
+			return nil
+		})
+	product := &dto.Product{Name: "Mug", Sku: "MUG-2", Stock: 5, CategoryIds: []uint{1}}
+
+	err := svc.Save(context.Background(), product)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(9), product.Id)
+}
@@ -0,0 +1,115 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../../../../internal/shared/repositories/invoice/invoice_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=../../../../internal/shared/repositories/invoice/invoice_repository.go -destination=mock_invoice_repo_test.go -package=returnrequest
+//
+
+// Package returnrequest is a generated GoMock package.
+package returnrequest
+
+import (
+	models "commerce/internal/shared/models"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockInvoiceRepositoryI is a mock of InvoiceRepositoryI interface.
+type MockInvoiceRepositoryI struct {
+	ctrl     *gomock.Controller
+	recorder *MockInvoiceRepositoryIMockRecorder
+	isgomock struct{}
+}
+
+// MockInvoiceRepositoryIMockRecorder is the mock recorder for MockInvoiceRepositoryI.
+type MockInvoiceRepositoryIMockRecorder struct {
+	mock *MockInvoiceRepositoryI
+}
+
+// NewMockInvoiceRepositoryI creates a new mock instance.
+func NewMockInvoiceRepositoryI(ctrl *gomock.Controller) *MockInvoiceRepositoryI {
+	mock := &MockInvoiceRepositoryI{ctrl: ctrl}
+	mock.recorder = &MockInvoiceRepositoryIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInvoiceRepositoryI) EXPECT() *MockInvoiceRepositoryIMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockInvoiceRepositoryI) Create(ctx context.Context, invoice *models.Invoice) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, invoice)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockInvoiceRepositoryIMockRecorder) Create(ctx, invoice any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockInvoiceRepositoryI)(nil).Create), ctx, invoice)
+}
+
+// GetAllByOrderId mocks base method.
+func (m *MockInvoiceRepositoryI) GetAllByOrderId(ctx context.Context, orderId uint) ([]*models.Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllByOrderId", ctx, orderId)
+	ret0, _ := ret[0].([]*models.Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllByOrderId indicates an expected call of GetAllByOrderId.
+func (mr *MockInvoiceRepositoryIMockRecorder) GetAllByOrderId(ctx, orderId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByOrderId", reflect.TypeOf((*MockInvoiceRepositoryI)(nil).GetAllByOrderId), ctx, orderId)
+}
+
+// GetById mocks base method.
+func (m *MockInvoiceRepositoryI) GetById(ctx context.Context, id uint) (*models.Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(*models.Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockInvoiceRepositoryIMockRecorder) GetById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockInvoiceRepositoryI)(nil).GetById), ctx, id)
+}
+
+// LockInvoice mocks base method.
+func (m *MockInvoiceRepositoryI) LockInvoice(ctx context.Context, orderId uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockInvoice", ctx, orderId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockInvoice indicates an expected call of LockInvoice.
+func (mr *MockInvoiceRepositoryIMockRecorder) LockInvoice(ctx, orderId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockInvoice", reflect.TypeOf((*MockInvoiceRepositoryI)(nil).LockInvoice), ctx, orderId)
+}
+
+// NextNumber mocks base method.
+func (m *MockInvoiceRepositoryI) NextNumber(ctx context.Context, invoiceType models.InvoiceType) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextNumber", ctx, invoiceType)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NextNumber indicates an expected call of NextNumber.
+func (mr *MockInvoiceRepositoryIMockRecorder) NextNumber(ctx, invoiceType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextNumber", reflect.TypeOf((*MockInvoiceRepositoryI)(nil).NextNumber), ctx, invoiceType)
+}
@@ -0,0 +1,115 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../../../../internal/shared/repositories/return-request/return_request_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=../../../../internal/shared/repositories/return-request/return_request_repository.go -destination=mock_return_request_repo_test.go -package=returnrequest
+//
+
+// Package returnrequest is a generated GoMock package.
+package returnrequest
+
+import (
+	models "commerce/internal/shared/models"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReturnRequestRepositoryI is a mock of ReturnRequestRepositoryI interface.
+type MockReturnRequestRepositoryI struct {
+	ctrl     *gomock.Controller
+	recorder *MockReturnRequestRepositoryIMockRecorder
+	isgomock struct{}
+}
+
+// MockReturnRequestRepositoryIMockRecorder is the mock recorder for MockReturnRequestRepositoryI.
+type MockReturnRequestRepositoryIMockRecorder struct {
+	mock *MockReturnRequestRepositoryI
+}
+
+// NewMockReturnRequestRepositoryI creates a new mock instance.
+func NewMockReturnRequestRepositoryI(ctrl *gomock.Controller) *MockReturnRequestRepositoryI {
+	mock := &MockReturnRequestRepositoryI{ctrl: ctrl}
+	mock.recorder = &MockReturnRequestRepositoryIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReturnRequestRepositoryI) EXPECT() *MockReturnRequestRepositoryIMockRecorder {
+	return m.recorder
+}
+
+// GetAllByOrderId mocks base method.
+func (m *MockReturnRequestRepositoryI) GetAllByOrderId(ctx context.Context, orderId uint) ([]*models.ReturnRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllByOrderId", ctx, orderId)
+	ret0, _ := ret[0].([]*models.ReturnRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllByOrderId indicates an expected call of GetAllByOrderId.
+func (mr *MockReturnRequestRepositoryIMockRecorder) GetAllByOrderId(ctx, orderId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByOrderId", reflect.TypeOf((*MockReturnRequestRepositoryI)(nil).GetAllByOrderId), ctx, orderId)
+}
+
+// GetById mocks base method.
+func (m *MockReturnRequestRepositoryI) GetById(ctx context.Context, id uint) (*models.ReturnRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(*models.ReturnRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockReturnRequestRepositoryIMockRecorder) GetById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockReturnRequestRepositoryI)(nil).GetById), ctx, id)
+}
+
+// GetByIdForUpdate mocks base method.
+func (m *MockReturnRequestRepositoryI) GetByIdForUpdate(ctx context.Context, id uint) (*models.ReturnRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIdForUpdate", ctx, id)
+	ret0, _ := ret[0].(*models.ReturnRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIdForUpdate indicates an expected call of GetByIdForUpdate.
+func (mr *MockReturnRequestRepositoryIMockRecorder) GetByIdForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIdForUpdate", reflect.TypeOf((*MockReturnRequestRepositoryI)(nil).GetByIdForUpdate), ctx, id)
+}
+
+// Save mocks base method.
+func (m *MockReturnRequestRepositoryI) Save(ctx context.Context, returnRequest *models.ReturnRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, returnRequest)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockReturnRequestRepositoryIMockRecorder) Save(ctx, returnRequest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockReturnRequestRepositoryI)(nil).Save), ctx, returnRequest)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockReturnRequestRepositoryI) UpdateStatus(ctx context.Context, id uint, status string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, id, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockReturnRequestRepositoryIMockRecorder) UpdateStatus(ctx, id, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockReturnRequestRepositoryI)(nil).UpdateStatus), ctx, id, status)
+}
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../../../../internal/shared/repositories/uow/unit_of_work.go
+//
+// Generated by this command:
+//
+//	mockgen -source=../../../../internal/shared/repositories/uow/unit_of_work.go -destination=mock_unit_of_work_test.go -package=returnrequest
+//
+
+// Package returnrequest is a generated GoMock package.
+package returnrequest
+
+import (
+	uow "commerce/internal/shared/repositories/uow"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUnitOfWorkI is a mock of UnitOfWorkI interface.
+type MockUnitOfWorkI struct {
+	ctrl     *gomock.Controller
+	recorder *MockUnitOfWorkIMockRecorder
+	isgomock struct{}
+}
+
+// MockUnitOfWorkIMockRecorder is the mock recorder for MockUnitOfWorkI.
+type MockUnitOfWorkIMockRecorder struct {
+	mock *MockUnitOfWorkI
+}
+
+// NewMockUnitOfWorkI creates a new mock instance.
+func NewMockUnitOfWorkI(ctrl *gomock.Controller) *MockUnitOfWorkI {
+	mock := &MockUnitOfWorkI{ctrl: ctrl}
+	mock.recorder = &MockUnitOfWorkIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUnitOfWorkI) EXPECT() *MockUnitOfWorkIMockRecorder {
+	return m.recorder
+}
+
+// Do mocks base method.
+func (m *MockUnitOfWorkI) Do(ctx context.Context, fn func(*uow.Repositories) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Do", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Do indicates an expected call of Do.
+func (mr *MockUnitOfWorkIMockRecorder) Do(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Do", reflect.TypeOf((*MockUnitOfWorkI)(nil).Do), ctx, fn)
+}
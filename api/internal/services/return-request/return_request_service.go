@@ -0,0 +1,227 @@
+package returnrequest
+
+import (
+	dto "commerce/api/internal/dto/return-request"
+	invoice_service "commerce/api/internal/services/invoice"
+	payment_service "commerce/api/internal/services/payment"
+	tax_service "commerce/api/internal/services/tax"
+	"commerce/internal/shared/models"
+	repo "commerce/internal/shared/repositories/return-request"
+	"commerce/internal/shared/repositories/uow"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+)
+
+type ReturnRequestServiceI interface {
+	GetById(ctx context.Context, id uint) (*dto.ReturnRequest, error)
+	GetByOrderId(ctx context.Context, orderId uint) ([]*dto.ReturnRequest, error)
+	Create(ctx context.Context, returnRequest dto.ReturnRequest) (*dto.ReturnRequest, error)
+	Approve(ctx context.Context, id uint) error
+	Reject(ctx context.Context, id uint) error
+	Receive(ctx context.Context, id uint) (*dto.ReturnRequest, error)
+}
+
+type ReturnRequestService struct {
+	repo repo.ReturnRequestRepositoryI
+	uow  uow.UnitOfWorkI
+}
+
+func NewReturnRequestService(repo repo.ReturnRequestRepositoryI,
+	uow uow.UnitOfWorkI) ReturnRequestServiceI {
+	return &ReturnRequestService{
+		repo: repo,
+		uow:  uow,
+	}
+}
+
+// GetById implements [ReturnRequestServiceI].
+func (r *ReturnRequestService) GetById(ctx context.Context, id uint) (*dto.ReturnRequest, error) {
+	model, err := r.repo.GetById(ctx, id)
+	if err != nil {
+		slog.Error("Exception occurred getting return by id.", "id", id, "error", err)
+		return nil, err
+	}
+	return dto.FromModel(model), nil
+}
+
+// GetByOrderId implements [ReturnRequestServiceI].
+func (r *ReturnRequestService) GetByOrderId(ctx context.Context, orderId uint) ([]*dto.ReturnRequest, error) {
+	models, err := r.repo.GetAllByOrderId(ctx, orderId)
+	if err != nil {
+		slog.Error("Exception occurred getting returns by order.", "order-id", orderId, "error", err)
+		return nil, err
+	}
+	returnRequests := make([]*dto.ReturnRequest, len(models))
+	for i, model := range models {
+		returnRequests[i] = dto.FromModel(model)
+	}
+	return returnRequests, nil
+}
+
+// Create implements [ReturnRequestServiceI]. Only goods that have shipped can
+// be returned, and never more of an item than shipped minus what earlier,
+// non-rejected returns already cover. The order's row is locked from the
+// check until the return is saved, so two returns requested at once can't
+// both claim the same goods.
+func (r *ReturnRequestService) Create(ctx context.Context, returnRequest dto.ReturnRequest) (*dto.ReturnRequest, error) {
+	var created *models.ReturnRequest
+	err := r.uow.Do(ctx, func(repos *uow.Repositories) error {
+		order, err := repos.Orders.GetByIdForUpdate(ctx, returnRequest.OrderId)
+		if err != nil {
+			return err
+		}
+		if _, ok := returnableOrderStatuses[order.Status]; !ok {
+			return fmt.Errorf("order %d is %s and cannot be returned", order.Id, order.Status)
+		}
+		existing, err := repos.ReturnRequests.GetAllByOrderId(ctx, order.Id)
+		if err != nil {
+			return err
+		}
+		returnable := returnableQuantities(order, existing)
+
+		for _, item := range returnRequest.Items {
+			remaining, ok := returnable[item.OrderItemId]
+			if !ok {
+				return fmt.Errorf("order item %d does not belong to order %d", item.OrderItemId, order.Id)
+			}
+			if item.Quantity <= 0 || item.Quantity > remaining {
+				return fmt.Errorf("cannot return %d of order item %d, %d returnable", item.Quantity, item.OrderItemId, remaining)
+			}
+			returnable[item.OrderItemId] = remaining - item.Quantity
+		}
+
+		returnRequest.Status = string(models.ReturnStatusRequested)
+		created = dto.ToModel(&returnRequest)
+		return repos.ReturnRequests.Save(ctx, created)
+	})
+	if err != nil {
+		slog.Error("Exception occurred creating return.", "order-id", returnRequest.OrderId, "error", err)
+		return nil, err
+	}
+	return dto.FromModel(created), nil
+}
+
+// Approve implements [ReturnRequestServiceI].
+func (r *ReturnRequestService) Approve(ctx context.Context, id uint) error {
+	return r.transition(ctx, id, models.ReturnStatusRequested, models.ReturnStatusApproved)
+}
+
+// Reject implements [ReturnRequestServiceI].
+func (r *ReturnRequestService) Reject(ctx context.Context, id uint) error {
+	return r.transition(ctx, id, models.ReturnStatusRequested, models.ReturnStatusRejected)
+}
+
+// Receive implements [ReturnRequestServiceI]. Receiving an approved return
+// puts its goods back in stock and refunds them, tax included, against the
+// order's payment with a credit note on its invoice, all in one transaction.
+// The return's row is locked while that happens, so a concurrent receive sees
+// it received and fails rather than refunding twice. When the order has no
+// payment that can cover the refund, the return stays received for manual
+// follow-up.
+func (r *ReturnRequestService) Receive(ctx context.Context, id uint) (*dto.ReturnRequest, error) {
+	var received *models.ReturnRequest
+	err := r.uow.Do(ctx, func(repos *uow.Repositories) error {
+		model, err := repos.ReturnRequests.GetByIdForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if model.Status != models.ReturnStatusApproved {
+			return fmt.Errorf("return %d is %s and cannot be received", id, model.Status)
+		}
+
+		for _, item := range model.Items {
+			if err := repos.AdjustStock(ctx, item.OrderItem, item.Quantity); err != nil {
+				return err
+			}
+		}
+
+		now := time.Now()
+		model.ReceivedDate = &now
+		model.RefundAmount = refundAmount(model)
+		model.Status = models.ReturnStatusReceived
+
+		payments := payment_service.NewPaymentService(repos.Payments)
+		payment, err := payments.RefundOrder(ctx, model.OrderId, model.RefundAmount)
+		switch {
+		case errors.Is(err, payment_service.ErrNoRefundablePayment):
+			slog.Warn("No refundable payment for return.", "id", id, "order-id", model.OrderId, "amount", model.RefundAmount)
+		case err != nil:
+			return err
+		default:
+			model.PaymentId = &payment.Id
+			model.Status = models.ReturnStatusRefunded
+			invoices := invoice_service.NewInvoiceService(repos.Invoices, repos.Orders, repos.Payments, tax_service.NewTaxService())
+			reason := fmt.Sprintf("Return #%d: %s", id, model.Reason)
+			if _, err := invoices.IssueCreditNote(ctx, model.OrderId, model.RefundAmount, reason); err != nil && !errors.Is(err, invoice_service.ErrNotInvoiced) {
+				return err
+			}
+		}
+
+		received = model
+		return repos.ReturnRequests.Save(ctx, model)
+	})
+	if err != nil {
+		slog.Error("Exception occurred receiving return.", "id", id, "error", err)
+		return nil, err
+	}
+	return dto.FromModel(received), nil
+}
+
+func (r *ReturnRequestService) transition(ctx context.Context, id uint, from models.ReturnStatus, to models.ReturnStatus) error {
+	model, err := r.repo.GetById(ctx, id)
+	if err != nil {
+		slog.Error("Exception occurred getting return by id.", "id", id, "error", err)
+		return err
+	}
+	if model.Status != from {
+		return fmt.Errorf("return %d is %s and cannot be %s", id, model.Status, to)
+	}
+	return r.repo.UpdateStatus(ctx, id, string(to))
+}
+
+var returnableOrderStatuses = map[models.OrderStatus]struct{}{
+	models.OrderStatusPartiallyShipped: {},
+	models.OrderStatusShipped:          {},
+	models.OrderStatusDelivered:        {},
+}
+
+// returnableQuantities maps each of the order's items to the quantity that has
+// shipped and isn't covered by another return yet.
+func returnableQuantities(order *models.Order, returns []*models.ReturnRequest) map[uint]int {
+	returnable := make(map[uint]int, len(order.OrderItems))
+	for _, item := range order.OrderItems {
+		returnable[item.Id] = 0
+	}
+	for _, shipment := range order.Shipments {
+		for _, item := range shipment.Items {
+			returnable[item.OrderItemId] += item.Quantity
+		}
+	}
+	for _, ret := range returns {
+		if ret.Status == models.ReturnStatusRejected {
+			continue
+		}
+		for _, item := range ret.Items {
+			returnable[item.OrderItemId] -= item.Quantity
+		}
+	}
+	return returnable
+}
+
+// refundAmount is the returned merchandise plus its share of the order's tax.
+// Shipping isn't refunded.
+func refundAmount(returnRequest *models.ReturnRequest) float64 {
+	merchandise := 0.0
+	for _, item := range returnRequest.Items {
+		merchandise += item.OrderItem.UnitPrice * float64(item.Quantity)
+	}
+	tax := 0.0
+	if order := returnRequest.Order; order.SubTotalAmount > 0 {
+		tax = merchandise * order.TaxAmount / order.SubTotalAmount
+	}
+	return math.Round((merchandise+tax)*100) / 100
+}
@@ -0,0 +1,201 @@
+package returnrequest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	dto "commerce/api/internal/dto/return-request"
+	"commerce/internal/shared/models"
+	"commerce/internal/shared/repositories/uow"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type mocks struct {
+	repo        *MockReturnRequestRepositoryI
+	invoiceRepo *MockInvoiceRepositoryI
+	orderRepo   *MockOrderRepositoryI
+	paymentRepo *MockPaymentRepositoryI
+	productRepo *MockProductRepositoryI
+}
+
+func setup(t *testing.T) (*mocks, ReturnRequestServiceI) {
+	t.Helper()
+	ctl := gomock.NewController(t)
+	t.Cleanup(ctl.Finish)
+	m := &mocks{
+		repo:        NewMockReturnRequestRepositoryI(ctl),
+		invoiceRepo: NewMockInvoiceRepositoryI(ctl),
+		orderRepo:   NewMockOrderRepositoryI(ctl),
+		paymentRepo: NewMockPaymentRepositoryI(ctl),
+		productRepo: NewMockProductRepositoryI(ctl),
+	}
+	mockUow := NewMockUnitOfWorkI(ctl)
+	mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, fn func(r *uow.Repositories) error) error {
+		return fn(&uow.Repositories{
+			Invoices:       m.invoiceRepo,
+			Orders:         m.orderRepo,
+			Payments:       m.paymentRepo,
+			Products:       m.productRepo,
+			ReturnRequests: m.repo,
+		})
+	}).AnyTimes()
+	return m, NewReturnRequestService(m.repo, mockUow)
+}
+
+func shippedOrder(status models.OrderStatus) *models.Order {
+	return &models.Order{
+		Base:   models.Base{Id: 1},
+		Status: status,
+		OrderItems: []models.OrderItem{
+			{Base: models.Base{Id: 10}, OrderId: 1, ProductId: 100, Quantity: 3, UnitPrice: 10},
+			{Base: models.Base{Id: 11}, OrderId: 1, ProductId: 101, Quantity: 1, UnitPrice: 20},
+		},
+		Shipments: []models.Shipment{
+			{OrderId: 1, Items: []models.ShipmentItem{{OrderItemId: 10, Quantity: 2}}},
+		},
+	}
+}
+
+func request(items ...dto.ReturnItem) dto.ReturnRequest {
+	return dto.ReturnRequest{OrderId: 1, Reason: "damaged", Items: items}
+}
+
+func TestCreate(t *testing.T) {
+	m, svc := setup(t)
+	m.orderRepo.EXPECT().GetByIdForUpdate(gomock.Any(), uint(1)).Return(shippedOrder(models.OrderStatusPartiallyShipped), nil)
+	m.repo.EXPECT().GetAllByOrderId(gomock.Any(), uint(1)).Return([]*models.ReturnRequest{
+		{Status: models.ReturnStatusRejected, Items: []models.ReturnItem{{OrderItemId: 10, Quantity: 2}}},
+	}, nil)
+	m.repo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, r *models.ReturnRequest) error {
+		assert.Equal(t, models.ReturnStatusRequested, r.Status)
+		return nil
+	})
+
+	returnRequest, err := svc.Create(context.Background(), request(dto.ReturnItem{OrderItemId: 10, Quantity: 2}))
+	assert.NoError(t, err)
+	assert.Equal(t, string(models.ReturnStatusRequested), returnRequest.Status)
+}
+
+func TestCreateMoreThanShipped(t *testing.T) {
+	m, svc := setup(t)
+	m.orderRepo.EXPECT().GetByIdForUpdate(gomock.Any(), uint(1)).Return(shippedOrder(models.OrderStatusPartiallyShipped), nil)
+	m.repo.EXPECT().GetAllByOrderId(gomock.Any(), uint(1)).Return([]*models.ReturnRequest{
+		{Status: models.ReturnStatusRequested, Items: []models.ReturnItem{{OrderItemId: 10, Quantity: 1}}},
+	}, nil)
+
+	_, err := svc.Create(context.Background(), request(dto.ReturnItem{OrderItemId: 10, Quantity: 2}))
+	assert.Error(t, err)
+}
+
+func TestCreateUnshippedItem(t *testing.T) {
+	m, svc := setup(t)
+	m.orderRepo.EXPECT().GetByIdForUpdate(gomock.Any(), uint(1)).Return(shippedOrder(models.OrderStatusPartiallyShipped), nil)
+	m.repo.EXPECT().GetAllByOrderId(gomock.Any(), uint(1)).Return([]*models.ReturnRequest{}, nil)
+
+	_, err := svc.Create(context.Background(), request(dto.ReturnItem{OrderItemId: 11, Quantity: 1}))
+	assert.Error(t, err)
+}
+
+func TestCreatePendingOrder(t *testing.T) {
+	m, svc := setup(t)
+	m.orderRepo.EXPECT().GetByIdForUpdate(gomock.Any(), uint(1)).Return(shippedOrder(models.OrderStatusPending), nil)
+
+	_, err := svc.Create(context.Background(), request(dto.ReturnItem{OrderItemId: 10, Quantity: 1}))
+	assert.Error(t, err)
+}
+
+func TestApprove(t *testing.T) {
+	m, svc := setup(t)
+	m.repo.EXPECT().GetById(gomock.Any(), uint(5)).Return(&models.ReturnRequest{Base: models.Base{Id: 5}, Status: models.ReturnStatusRequested}, nil)
+	m.repo.EXPECT().UpdateStatus(gomock.Any(), uint(5), string(models.ReturnStatusApproved)).Return(nil)
+	assert.NoError(t, svc.Approve(context.Background(), 5))
+}
+
+func TestRejectApproved(t *testing.T) {
+	m, svc := setup(t)
+	m.repo.EXPECT().GetById(gomock.Any(), uint(5)).Return(&models.ReturnRequest{Base: models.Base{Id: 5}, Status: models.ReturnStatusApproved}, nil)
+	assert.Error(t, svc.Reject(context.Background(), 5))
+}
+
+func approvedReturn() *models.ReturnRequest {
+	return &models.ReturnRequest{
+		Base:    models.Base{Id: 5},
+		OrderId: 1,
+		Status:  models.ReturnStatusApproved,
+		Order:   models.Order{Base: models.Base{Id: 1}, SubTotalAmount: 50, TaxAmount: 3},
+		Items: []models.ReturnItem{
+			{OrderItemId: 10, Quantity: 2, OrderItem: models.OrderItem{ProductId: 100, UnitPrice: 10}},
+		},
+	}
+}
+
+func TestReceiveRestocksAndRefunds(t *testing.T) {
+	m, svc := setup(t)
+	m.repo.EXPECT().GetByIdForUpdate(gomock.Any(), uint(5)).Return(approvedReturn(), nil)
+	m.productRepo.EXPECT().AdjustStock(gomock.Any(), uint(100), 2).Return(nil)
+	m.paymentRepo.EXPECT().GetByOrder(gomock.Any(), uint(1)).Return([]*models.Payment{
+		{Base: models.Base{Id: 7}, OrderId: 1, Amount: 53, Status: models.PaymentStatusCaptured},
+	}, nil)
+	m.paymentRepo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, p *models.Payment) error {
+		assert.InDelta(t, 21.20, p.RefundedAmount, 0.001)
+		assert.Equal(t, models.PaymentStatusPartiallyRefunded, p.Status)
+		return nil
+	})
+	m.invoiceRepo.EXPECT().LockInvoice(gomock.Any(), uint(1)).Return(nil)
+	m.invoiceRepo.EXPECT().GetAllByOrderId(gomock.Any(), uint(1)).Return([]*models.Invoice{
+		{Base: models.Base{Id: 9}, OrderId: 1, Type: models.InvoiceTypeInvoice, TaxAmount: 3, TotalAmount: 53},
+	}, nil)
+	m.invoiceRepo.EXPECT().NextNumber(gomock.Any(), models.InvoiceTypeCreditNote).Return(int64(1), nil)
+	m.invoiceRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, i *models.Invoice) error {
+		assert.Equal(t, models.InvoiceTypeCreditNote, i.Type)
+		assert.InDelta(t, 21.20, i.TotalAmount, 0.001)
+		assert.InDelta(t, 1.20, i.TaxAmount, 0.001)
+		assert.InDelta(t, 20.00, i.SubTotalAmount, 0.001)
+		return nil
+	})
+	m.repo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+	returnRequest, err := svc.Receive(context.Background(), 5)
+	assert.NoError(t, err)
+	assert.Equal(t, string(models.ReturnStatusRefunded), returnRequest.Status)
+	assert.InDelta(t, 21.20, returnRequest.RefundAmount, 0.001, "20.00 of goods plus 6% tax")
+	assert.Equal(t, uint(7), *returnRequest.PaymentId)
+	assert.NotNil(t, returnRequest.ReceivedDate)
+}
+
+func TestReceiveWithoutPayment(t *testing.T) {
+	m, svc := setup(t)
+	m.repo.EXPECT().GetByIdForUpdate(gomock.Any(), uint(5)).Return(approvedReturn(), nil)
+	m.productRepo.EXPECT().AdjustStock(gomock.Any(), uint(100), 2).Return(nil)
+	m.paymentRepo.EXPECT().GetByOrder(gomock.Any(), uint(1)).Return([]*models.Payment{
+		{Base: models.Base{Id: 7}, OrderId: 1, Amount: 53, Status: models.PaymentStatusFailed},
+	}, nil)
+	m.repo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+	returnRequest, err := svc.Receive(context.Background(), 5)
+	assert.NoError(t, err)
+	assert.Equal(t, string(models.ReturnStatusReceived), returnRequest.Status)
+	assert.Nil(t, returnRequest.PaymentId)
+}
+
+func TestReceiveNotApproved(t *testing.T) {
+	m, svc := setup(t)
+	ret := approvedReturn()
+	ret.Status = models.ReturnStatusRequested
+	m.repo.EXPECT().GetByIdForUpdate(gomock.Any(), uint(5)).Return(ret, nil)
+
+	_, err := svc.Receive(context.Background(), 5)
+	assert.Error(t, err)
+}
+
+func TestReceiveStockError(t *testing.T) {
+	m, svc := setup(t)
+	m.repo.EXPECT().GetByIdForUpdate(gomock.Any(), uint(5)).Return(approvedReturn(), nil)
+	m.productRepo.EXPECT().AdjustStock(gomock.Any(), uint(100), 2).Return(fmt.Errorf("db error"))
+
+	_, err := svc.Receive(context.Background(), 5)
+	assert.Error(t, err)
+}
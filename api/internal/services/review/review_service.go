@@ -1,16 +1,21 @@
 package review
 
 import (
+	"commerce/api/internal/dto/page"
 	dto "commerce/api/internal/dto/review"
+	"commerce/internal/shared/repositories/query"
 	repo "commerce/internal/shared/repositories/review"
+	"context"
 	"log/slog"
 )
 
 type ReviewServiceI interface {
-	GetById(id uint) (*dto.Review, error)
-	GetAllByProduct(productId uint) ([]*dto.Review, error)
-	Save(review *dto.Review) error
-	Delete(id uint, hard bool) error
+	GetById(ctx context.Context, id uint) (*dto.Review, error)
+	GetAllByProduct(ctx context.Context, productId uint, opts query.Options) (*page.Page[dto.Review], error)
+	GetAllByUser(ctx context.Context, userId uint, opts query.Options) (*page.Page[dto.Review], error)
+	Save(ctx context.Context, review *dto.Review) error
+	Update(ctx context.Context, id uint, review *dto.Review) error
+	Delete(ctx context.Context, id uint, hard bool) error
 }
 
 type ReviewService struct {
@@ -22,23 +27,33 @@ func NewReviewService(repo repo.ReviewRepositoryI) ReviewServiceI {
 }
 
 // Delete implements [ReviewServiceI].
-func (r *ReviewService) Delete(id uint, hard bool) error {
-	return r.repo.Delete(id, hard)
+func (r *ReviewService) Delete(ctx context.Context, id uint, hard bool) error {
+	return r.repo.Delete(ctx, id, hard)
 }
 
 // GetAllByProduct implements [ReviewServiceI].
-func (r *ReviewService) GetAllByProduct(productId uint) ([]*dto.Review, error) {
-	models, err := r.repo.GetByProductId(productId)
+func (r *ReviewService) GetAllByProduct(ctx context.Context, productId uint, opts query.Options) (*page.Page[dto.Review], error) {
+	models, err := r.repo.GetByProductId(ctx, productId, opts)
 	if err != nil {
 		slog.Error("Exception occured in get reviews by product", "productId", productId, "error", err)
 		return nil, err
 	}
-	return dto.FromAllModels(models), nil
+	return page.FromPage(models, dto.FromModel), nil
+}
+
+// GetAllByUser implements [ReviewServiceI].
+func (r *ReviewService) GetAllByUser(ctx context.Context, userId uint, opts query.Options) (*page.Page[dto.Review], error) {
+	models, err := r.repo.GetAllByUserId(ctx, userId, opts)
+	if err != nil {
+		slog.Error("Exception occured in get reviews by user", "userId", userId, "error", err)
+		return nil, err
+	}
+	return page.FromPage(models, dto.FromModel), nil
 }
 
 // GetById implements [ReviewServiceI].
-func (r *ReviewService) GetById(id uint) (*dto.Review, error) {
-	model, err := r.repo.GetById(id)
+func (r *ReviewService) GetById(ctx context.Context, id uint) (*dto.Review, error) {
+	model, err := r.repo.GetById(ctx, id)
 	if err != nil {
 		slog.Error("Exception occured retreving review by id", "errro", err)
 		return nil, err
@@ -46,8 +61,32 @@ func (r *ReviewService) GetById(id uint) (*dto.Review, error) {
 	return dto.FromModel(model), nil
 }
 
-// Save implements [ReviewServiceI].
-func (r *ReviewService) Save(review *dto.Review) error {
+// Save implements [ReviewServiceI]. It always creates a review; see Update.
+func (r *ReviewService) Save(ctx context.Context, review *dto.Review) error {
+	model := dto.ToModel(review)
+	if err := r.repo.Save(ctx, model); err != nil {
+		return err
+	}
+	review.Id = model.Id
+	return nil
+}
+
+// Update implements [ReviewServiceI]. The review stays with its product and
+// author whatever the request says.
+func (r *ReviewService) Update(ctx context.Context, id uint, review *dto.Review) error {
+	existing, err := r.repo.GetById(ctx, id)
+	if err != nil {
+		slog.Error("Exception occured retreving review to update", "id", id, "error", err)
+		return err
+	}
 	model := dto.ToModel(review)
-	return r.repo.Save(model)
+	model.Base = existing.Base
+	model.ProductId = existing.ProductId
+	model.UserId = existing.UserId
+	if err := r.repo.Save(ctx, model); err != nil {
+		slog.Error("Exception occured updating review", "id", id, "error", err)
+		return err
+	}
+	*review = *dto.FromModel(model)
+	return nil
 }
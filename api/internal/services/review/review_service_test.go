@@ -0,0 +1,40 @@
+package review
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	dto "commerce/api/internal/dto/review"
+	"commerce/internal/shared/models"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUpdateKeepsOwnerProductAndBase(t *testing.T) {
+	ctl := gomock.NewController(t)
+	repo := NewMockReviewRepositoryI(ctl)
+	svc := NewReviewService(repo)
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	repo.EXPECT().GetById(gomock.Any(), uint(5)).Return(&models.Review{
+		Base:      models.Base{Id: 5, CreatedDate: created},
+		ProductId: 3,
+		UserId:    7,
+		Rating:    2,
+	}, nil)
+	repo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, r *models.Review) error {
+		assert.Equal(t, uint(5), r.Id)
+		assert.Equal(t, created, r.CreatedDate)
+		assert.Equal(t, uint(7), r.UserId, "the author comes from the stored review")
+		assert.Equal(t, uint(3), r.ProductId, "a review can't move to another product")
+		assert.Equal(t, 4, r.Rating)
+		return nil
+	})
+	review := &dto.Review{Id: 99, ProductId: 9, UserId: 8, Rating: 4, Title: "Better"}
+
+	err := svc.Update(context.Background(), 5, review)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(7), review.UserId)
+	assert.Equal(t, uint(3), review.ProductId)
+}
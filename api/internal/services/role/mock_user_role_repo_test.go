@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../../../../internal/shared/repositories/user-role/user_role_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=../../../../internal/shared/repositories/user-role/user_role_repository.go -destination=mock_user_role_repo_test.go -package=role
+//
+
+// Package role is a generated GoMock package.
+package role
+
+import (
+	models "commerce/internal/shared/models"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUserRoleRepositoryI is a mock of UserRoleRepositoryI interface.
+type MockUserRoleRepositoryI struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserRoleRepositoryIMockRecorder
+	isgomock struct{}
+}
+
+// MockUserRoleRepositoryIMockRecorder is the mock recorder for MockUserRoleRepositoryI.
+type MockUserRoleRepositoryIMockRecorder struct {
+	mock *MockUserRoleRepositoryI
+}
+
+// NewMockUserRoleRepositoryI creates a new mock instance.
+func NewMockUserRoleRepositoryI(ctrl *gomock.Controller) *MockUserRoleRepositoryI {
+	mock := &MockUserRoleRepositoryI{ctrl: ctrl}
+	mock.recorder = &MockUserRoleRepositoryIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserRoleRepositoryI) EXPECT() *MockUserRoleRepositoryIMockRecorder {
+	return m.recorder
+}
+
+// GetByUserId mocks base method.
+func (m *MockUserRoleRepositoryI) GetByUserId(ctx context.Context, userId uint) ([]*models.UserRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserId", ctx, userId)
+	ret0, _ := ret[0].([]*models.UserRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserId indicates an expected call of GetByUserId.
+func (mr *MockUserRoleRepositoryIMockRecorder) GetByUserId(ctx, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserId", reflect.TypeOf((*MockUserRoleRepositoryI)(nil).GetByUserId), ctx, userId)
+}
+
+// Replace mocks base method.
+func (m *MockUserRoleRepositoryI) Replace(ctx context.Context, userId uint, roles []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Replace", ctx, userId, roles)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Replace indicates an expected call of Replace.
+func (mr *MockUserRoleRepositoryIMockRecorder) Replace(ctx, userId, roles any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replace", reflect.TypeOf((*MockUserRoleRepositoryI)(nil).Replace), ctx, userId, roles)
+}
@@ -0,0 +1,49 @@
+package role
+
+import (
+	repo "commerce/internal/shared/repositories/user-role"
+	"context"
+	"log/slog"
+	"slices"
+)
+
+type RoleServiceI interface {
+	GetByUserId(ctx context.Context, userId uint) ([]string, error)
+	Assign(ctx context.Context, userId uint, roles []string) ([]string, error)
+}
+
+type RoleService struct {
+	repo repo.UserRoleRepositoryI
+}
+
+func NewRoleService(repo repo.UserRoleRepositoryI) RoleServiceI {
+	return &RoleService{repo: repo}
+}
+
+// GetByUserId implements [RoleServiceI]. Only roles assigned in this API are
+// returned; roles carried in the token are added by the auth middleware.
+func (r *RoleService) GetByUserId(ctx context.Context, userId uint) ([]string, error) {
+	models, err := r.repo.GetByUserId(ctx, userId)
+	if err != nil {
+		slog.Error("Exception occurred getting roles by user.", "user-id", userId, "error", err)
+		return nil, err
+	}
+	roles := make([]string, 0, len(models))
+	for _, model := range models {
+		roles = append(roles, model.Role)
+	}
+	return roles, nil
+}
+
+// Assign implements [RoleServiceI]. The user's assigned roles are replaced
+// with the given ones; an empty list revokes them all.
+func (r *RoleService) Assign(ctx context.Context, userId uint, roles []string) ([]string, error) {
+	assigned := slices.Clone(roles)
+	slices.Sort(assigned)
+	assigned = slices.Compact(assigned)
+	if err := r.repo.Replace(ctx, userId, assigned); err != nil {
+		slog.Error("Exception occurred assigning roles.", "user-id", userId, "roles", assigned, "error", err)
+		return nil, err
+	}
+	return assigned, nil
+}
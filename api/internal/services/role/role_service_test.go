@@ -0,0 +1,54 @@
+package role
+
+import (
+	"commerce/internal/shared/models"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetByUserId(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	mockRepo := NewMockUserRoleRepositoryI(ctl)
+	mockRepo.EXPECT().GetByUserId(gomock.Any(), uint(1)).Return([]*models.UserRole{
+		{UserId: 1, Role: "admin"},
+		{UserId: 1, Role: "support"},
+	}, nil)
+
+	svc := NewRoleService(mockRepo)
+	roles, err := svc.GetByUserId(context.Background(), 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"admin", "support"}, roles)
+}
+
+func TestAssign_SortsAndRemovesDuplicates(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	mockRepo := NewMockUserRoleRepositoryI(ctl)
+	mockRepo.EXPECT().Replace(gomock.Any(), uint(1), []string{"admin", "support"}).Return(nil)
+
+	svc := NewRoleService(mockRepo)
+	roles, err := svc.Assign(context.Background(), 1, []string{"support", "admin", "support"})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"admin", "support"}, roles)
+}
+
+func TestAssign_RepoError(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	mockRepo := NewMockUserRoleRepositoryI(ctl)
+	mockRepo.EXPECT().Replace(gomock.Any(), uint(1), []string{}).Return(errors.New("insert or update on table violates foreign key constraint"))
+
+	svc := NewRoleService(mockRepo)
+	roles, err := svc.Assign(context.Background(), 1, []string{})
+
+	require.Error(t, err)
+	assert.Nil(t, roles)
+}
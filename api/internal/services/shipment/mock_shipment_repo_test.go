@@ -0,0 +1,116 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../../../../internal/shared/repositories/shipment/shipment_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=../../../../internal/shared/repositories/shipment/shipment_repository.go -destination=mock_shipment_repo_test.go -package=shipment
+//
+
+// Package shipment is a generated GoMock package.
+package shipment
+
+import (
+	models "commerce/internal/shared/models"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockShipmentRepositoryI is a mock of ShipmentRepositoryI interface.
+type MockShipmentRepositoryI struct {
+	ctrl     *gomock.Controller
+	recorder *MockShipmentRepositoryIMockRecorder
+	isgomock struct{}
+}
+
+// MockShipmentRepositoryIMockRecorder is the mock recorder for MockShipmentRepositoryI.
+type MockShipmentRepositoryIMockRecorder struct {
+	mock *MockShipmentRepositoryI
+}
+
+// NewMockShipmentRepositoryI creates a new mock instance.
+func NewMockShipmentRepositoryI(ctrl *gomock.Controller) *MockShipmentRepositoryI {
+	mock := &MockShipmentRepositoryI{ctrl: ctrl}
+	mock.recorder = &MockShipmentRepositoryIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShipmentRepositoryI) EXPECT() *MockShipmentRepositoryIMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockShipmentRepositoryI) Create(ctx context.Context, shipment *models.Shipment, orderStatus models.OrderStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, shipment, orderStatus)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockShipmentRepositoryIMockRecorder) Create(ctx, shipment, orderStatus any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockShipmentRepositoryI)(nil).Create), ctx, shipment, orderStatus)
+}
+
+// GetAllByOrderId mocks base method.
+func (m *MockShipmentRepositoryI) GetAllByOrderId(ctx context.Context, orderId uint) ([]*models.Shipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllByOrderId", ctx, orderId)
+	ret0, _ := ret[0].([]*models.Shipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllByOrderId indicates an expected call of GetAllByOrderId.
+func (mr *MockShipmentRepositoryIMockRecorder) GetAllByOrderId(ctx, orderId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByOrderId", reflect.TypeOf((*MockShipmentRepositoryI)(nil).GetAllByOrderId), ctx, orderId)
+}
+
+// GetAllUndelivered mocks base method.
+func (m *MockShipmentRepositoryI) GetAllUndelivered(ctx context.Context) ([]*models.Shipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllUndelivered", ctx)
+	ret0, _ := ret[0].([]*models.Shipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllUndelivered indicates an expected call of GetAllUndelivered.
+func (mr *MockShipmentRepositoryIMockRecorder) GetAllUndelivered(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllUndelivered", reflect.TypeOf((*MockShipmentRepositoryI)(nil).GetAllUndelivered), ctx)
+}
+
+// GetById mocks base method.
+func (m *MockShipmentRepositoryI) GetById(ctx context.Context, id uint) (*models.Shipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(*models.Shipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockShipmentRepositoryIMockRecorder) GetById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockShipmentRepositoryI)(nil).GetById), ctx, id)
+}
+
+// UpdateTracking mocks base method.
+func (m *MockShipmentRepositoryI) UpdateTracking(ctx context.Context, id uint, status string, deliveredDate *time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTracking", ctx, id, status, deliveredDate)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTracking indicates an expected call of UpdateTracking.
+func (mr *MockShipmentRepositoryIMockRecorder) UpdateTracking(ctx, id, status, deliveredDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTracking", reflect.TypeOf((*MockShipmentRepositoryI)(nil).UpdateTracking), ctx, id, status, deliveredDate)
+}
@@ -0,0 +1,35 @@
+package shipment
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Poller refreshes shipment tracking on a fixed interval until its context is
+// cancelled.
+type Poller struct {
+	svc      ShipmentServiceI
+	interval time.Duration
+}
+
+func NewPoller(svc ShipmentServiceI, interval time.Duration) *Poller {
+	return &Poller{svc: svc, interval: interval}
+}
+
+func (p *Poller) Start(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	slog.Info("Tracking poller started.", "interval", p.interval)
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("Tracking poller stopped.")
+			return
+		case <-ticker.C:
+			if err := p.svc.RefreshTracking(ctx); err != nil {
+				slog.Error("Exception occurred refreshing shipment tracking.", "error", err)
+			}
+		}
+	}
+}
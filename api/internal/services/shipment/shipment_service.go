@@ -0,0 +1,221 @@
+package shipment
+
+import (
+	"commerce/api/internal/carrier"
+	dto "commerce/api/internal/dto/shipment"
+	"commerce/internal/shared/models"
+	order_repo "commerce/internal/shared/repositories/order"
+	repo "commerce/internal/shared/repositories/shipment"
+	"commerce/internal/shared/repositories/uow"
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+type ShipmentServiceI interface {
+	GetById(ctx context.Context, id uint) (*dto.Shipment, error)
+	GetByOrderId(ctx context.Context, orderId uint) ([]*dto.Shipment, error)
+	Create(ctx context.Context, shipment dto.Shipment) (*dto.Shipment, error)
+	RefreshTracking(ctx context.Context) error
+}
+
+type ShipmentService struct {
+	repo      repo.ShipmentRepositoryI
+	orderRepo order_repo.OrderRepositoryI
+	uow       uow.UnitOfWorkI
+	carriers  carrier.Registry
+}
+
+func NewShipmentService(repo repo.ShipmentRepositoryI,
+	orderRepo order_repo.OrderRepositoryI,
+	uow uow.UnitOfWorkI,
+	carriers carrier.Registry) ShipmentServiceI {
+	return &ShipmentService{
+		repo:      repo,
+		orderRepo: orderRepo,
+		uow:       uow,
+		carriers:  carriers,
+	}
+}
+
+// GetById implements [ShipmentServiceI].
+func (s *ShipmentService) GetById(ctx context.Context, id uint) (*dto.Shipment, error) {
+	model, err := s.repo.GetById(ctx, id)
+	if err != nil {
+		slog.Error("Exception occurred getting shipment by id.", "id", id, "error", err)
+		return nil, err
+	}
+	return dto.FromModel(model), nil
+}
+
+// GetByOrderId implements [ShipmentServiceI].
+func (s *ShipmentService) GetByOrderId(ctx context.Context, orderId uint) ([]*dto.Shipment, error) {
+	models, err := s.repo.GetAllByOrderId(ctx, orderId)
+	if err != nil {
+		slog.Error("Exception occurred getting shipments by order.", "order-id", orderId, "error", err)
+		return nil, err
+	}
+	shipments := make([]*dto.Shipment, len(models))
+	for i, model := range models {
+		shipments[i] = dto.FromModel(model)
+	}
+	return shipments, nil
+}
+
+// Create implements [ShipmentServiceI]. Every item must belong to the order
+// and may not ship more than is still outstanding; the order then moves to
+// shipped once nothing is outstanding, or partially shipped otherwise.
+// Without a tracking number, a label is bought from the shipment's carrier.
+// The order's row is locked from the check until the shipment is saved, so
+// two shipments created at once can't both ship the same outstanding items.
+func (s *ShipmentService) Create(ctx context.Context, shipment dto.Shipment) (*dto.Shipment, error) {
+	var created *models.Shipment
+	err := s.uow.Do(ctx, func(repos *uow.Repositories) error {
+		order, err := repos.Orders.GetByIdForUpdate(ctx, shipment.OrderId)
+		if err != nil {
+			return err
+		}
+		if !isShippable(order.Status) {
+			return fmt.Errorf("order %d is %s and cannot be shipped", order.Id, order.Status)
+		}
+
+		existing, err := repos.Shipments.GetAllByOrderId(ctx, order.Id)
+		if err != nil {
+			return err
+		}
+		outstanding := outstandingQuantities(order, existing)
+
+		for _, item := range shipment.Items {
+			remaining, ok := outstanding[item.OrderItemId]
+			if !ok {
+				return fmt.Errorf("order item %d does not belong to order %d", item.OrderItemId, order.Id)
+			}
+			if item.Quantity <= 0 || item.Quantity > remaining {
+				return fmt.Errorf("cannot ship %d of order item %d, %d outstanding", item.Quantity, item.OrderItemId, remaining)
+			}
+			outstanding[item.OrderItemId] = remaining - item.Quantity
+		}
+
+		status := models.OrderStatusShipped
+		for _, remaining := range outstanding {
+			if remaining > 0 {
+				status = models.OrderStatusPartiallyShipped
+				break
+			}
+		}
+
+		if shipment.TrackingNumber == "" {
+			c, err := s.carriers.Get(shipment.Carrier)
+			if err != nil {
+				return fmt.Errorf("a tracking number is required: %w", err)
+			}
+			label, err := c.CreateLabel(ctx, carrier.LabelRequest{
+				OrderId: order.Id,
+				Method:  order.ShippingMethod,
+				State:   order.ShippingAddress.State,
+			})
+			if err != nil {
+				return fmt.Errorf("creating shipping label: %w", err)
+			}
+			shipment.TrackingNumber = label.TrackingNumber
+		}
+		shipment.TrackingStatus = string(carrier.TrackingStatusLabelCreated)
+		if shipment.ShippedDate.IsZero() {
+			shipment.ShippedDate = time.Now()
+		}
+		created = dto.ToModel(&shipment)
+		return repos.Shipments.Create(ctx, created, status)
+	})
+	if err != nil {
+		slog.Error("Exception occurred creating shipment.", "order-id", shipment.OrderId, "carrier", shipment.Carrier, "error", err)
+		return nil, err
+	}
+	return dto.FromModel(created), nil
+}
+
+// RefreshTracking implements [ShipmentServiceI]. It asks each carrier for the
+// latest status of every undelivered shipment, and moves an order to delivered
+// once it has shipped in full and all of its shipments have been delivered.
+// Shipments from carriers without an integration are left alone.
+func (s *ShipmentService) RefreshTracking(ctx context.Context) error {
+	shipments, err := s.repo.GetAllUndelivered(ctx)
+	if err != nil {
+		slog.Error("Exception occurred getting undelivered shipments.", "error", err)
+		return err
+	}
+
+	delivered := map[uint]struct{}{}
+	for _, shipment := range shipments {
+		c, err := s.carriers.Get(shipment.Carrier)
+		if err != nil {
+			continue
+		}
+		tracking, err := c.Track(ctx, shipment.TrackingNumber)
+		if err != nil {
+			slog.Error("Exception occurred tracking shipment.", "id", shipment.Id, "carrier", shipment.Carrier, "error", err)
+			continue
+		}
+		if string(tracking.Status) == shipment.TrackingStatus {
+			continue
+		}
+
+		var deliveredDate *time.Time
+		if tracking.Status == carrier.TrackingStatusDelivered {
+			deliveredDate = &tracking.UpdatedDate
+			delivered[shipment.OrderId] = struct{}{}
+		}
+		if err := s.repo.UpdateTracking(ctx, shipment.Id, string(tracking.Status), deliveredDate); err != nil {
+			slog.Error("Exception occurred updating shipment tracking.", "id", shipment.Id, "error", err)
+			return err
+		}
+	}
+
+	for orderId := range delivered {
+		if err := s.completeDelivery(ctx, orderId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *ShipmentService) completeDelivery(ctx context.Context, orderId uint) error {
+	order, err := s.orderRepo.GetById(ctx, orderId)
+	if err != nil {
+		slog.Error("Exception occurred getting order for delivery.", "order-id", orderId, "error", err)
+		return err
+	}
+	if order.Status != models.OrderStatusShipped {
+		return nil
+	}
+	shipments, err := s.repo.GetAllByOrderId(ctx, orderId)
+	if err != nil {
+		slog.Error("Exception occurred getting shipments by order.", "order-id", orderId, "error", err)
+		return err
+	}
+	for _, shipment := range shipments {
+		if shipment.DeliveredDate == nil {
+			return nil
+		}
+	}
+	return s.orderRepo.UpdateStatus(ctx, orderId, string(models.OrderStatusDelivered))
+}
+
+func isShippable(status models.OrderStatus) bool {
+	return status == models.OrderStatusPending || status == models.OrderStatusPartiallyShipped
+}
+
+// outstandingQuantities maps each of the order's items to the quantity not yet
+// covered by a shipment.
+func outstandingQuantities(order *models.Order, shipments []*models.Shipment) map[uint]int {
+	outstanding := make(map[uint]int, len(order.OrderItems))
+	for _, item := range order.OrderItems {
+		outstanding[item.Id] += item.Quantity
+	}
+	for _, shipment := range shipments {
+		for _, item := range shipment.Items {
+			outstanding[item.OrderItemId] -= item.Quantity
+		}
+	}
+	return outstanding
+}
@@ -0,0 +1,220 @@
+package shipment
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"commerce/api/internal/carrier"
+	dto "commerce/api/internal/dto/shipment"
+	"commerce/internal/shared/models"
+	"commerce/internal/shared/repositories/uow"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func setup(t *testing.T) (*MockShipmentRepositoryI, *MockOrderRepositoryI, ShipmentServiceI) {
+	t.Helper()
+	ctl := gomock.NewController(t)
+	t.Cleanup(ctl.Finish)
+	mockRepo := NewMockShipmentRepositoryI(ctl)
+	mockOrderRepo := NewMockOrderRepositoryI(ctl)
+	mockUow := NewMockUnitOfWorkI(ctl)
+	mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, fn func(r *uow.Repositories) error) error {
+		return fn(&uow.Repositories{Orders: mockOrderRepo, Shipments: mockRepo})
+	}).AnyTimes()
+	carriers := carrier.NewRegistry(carrier.NewSimulator(time.Hour, clock))
+	return mockRepo, mockOrderRepo, NewShipmentService(mockRepo, mockOrderRepo, mockUow, carriers)
+}
+
+var now = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func clock() time.Time {
+	return now
+}
+
+func order(status models.OrderStatus) *models.Order {
+	return &models.Order{
+		Base:   models.Base{Id: 1},
+		Status: status,
+		OrderItems: []models.OrderItem{
+			{Base: models.Base{Id: 10}, OrderId: 1, Quantity: 2},
+			{Base: models.Base{Id: 11}, OrderId: 1, Quantity: 1},
+		},
+	}
+}
+
+func request(items ...dto.ShipmentItem) dto.Shipment {
+	return dto.Shipment{
+		OrderId:        1,
+		Carrier:        "ups",
+		TrackingNumber: "1Z999AA10123456784",
+		Items:          items,
+	}
+}
+
+func TestCreatePartial(t *testing.T) {
+	mockRepo, mockOrderRepo, svc := setup(t)
+	mockOrderRepo.EXPECT().GetByIdForUpdate(gomock.Any(), uint(1)).Return(order(models.OrderStatusPending), nil)
+	mockRepo.EXPECT().GetAllByOrderId(gomock.Any(), uint(1)).Return([]*models.Shipment{}, nil)
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any(), models.OrderStatusPartiallyShipped).Return(nil)
+
+	shipment, err := svc.Create(context.Background(), request(dto.ShipmentItem{OrderItemId: 10, Quantity: 2}))
+	assert.NoError(t, err)
+	assert.NotNil(t, shipment)
+	assert.False(t, shipment.ShippedDate.IsZero(), "shipped date should default to now")
+}
+
+func TestCreateBuysLabel(t *testing.T) {
+	mockRepo, mockOrderRepo, svc := setup(t)
+	mockOrderRepo.EXPECT().GetByIdForUpdate(gomock.Any(), uint(1)).Return(order(models.OrderStatusPending), nil)
+	mockRepo.EXPECT().GetAllByOrderId(gomock.Any(), uint(1)).Return([]*models.Shipment{}, nil)
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any(), models.OrderStatusPartiallyShipped).Return(nil)
+
+	req := request(dto.ShipmentItem{OrderItemId: 10, Quantity: 1})
+	req.Carrier, req.TrackingNumber = carrier.SimulatorCode, ""
+	shipment, err := svc.Create(context.Background(), req)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(shipment.TrackingNumber, "SIM-"), "tracking number should come from the carrier")
+	assert.Equal(t, string(carrier.TrackingStatusLabelCreated), shipment.TrackingStatus)
+}
+
+func TestCreateUnknownCarrierWithoutTracking(t *testing.T) {
+	mockRepo, mockOrderRepo, svc := setup(t)
+	mockOrderRepo.EXPECT().GetByIdForUpdate(gomock.Any(), uint(1)).Return(order(models.OrderStatusPending), nil)
+	mockRepo.EXPECT().GetAllByOrderId(gomock.Any(), uint(1)).Return([]*models.Shipment{}, nil)
+
+	req := request(dto.ShipmentItem{OrderItemId: 10, Quantity: 1})
+	req.TrackingNumber = ""
+	_, err := svc.Create(context.Background(), req)
+	assert.Error(t, err)
+}
+
+func TestCreateCompletesOrder(t *testing.T) {
+	mockRepo, mockOrderRepo, svc := setup(t)
+	mockOrderRepo.EXPECT().GetByIdForUpdate(gomock.Any(), uint(1)).Return(order(models.OrderStatusPartiallyShipped), nil)
+	mockRepo.EXPECT().GetAllByOrderId(gomock.Any(), uint(1)).Return([]*models.Shipment{
+		{OrderId: 1, Items: []models.ShipmentItem{{OrderItemId: 10, Quantity: 2}}},
+	}, nil)
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any(), models.OrderStatusShipped).Return(nil)
+
+	_, err := svc.Create(context.Background(), request(dto.ShipmentItem{OrderItemId: 11, Quantity: 1}))
+	assert.NoError(t, err)
+}
+
+func TestCreateOverShips(t *testing.T) {
+	mockRepo, mockOrderRepo, svc := setup(t)
+	mockOrderRepo.EXPECT().GetByIdForUpdate(gomock.Any(), uint(1)).Return(order(models.OrderStatusPartiallyShipped), nil)
+	mockRepo.EXPECT().GetAllByOrderId(gomock.Any(), uint(1)).Return([]*models.Shipment{
+		{OrderId: 1, Items: []models.ShipmentItem{{OrderItemId: 10, Quantity: 1}}},
+	}, nil)
+
+	shipment, err := svc.Create(context.Background(), request(
+		dto.ShipmentItem{OrderItemId: 10, Quantity: 1},
+		dto.ShipmentItem{OrderItemId: 10, Quantity: 1},
+	))
+	assert.Error(t, err)
+	assert.Nil(t, shipment)
+}
+
+func TestCreateForeignItem(t *testing.T) {
+	mockRepo, mockOrderRepo, svc := setup(t)
+	mockOrderRepo.EXPECT().GetByIdForUpdate(gomock.Any(), uint(1)).Return(order(models.OrderStatusPending), nil)
+	mockRepo.EXPECT().GetAllByOrderId(gomock.Any(), uint(1)).Return([]*models.Shipment{}, nil)
+
+	_, err := svc.Create(context.Background(), request(dto.ShipmentItem{OrderItemId: 99, Quantity: 1}))
+	assert.Error(t, err)
+}
+
+func TestCreateCancelledOrder(t *testing.T) {
+	_, mockOrderRepo, svc := setup(t)
+	mockOrderRepo.EXPECT().GetByIdForUpdate(gomock.Any(), uint(1)).Return(order(models.OrderStatusCancelled), nil)
+
+	_, err := svc.Create(context.Background(), request(dto.ShipmentItem{OrderItemId: 10, Quantity: 1}))
+	assert.Error(t, err)
+}
+
+func TestCreateRepoError(t *testing.T) {
+	mockRepo, mockOrderRepo, svc := setup(t)
+	mockOrderRepo.EXPECT().GetByIdForUpdate(gomock.Any(), uint(1)).Return(order(models.OrderStatusPending), nil)
+	mockRepo.EXPECT().GetAllByOrderId(gomock.Any(), uint(1)).Return([]*models.Shipment{}, nil)
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any(), models.OrderStatusShipped).Return(fmt.Errorf("db error"))
+
+	_, err := svc.Create(context.Background(), request(
+		dto.ShipmentItem{OrderItemId: 10, Quantity: 2},
+		dto.ShipmentItem{OrderItemId: 11, Quantity: 1},
+	))
+	assert.Error(t, err)
+}
+
+func TestGetByOrderId(t *testing.T) {
+	mockRepo, _, svc := setup(t)
+	mockRepo.EXPECT().GetAllByOrderId(gomock.Any(), uint(1)).Return([]*models.Shipment{
+		{Base: models.Base{Id: 1}, OrderId: 1, TrackingNumber: "1Z1"},
+		{Base: models.Base{Id: 2}, OrderId: 1, TrackingNumber: "1Z2"},
+	}, nil)
+	shipments, err := svc.GetByOrderId(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(shipments))
+}
+
+func simulatorShipment(id uint, orderId uint, age time.Duration) *models.Shipment {
+	label, _ := carrier.NewSimulator(time.Hour, func() time.Time { return now.Add(-age) }).
+		CreateLabel(context.Background(), carrier.LabelRequest{})
+	return &models.Shipment{
+		Base:           models.Base{Id: id},
+		OrderId:        orderId,
+		Carrier:        carrier.SimulatorCode,
+		TrackingNumber: label.TrackingNumber,
+		TrackingStatus: string(carrier.TrackingStatusLabelCreated),
+	}
+}
+
+func TestRefreshTrackingDeliversOrder(t *testing.T) {
+	mockRepo, mockOrderRepo, svc := setup(t)
+	shipment := simulatorShipment(1, 1, 4*time.Hour)
+	mockRepo.EXPECT().GetAllUndelivered(gomock.Any()).Return([]*models.Shipment{shipment}, nil)
+	mockRepo.EXPECT().UpdateTracking(gomock.Any(), uint(1), string(carrier.TrackingStatusDelivered), gomock.Not(gomock.Nil())).
+		DoAndReturn(func(_ context.Context, id uint, status string, deliveredDate *time.Time) error {
+			shipment.DeliveredDate = deliveredDate
+			return nil
+		})
+	mockOrderRepo.EXPECT().GetById(gomock.Any(), uint(1)).Return(order(models.OrderStatusShipped), nil)
+	mockRepo.EXPECT().GetAllByOrderId(gomock.Any(), uint(1)).Return([]*models.Shipment{shipment}, nil)
+	mockOrderRepo.EXPECT().UpdateStatus(gomock.Any(), uint(1), string(models.OrderStatusDelivered)).Return(nil)
+
+	err := svc.RefreshTracking(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestRefreshTrackingInTransit(t *testing.T) {
+	mockRepo, _, svc := setup(t)
+	mockRepo.EXPECT().GetAllUndelivered(gomock.Any()).Return([]*models.Shipment{simulatorShipment(1, 1, 90*time.Minute)}, nil)
+	mockRepo.EXPECT().UpdateTracking(gomock.Any(), uint(1), string(carrier.TrackingStatusInTransit), nil).Return(nil)
+
+	err := svc.RefreshTracking(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestRefreshTrackingPartiallyShippedOrder(t *testing.T) {
+	mockRepo, mockOrderRepo, svc := setup(t)
+	mockRepo.EXPECT().GetAllUndelivered(gomock.Any()).Return([]*models.Shipment{simulatorShipment(1, 1, 4*time.Hour)}, nil)
+	mockRepo.EXPECT().UpdateTracking(gomock.Any(), uint(1), string(carrier.TrackingStatusDelivered), gomock.Any()).Return(nil)
+	mockOrderRepo.EXPECT().GetById(gomock.Any(), uint(1)).Return(order(models.OrderStatusPartiallyShipped), nil)
+
+	err := svc.RefreshTracking(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestRefreshTrackingSkipsUnknownCarrier(t *testing.T) {
+	mockRepo, _, svc := setup(t)
+	mockRepo.EXPECT().GetAllUndelivered(gomock.Any()).Return([]*models.Shipment{
+		{Base: models.Base{Id: 1}, OrderId: 1, Carrier: "ups", TrackingNumber: "1Z999AA10123456784"},
+	}, nil)
+
+	err := svc.RefreshTracking(context.Background())
+	assert.NoError(t, err)
+}
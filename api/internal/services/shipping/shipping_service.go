@@ -0,0 +1,245 @@
+package shipping
+
+import (
+	dto "commerce/api/internal/dto/shipping"
+	repo "commerce/internal/shared/repositories/product"
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+)
+
+type ShippingServiceI interface {
+	GetMethods() []dto.ShippingMethod
+	GetZones() []dto.ShippingZone
+	Quote(ctx context.Context, items []dto.QuoteItem, state string) ([]dto.Quote, error)
+	Calculate(ctx context.Context, items []dto.QuoteItem, state string, method string) (*dto.Quote, error)
+}
+
+type ShippingService struct {
+	productRepo repo.ProductRepositoryI
+}
+
+func NewShippingService(productRepo repo.ProductRepositoryI) ShippingServiceI {
+	return &ShippingService{productRepo: productRepo}
+}
+
+// GetMethods implements [ShippingServiceI].
+func (s *ShippingService) GetMethods() []dto.ShippingMethod {
+	return shippingMethods
+}
+
+// GetZones implements [ShippingServiceI].
+func (s *ShippingService) GetZones() []dto.ShippingZone {
+	return shippingZones
+}
+
+// Quote implements [ShippingServiceI].
+func (s *ShippingService) Quote(ctx context.Context, items []dto.QuoteItem, state string) ([]dto.Quote, error) {
+	zone, err := findZone(state)
+	if err != nil {
+		return nil, err
+	}
+	weight, subTotal, err := s.measure(ctx, items)
+	if err != nil {
+		return nil, err
+	}
+	quotes := []dto.Quote{}
+	for _, method := range shippingMethods {
+		if method.Zone != zone.Code {
+			continue
+		}
+		quotes = append(quotes, quote(method, weight, subTotal))
+	}
+	return quotes, nil
+}
+
+// Calculate implements [ShippingServiceI].
+func (s *ShippingService) Calculate(ctx context.Context, items []dto.QuoteItem, state string, method string) (*dto.Quote, error) {
+	zone, err := findZone(state)
+	if err != nil {
+		return nil, err
+	}
+	m, err := findMethod(method, zone.Code)
+	if err != nil {
+		return nil, err
+	}
+	weight, subTotal, err := s.measure(ctx, items)
+	if err != nil {
+		return nil, err
+	}
+	q := quote(*m, weight, subTotal)
+	return &q, nil
+}
+
+// measure returns the billable weight of the items, rounded up to the next whole
+// pound the way carriers bill it, along with their merchandise subtotal.
+func (s *ShippingService) measure(ctx context.Context, items []dto.QuoteItem) (float64, float64, error) {
+	weight, subTotal := 0.0, 0.0
+	now := time.Now()
+	for _, item := range items {
+		product, err := s.productRepo.GetById(ctx, item.ProductId)
+		if err != nil {
+			slog.Error("Exception occurred getting product for shipping quote.", "product-id", item.ProductId, "error", err)
+			return 0, 0, err
+		}
+		weight += billableWeight(product.Weight, product.Length, product.Width, product.Height) * float64(item.Quantity)
+
+		price := item.UnitPrice
+		if price == 0 {
+			active, _ := product.PriceAt(now)
+			if item.VariantId != nil {
+				variant := product.Variant(*item.VariantId)
+				if variant == nil {
+					return 0, 0, fmt.Errorf("product %d has no variant %d", item.ProductId, *item.VariantId)
+				}
+				active, _ = product.VariantPriceAt(variant, now)
+			}
+			price = float64(active)
+		}
+		subTotal += price * float64(item.Quantity)
+	}
+	return math.Ceil(weight), subTotal, nil
+}
+
+// dimensionalDivisor converts cubic inches to dimensional pounds.
+const dimensionalDivisor = 139.0
+
+func billableWeight(weight, length, width, height float64) float64 {
+	return math.Max(weight, (length*width*height)/dimensionalDivisor)
+}
+
+func quote(method dto.ShippingMethod, weight float64, subTotal float64) dto.Quote {
+	q := dto.Quote{
+		Method:         method.Code,
+		Name:           method.Name,
+		Zone:           method.Zone,
+		BillableWeight: weight,
+		EstimatedDays:  method.EstimatedDays,
+	}
+	if method.FreeShippingThreshold > 0 && subTotal >= method.FreeShippingThreshold {
+		q.FreeShipping = true
+		return q
+	}
+	q.Amount = rate(method, weight)
+	return q
+}
+
+func rate(method dto.ShippingMethod, weight float64) float64 {
+	for _, r := range method.Rates {
+		if weight <= r.MaxWeight {
+			return r.Rate
+		}
+	}
+	last := method.Rates[len(method.Rates)-1]
+	extra := math.Ceil(weight-last.MaxWeight) * method.AdditionalRatePerLb
+	return math.Round((last.Rate+extra)*100) / 100
+}
+
+func findZone(state string) (*dto.ShippingZone, error) {
+	for i, zone := range shippingZones {
+		for _, s := range zone.States {
+			if s == state {
+				return &shippingZones[i], nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("shipping is not available to state: %q", state)
+}
+
+func findMethod(code string, zone string) (*dto.ShippingMethod, error) {
+	for i, method := range shippingMethods {
+		if method.Code == code && method.Zone == zone {
+			return &shippingMethods[i], nil
+		}
+	}
+	return nil, fmt.Errorf("shipping method %q is not available in zone %q", code, zone)
+}
+
+var shippingZones = []dto.ShippingZone{
+	{
+		Code: "contiguous",
+		Name: "Contiguous US",
+		States: []string{
+			"AL", "AZ", "AR", "CA", "CO", "CT", "DE", "FL", "GA", "ID", "IL", "IN", "IA", "KS", "KY", "LA", "ME",
+			"MD", "MA", "MI", "MN", "MS", "MO", "MT", "NE", "NV", "NH", "NJ", "NM", "NY", "NC", "ND", "OH", "OK",
+			"OR", "PA", "RI", "SC", "SD", "TN", "TX", "UT", "VT", "VA", "WA", "WV", "WI", "WY", "DC",
+		},
+	},
+	{
+		Code:   "noncontiguous",
+		Name:   "Alaska & Hawaii",
+		States: []string{"AK", "HI"},
+	},
+}
+
+var shippingMethods = []dto.ShippingMethod{
+	{
+		Code:          "standard",
+		Name:          "Standard",
+		Zone:          "contiguous",
+		EstimatedDays: 5,
+		Rates: []dto.WeightRate{
+			{MaxWeight: 1, Rate: 5.99},
+			{MaxWeight: 5, Rate: 8.99},
+			{MaxWeight: 10, Rate: 12.99},
+			{MaxWeight: 20, Rate: 18.99},
+		},
+		AdditionalRatePerLb:   0.85,
+		FreeShippingThreshold: 75,
+	},
+	{
+		Code:          "expedited",
+		Name:          "Expedited",
+		Zone:          "contiguous",
+		EstimatedDays: 2,
+		Rates: []dto.WeightRate{
+			{MaxWeight: 1, Rate: 12.99},
+			{MaxWeight: 5, Rate: 16.99},
+			{MaxWeight: 10, Rate: 22.99},
+			{MaxWeight: 20, Rate: 32.99},
+		},
+		AdditionalRatePerLb: 1.50,
+	},
+	{
+		Code:          "overnight",
+		Name:          "Overnight",
+		Zone:          "contiguous",
+		EstimatedDays: 1,
+		Rates: []dto.WeightRate{
+			{MaxWeight: 1, Rate: 24.99},
+			{MaxWeight: 5, Rate: 34.99},
+			{MaxWeight: 10, Rate: 49.99},
+			{MaxWeight: 20, Rate: 69.99},
+		},
+		AdditionalRatePerLb: 3.00,
+	},
+	{
+		Code:          "standard",
+		Name:          "Standard",
+		Zone:          "noncontiguous",
+		EstimatedDays: 8,
+		Rates: []dto.WeightRate{
+			{MaxWeight: 1, Rate: 14.99},
+			{MaxWeight: 5, Rate: 24.99},
+			{MaxWeight: 10, Rate: 39.99},
+			{MaxWeight: 20, Rate: 64.99},
+		},
+		AdditionalRatePerLb:   2.50,
+		FreeShippingThreshold: 150,
+	},
+	{
+		Code:          "expedited",
+		Name:          "Expedited",
+		Zone:          "noncontiguous",
+		EstimatedDays: 4,
+		Rates: []dto.WeightRate{
+			{MaxWeight: 1, Rate: 29.99},
+			{MaxWeight: 5, Rate: 44.99},
+			{MaxWeight: 10, Rate: 69.99},
+			{MaxWeight: 20, Rate: 99.99},
+		},
+		AdditionalRatePerLb: 4.00,
+	},
+}
@@ -0,0 +1,129 @@
+package shipping
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	dto "commerce/api/internal/dto/shipping"
+	"commerce/internal/shared/models"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func setup(t *testing.T) (*MockProductRepositoryI, ShippingServiceI) {
+	t.Helper()
+	ctl := gomock.NewController(t)
+	t.Cleanup(ctl.Finish)
+	mockRepo := NewMockProductRepositoryI(ctl)
+	return mockRepo, NewShippingService(mockRepo)
+}
+
+func newProduct(id uint, price float32, weight, length, width, height float64) *models.Product {
+	return &models.Product{
+		Base:   models.Base{Id: id},
+		Price:  price,
+		Weight: weight,
+		Length: length,
+		Width:  width,
+		Height: height,
+	}
+}
+
+func TestGetZones(t *testing.T) {
+	_, svc := setup(t)
+	zones := svc.GetZones()
+	states := 0
+	for _, zone := range zones {
+		states += len(zone.States)
+	}
+	assert.Equal(t, 51, states, "every taxable state should belong to a zone")
+}
+
+func TestCalculateByWeight(t *testing.T) {
+	mockRepo, svc := setup(t)
+	mockRepo.EXPECT().GetById(gomock.Any(), uint(1)).Return(newProduct(1, 10, 2.2, 4, 4, 4), nil)
+
+	quote, err := svc.Calculate(context.Background(), []dto.QuoteItem{{ProductId: 1, Quantity: 2}}, "MD", "standard")
+	assert.NoError(t, err)
+	assert.Equal(t, 5.0, quote.BillableWeight, "4.4lbs should bill as 5lbs")
+	assert.Equal(t, 8.99, quote.Amount)
+	assert.False(t, quote.FreeShipping)
+}
+
+func TestCalculateByDimensionalWeight(t *testing.T) {
+	mockRepo, svc := setup(t)
+	mockRepo.EXPECT().GetById(gomock.Any(), uint(1)).Return(newProduct(1, 10, 3, 10, 10, 10), nil)
+
+	quote, err := svc.Calculate(context.Background(), []dto.QuoteItem{{ProductId: 1, Quantity: 1}}, "MD", "standard")
+	assert.NoError(t, err)
+	assert.Equal(t, 8.0, quote.BillableWeight, "a 1000in³ box should bill at its dimensional weight")
+	assert.Equal(t, 12.99, quote.Amount)
+}
+
+func TestCalculateAboveLastBracket(t *testing.T) {
+	mockRepo, svc := setup(t)
+	mockRepo.EXPECT().GetById(gomock.Any(), uint(1)).Return(newProduct(1, 10, 12, 0, 0, 0), nil)
+
+	quote, err := svc.Calculate(context.Background(), []dto.QuoteItem{{ProductId: 1, Quantity: 2}}, "MD", "expedited")
+	assert.NoError(t, err)
+	assert.InDelta(t, 38.99, quote.Amount, 0.001, "4lbs over the last bracket at 1.50/lb")
+}
+
+func TestCalculateFreeShipping(t *testing.T) {
+	mockRepo, svc := setup(t)
+	mockRepo.EXPECT().GetById(gomock.Any(), uint(1)).Return(newProduct(1, 10, 1, 0, 0, 0), nil)
+
+	quote, err := svc.Calculate(context.Background(), []dto.QuoteItem{{ProductId: 1, Quantity: 2, UnitPrice: 40}}, "MD", "standard")
+	assert.NoError(t, err)
+	assert.True(t, quote.FreeShipping)
+	assert.Equal(t, 0.0, quote.Amount)
+}
+
+func TestCalculateFreeShippingAtVariantPrice(t *testing.T) {
+	mockRepo, svc := setup(t)
+	price := float32(40)
+	product := newProduct(1, 10, 1, 0, 0, 0)
+	product.Variants = []models.ProductVariant{{Base: models.Base{Id: 7}, ProductId: 1, Price: &price}}
+	mockRepo.EXPECT().GetById(gomock.Any(), uint(1)).Return(product, nil)
+
+	variantId := uint(7)
+	quote, err := svc.Calculate(context.Background(), []dto.QuoteItem{{ProductId: 1, VariantId: &variantId, Quantity: 2}}, "MD", "standard")
+	assert.NoError(t, err)
+	assert.True(t, quote.FreeShipping, "the variant's price should count towards the threshold")
+}
+
+func TestCalculateInvalidState(t *testing.T) {
+	_, svc := setup(t)
+	quote, err := svc.Calculate(context.Background(), []dto.QuoteItem{{ProductId: 1, Quantity: 1}}, "BC", "standard")
+	assert.Error(t, err)
+	assert.Nil(t, quote)
+}
+
+func TestCalculateMethodNotInZone(t *testing.T) {
+	_, svc := setup(t)
+	quote, err := svc.Calculate(context.Background(), []dto.QuoteItem{{ProductId: 1, Quantity: 1}}, "HI", "overnight")
+	assert.Error(t, err)
+	assert.Nil(t, quote)
+}
+
+func TestCalculateProductError(t *testing.T) {
+	mockRepo, svc := setup(t)
+	mockRepo.EXPECT().GetById(gomock.Any(), uint(1)).Return(nil, fmt.Errorf("record not found"))
+	quote, err := svc.Calculate(context.Background(), []dto.QuoteItem{{ProductId: 1, Quantity: 1}}, "MD", "standard")
+	assert.Error(t, err)
+	assert.Nil(t, quote)
+}
+
+func TestQuote(t *testing.T) {
+	mockRepo, svc := setup(t)
+	mockRepo.EXPECT().GetById(gomock.Any(), uint(1)).Return(newProduct(1, 10, 1, 0, 0, 0), nil)
+
+	quotes, err := svc.Quote(context.Background(), []dto.QuoteItem{{ProductId: 1, Quantity: 1}}, "AK")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(quotes), "only noncontiguous methods should be quoted")
+	for _, q := range quotes {
+		assert.Equal(t, "noncontiguous", q.Zone)
+	}
+}
@@ -10,6 +10,7 @@ type TaxServiceI interface {
 	GetAll() []dto.Tax
 	GetStates() []string
 	Calculate(amount float64, state string) (*float64, error)
+	IsShippingTaxable(state string) bool
 }
 
 type TaxService struct {
@@ -49,6 +50,12 @@ func (t *TaxService) GetStates() []string {
 	return keys
 }
 
+// IsShippingTaxable implements [TaxServiceI].
+func (t *TaxService) IsShippingTaxable(state string) bool {
+	_, ok := shippingTaxableStates[state]
+	return ok
+}
+
 var stateTaxes = map[string]dto.Tax{
 	"AL": {State: "AL", Amount: 0.04},
 	"AK": {State: "AK", Amount: 0.00},
@@ -102,3 +109,12 @@ var stateTaxes = map[string]dto.Tax{
 	"WY": {State: "WY", Amount: 0.04},
 	"DC": {State: "DC", Amount: 0.06},
 }
+
+// shippingTaxableStates lists the states that include delivery charges in the
+// taxable amount of a sale.
+var shippingTaxableStates = map[string]struct{}{
+	"AR": {}, "CT": {}, "DC": {}, "GA": {}, "HI": {}, "IN": {}, "KS": {},
+	"KY": {}, "MI": {}, "MN": {}, "MS": {}, "NE": {}, "NJ": {}, "NM": {},
+	"NY": {}, "NC": {}, "ND": {}, "OH": {}, "PA": {}, "RI": {}, "SC": {},
+	"SD": {}, "TN": {}, "TX": {}, "VT": {}, "WA": {}, "WV": {}, "WI": {},
+}
@@ -11,7 +11,10 @@ package user
 
 import (
 	models "commerce/internal/shared/models"
+	query "commerce/internal/shared/repositories/query"
+	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -40,90 +43,104 @@ func (m *MockUserRepositoryI) EXPECT() *MockUserRepositoryIMockRecorder {
 	return m.recorder
 }
 
+// Anonymize mocks base method.
+func (m *MockUserRepositoryI) Anonymize(ctx context.Context, id uint, erasedDate time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Anonymize", ctx, id, erasedDate)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Anonymize indicates an expected call of Anonymize.
+func (mr *MockUserRepositoryIMockRecorder) Anonymize(ctx, id, erasedDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Anonymize", reflect.TypeOf((*MockUserRepositoryI)(nil).Anonymize), ctx, id, erasedDate)
+}
+
 // Delete mocks base method.
-func (m *MockUserRepositoryI) Delete(id uint, hard bool) error {
+func (m *MockUserRepositoryI) Delete(ctx context.Context, id uint, hard bool) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", id, hard)
+	ret := m.ctrl.Call(m, "Delete", ctx, id, hard)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockUserRepositoryIMockRecorder) Delete(id, hard any) *gomock.Call {
+func (mr *MockUserRepositoryIMockRecorder) Delete(ctx, id, hard any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUserRepositoryI)(nil).Delete), id, hard)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUserRepositoryI)(nil).Delete), ctx, id, hard)
 }
 
 // GetAll mocks base method.
-func (m *MockUserRepositoryI) GetAll() ([]*models.User, error) {
+func (m *MockUserRepositoryI) GetAll(ctx context.Context, opts query.Options) (*query.Page[models.User], error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll")
-	ret0, _ := ret[0].([]*models.User)
+	ret := m.ctrl.Call(m, "GetAll", ctx, opts)
+	ret0, _ := ret[0].(*query.Page[models.User])
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockUserRepositoryIMockRecorder) GetAll() *gomock.Call {
+func (mr *MockUserRepositoryIMockRecorder) GetAll(ctx, opts any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockUserRepositoryI)(nil).GetAll))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockUserRepositoryI)(nil).GetAll), ctx, opts)
 }
 
 // GetByAuthSub mocks base method.
-func (m *MockUserRepositoryI) GetByAuthSub(sub string) (*models.User, error) {
+func (m *MockUserRepositoryI) GetByAuthSub(ctx context.Context, sub string) (*models.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByAuthSub", sub)
+	ret := m.ctrl.Call(m, "GetByAuthSub", ctx, sub)
 	ret0, _ := ret[0].(*models.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByAuthSub indicates an expected call of GetByAuthSub.
-func (mr *MockUserRepositoryIMockRecorder) GetByAuthSub(sub any) *gomock.Call {
+func (mr *MockUserRepositoryIMockRecorder) GetByAuthSub(ctx, sub any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByAuthSub", reflect.TypeOf((*MockUserRepositoryI)(nil).GetByAuthSub), sub)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByAuthSub", reflect.TypeOf((*MockUserRepositoryI)(nil).GetByAuthSub), ctx, sub)
 }
 
 // GetByEmail mocks base method.
-func (m *MockUserRepositoryI) GetByEmail(email string) (*models.User, error) {
+func (m *MockUserRepositoryI) GetByEmail(ctx context.Context, email string) (*models.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByEmail", email)
+	ret := m.ctrl.Call(m, "GetByEmail", ctx, email)
 	ret0, _ := ret[0].(*models.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByEmail indicates an expected call of GetByEmail.
-func (mr *MockUserRepositoryIMockRecorder) GetByEmail(email any) *gomock.Call {
+func (mr *MockUserRepositoryIMockRecorder) GetByEmail(ctx, email any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEmail", reflect.TypeOf((*MockUserRepositoryI)(nil).GetByEmail), email)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEmail", reflect.TypeOf((*MockUserRepositoryI)(nil).GetByEmail), ctx, email)
 }
 
 // GetById mocks base method.
-func (m *MockUserRepositoryI) GetById(id uint) (*models.User, error) {
+func (m *MockUserRepositoryI) GetById(ctx context.Context, id uint) (*models.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetById", id)
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
 	ret0, _ := ret[0].(*models.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetById indicates an expected call of GetById.
-func (mr *MockUserRepositoryIMockRecorder) GetById(id any) *gomock.Call {
+func (mr *MockUserRepositoryIMockRecorder) GetById(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockUserRepositoryI)(nil).GetById), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockUserRepositoryI)(nil).GetById), ctx, id)
 }
 
 // Save mocks base method.
-func (m *MockUserRepositoryI) Save(user *models.User) error {
+func (m *MockUserRepositoryI) Save(ctx context.Context, user *models.User) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Save", user)
+	ret := m.ctrl.Call(m, "Save", ctx, user)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Save indicates an expected call of Save.
-func (mr *MockUserRepositoryIMockRecorder) Save(user any) *gomock.Call {
+func (mr *MockUserRepositoryIMockRecorder) Save(ctx, user any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockUserRepositoryI)(nil).Save), user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockUserRepositoryI)(nil).Save), ctx, user)
 }
@@ -1,21 +1,30 @@
 package user
 
 import (
+	"commerce/api/internal/dto/page"
 	dto "commerce/api/internal/dto/user"
 	"commerce/internal/shared/models"
+	"commerce/internal/shared/repositories/query"
 	repo "commerce/internal/shared/repositories/user"
+	"context"
 	"errors"
 	"log/slog"
+
+	"gorm.io/gorm"
 )
 
+// ErrAccountDeleted is returned by ResolveByAuth when the token's user has
+// been deleted.
+var ErrAccountDeleted = errors.New("this account has been deleted")
+
 type UserServiceI interface {
-	Authenticate(email, password string) (*dto.User, error)
-	GetAll() ([]*dto.User, error)
-	GetById(id uint) (*dto.User, error)
-	GetByEmail(email string) (*dto.User, error)
-	ResolveByAuth(sub, email, firstName, lastName string) (*dto.User, error)
-	Delete(id uint) error
-	Save(user *dto.User) error
+	GetAll(ctx context.Context, opts query.Options) (*page.Page[dto.User], error)
+	GetById(ctx context.Context, id uint) (*dto.User, error)
+	GetByEmail(ctx context.Context, email string) (*dto.User, error)
+	ResolveByAuth(ctx context.Context, sub, email string, emailVerified bool, firstName, lastName string) (*dto.User, error)
+	Delete(ctx context.Context, id uint) error
+	Save(ctx context.Context, user *dto.User) error
+	UpdateProfile(ctx context.Context, id uint, profile dto.Profile) (*dto.User, error)
 }
 
 func NewUserService(repo repo.UserRepositoryI) UserServiceI {
@@ -26,83 +35,90 @@ type UserService struct {
 	repo repo.UserRepositoryI
 }
 
-// ResolveByAuth implements [UserServiceI].
-func (u *UserService) ResolveByAuth(sub string, email string, firstName string, lastName string) (*dto.User, error) {
-	user, err := u.getByAuthSub(sub)
+// ResolveByAuth implements [UserServiceI]. An account from before Auth0 is
+// claimed by the first login with its email, but only when the token says
+// the email is verified; anyone can sign up with an address they don't own.
+// A deleted user gets [ErrAccountDeleted] rather than a new account.
+func (u *UserService) ResolveByAuth(ctx context.Context, sub string, email string, emailVerified bool, firstName string, lastName string) (*dto.User, error) {
+	user, err := u.getByAuthSub(ctx, sub)
+	if errors.Is(err, ErrAccountDeleted) {
+		return nil, err
+	}
 	if err == nil && user != nil {
 		return user, nil
 	}
+	if legacy, lookupErr := u.legacyByEmail(ctx, email, emailVerified); lookupErr == nil && legacy.IsLegacy() {
+		legacy.AuthSub = sub
+		if err := u.repo.Save(ctx, legacy); err != nil {
+			slog.Error("ResolveByAuth: failed to link legacy user", "sub", sub, "id", legacy.Id, "error", err)
+			return nil, err
+		}
+		return dto.FromModel(legacy), nil
+	}
 	newUser := &models.User{
 		AuthSub:   sub,
 		Email:     email,
 		FirstName: firstName,
 		LastName:  lastName,
 	}
-	if err := u.repo.Save(newUser); err != nil {
+	if err := u.repo.Save(ctx, newUser); err != nil {
 		slog.Error("ResolveByAuth: failed to create user", "sub", sub, "error", err)
-		if existing, lookupErr := u.repo.GetByAuthSub(sub); lookupErr == nil && existing != nil {
-			return dto.FromModel(existing), nil
+		if existing, lookupErr := u.getByAuthSub(ctx, sub); lookupErr == nil && existing != nil {
+			return existing, nil
 		}
 		return nil, err
 	}
 	return dto.FromModel(newUser), nil
 }
 
-func (u *UserService) getByAuthSub(sub string) (*dto.User, error) {
-	user, err := u.repo.GetByAuthSub(sub)
-	if err != nil {
-		slog.Error("exception occured when retrieving user by auth sub", "error", err)
-		return nil, err
+// legacyByEmail looks up the user a verified email could claim.
+func (u *UserService) legacyByEmail(ctx context.Context, email string, emailVerified bool) (*models.User, error) {
+	if !emailVerified {
+		return nil, gorm.ErrRecordNotFound
 	}
-	return dto.FromModel(user), nil
+	return u.repo.GetByEmail(ctx, email)
 }
 
-// Authenticate implements [UserServiceI].
-func (u *UserService) Authenticate(email string, password string) (*dto.User, error) {
-	model, err := u.repo.GetByEmail(email)
+func (u *UserService) getByAuthSub(ctx context.Context, sub string) (*dto.User, error) {
+	user, err := u.repo.GetByAuthSub(ctx, sub)
 	if err != nil {
-		slog.Error("Exception occured retrieving user by email", "email", email, "error", err)
+		slog.Error("exception occured when retrieving user by auth sub", "error", err)
 		return nil, err
 	}
-	if !model.CheckPassword(password) {
-		return nil, errors.New("invalid credentials")
+	if user.DeletedDate.Valid {
+		return nil, ErrAccountDeleted
 	}
-	return dto.FromModel(model), nil
+	return dto.FromModel(user), nil
 }
 
 // GetAll implements [UserServiceI].
-func (u *UserService) GetAll() ([]*dto.User, error) {
-	users, err := u.repo.GetAll()
+func (u *UserService) GetAll(ctx context.Context, opts query.Options) (*page.Page[dto.User], error) {
+	users, err := u.repo.GetAll(ctx, opts)
 	if err != nil {
-		slog.Error("Exception occured retrieving all of the users")
+		slog.Error("Exception occured retrieving all of the users", "error", err)
 		return nil, err
 	}
-	dtos := []*dto.User{}
-
-	for _, user := range users {
-		dtos = append(dtos, dto.FromModel(user))
-	}
-	return dtos, nil
+	return page.FromPage(users, dto.FromModel), nil
 }
 
 // Delete implements [UserServiceI].
-func (u *UserService) Delete(id uint) error {
-	return u.repo.Delete(id, false)
+func (u *UserService) Delete(ctx context.Context, id uint) error {
+	return u.repo.Delete(ctx, id, false)
 }
 
 // GetByEmail implements [UserServiceI].
-func (u *UserService) GetByEmail(email string) (*dto.User, error) {
-	model, err := u.repo.GetByEmail(email)
+func (u *UserService) GetByEmail(ctx context.Context, email string) (*dto.User, error) {
+	model, err := u.repo.GetByEmail(ctx, email)
 	if err != nil {
 		slog.Error("Exception occured retrieving user by email", "email", email, "error", err)
-		return nil, errors.New("invalid credentials")
+		return nil, err
 	}
 	return dto.FromModel(model), nil
 }
 
 // GetById implements [UserServiceI].
-func (u *UserService) GetById(id uint) (*dto.User, error) {
-	model, err := u.repo.GetById(id)
+func (u *UserService) GetById(ctx context.Context, id uint) (*dto.User, error) {
+	model, err := u.repo.GetById(ctx, id)
 	if err != nil {
 		slog.Error("Exception occured retrieving user by id", "id", id, "error", err)
 		return nil, err
@@ -111,7 +127,27 @@ func (u *UserService) GetById(id uint) (*dto.User, error) {
 }
 
 // Save implements [UserServiceI].
-func (u *UserService) Save(user *dto.User) error {
+func (u *UserService) Save(ctx context.Context, user *dto.User) error {
 	model := dto.ToModel(user)
-	return u.repo.Save(model)
+	return u.repo.Save(ctx, model)
+}
+
+// UpdateProfile implements [UserServiceI].
+func (u *UserService) UpdateProfile(ctx context.Context, id uint, profile dto.Profile) (*dto.User, error) {
+	model, err := u.repo.GetById(ctx, id)
+	if err != nil {
+		slog.Error("Exception occured retrieving user by id", "id", id, "error", err)
+		return nil, err
+	}
+	if profile.FirstName != nil {
+		model.FirstName = *profile.FirstName
+	}
+	if profile.LastName != nil {
+		model.LastName = *profile.LastName
+	}
+	if err := u.repo.Save(ctx, model); err != nil {
+		slog.Error("Exception occured updating user profile", "id", id, "error", err)
+		return nil, err
+	}
+	return dto.FromModel(model), nil
 }
@@ -3,6 +3,8 @@ package user
 import (
 	dto "commerce/api/internal/dto/user"
 	"commerce/internal/shared/models"
+	"commerce/internal/shared/repositories/query"
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -10,66 +12,15 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
-	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 )
 
-func TestAuthenticate(t *testing.T) {
-	password := "hashed_password"
-	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
-	ctl := gomock.NewController(t)
-	defer ctl.Finish()
-
-	mockRepo := NewMockUserRepositoryI(ctl)
-	mockRepo.EXPECT().GetByEmail("jon.doe@example.com").Return(&models.User{
-		Base: models.Base{
-			Id:          1,
-			CreatedDate: time.Now(),
-			UpdatedDate: time.Now(),
-		},
-		Email:     "jon.doe@example.com",
-		Password:  string(hashedPassword),
-		FirstName: "Jon",
-		LastName:  "Doe",
-	}, nil)
-	svc := NewUserService(mockRepo)
-	user, err := svc.Authenticate("jon.doe@example.com", password)
-	assert.NoError(t, err)
-	assert.NotNil(t, user)
-	assert.Equal(t, "jon.doe@example.com", user.Email)
-	assert.Equal(t, "Jon", user.FirstName)
-	assert.Equal(t, "Doe", user.LastName)
-}
-
-func TestInvalidAuthentication(t *testing.T) {
-	password, wrong_password := "hashed_password", "wrong_password"
-	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
-	ctl := gomock.NewController(t)
-	defer ctl.Finish()
-	mockRepo := NewMockUserRepositoryI(ctl)
-	mockRepo.EXPECT().GetByEmail("jon.doe@example.com").Return(&models.User{
-		Base: models.Base{
-			Id:          1,
-			CreatedDate: time.Now(),
-			UpdatedDate: time.Now(),
-		},
-		Email:     "jon.doe@example.com",
-		Password:  string(hashedPassword),
-		FirstName: "Jon",
-		LastName:  "Doe",
-	}, nil)
-
-	svc := NewUserService(mockRepo)
-	user, err := svc.Authenticate("jon.doe@example.com", wrong_password)
-	assert.Error(t, err)
-	assert.Nil(t, user)
-}
-
 func TestGetById(t *testing.T) {
 	id := uint(1)
 	ctl := gomock.NewController(t)
 	mockRepo := NewMockUserRepositoryI(ctl)
 	defer ctl.Finish()
-	mockRepo.EXPECT().GetById(id).Return(&models.User{
+	mockRepo.EXPECT().GetById(gomock.Any(), id).Return(&models.User{
 		Base: models.Base{
 			Id:          id,
 			CreatedDate: time.Now(),
@@ -80,7 +31,7 @@ func TestGetById(t *testing.T) {
 		LastName:  "Doe",
 	}, nil)
 	svc := NewUserService(mockRepo)
-	user, err := svc.GetById(1)
+	user, err := svc.GetById(context.Background(), 1)
 	assert.NotNil(t, user)
 	assert.NoError(t, err)
 }
@@ -89,7 +40,7 @@ func TestGetAll(t *testing.T) {
 	ctl := gomock.NewController(t)
 	mockRepo := NewMockUserRepositoryI(ctl)
 	defer ctl.Finish()
-	mockRepo.EXPECT().GetAll().Return([]*models.User{
+	mockRepo.EXPECT().GetAll(gomock.Any(), query.Options{}).Return(&query.Page[models.User]{Total: 3, Items: []*models.User{
 		{
 			Base:      models.Base{Id: 1, CreatedDate: time.Now(), UpdatedDate: time.Now()},
 			FirstName: "Jon",
@@ -105,24 +56,25 @@ func TestGetAll(t *testing.T) {
 			FirstName: "Joe",
 			LastName:  "Smith",
 		},
-	}, nil)
+	}}, nil)
 	svc := NewUserService(mockRepo)
-	users, err := svc.GetAll()
+	users, err := svc.GetAll(context.Background(), query.Options{})
 	assert.NoError(t, err)
-	assert.NotEmpty(t, users)
+	assert.Len(t, users.Items, 3)
+	assert.Nil(t, users.NextCursor)
 }
 
 func TestSave(t *testing.T) {
 	ctl := gomock.NewController(t)
 	mockRepo := NewMockUserRepositoryI(ctl)
 	defer ctl.Finish()
-	mockRepo.EXPECT().Save(gomock.Any()).Return(nil)
+	mockRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
 	svc := NewUserService(mockRepo)
-	err := svc.Save(&dto.User{
+	err := svc.Save(context.Background(), &dto.User{
 		FirstName: "Jon",
 		LastName:  "Doe",
 		Email:     "jon.doe@example.com",
-		Password:  "secret",
+		AuthSub:   "auth0|abc123",
 	})
 	assert.NoError(t, err)
 }
@@ -132,9 +84,9 @@ func TestDelete(t *testing.T) {
 	ctl := gomock.NewController(t)
 	mockRepo := NewMockUserRepositoryI(ctl)
 	defer ctl.Finish()
-	mockRepo.EXPECT().Delete(id, false).Return(nil)
+	mockRepo.EXPECT().Delete(gomock.Any(), id, false).Return(nil)
 	svc := NewUserService(mockRepo)
-	err := svc.Delete(id)
+	err := svc.Delete(context.Background(), id)
 	assert.NoError(t, err)
 }
 
@@ -144,7 +96,7 @@ func TestResolveByAuth_ExistingUser_NoSave(t *testing.T) {
 	defer ctl.Finish()
 	mockRepo := NewMockUserRepositoryI(ctl)
 
-	mockRepo.EXPECT().GetByAuthSub("auth0|abc123").Return(&models.User{
+	mockRepo.EXPECT().GetByAuthSub(gomock.Any(), "auth0|abc123").Return(&models.User{
 		Base: models.Base{
 			Id:          7,
 			CreatedDate: time.Now(),
@@ -158,7 +110,7 @@ func TestResolveByAuth_ExistingUser_NoSave(t *testing.T) {
 	// Save must NOT be called — gomock will fail the test if it is.
 
 	svc := NewUserService(mockRepo)
-	user, err := svc.ResolveByAuth("auth0|abc123", "ali@example.com", "Ali", "Khakpouri")
+	user, err := svc.ResolveByAuth(context.Background(), "auth0|abc123", "ali@example.com", true, "Ali", "Khakpouri")
 
 	require.NoError(t, err)
 	require.NotNil(t, user)
@@ -168,6 +120,26 @@ func TestResolveByAuth_ExistingUser_NoSave(t *testing.T) {
 	assert.Equal(t, "Khakpouri", user.LastName)
 }
 
+// Hit on a deleted user — no new account is made for their sub.
+func TestResolveByAuth_DeletedUser_ReturnsErrAccountDeleted(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	mockRepo := NewMockUserRepositoryI(ctl)
+
+	mockRepo.EXPECT().GetByAuthSub(gomock.Any(), "auth0|abc123").Return(&models.User{
+		Base:    models.Base{Id: 7, DeletedDate: gorm.DeletedAt{Time: time.Now(), Valid: true}},
+		AuthSub: "auth0|abc123",
+		Email:   "ali@example.com",
+	}, nil)
+	// Neither GetByEmail nor Save may be called.
+
+	svc := NewUserService(mockRepo)
+	user, err := svc.ResolveByAuth(context.Background(), "auth0|abc123", "ali@example.com", true, "Ali", "Khakpouri")
+
+	assert.ErrorIs(t, err, ErrAccountDeleted)
+	assert.Nil(t, user)
+}
+
 // Miss — repo returns not-found, service builds a new user and saves it.
 // DoAndReturn captures the model and simulates GORM populating Id on insert.
 func TestResolveByAuth_NewUser_SavesWithClaimFields(t *testing.T) {
@@ -176,12 +148,15 @@ func TestResolveByAuth_NewUser_SavesWithClaimFields(t *testing.T) {
 	mockRepo := NewMockUserRepositoryI(ctl)
 
 	mockRepo.EXPECT().
-		GetByAuthSub("auth0|new123").
+		GetByAuthSub(gomock.Any(), "auth0|new123").
+		Return(nil, errors.New("record not found"))
+	mockRepo.EXPECT().
+		GetByEmail(gomock.Any(), "ali@example.com").
 		Return(nil, errors.New("record not found"))
 
 	mockRepo.EXPECT().
-		Save(gomock.Any()).
-		DoAndReturn(func(m *models.User) error {
+		Save(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, m *models.User) error {
 			// Assert the model was built from claim fields.
 			assert.Equal(t, "auth0|new123", m.AuthSub)
 			assert.Equal(t, "ali@example.com", m.Email)
@@ -194,7 +169,7 @@ func TestResolveByAuth_NewUser_SavesWithClaimFields(t *testing.T) {
 		})
 
 	svc := NewUserService(mockRepo)
-	user, err := svc.ResolveByAuth("auth0|new123", "ali@example.com", "Ali", "Khakpouri")
+	user, err := svc.ResolveByAuth(context.Background(), "auth0|new123", "ali@example.com", true, "Ali", "Khakpouri")
 
 	require.NoError(t, err)
 	require.NotNil(t, user)
@@ -215,15 +190,18 @@ func TestResolveByAuth_SaveError_ReSelectFindsExisting_ReturnsUser(t *testing.T)
 
 	// Call #1: initial lookup misses.
 	mockRepo.EXPECT().
-		GetByAuthSub("auth0|new123").
+		GetByAuthSub(gomock.Any(), "auth0|new123").
+		Return(nil, errors.New("record not found"))
+	mockRepo.EXPECT().
+		GetByEmail(gomock.Any(), "ali@example.com").
 		Return(nil, errors.New("record not found"))
 	// Save loses the race against the concurrent inserter.
 	mockRepo.EXPECT().
-		Save(gomock.Any()).
+		Save(gomock.Any(), gomock.Any()).
 		Return(errors.New("duplicate key violates unique constraint"))
 	// Call #2: re-SELECT now finds the winner's row.
 	mockRepo.EXPECT().
-		GetByAuthSub("auth0|new123").
+		GetByAuthSub(gomock.Any(), "auth0|new123").
 		Return(&models.User{
 			Base:      models.Base{Id: 42},
 			AuthSub:   "auth0|new123",
@@ -233,7 +211,7 @@ func TestResolveByAuth_SaveError_ReSelectFindsExisting_ReturnsUser(t *testing.T)
 		}, nil)
 
 	svc := NewUserService(mockRepo)
-	user, err := svc.ResolveByAuth("auth0|new123", "ali@example.com", "Ali", "Khakpouri")
+	user, err := svc.ResolveByAuth(context.Background(), "auth0|new123", "ali@example.com", true, "Ali", "Khakpouri")
 
 	require.NoError(t, err)
 	require.NotNil(t, user)
@@ -251,18 +229,148 @@ func TestResolveByAuth_SaveError_ReSelectAlsoFails_PropagatesAndReturnsNil(t *te
 
 	// Call #1: initial lookup misses.
 	mockRepo.EXPECT().
-		GetByAuthSub("auth0|new123").
+		GetByAuthSub(gomock.Any(), "auth0|new123").
+		Return(nil, errors.New("record not found"))
+	mockRepo.EXPECT().
+		GetByEmail(gomock.Any(), "ali@example.com").
 		Return(nil, errors.New("record not found"))
 	mockRepo.EXPECT().
-		Save(gomock.Any()).
+		Save(gomock.Any(), gomock.Any()).
 		Return(errors.New("duplicate key violates unique constraint"))
 	// Call #2: re-SELECT also fails — nothing to recover, original error wins.
 	mockRepo.EXPECT().
-		GetByAuthSub("auth0|new123").
+		GetByAuthSub(gomock.Any(), "auth0|new123").
+		Return(nil, errors.New("record not found"))
+
+	svc := NewUserService(mockRepo)
+	user, err := svc.ResolveByAuth(context.Background(), "auth0|new123", "ali@example.com", true, "Ali", "Khakpouri")
+
+	require.Error(t, err)
+	assert.Nil(t, user)
+}
+
+// Miss on sub, hit on email for a pre-Auth0 account — the account is claimed
+// by taking the real sub instead of creating a duplicate user.
+func TestResolveByAuth_LegacyUser_LinksSub(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	mockRepo := NewMockUserRepositoryI(ctl)
+
+	mockRepo.EXPECT().
+		GetByAuthSub(gomock.Any(), "auth0|new123").
 		Return(nil, errors.New("record not found"))
+	mockRepo.EXPECT().
+		GetByEmail(gomock.Any(), "ali@example.com").
+		Return(&models.User{
+			Base:      models.Base{Id: 5},
+			AuthSub:   models.LegacyAuthSubPrefix + "5",
+			Email:     "ali@example.com",
+			FirstName: "Ali",
+			LastName:  "Khakpouri",
+		}, nil)
+	mockRepo.EXPECT().
+		Save(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, m *models.User) error {
+			assert.Equal(t, uint(5), m.Id, "must update the legacy row, not insert")
+			assert.Equal(t, "auth0|new123", m.AuthSub)
+			return nil
+		})
+
+	svc := NewUserService(mockRepo)
+	user, err := svc.ResolveByAuth(context.Background(), "auth0|new123", "ali@example.com", true, "Ali", "Khakpouri")
+
+	require.NoError(t, err)
+	assert.Equal(t, uint(5), user.Id)
+	assert.Equal(t, "auth0|new123", user.AuthSub)
+}
+
+// Miss on sub, hit on email for an account already linked to another sub —
+// it isn't taken over, so the insert hits the unique email and fails.
+func TestResolveByAuth_LinkedEmail_NotTakenOver(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	mockRepo := NewMockUserRepositoryI(ctl)
+
+	mockRepo.EXPECT().
+		GetByAuthSub(gomock.Any(), "auth0|new123").
+		Return(nil, errors.New("record not found")).
+		Times(2)
+	mockRepo.EXPECT().
+		GetByEmail(gomock.Any(), "ali@example.com").
+		Return(&models.User{Base: models.Base{Id: 5}, AuthSub: "google-oauth2|999", Email: "ali@example.com"}, nil)
+	mockRepo.EXPECT().
+		Save(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, m *models.User) error {
+			assert.Equal(t, uint(0), m.Id, "the linked row must not be updated")
+			return errors.New("duplicate key violates unique constraint")
+		})
+
+	svc := NewUserService(mockRepo)
+	user, err := svc.ResolveByAuth(context.Background(), "auth0|new123", "ali@example.com", true, "Ali", "Khakpouri")
+
+	require.Error(t, err)
+	assert.Nil(t, user)
+}
+
+// Miss on sub for a legacy account's email, but the token doesn't vouch for
+// the email — the account isn't looked up, let alone claimed.
+func TestResolveByAuth_UnverifiedEmail_LegacyNotClaimed(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	mockRepo := NewMockUserRepositoryI(ctl)
+
+	mockRepo.EXPECT().
+		GetByAuthSub(gomock.Any(), "auth0|new123").
+		Return(nil, errors.New("record not found")).
+		Times(2)
+	mockRepo.EXPECT().
+		Save(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, m *models.User) error {
+			assert.Equal(t, uint(0), m.Id, "the legacy row must not be updated")
+			return errors.New("duplicate key violates unique constraint")
+		})
+
+	svc := NewUserService(mockRepo)
+	user, err := svc.ResolveByAuth(context.Background(), "auth0|new123", "ali@example.com", false, "Ali", "Khakpouri")
+
+	require.Error(t, err)
+	assert.Nil(t, user)
+}
+
+func TestUpdateProfile(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	mockRepo := NewMockUserRepositoryI(ctl)
+	mockRepo.EXPECT().GetById(gomock.Any(), uint(1)).Return(&models.User{
+		Base:      models.Base{Id: 1},
+		Email:     "jon.doe@example.com",
+		FirstName: "Jon",
+		LastName:  "Doe",
+	}, nil)
+	mockRepo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, user *models.User) error {
+		assert.Equal(t, "Jonathan", user.FirstName)
+		assert.Equal(t, "Doe", user.LastName, "fields left out of the profile are kept")
+		assert.Equal(t, "jon.doe@example.com", user.Email)
+		return nil
+	})
+
+	svc := NewUserService(mockRepo)
+	firstName := "Jonathan"
+	user, err := svc.UpdateProfile(context.Background(), 1, dto.Profile{FirstName: &firstName})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Jonathan", user.FirstName)
+	assert.Equal(t, "Doe", user.LastName)
+}
+
+func TestUpdateProfile_UnknownUser(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	mockRepo := NewMockUserRepositoryI(ctl)
+	mockRepo.EXPECT().GetById(gomock.Any(), uint(1)).Return(nil, errors.New("record not found"))
 
 	svc := NewUserService(mockRepo)
-	user, err := svc.ResolveByAuth("auth0|new123", "ali@example.com", "Ali", "Khakpouri")
+	user, err := svc.UpdateProfile(context.Background(), 1, dto.Profile{})
 
 	require.Error(t, err)
 	assert.Nil(t, user)
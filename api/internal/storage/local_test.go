@@ -0,0 +1,36 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalStoreRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	store := NewLocalStore(t.TempDir(), "/media/")
+
+	assert.NoError(t, store.Put(ctx, "products/1/a.txt", strings.NewReader("hello"), "text/plain"))
+	body, err := store.Get(ctx, "products/1/a.txt")
+	assert.NoError(t, err)
+	data, _ := io.ReadAll(body)
+	body.Close()
+	assert.Equal(t, "hello", string(data))
+	assert.Equal(t, "/media/products/1/a.txt", store.URL("products/1/a.txt"))
+
+	assert.NoError(t, store.Delete(ctx, "products/1/a.txt"))
+	_, err = store.Get(ctx, "products/1/a.txt")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.NoError(t, store.Delete(ctx, "products/1/a.txt"))
+}
+
+func TestLocalStoreRejectsKeysOutsideItsDirectory(t *testing.T) {
+	store := NewLocalStore(t.TempDir(), "/media")
+
+	for _, key := range []string{"../a.txt", "/etc/passwd", "products/../../a.txt"} {
+		assert.Error(t, store.Put(context.Background(), key, strings.NewReader("x"), "text/plain"), key)
+	}
+}
@@ -0,0 +1,50 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"strings"
+)
+
+// S3Client is the part of an S3 client S3Store needs. It is small enough to
+// wrap the AWS SDK's client, or another S3-compatible one such as MinIO's,
+// in a few lines, which keeps their dependencies out of this module until a
+// deployment needs them.
+type S3Client interface {
+	PutObject(ctx context.Context, bucket string, key string, body io.Reader, contentType string) error
+	// GetObject returns [ErrNotFound] for keys that hold no object.
+	GetObject(ctx context.Context, bucket string, key string) (io.ReadCloser, error)
+	DeleteObject(ctx context.Context, bucket string, key string) error
+}
+
+// S3Store keeps blobs in a bucket of an S3-compatible object store. Clients
+// download them from the bucket's public URL, or a CDN in front of it.
+type S3Store struct {
+	client  S3Client
+	bucket  string
+	baseURL string
+}
+
+func NewS3Store(client S3Client, bucket string, baseURL string) *S3Store {
+	return &S3Store{client: client, bucket: bucket, baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+// Put implements [BlobStore].
+func (s *S3Store) Put(ctx context.Context, key string, body io.Reader, contentType string) error {
+	return s.client.PutObject(ctx, s.bucket, key, body, contentType)
+}
+
+// Get implements [BlobStore].
+func (s *S3Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	return s.client.GetObject(ctx, s.bucket, key)
+}
+
+// Delete implements [BlobStore].
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	return s.client.DeleteObject(ctx, s.bucket, key)
+}
+
+// URL implements [BlobStore].
+func (s *S3Store) URL(key string) string {
+	return s.baseURL + "/" + key
+}
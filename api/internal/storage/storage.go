@@ -0,0 +1,22 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrNotFound is returned by Get for keys that hold no blob.
+var ErrNotFound = errors.New("blob not found")
+
+// BlobStore is implemented by every place uploaded files can be kept. Keys
+// are slash-separated paths such as products/12/abc.jpg; the store decides
+// where they live and how clients reach them.
+type BlobStore interface {
+	Put(ctx context.Context, key string, body io.Reader, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the blob. Deleting a key that holds none isn't an error.
+	Delete(ctx context.Context, key string) error
+	// URL is where clients download the blob from.
+	URL(key string) string
+}
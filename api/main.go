@@ -3,8 +3,16 @@ package main
 import (
 	"commerce/api/configs"
 	"commerce/api/container"
+	"commerce/api/internal/carrier"
+	"commerce/api/internal/storage"
 	"commerce/api/server"
+	"context"
 	"log/slog"
+	"time"
+
+	privacy_service "commerce/api/internal/services/privacy"
+	product_import_service "commerce/api/internal/services/product-import"
+	shipment_service "commerce/api/internal/services/shipment"
 
 	routes "commerce/api/server/router"
 
@@ -25,7 +33,21 @@ func main() {
 		slog.Error("failed to connect to database", "error", err)
 		panic("Failed to connect to the database")
 	}
-	container := container.NewContainer(db)
+	carriers := carrier.NewRegistry(
+		carrier.NewSimulator(config.Carrier.SimulatorStep, time.Now),
+	)
+	store := storage.NewLocalStore(config.Media.Dir, config.Media.URL)
+	container := container.NewContainer(db, config, carriers, store)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	poller := shipment_service.NewPoller(container.ShipmentService, config.Carrier.PollInterval)
+	go poller.Start(ctx)
+	erasures := privacy_service.NewErasureJob(container.PrivacyService, config.Privacy.ErasureInterval)
+	go erasures.Start(ctx)
+	imports := product_import_service.NewImportJob(container.ImportService, config.Import.Interval)
+	go imports.Start(ctx)
+
 	router := gin.Default()
 	router.Use(config.CorsNew())
 
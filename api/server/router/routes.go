@@ -5,15 +5,29 @@ import (
 	"commerce/api/container"
 	"commerce/api/internal/auth"
 	address_handler "commerce/api/internal/handlers/address"
+	api_key_handler "commerce/api/internal/handlers/api-key"
+	audit_event_handler "commerce/api/internal/handlers/audit-event"
 	auth_handler "commerce/api/internal/handlers/auth"
 	category_handler "commerce/api/internal/handlers/category"
+	invoice_handler "commerce/api/internal/handlers/invoice"
+	me_handler "commerce/api/internal/handlers/me"
 	order_handler "commerce/api/internal/handlers/order"
 	payment_handler "commerce/api/internal/handlers/payment"
+	privacy_handler "commerce/api/internal/handlers/privacy"
 	product_handler "commerce/api/internal/handlers/product"
+	product_image_handler "commerce/api/internal/handlers/product-image"
+	product_import_handler "commerce/api/internal/handlers/product-import"
+	product_price_handler "commerce/api/internal/handlers/product-price"
+	product_variant_handler "commerce/api/internal/handlers/product-variant"
+	return_request_handler "commerce/api/internal/handlers/return-request"
 	review_handler "commerce/api/internal/handlers/review"
+	role_handler "commerce/api/internal/handlers/role"
+	shipment_handler "commerce/api/internal/handlers/shipment"
+	shipping_handler "commerce/api/internal/handlers/shipping"
 	tax_handler "commerce/api/internal/handlers/tax"
 	user_handler "commerce/api/internal/handlers/user"
 	"fmt"
+	"strings"
 
 	health_handler "commerce/api/internal/handlers/health"
 
@@ -26,7 +40,7 @@ func RegisterRoutes(router *gin.Engine, c *container.Container, config *configs.
 	api := router.Group("/api")
 	health := router.Group("/health")
 
-	valid, err := auth.NewValidator(config.Auth.Domain, config.Auth.Audience)
+	valid, err := auth.NewValidator(config.Auth.Domain, config.Auth.Audience, config.Auth.RolesClaim, config.Auth.JWKS)
 	if err != nil {
 		panic(fmt.Errorf("auth validator: %w", err))
 	}
@@ -40,35 +54,77 @@ func RegisterRoutes(router *gin.Engine, c *container.Container, config *configs.
 	authHandler := auth_handler.NewAuthHandler()
 	authHandler.RegisterRoutes(authGroup)
 
-	authedApi := api.Group("", ginAuth, auth.ResolveIdentity(c.UserService))
+	authedApi := api.Group("", auth.ApiKeyOr(ginAuth, c.ApiKeyService), auth.ResolveIdentity(c.UserService, c.RoleService))
 
 	addressHandler := address_handler.NewAddressHandler(c.AddressService)
+	apiKeyHandler := api_key_handler.NewApiKeyHandler(c.ApiKeyService)
+	auditEventHandler := audit_event_handler.NewAuditEventHandler(c.AuditService)
 	categoryHandler := category_handler.NewCategoryHandler(c.ProductService, c.CategoryService)
 	taxHandler := tax_handler.NewTaxHandler(c.TaxService)
 	orderHandler := order_handler.NewOrderHandler(c.OrderService)
-	paymentHandler := payment_handler.NewPaymentHandler(c.PaymentService)
+	meHandler := me_handler.NewMeHandler(c.UserService, c.OrderService, c.AddressService, c.ReviewService, c.PrivacyService)
+	paymentHandler := payment_handler.NewPaymentHandler(c.PaymentService, c.InvoiceService, c.OrderService)
+	invoiceHandler := invoice_handler.NewInvoiceHandler(c.InvoiceService, c.OrderService)
+	privacyHandler := privacy_handler.NewPrivacyHandler(c.PrivacyService)
 	productHandler := product_handler.NewProductHandler(c.ProductService)
+	productImageHandler := product_image_handler.NewProductImageHandler(c.ImageService, config.Media.MaxUploadBytes)
+	productImportHandler := product_import_handler.NewProductImportHandler(c.ImportService, config.Import.MaxUploadBytes)
+	productPriceHandler := product_price_handler.NewProductPriceHandler(c.PriceService)
+	productVariantHandler := product_variant_handler.NewProductVariantHandler(c.VariantService)
 	userHandler := user_handler.NewUserHandler(c.UserService)
 	reviewHandler := review_handler.NewReviewHandler(c.ReviewService)
+	roleHandler := role_handler.NewRoleHandler(c.RoleService)
+	returnHandler := return_request_handler.NewReturnRequestHandler(c.ReturnService, c.OrderService)
+	shipmentHandler := shipment_handler.NewShipmentHandler(c.ShipmentService, c.OrderService)
+	shippingHandler := shipping_handler.NewShippingHandler(c.ShippingService, c.OrderService)
 
 	healthHandler := health_handler.NewHealthHandler()
 	taxHandler.RegisterRoutes(api.Group("/tax"))
 
 	addressHandler.RegisterRoutes(authedApi.Group("/address"))
+	apiKeyHandler.RegisterRoutes(authedApi.Group("/api-keys"))
+	auditEventHandler.RegisterRoutes(authedApi.Group("/audit-events"))
 	categoryHandler.RegisterRoutes(authedApi.Group("/category"))
 	orderHandler.RegisterRoutes(authedApi.Group("/orders"))
+	meHandler.RegisterRoutes(authedApi.Group("/me"))
 	paymentHandler.RegisterRoutes(authedApi.Group("/payment"))
+	invoiceHandler.RegisterRoutes(authedApi.Group("/invoices"))
 	productHandler.RegisterRoutes(authedApi.Group("/products"))
+	productImportHandler.RegisterRoutes(authedApi.Group("/products"))
 	userHandler.RegisterRoutes(authedApi.Group("/user"))
 	reviewHandler.RegisterRoutes(authedApi.Group("/review"))
+	roleHandler.RegisterRoutes(authedApi.Group("/roles"))
+	returnHandler.RegisterRoutes(authedApi.Group("/returns"))
+	shipmentHandler.RegisterRoutes(authedApi.Group("/shipments"))
+	shippingHandler.RegisterRoutes(authedApi.Group("/shipping"))
 
 	healthHandler.RegisterRoutes(health.Group("/status"))
 
-	authedApi.Group("/users/:user_id").GET("/addresses", auth.RequireScope(auth.Scopes.Users.Read), addressHandler.GetByUserId)
-	authedApi.Group("/users/:user_id").GET("/orders", auth.RequireScope(auth.Scopes.Orders.Read), orderHandler.GetByUser)
+	userReader := auth.RequireReadOwner("user_id")
+	authedApi.Group("/users/:user_id").GET("/addresses", auth.RequireScope(auth.Scopes.Users.Read), userReader, addressHandler.GetByUserId)
+	authedApi.Group("/users/:user_id").GET("/orders", auth.RequireScope(auth.Scopes.Orders.Read), userReader, orderHandler.GetByUser)
+	authedApi.Group("/users/:user_id").GET("/roles", auth.RequireRole(auth.RoleAdmin), roleHandler.GetByUser)
+	authedApi.Group("/users/:user_id").PUT("/roles", auth.RequireRole(auth.RoleAdmin), roleHandler.Assign)
+	privacyHandler.RegisterRoutes(authedApi.Group("/users/:user_id"))
 
-	authedApi.Group("/orders/:id").GET("/payments", auth.RequireScope(auth.Scopes.Payment.Read), paymentHandler.GetByOrder)
+	orderOwner := auth.RequireOwnerOf("id", c.OrderService.GetOwnerId)
+	orderReader := auth.RequireReadOwnerOf("id", c.OrderService.GetOwnerId)
+	authedApi.Group("/orders/:id").GET("/payments", auth.RequireScope(auth.Scopes.Payment.Read), orderReader, paymentHandler.GetByOrder)
+	authedApi.Group("/orders/:id").GET("/invoice", auth.RequireScope(auth.Scopes.Orders.Read), orderReader, invoiceHandler.GetByOrder)
+	authedApi.Group("/orders/:id").GET("/invoices", auth.RequireScope(auth.Scopes.Orders.Read), orderReader, invoiceHandler.GetAllByOrder)
+	authedApi.Group("/orders/:id").GET("/shipments", auth.RequireScope(auth.Scopes.Orders.Read), orderReader, shipmentHandler.GetByOrder)
+	authedApi.Group("/orders/:id").POST("/shipments", auth.RequireStaff(), shipmentHandler.Create)
+	authedApi.Group("/orders/:id").GET("/returns", auth.RequireScope(auth.Scopes.Orders.Read), orderReader, returnHandler.GetByOrder)
+	authedApi.Group("/orders/:id").POST("/returns", auth.RequireScope(auth.Scopes.Orders.Write), orderOwner, returnHandler.Create)
 
 	authedApi.Group("/products/:id").GET("/reviews", auth.RequireScope(auth.Scopes.Reviews.Read), reviewHandler.GetAllByProduct)
+	productVariantHandler.RegisterRoutes(authedApi.Group("/products/:id"))
+	productImageHandler.RegisterRoutes(authedApi.Group("/products/:id"))
+	productPriceHandler.RegisterRoutes(authedApi.Group("/products/:id"))
+	// Images are served from disk, without auth like any storefront asset,
+	// unless MEDIA_URL points at something else serving them.
+	if strings.HasPrefix(config.Media.URL, "/") {
+		router.Static(config.Media.URL, config.Media.Dir)
+	}
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swagger.Handler))
 }
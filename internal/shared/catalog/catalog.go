@@ -0,0 +1,332 @@
+// Package catalog imports and exports products as CSV. It works on the
+// database directly, so the API and the utils tool run the same import.
+package catalog
+
+import (
+	"commerce/internal/shared/models"
+	product_repo "commerce/internal/shared/repositories/product"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// batchSize is how many SKUs, slugs or products are looked up per query.
+const batchSize = 1000
+
+type CatalogI interface {
+	Import(ctx context.Context, r io.Reader, dryRun bool) (*ImportResult, error)
+	Export(ctx context.Context, w io.Writer) error
+}
+
+// ImportResult says what an import did, or on a dry run what it would have
+// done. Rows counts the records in the file; the others count products.
+type ImportResult struct {
+	DryRun    bool
+	Rows      int
+	Created   int
+	Updated   int
+	Unchanged int
+	Errors    []RowError
+}
+
+// Applied reports whether the import was written to the database.
+func (r *ImportResult) Applied() bool {
+	return !r.DryRun && len(r.Errors) == 0
+}
+
+type Catalog struct {
+	db *gorm.DB
+}
+
+func NewCatalog(db *gorm.DB) CatalogI {
+	return &Catalog{db: db}
+}
+
+// productChange is what a row does to its product. product is nil for a new
+// one.
+type productChange struct {
+	row         *row
+	product     *models.Product
+	updates     map[string]any
+	categoryIds []uint
+}
+
+func (c *productChange) changesCategories(linked map[uint][]uint) bool {
+	if !c.row.setCategories {
+		return false
+	}
+	if c.product == nil {
+		return len(c.categoryIds) > 0
+	}
+	current := linked[c.product.Id]
+	return len(current) != len(c.categoryIds) ||
+		slices.ContainsFunc(c.categoryIds, func(id uint) bool { return !slices.Contains(current, id) })
+}
+
+// Import implements [CatalogI]. Rows are matched to products by SKU: new
+// SKUs are created, and need a name, and known ones are updated with the
+// columns the file has. The import is all or nothing. Every row is checked
+// first and, if any has an error, nothing is written and the result lists
+// them all; otherwise it runs in one transaction. It fails with
+// [ErrInvalidFile] if the file can't be read as a product CSV.
+func (c *Catalog) Import(ctx context.Context, r io.Reader, dryRun bool) (*ImportResult, error) {
+	rows, records, errs, err := readRows(r)
+	if err != nil {
+		return nil, err
+	}
+	result := &ImportResult{DryRun: dryRun, Rows: records, Errors: errs}
+
+	err = c.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		changes, linked, errs, err := plan(tx, rows)
+		if err != nil {
+			return err
+		}
+		result.Errors = append(result.Errors, errs...)
+		slices.SortStableFunc(result.Errors, func(a, b RowError) int { return a.Row - b.Row })
+
+		for _, change := range changes {
+			switch {
+			case change.product == nil:
+				result.Created++
+			case len(change.updates) > 0 || change.changesCategories(linked):
+				result.Updated++
+			default:
+				result.Unchanged++
+			}
+		}
+		if !result.Applied() {
+			return nil
+		}
+
+		products := product_repo.NewProductRepository(tx)
+		for _, change := range changes {
+			if err := apply(ctx, tx, products, change, linked); err != nil {
+				return fmt.Errorf("row %d: %w", change.row.line, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// plan checks the rows against the database and works out their changes.
+// linked are the live categories of the products being updated.
+func plan(tx *gorm.DB, rows []*row) ([]*productChange, map[uint][]uint, []RowError, error) {
+	var errs []RowError
+	fail := func(r *row, column string, format string, args ...any) {
+		errs = append(errs, RowError{Row: r.line, Sku: r.sku, Column: column, Message: fmt.Sprintf(format, args...)})
+	}
+
+	seen := make(map[string]int, len(rows))
+	seenSlugs := map[string]bool{}
+	var skus, slugs []string
+	for _, r := range rows {
+		if line, ok := seen[r.sku]; ok {
+			fail(r, "sku", "repeats the sku of row %d", line)
+			continue
+		}
+		seen[r.sku] = r.line
+		skus = append(skus, r.sku)
+		for _, slug := range r.categories {
+			if !seenSlugs[slug] {
+				seenSlugs[slug] = true
+				slugs = append(slugs, slug)
+			}
+		}
+	}
+
+	existing := make(map[string]*models.Product, len(skus))
+	for batch := range slices.Chunk(skus, batchSize) {
+		var products []*models.Product
+		if err := tx.Unscoped().Where("sku IN ?", batch).Find(&products).Error; err != nil {
+			return nil, nil, nil, err
+		}
+		for _, p := range products {
+			existing[p.Sku] = p
+		}
+	}
+
+	categories := make(map[string][]uint, len(slugs))
+	for batch := range slices.Chunk(slugs, batchSize) {
+		var found []models.Category
+		if err := tx.Select("id", "slug").Where("slug IN ?", batch).Find(&found).Error; err != nil {
+			return nil, nil, nil, err
+		}
+		for _, category := range found {
+			categories[category.Slug] = append(categories[category.Slug], category.Id)
+		}
+	}
+
+	var changes []*productChange
+	var updated []uint
+	for _, r := range rows {
+		if seen[r.sku] != r.line {
+			continue
+		}
+		change := &productChange{row: r, product: existing[r.sku]}
+		valid := true
+		switch {
+		case change.product == nil && r.name == nil:
+			fail(r, "name", "is required for a new product")
+			valid = false
+		case change.product != nil && change.product.DeletedDate.Valid:
+			fail(r, "sku", "belongs to a deleted product")
+			valid = false
+		}
+		for _, slug := range r.categories {
+			switch ids := categories[slug]; len(ids) {
+			case 0:
+				fail(r, "categories", "there is no category %q", slug)
+				valid = false
+			case 1:
+				change.categoryIds = append(change.categoryIds, ids[0])
+			default:
+				fail(r, "categories", "%d categories have the slug %q", len(ids), slug)
+				valid = false
+			}
+		}
+		if !valid {
+			continue
+		}
+		if change.product != nil {
+			change.updates = updates(change.product, r)
+			if r.setCategories {
+				updated = append(updated, change.product.Id)
+			}
+		}
+		changes = append(changes, change)
+	}
+
+	linked := make(map[uint][]uint, len(updated))
+	for batch := range slices.Chunk(updated, batchSize) {
+		var links []models.ProductCategory
+		if err := tx.Select("product_id", "category_id").
+			Where("product_id IN ?", batch).
+			Where("category_id IN (?)", tx.Model(&models.Category{}).Select("id")).
+			Find(&links).Error; err != nil {
+			return nil, nil, nil, err
+		}
+		for _, link := range links {
+			linked[link.ProductId] = append(linked[link.ProductId], link.CategoryId)
+		}
+	}
+	return changes, linked, errs, nil
+}
+
+// updates are the columns the row changes on product.
+func updates(product *models.Product, r *row) map[string]any {
+	updates := map[string]any{}
+	if r.name != nil && *r.name != product.Name {
+		updates["name"] = *r.name
+	}
+	if r.price != nil && *r.price != product.Price {
+		updates["price"] = *r.price
+	}
+	if r.stock != nil && *r.stock != product.Stock {
+		updates["stock"] = *r.stock
+	}
+	if r.isActive != nil && *r.isActive != product.IsActive {
+		updates["is_active"] = *r.isActive
+	}
+	if r.isFeatured != nil && *r.isFeatured != product.IsFeatured {
+		updates["is_featured"] = *r.isFeatured
+	}
+	return updates
+}
+
+func apply(ctx context.Context, tx *gorm.DB, products product_repo.ProductRepositoryI, change *productChange, linked map[uint][]uint) error {
+	r := change.row
+	if change.product == nil {
+		product := &models.Product{Sku: r.sku, Name: *r.name, IsActive: true}
+		if r.price != nil {
+			product.Price = *r.price
+		}
+		if r.stock != nil {
+			product.Stock = *r.stock
+		}
+		if r.isFeatured != nil {
+			product.IsFeatured = *r.isFeatured
+		}
+		if err := tx.Create(product).Error; err != nil {
+			return err
+		}
+		// GORM leaves false out of the insert in favour of the column's
+		// default, which is true.
+		if r.isActive != nil && !*r.isActive {
+			if err := tx.Model(product).Update("is_active", false).Error; err != nil {
+				return err
+			}
+		}
+		change.product = product
+	} else if len(change.updates) > 0 {
+		if err := tx.Model(change.product).Updates(change.updates).Error; err != nil {
+			return err
+		}
+	}
+	if change.changesCategories(linked) {
+		return products.SetCategories(ctx, change.product.Id, change.categoryIds)
+	}
+	return nil
+}
+
+// Export implements [CatalogI]. It writes the products that aren't deleted,
+// by id, with the columns of [Columns]. Categories without a slug can't be
+// named in the file and are left out of it.
+func (c *Catalog) Export(ctx context.Context, w io.Writer) error {
+	out := csv.NewWriter(w)
+	if err := out.Write(Columns); err != nil {
+		return err
+	}
+	var products []*models.Product
+	err := c.db.WithContext(ctx).Order("id").FindInBatches(&products, batchSize, func(_ *gorm.DB, _ int) error {
+		ids := make([]uint, len(products))
+		for i, p := range products {
+			ids[i] = p.Id
+		}
+		var links []struct {
+			ProductId uint
+			Slug      string
+		}
+		if err := c.db.WithContext(ctx).Table("product_categories").
+			Select("product_categories.product_id, categories.slug").
+			Joins("JOIN categories ON categories.id = product_categories.category_id AND categories.deleted_date IS NULL").
+			Where("product_categories.deleted_date IS NULL AND product_categories.product_id IN ? AND categories.slug <> ''", ids).
+			Order("categories.slug").
+			Scan(&links).Error; err != nil {
+			return err
+		}
+		slugs := make(map[uint][]string, len(products))
+		for _, link := range links {
+			slugs[link.ProductId] = append(slugs[link.ProductId], link.Slug)
+		}
+		for _, p := range products {
+			if err := out.Write([]string{
+				p.Sku,
+				p.Name,
+				strconv.FormatFloat(float64(p.Price), 'f', 2, 32),
+				strconv.Itoa(p.Stock),
+				strconv.FormatBool(p.IsActive),
+				strconv.FormatBool(p.IsFeatured),
+				strings.Join(slugs[p.Id], CategorySeparator),
+			}); err != nil {
+				return err
+			}
+		}
+		out.Flush()
+		return out.Error()
+	}).Error
+	if err != nil {
+		return err
+	}
+	out.Flush()
+	return out.Error()
+}
@@ -0,0 +1,217 @@
+package catalog
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Columns are the columns of a product CSV, in the order Export writes them.
+// An import needs sku; the others can be left out, and products keep what
+// they have for the columns that are.
+var Columns = []string{"sku", "name", "price", "stock", "is_active", "is_featured", "categories"}
+
+// CategorySeparator separates the category slugs in the categories column.
+const CategorySeparator = "|"
+
+// MaxRows is the most products a file can hold.
+const MaxRows = 50_000
+
+// maxPrice is the largest price a decimal(10,2) column holds.
+const maxPrice = 99_999_999.99
+
+// ErrInvalidFile is returned for files that can't be read as a product CSV at
+// all, as opposed to ones with bad rows.
+var ErrInvalidFile = errors.New("invalid product CSV")
+
+// RowError is a problem with one row of an import. Row is the line the
+// record starts on, the header being line 1.
+type RowError struct {
+	Row     int
+	Sku     string
+	Column  string
+	Message string
+}
+
+// row is a record of the file. Nil fields are for columns the file doesn't
+// have or cells that are empty, and leave the product as it is. The
+// categories cell is a whole list, so an empty one takes the product out of
+// its categories; setCategories is false only when there is no such column.
+type row struct {
+	line          int
+	sku           string
+	name          *string
+	price         *float32
+	stock         *int
+	isActive      *bool
+	isFeatured    *bool
+	categories    []string
+	setCategories bool
+}
+
+// CheckHeader reads the header of a product CSV and fails with
+// [ErrInvalidFile] if it isn't one.
+func CheckHeader(r io.Reader) error {
+	_, err := readHeader(newReader(r))
+	return err
+}
+
+func newReader(r io.Reader) *csv.Reader {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	return reader
+}
+
+// readHeader returns the index of each column in the file.
+func readHeader(reader *csv.Reader) (map[string]int, error) {
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: the file is empty", ErrInvalidFile)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			// Spreadsheets often save with a byte order mark.
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !slices.Contains(Columns, name) {
+			return nil, fmt.Errorf("%w: unknown column %q", ErrInvalidFile, name)
+		}
+		if _, ok := index[name]; ok {
+			return nil, fmt.Errorf("%w: column %q appears twice", ErrInvalidFile, name)
+		}
+		index[name] = i
+	}
+	if _, ok := index["sku"]; !ok {
+		return nil, fmt.Errorf("%w: there is no sku column", ErrInvalidFile)
+	}
+	return index, nil
+}
+
+// readRows parses the file's records and counts them. Records that don't
+// parse are reported as row errors; only a file that can't be read any
+// further fails outright.
+func readRows(r io.Reader) ([]*row, int, []RowError, error) {
+	reader := newReader(r)
+	index, err := readHeader(reader)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	var rows []*row
+	var errs []RowError
+	records := 0
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if records++; records > MaxRows {
+			return nil, 0, nil, fmt.Errorf("%w: more than %d rows", ErrInvalidFile, MaxRows)
+		}
+		if err != nil && !errors.Is(err, csv.ErrFieldCount) {
+			return nil, 0, nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+		}
+		line, _ := reader.FieldPos(0)
+		if err != nil {
+			errs = append(errs, RowError{Row: line, Message: fmt.Sprintf("has %d fields, the header has %d", len(record), len(index))})
+			continue
+		}
+		parsed, rowErrs := parseRow(line, record, index)
+		errs = append(errs, rowErrs...)
+		if parsed != nil {
+			rows = append(rows, parsed)
+		}
+	}
+	return rows, records, errs, nil
+}
+
+// parseRow reads a record. The row is nil if it has no usable sku.
+func parseRow(line int, record []string, index map[string]int) (*row, []RowError) {
+	cell := func(column string) (string, bool) {
+		i, ok := index[column]
+		if !ok {
+			return "", false
+		}
+		return strings.TrimSpace(record[i]), true
+	}
+	r := &row{line: line}
+	var errs []RowError
+	fail := func(column string, format string, args ...any) {
+		errs = append(errs, RowError{Row: line, Sku: r.sku, Column: column, Message: fmt.Sprintf(format, args...)})
+	}
+
+	r.sku, _ = cell("sku")
+	switch {
+	case r.sku == "":
+		fail("sku", "is required")
+		return nil, errs
+	case utf8.RuneCountInString(r.sku) > 100:
+		fail("sku", "is longer than 100 characters")
+		return nil, errs
+	}
+
+	if value, ok := cell("name"); ok && value != "" {
+		if utf8.RuneCountInString(value) > 150 {
+			fail("name", "is longer than 150 characters")
+		}
+		r.name = &value
+	}
+	if value, ok := cell("price"); ok && value != "" {
+		price, err := strconv.ParseFloat(value, 64)
+		switch {
+		case err != nil || math.IsNaN(price) || math.IsInf(price, 0):
+			fail("price", "%q is not a number", value)
+		case price < 0 || price > maxPrice:
+			fail("price", "must be between 0 and %.2f", maxPrice)
+		case math.Abs(price*100-math.Round(price*100)) > 1e-6:
+			fail("price", "has more than two decimal places")
+		default:
+			p := float32(price)
+			r.price = &p
+		}
+	}
+	if value, ok := cell("stock"); ok && value != "" {
+		stock, err := strconv.Atoi(value)
+		switch {
+		case err != nil:
+			fail("stock", "%q is not a whole number", value)
+		case stock < 0:
+			fail("stock", "can't be negative")
+		default:
+			r.stock = &stock
+		}
+	}
+	for _, flag := range []struct {
+		column string
+		value  **bool
+	}{{"is_active", &r.isActive}, {"is_featured", &r.isFeatured}} {
+		if value, ok := cell(flag.column); ok && value != "" {
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				fail(flag.column, "%q is not true or false", value)
+				continue
+			}
+			*flag.value = &b
+		}
+	}
+	if value, ok := cell("categories"); ok {
+		r.setCategories = true
+		for slug := range strings.SplitSeq(value, CategorySeparator) {
+			if slug = strings.TrimSpace(slug); slug != "" && !slices.Contains(r.categories, slug) {
+				r.categories = append(r.categories, slug)
+			}
+		}
+	}
+	return r, errs
+}
@@ -0,0 +1,330 @@
+package database
+
+import (
+	"commerce/internal/shared/models"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Actor is who a write is made on behalf of, as recorded on its audit events.
+type Actor struct {
+	Subject string
+	UserId  *uint
+}
+
+// SystemSubject is recorded for writes made without an actor in their
+// context, such as background jobs.
+const SystemSubject = "system"
+
+type actorKey struct{}
+
+// WithActor returns a copy of ctx whose writes are audited as actor's.
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFrom returns the actor set by [WithActor], or the system actor when
+// there isn't one.
+func ActorFrom(ctx context.Context) Actor {
+	if actor, ok := ctx.Value(actorKey{}).(Actor); ok {
+		return actor
+	}
+	return Actor{Subject: SystemSubject}
+}
+
+const beforeKey = "audit:before"
+
+// redactedColumns are recorded as changed without their values: secrets, and
+// uploaded files too big to copy into every event.
+var redactedColumns = map[string]struct{}{
+	"secret_hash": {},
+	"file":        {},
+}
+
+// personalColumns hold personal data, by table. They are redacted like
+// redactedColumns, so the log never keeps a copy that erasing the user can't
+// reach; events still show that they changed, and who changed them.
+var personalColumns = map[string]map[string]struct{}{
+	"users":     {"first_name": {}, "last_name": {}, "email": {}, "auth_sub": {}},
+	"addresses": {"street": {}, "city": {}, "state": {}, "postal_code": {}, "country": {}},
+	"orders": {
+		"billing_street": {}, "billing_city": {}, "billing_postal_code": {},
+		"shipping_street": {}, "shipping_city": {}, "shipping_postal_code": {},
+	},
+	"invoices": {
+		"billing_street": {}, "billing_city": {}, "billing_postal_code": {},
+		"shipping_street": {}, "shipping_city": {}, "shipping_postal_code": {},
+	},
+	"reviews": {"title": {}, "comment": {}},
+}
+
+// untrackedColumns change as bookkeeping or are derived from other columns; they are left out of events and
+// don't make one on their own.
+var untrackedColumns = map[string]struct{}{
+	"updated_date":   {},
+	"last_used_date": {},
+	"search_vector":  {},
+}
+
+// RegisterAudit adds the callbacks that write a [models.AuditEvent] for every
+// row created, updated or deleted through db. Rows are read back by primary
+// key rather than taken from the statement, so map updates and deletes by
+// condition are covered as well as saves. Events go out on the same
+// connection as the change and commit or roll back with it.
+func RegisterAudit(db *gorm.DB) error {
+	return errors.Join(
+		db.Callback().Create().After("gorm:create").Register("audit:after_create", auditCreate),
+		db.Callback().Update().Before("gorm:update").Register("audit:before_update", snapshotBefore),
+		db.Callback().Update().After("gorm:update").Register("audit:after_update", auditUpdate),
+		db.Callback().Delete().Before("gorm:delete").Register("audit:before_delete", snapshotBefore),
+		db.Callback().Delete().After("gorm:delete").Register("audit:after_delete", auditDelete),
+	)
+}
+
+func audited(tx *gorm.DB) bool {
+	schema := tx.Statement.Schema
+	return tx.Error == nil && schema != nil && schema.PrioritizedPrimaryField != nil &&
+		schema.Table != (models.AuditEvent{}).TableName()
+}
+
+// snapshotBefore keeps the rows an update or delete is about to change. They
+// are found with the statement's own conditions plus the primary key of the
+// value it was given, the same way GORM scopes the write.
+func snapshotBefore(tx *gorm.DB) {
+	if !audited(tx) {
+		return
+	}
+	where, hasWhere := tx.Statement.Clauses["WHERE"]
+	ids := primaryKeys(tx.Statement)
+	if !hasWhere && len(ids) == 0 {
+		return
+	}
+	rows, err := load(tx, func(q *gorm.DB) *gorm.DB {
+		if hasWhere {
+			q = q.Clauses(where.Expression)
+		}
+		if len(ids) > 0 {
+			q = q.Where(clause.IN{Column: clause.PrimaryColumn, Values: toValues(ids)})
+		}
+		return q
+	})
+	if err != nil {
+		tx.AddError(fmt.Errorf("audit: %w", err))
+		return
+	}
+	tx.InstanceSet(beforeKey, rows)
+}
+
+func auditCreate(tx *gorm.DB) {
+	if !audited(tx) || tx.RowsAffected == 0 {
+		return
+	}
+	ids := primaryKeys(tx.Statement)
+	if len(ids) == 0 {
+		return
+	}
+	after, err := loadByIds(tx, ids)
+	if err != nil {
+		tx.AddError(fmt.Errorf("audit: %w", err))
+		return
+	}
+	events := make([]models.AuditEvent, 0, len(after))
+	for _, id := range ids {
+		if row, ok := after[id]; ok {
+			events = append(events, event(tx, models.AuditActionCreate, id, nil, row))
+		}
+	}
+	record(tx, events)
+}
+
+func auditUpdate(tx *gorm.DB) {
+	before, ok := beforeRows(tx)
+	if !ok {
+		return
+	}
+	ids := make([]uint, 0, len(before))
+	for id := range before {
+		ids = append(ids, id)
+	}
+	after, err := loadByIds(tx, ids)
+	if err != nil {
+		tx.AddError(fmt.Errorf("audit: %w", err))
+		return
+	}
+	events := make([]models.AuditEvent, 0, len(ids))
+	for _, id := range ids {
+		row, ok := after[id]
+		if !ok {
+			continue
+		}
+		if changedBefore, changedAfter := diff(before[id], row); changedAfter != nil {
+			events = append(events, event(tx, models.AuditActionUpdate, id, changedBefore, changedAfter))
+		}
+	}
+	record(tx, events)
+}
+
+func auditDelete(tx *gorm.DB) {
+	before, ok := beforeRows(tx)
+	if !ok {
+		return
+	}
+	events := make([]models.AuditEvent, 0, len(before))
+	for id, row := range before {
+		events = append(events, event(tx, models.AuditActionDelete, id, row, nil))
+	}
+	record(tx, events)
+}
+
+func beforeRows(tx *gorm.DB) (map[uint]map[string]any, bool) {
+	if !audited(tx) || tx.RowsAffected == 0 {
+		return nil, false
+	}
+	v, ok := tx.InstanceGet(beforeKey)
+	if !ok {
+		return nil, false
+	}
+	rows, ok := v.(map[uint]map[string]any)
+	return rows, ok && len(rows) > 0
+}
+
+// load reads the statement's model table as column maps, keyed by primary key.
+// It sees soft-deleted rows when the statement does, as a restore or a hard
+// delete does.
+func load(tx *gorm.DB, scope func(q *gorm.DB) *gorm.DB) (map[uint]map[string]any, error) {
+	schema := tx.Statement.Schema
+	q := tx.Session(&gorm.Session{NewDB: true}).Model(reflect.New(schema.ModelType).Interface())
+	if tx.Statement.Unscoped {
+		q = q.Unscoped()
+	}
+	var rows []map[string]any
+	if err := scope(q).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	byId := make(map[uint]map[string]any, len(rows))
+	for _, row := range rows {
+		byId[toUint(row[schema.PrioritizedPrimaryField.DBName])] = row
+	}
+	return byId, nil
+}
+
+func loadByIds(tx *gorm.DB, ids []uint) (map[uint]map[string]any, error) {
+	return load(tx, func(q *gorm.DB) *gorm.DB {
+		return q.Where(clause.IN{Column: clause.PrimaryColumn, Values: toValues(ids)})
+	})
+}
+
+func event(tx *gorm.DB, action models.AuditAction, id uint, before map[string]any, after map[string]any) models.AuditEvent {
+	actor := ActorFrom(tx.Statement.Context)
+	table := tx.Statement.Schema.Table
+	return models.AuditEvent{
+		Subject:    actor.Subject,
+		UserId:     actor.UserId,
+		Action:     action,
+		EntityType: table,
+		EntityId:   id,
+		Before:     encode(table, before),
+		After:      encode(table, after),
+	}
+}
+
+func record(tx *gorm.DB, events []models.AuditEvent) {
+	if len(events) == 0 {
+		return
+	}
+	if err := tx.Session(&gorm.Session{NewDB: true}).Create(&events).Error; err != nil {
+		tx.AddError(fmt.Errorf("audit: %w", err))
+	}
+}
+
+// diff returns the tracked columns that differ between before and after, or
+// nils when there are none.
+func diff(before map[string]any, after map[string]any) (map[string]any, map[string]any) {
+	changedBefore, changedAfter := map[string]any{}, map[string]any{}
+	for column, value := range after {
+		if _, ok := untrackedColumns[column]; ok || reflect.DeepEqual(before[column], value) {
+			continue
+		}
+		changedBefore[column] = before[column]
+		changedAfter[column] = value
+	}
+	if len(changedAfter) == 0 {
+		return nil, nil
+	}
+	return changedBefore, changedAfter
+}
+
+func encode(table string, row map[string]any) *string {
+	if row == nil {
+		return nil
+	}
+	values := make(map[string]any, len(row))
+	for column, value := range row {
+		if _, ok := untrackedColumns[column]; ok {
+			continue
+		}
+		_, secret := redactedColumns[column]
+		_, personal := personalColumns[table][column]
+		if secret || personal {
+			value = "[redacted]"
+		}
+		values[column] = value
+	}
+	b, err := json.Marshal(values)
+	if err != nil {
+		return nil
+	}
+	s := string(b)
+	return &s
+}
+
+// primaryKeys returns the non-zero primary keys of the value a statement was
+// given, which is a single model or a slice of them.
+func primaryKeys(stmt *gorm.Statement) []uint {
+	field := stmt.Schema.PrioritizedPrimaryField
+	rv := reflect.Indirect(stmt.ReflectValue)
+	var values []reflect.Value
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			values = append(values, reflect.Indirect(rv.Index(i)))
+		}
+	case reflect.Struct:
+		values = append(values, rv)
+	}
+	ids := []uint{}
+	for _, value := range values {
+		if value.Type() != stmt.Schema.ModelType {
+			continue
+		}
+		if v, zero := field.ValueOf(stmt.Context, value); !zero {
+			ids = append(ids, toUint(v))
+		}
+	}
+	return ids
+}
+
+func toUint(v any) uint {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	switch {
+	case rv.CanInt():
+		return uint(rv.Int())
+	case rv.CanUint():
+		return uint(rv.Uint())
+	}
+	return 0
+}
+
+func toValues(ids []uint) []any {
+	values := make([]any, len(ids))
+	for i, id := range ids {
+		values[i] = id
+	}
+	return values
+}
@@ -4,11 +4,43 @@ import (
 	"commerce/internal/shared/models"
 	"fmt"
 	"log"
+	"time"
 
 	database "github.com/akhakpouri/gorm-kit/database"
 	pg "github.com/akhakpouri/gorm-kit/pg"
+	"gorm.io/gorm"
 )
 
+// entities are the models AutoMigrate manages.
+var entities = []any{
+	&models.Address{},
+	&models.User{},
+	&models.UserRole{},
+	&models.ApiKey{},
+	&models.ErasureRequest{},
+	&models.ImportJob{},
+	&models.AuditEvent{},
+	&models.Product{},
+	&models.Category{},
+	&models.ProductCategory{},
+	&models.ProductOption{},
+	&models.OptionValue{},
+	&models.ProductVariant{},
+	&models.VariantOptionValue{},
+	&models.ProductImage{},
+	&models.ProductPrice{},
+	&models.Review{},
+	&models.Order{},
+	&models.OrderItem{},
+	&models.Payment{},
+	&models.Shipment{},
+	&models.ShipmentItem{},
+	&models.ReturnRequest{},
+	&models.ReturnItem{},
+	&models.Invoice{},
+	&models.InvoiceLine{},
+}
+
 func Migrate(cfg database.DbConfig) {
 	db, err := pg.Connect(cfg)
 	if err != nil {
@@ -19,20 +51,153 @@ func Migrate(cfg database.DbConfig) {
 	log.Println("Connected to the database successfully.")
 	log.Println("Running migration.")
 
-	if err := database.Migrate(
-		db,
-		&models.Address{},
-		&models.User{},
-		&models.Product{},
-		&models.Category{},
-		&models.ProductCategory{},
-		&models.Review{},
-		&models.Order{},
-		&models.OrderItem{},
-		&models.Payment{},
-	); err != nil {
+	// Has to run before AutoMigrate, which would otherwise fail to make
+	// auth_sub NOT NULL while pre-Auth0 users still lack one.
+	if err := retirePasswords(db); err != nil {
+		log.Fatal("Migration failed: ", err)
+		panic(fmt.Sprintf("Failed to retire user passwords, %v", err))
+	}
+	// Also before AutoMigrate, whose unique index on product_categories would
+	// fail on duplicate links.
+	if err := dedupeProductCategories(db); err != nil {
+		log.Fatal("Migration failed: ", err)
+		panic(fmt.Sprintf("Failed to dedupe product categories, %v", err))
+	}
+	if err := database.Migrate(db, entities...); err != nil {
 		log.Fatal("Migration failed: ", err)
 		panic(fmt.Sprintf("Failed to migrate database, %v", err))
 	}
+	// Order, invoice and credit note numbers draw from sequences rather than
+	// max(id)+1 so concurrent writers never collide; AutoMigrate doesn't
+	// manage sequences.
+	for _, sequence := range []string{"order_number_seq", "invoice_number_seq", "credit_note_number_seq"} {
+		if err := db.Exec("CREATE SEQUENCE IF NOT EXISTS " + sequence).Error; err != nil {
+			log.Fatal("Migration failed: ", err)
+			panic(fmt.Sprintf("Failed to create sequence %s, %v", sequence, err))
+		}
+	}
+	if err := snapshotOrderAddresses(db); err != nil {
+		log.Fatal("Migration failed: ", err)
+		panic(fmt.Sprintf("Failed to snapshot order addresses, %v", err))
+	}
+	if err := nullDeletedDates(db); err != nil {
+		log.Fatal("Migration failed: ", err)
+		panic(fmt.Sprintf("Failed to null deleted dates, %v", err))
+	}
+	if err := productSearchIndex(db); err != nil {
+		log.Fatal("Migration failed: ", err)
+		panic(fmt.Sprintf("Failed to index products for search, %v", err))
+	}
 	log.Println("Migration completed successfully.")
 }
+
+// snapshotOrderAddresses moves orders off the foreign keys they used to hold on
+// addresses. The old constraints are dropped so address book entries can be
+// deleted, and orders placed before snapshots existed get theirs copied from
+// the address they still point at.
+func snapshotOrderAddresses(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, kind := range []string{"shipping", "billing"} {
+			statements := []string{
+				fmt.Sprintf("ALTER TABLE orders DROP CONSTRAINT IF EXISTS fk_orders_%s_address", kind),
+				fmt.Sprintf(`UPDATE orders o SET
+					%[1]s_street = a.street,
+					%[1]s_city = a.city,
+					%[1]s_state = a.state,
+					%[1]s_postal_code = a.postal_code,
+					%[1]s_country = a.country
+				FROM addresses a
+				WHERE a.id = o.%[1]s_address_id AND COALESCE(o.%[1]s_state, '') = ''`, kind),
+			}
+			for _, statement := range statements {
+				if err := tx.Exec(statement).Error; err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// productSearchIndex adds the tsvector products are searched by. It is a
+// generated column so it can't go stale, and it stays off the model since
+// GORM would try to write it. Names and SKUs outrank descriptions.
+func productSearchIndex(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		statements := []string{
+			`ALTER TABLE products ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+				setweight(to_tsvector('english', coalesce(name, '')), 'A') ||
+				setweight(to_tsvector('english', coalesce(sku, '')), 'A') ||
+				setweight(to_tsvector('english', coalesce(description, '')), 'B')
+			) STORED`,
+			"CREATE INDEX IF NOT EXISTS idx_products_search_vector ON products USING GIN (search_vector)",
+		}
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// retirePasswords drops the bcrypt password column now that Auth0 owns
+// sign-in. Users created before the cutover get a placeholder auth_sub; the
+// first login with their email swaps in the real one.
+func retirePasswords(db *gorm.DB) error {
+	if !db.Migrator().HasTable(&models.User{}) {
+		return nil
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		if tx.Migrator().HasColumn(&models.User{}, "password") {
+			if err := tx.Migrator().DropColumn(&models.User{}, "password"); err != nil {
+				return err
+			}
+		}
+		return tx.Exec(
+			"UPDATE users SET auth_sub = ? || CAST(id AS text) WHERE COALESCE(auth_sub, '') = ''",
+			models.LegacyAuthSubPrefix,
+		).Error
+	})
+}
+
+// dedupeProductCategories clears the way for the unique index on a product's
+// links to categories. Links are deleted for good now, so soft-deleted ones
+// are purged, and of duplicate links only the oldest is kept. Rows whose
+// deleted_date holds the zero time were never deleted; see nullDeletedDates.
+func dedupeProductCategories(db *gorm.DB) error {
+	if !db.Migrator().HasTable(&models.ProductCategory{}) {
+		return nil
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(
+			"DELETE FROM product_categories WHERE deleted_date >= ?",
+			time.Date(1, 1, 2, 0, 0, 0, 0, time.UTC),
+		).Error; err != nil {
+			return err
+		}
+		return tx.Exec(`DELETE FROM product_categories a USING product_categories b
+			WHERE a.product_id = b.product_id AND a.category_id = b.category_id AND a.id > b.id`).Error
+	})
+}
+
+// nullDeletedDates moves rows onto gorm.DeletedAt. deleted_date used to be a
+// plain time, so rows that were never deleted hold the zero time rather than
+// NULL and GORM's soft delete filter would hide every one of them.
+func nullDeletedDates(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, entity := range entities {
+			stmt := &gorm.Statement{DB: tx}
+			if err := stmt.Parse(entity); err != nil {
+				return err
+			}
+			if err := tx.Exec(
+				"UPDATE "+stmt.Quote(stmt.Schema.Table)+" SET deleted_date = NULL WHERE deleted_date < ?",
+				time.Date(1, 1, 2, 0, 0, 0, 0, time.UTC),
+			).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
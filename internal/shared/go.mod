@@ -4,7 +4,6 @@ go 1.26.4
 
 require (
 	github.com/akhakpouri/gorm-kit v1.0.0
-	golang.org/x/crypto v0.53.0
 	gorm.io/gorm v1.31.2
 )
 
@@ -15,6 +14,7 @@ require (
 	github.com/jackc/puddle/v2 v2.2.2 // indirect
 	github.com/jinzhu/inflection v1.0.0 // indirect
 	github.com/jinzhu/now v1.1.5 // indirect
+	golang.org/x/crypto v0.53.0 // indirect
 	golang.org/x/sync v0.21.0 // indirect
 	golang.org/x/text v0.38.0 // indirect
 	gorm.io/driver/postgres v1.6.0 // indirect
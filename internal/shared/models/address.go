@@ -15,3 +15,15 @@ type Address struct {
 func (Address) TableName() string {
 	return "addresses"
 }
+
+// AddressSnapshot is an address as it stood when an order was placed. It is
+// embedded in the order's own row so that editing or deleting the address
+// book entry it was copied from leaves order history untouched.
+type AddressSnapshot struct {
+	AddressId  *uint
+	Street     string `gorm:"type:text;size:255"`
+	City       string `gorm:"type:text;size:100"`
+	State      string `gorm:"type:text;size:50"`
+	PostalCode string `gorm:"type:text;size:15"`
+	Country    string `gorm:"type:text;size:75"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// ApiKey lets a partner that can't do OAuth call the API. Only a hash of the
+// secret is stored; Prefix is the public part of the key used to find it.
+type ApiKey struct {
+	Base
+	Name         string `gorm:"not null;size:100"`
+	Owner        string `gorm:"not null;size:100"`
+	Prefix       string `gorm:"not null;size:16;uniqueIndex"`
+	SecretHash   string `gorm:"not null;size:64"`
+	Scopes       string `gorm:"type:text;not null"`
+	ExpiresDate  *time.Time
+	LastUsedDate *time.Time
+	RevokedDate  *time.Time
+}
+
+func (ApiKey) TableName() string {
+	return "api_keys"
+}
@@ -0,0 +1,28 @@
+package models
+
+// AuditEvent records one row created, updated or deleted through GORM, with
+// who did it. Before and After hold only the columns that changed: After is
+// nil for deletes and Before for creates. Events are written by the
+// callbacks in the database package, never by repositories.
+type AuditEvent struct {
+	Base
+	Subject    string      `gorm:"not null;size:250;index"`
+	UserId     *uint       `gorm:"index"`
+	Action     AuditAction `gorm:"type:varchar(10);not null;index"`
+	EntityType string      `gorm:"not null;size:100;index:idx_audit_events_entity"`
+	EntityId   uint        `gorm:"not null;index:idx_audit_events_entity"`
+	Before     *string     `gorm:"type:jsonb"`
+	After      *string     `gorm:"type:jsonb"`
+}
+
+type AuditAction string
+
+const (
+	AuditActionCreate AuditAction = "create"
+	AuditActionUpdate AuditAction = "update"
+	AuditActionDelete AuditAction = "delete"
+)
+
+func (AuditEvent) TableName() string {
+	return "audit_events"
+}
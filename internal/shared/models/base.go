@@ -1,10 +1,25 @@
 package models
 
-import "time"
+import (
+	"time"
 
+	"gorm.io/gorm"
+)
+
+// Base is embedded in every model. DeletedDate makes deletes soft: GORM sets
+// it instead of removing the row and leaves the row out of every query that
+// isn't Unscoped.
 type Base struct {
-	Id          uint      `gorm:"primaryKey"`
-	CreatedDate time.Time `gorm:"autoCreateTime"`
-	UpdatedDate time.Time `gorm:"autoUpdateTime"`
-	DeletedDate time.Time `gorm:"index"`
+	Id          uint           `gorm:"primaryKey"`
+	CreatedDate time.Time      `gorm:"autoCreateTime"`
+	UpdatedDate time.Time      `gorm:"autoUpdateTime"`
+	DeletedDate gorm.DeletedAt `gorm:"index"`
+}
+
+// DeletedTime returns when the row was soft-deleted, or nil if it wasn't.
+func (b Base) DeletedTime() *time.Time {
+	if !b.DeletedDate.Valid {
+		return nil
+	}
+	return &b.DeletedDate.Time
 }
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// ErasureRequest asks for a user's personal data to be anonymized. Rows are
+// never deleted: together they are the record that each erasure was done.
+type ErasureRequest struct {
+	Base
+	UserId        uint          `gorm:"not null;index"`
+	RequestedBy   string        `gorm:"not null;size:250"`
+	Status        ErasureStatus `gorm:"type:varchar(20);not null;default:'pending';index"`
+	CompletedDate *time.Time
+	Error         string `gorm:"type:text"`
+}
+
+type ErasureStatus string
+
+const (
+	ErasureStatusPending   ErasureStatus = "pending"
+	ErasureStatusCompleted ErasureStatus = "completed"
+	ErasureStatusFailed    ErasureStatus = "failed"
+)
+
+func (ErasureRequest) TableName() string {
+	return "erasure_requests"
+}
@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// ImportJob is a product CSV import run in the background. File holds the
+// upload until the job has run; the outcome is kept after it is cleared.
+// Errors is the JSON list of row errors.
+type ImportJob struct {
+	Base
+	Status            ImportStatus `gorm:"type:varchar(20);not null;default:'pending';index"`
+	DryRun            bool
+	RequestedBy       string `gorm:"not null;size:250"`
+	RequestedByUserId *uint
+	File              []byte `gorm:"type:bytea"`
+	Rows              int
+	Created           int
+	Updated           int
+	Unchanged         int
+	Errors            *string `gorm:"type:jsonb"`
+	Error             string  `gorm:"type:text"`
+	StartedDate       *time.Time
+	CompletedDate     *time.Time
+}
+
+type ImportStatus string
+
+const (
+	ImportStatusPending   ImportStatus = "pending"
+	ImportStatusRunning   ImportStatus = "running"
+	ImportStatusCompleted ImportStatus = "completed"
+	ImportStatusFailed    ImportStatus = "failed"
+)
+
+func (ImportJob) TableName() string {
+	return "import_jobs"
+}
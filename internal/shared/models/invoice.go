@@ -0,0 +1,56 @@
+package models
+
+import "time"
+
+// Invoice is the billing document issued for a paid order. A credit note is
+// an Invoice of type credit_note that points back at the invoice it credits;
+// its amounts are positive and read as money given back. Everything needed to
+// print the document is copied onto it, so it never changes after issue.
+type Invoice struct {
+	Base
+	InvoiceNumber     string          `gorm:"type:varchar(30);not null;unique"`
+	Type              InvoiceType     `gorm:"type:varchar(20);not null;default:'invoice'"`
+	OrderId           uint            `gorm:"not null;index;uniqueIndex:idx_invoices_order_invoice,where:type = 'invoice'"`
+	CreditedInvoiceId *uint           `gorm:"index"`
+	OrderNumber       string          `gorm:"type:varchar(100);not null"`
+	Reason            string          `gorm:"type:text"`
+	IssuedDate        time.Time       `gorm:"not null"`
+	BillingAddress    AddressSnapshot `gorm:"embedded;embeddedPrefix:billing_"`
+	ShippingAddress   AddressSnapshot `gorm:"embedded;embeddedPrefix:shipping_"`
+	Currency          string          `gorm:"type:varchar(10);not null;default:'USD'"`
+	SubTotalAmount    float64         `gorm:"not null"`
+	ShippingAmount    float64         `gorm:"not null;default:0"`
+	TaxState          string          `gorm:"type:varchar(2)"`
+	TaxRate           float64         `gorm:"not null;default:0"`
+	TaxableAmount     float64         `gorm:"not null;default:0"`
+	TaxAmount         float64         `gorm:"not null"`
+	TotalAmount       float64         `gorm:"not null"`
+	Order             Order           `gorm:"foreignKey:OrderId;constraint:OnDelete:RESTRICT"`
+	CreditedInvoice   *Invoice        `gorm:"foreignKey:CreditedInvoiceId;constraint:OnDelete:RESTRICT"`
+	Lines             []InvoiceLine   `gorm:"foreignKey:InvoiceId;constraint:OnDelete:CASCADE"`
+}
+
+type InvoiceType string
+
+const (
+	InvoiceTypeInvoice    InvoiceType = "invoice"
+	InvoiceTypeCreditNote InvoiceType = "credit_note"
+)
+
+func (i *Invoice) TableName() string {
+	return "invoices"
+}
+
+type InvoiceLine struct {
+	Base
+	InvoiceId   uint    `gorm:"not null;index"`
+	ProductId   *uint   `gorm:"index"`
+	Description string  `gorm:"type:text;not null"`
+	Quantity    int     `gorm:"not null"`
+	UnitPrice   float64 `gorm:"not null"`
+	Amount      float64 `gorm:"not null"`
+}
+
+func (l *InvoiceLine) TableName() string {
+	return "invoice_lines"
+}
@@ -2,12 +2,14 @@ package models
 
 type OrderItem struct {
 	Base
-	OrderId   uint    `gorm:"not null;"`
-	ProductId uint    `gorm:"not null;"`
-	Quantity  int     `gorm:"not null"`
-	UnitPrice float64 `gorm:"not null"`
-	Order     Order   `gorm:"foreignKey:OrderId;constraint:OnDelete:CASCADE"`
-	Product   Product `gorm:"foreignKey:ProductId;constraint:OnDelete:CASCADE"`
+	OrderId   uint            `gorm:"not null;"`
+	ProductId uint            `gorm:"not null;"`
+	VariantId *uint           `gorm:"index"`
+	Quantity  int             `gorm:"not null"`
+	UnitPrice float64         `gorm:"not null"`
+	Order     Order           `gorm:"foreignKey:OrderId;constraint:OnDelete:CASCADE"`
+	Product   Product         `gorm:"foreignKey:ProductId;constraint:OnDelete:CASCADE"`
+	Variant   *ProductVariant `gorm:"foreignKey:VariantId"`
 }
 
 func (oi *OrderItem) TableName() string {
@@ -7,6 +7,7 @@ type Payment struct {
 	OrderId              uint           `gorm:"not null;"`
 	Order                Order          `gorm:"foreignKey:OrderId;constraint:OnDelete:CASCADE"`
 	Amount               float64        `gorm:"not null"`
+	RefundedAmount       float64        `gorm:"not null;default:0"`
 	Status               PaymentStatus  `gorm:"type:varchar(20);not null;default:'pending'"`
 	GatewayTransactionId string         `gorm:"type:varchar(100);unique"`
 	GatewayResponse      string         `gorm:"type:text"`
@@ -30,6 +31,7 @@ const (
 	PaymentStatusFailed            PaymentStatus = "failed"
 	PaymentStatusRefunded          PaymentStatus = "refunded"
 	PaymentStatusPartiallyRefunded PaymentStatus = "partially_refunded"
+	PaymentStatusVoided            PaymentStatus = "voided"
 )
 
 type PaymentMethod string
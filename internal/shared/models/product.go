@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 type Product struct {
 	Base
 	Name              string            `gorm:"type:text;size:150" sql:"type:text"`
@@ -9,8 +11,48 @@ type Product struct {
 	Stock             int               `gorm:"default:0"`
 	IsActive          bool              `gorm:"default:true"`
 	IsFeatured        bool              `gorm:"default:false"`
+	Weight            float64           `gorm:"type:decimal(10,2);default:0"` // pounds
+	Length            float64           `gorm:"type:decimal(10,2);default:0"` // inches
+	Width             float64           `gorm:"type:decimal(10,2);default:0"` // inches
+	Height            float64           `gorm:"type:decimal(10,2);default:0"` // inches
 	ProductCategories []ProductCategory `gorm:"foreignKey:ProductId;constraint:OnDelete:CASCADE"`
 	Reviews           []Review          `gorm:"foreignKey:ProductId;constraint:OnDelete:CASCADE"`
+	Options           []ProductOption   `gorm:"foreignKey:ProductId;constraint:OnDelete:CASCADE"`
+	Variants          []ProductVariant  `gorm:"foreignKey:ProductId;constraint:OnDelete:CASCADE"`
+	Images            []ProductImage    `gorm:"foreignKey:ProductId;constraint:OnDelete:CASCADE"`
+	Prices            []ProductPrice    `gorm:"foreignKey:ProductId;constraint:OnDelete:CASCADE"`
+}
+
+// PriceAt returns what the product sells for at t, out of its loaded Prices
+// or else Price, with the compare-at price to strike through, if any.
+func (p *Product) PriceAt(t time.Time) (float32, *float32) {
+	if active := ActivePrice(p.Prices, nil, t); active != nil {
+		return active.Price, active.CompareAtPrice
+	}
+	return p.Price, nil
+}
+
+// VariantPriceAt is [Product.PriceAt] for one of the product's variants. A
+// price set for the variant comes first, then the variant's own Price, then
+// the product's price at t.
+func (p *Product) VariantPriceAt(v *ProductVariant, t time.Time) (float32, *float32) {
+	if active := ActivePrice(p.Prices, &v.Id, t); active != nil {
+		return active.Price, active.CompareAtPrice
+	}
+	if v.Price != nil {
+		return *v.Price, nil
+	}
+	return p.PriceAt(t)
+}
+
+// Variant returns the product's variant with the given id, if it was loaded.
+func (p *Product) Variant(id uint) *ProductVariant {
+	for i := range p.Variants {
+		if p.Variants[i].Id == id {
+			return &p.Variants[i]
+		}
+	}
+	return nil
 }
 
 func (Product) TableName() string {
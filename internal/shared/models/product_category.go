@@ -2,8 +2,8 @@ package models
 
 type ProductCategory struct {
 	Base
-	ProductId  uint     `gorm:"not null;"`
-	CategoryId uint     `gorm:"not null;"`
+	ProductId  uint     `gorm:"not null;uniqueIndex:idx_product_categories_product_category"`
+	CategoryId uint     `gorm:"not null;uniqueIndex:idx_product_categories_product_category"`
 	Product    Product  `gorm:"foreignKey:ProductId;constraint:OnDelete:CASCADE"`
 	Category   Category `gorm:"foreignKey:CategoryId;constraint:OnDelete:CASCADE"`
 }
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"path"
+	"strings"
+)
+
+// ThumbnailSizes are the thumbnails made of every product image: the longest
+// side of each, in pixels, by name.
+var ThumbnailSizes = map[string]int{
+	"small":  200,
+	"medium": 600,
+}
+
+// ProductImage is a picture of a product. Path is the key of the uploaded
+// file in the blob store; its thumbnails are kept next to it. Images are
+// shown in Position order, and the product's primary image is the one lists
+// and carts show.
+type ProductImage struct {
+	Base
+	ProductId   uint    `gorm:"not null;index"`
+	Path        string  `gorm:"type:text;not null" sql:"type:text"`
+	AltText     string  `gorm:"type:text;size:255" sql:"type:text"`
+	Position    int     `gorm:"default:0"`
+	IsPrimary   bool    `gorm:"default:false"`
+	ContentType string  `gorm:"type:text;size:50" sql:"type:text"`
+	Width       int     `gorm:"default:0"`
+	Height      int     `gorm:"default:0"`
+	Product     Product `gorm:"foreignKey:ProductId;constraint:OnDelete:CASCADE"`
+}
+
+func (ProductImage) TableName() string {
+	return "product_images"
+}
+
+// ThumbnailPath is the key of the image's thumbnail of the named size.
+// Thumbnails of JPEGs are JPEGs; the rest are PNGs, which keep transparency.
+func (i *ProductImage) ThumbnailPath(size string) string {
+	ext := ".png"
+	if i.ContentType == "image/jpeg" {
+		ext = ".jpg"
+	}
+	return strings.TrimSuffix(i.Path, path.Ext(i.Path)) + "_" + size + ext
+}
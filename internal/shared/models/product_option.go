@@ -0,0 +1,27 @@
+package models
+
+// ProductOption is a way a product varies, such as size or color. Its values
+// are what its variants choose from.
+type ProductOption struct {
+	Base
+	ProductId uint          `gorm:"not null;index"`
+	Name      string        `gorm:"type:varchar(50);not null"`
+	Position  int           `gorm:"not null;default:0"`
+	Values    []OptionValue `gorm:"foreignKey:OptionId;constraint:OnDelete:CASCADE"`
+	Product   Product       `gorm:"foreignKey:ProductId;constraint:OnDelete:CASCADE"`
+}
+
+func (ProductOption) TableName() string {
+	return "product_options"
+}
+
+type OptionValue struct {
+	Base
+	OptionId uint   `gorm:"not null;index"`
+	Value    string `gorm:"type:varchar(50);not null"`
+	Position int    `gorm:"not null;default:0"`
+}
+
+func (OptionValue) TableName() string {
+	return "option_values"
+}
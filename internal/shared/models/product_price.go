@@ -0,0 +1,65 @@
+package models
+
+import "time"
+
+// ProductPrice is what a product, or one of its variants when VariantId is
+// set, sells for from EffectiveFrom until EffectiveTo. A row with an end is a
+// promotion; one without is a price change that lasts until a later one
+// starts. Rows that have started are never deleted, so together they are the
+// product's price history. CompareAtPrice is shown struck through.
+type ProductPrice struct {
+	Base
+	ProductId      uint      `gorm:"not null;index:idx_product_prices_product_from,priority:1"`
+	VariantId      *uint     `gorm:"index"`
+	Price          float32   `gorm:"type:decimal(10,2);not null" sql:"type:decimal(10,2)"`
+	CompareAtPrice *float32  `gorm:"type:decimal(10,2)" sql:"type:decimal(10,2)"`
+	EffectiveFrom  time.Time `gorm:"not null;index:idx_product_prices_product_from,priority:2"`
+	EffectiveTo    *time.Time
+	CreatedBy      string          `gorm:"size:250"`
+	Product        Product         `gorm:"foreignKey:ProductId;constraint:OnDelete:CASCADE"`
+	Variant        *ProductVariant `gorm:"foreignKey:VariantId;constraint:OnDelete:CASCADE"`
+}
+
+func (ProductPrice) TableName() string {
+	return "product_prices"
+}
+
+// ActiveAt reports whether t falls in the price's window.
+func (p *ProductPrice) ActiveAt(t time.Time) bool {
+	return !p.EffectiveFrom.After(t) && (p.EffectiveTo == nil || p.EffectiveTo.After(t))
+}
+
+// ActivePrice picks the price in effect at t for the variant, or for the
+// product itself when variantId is nil. A promotion beats a price change and
+// a later start beats an earlier one, so a sale runs over a standing price
+// and the newest decision wins. It is nil when none of the prices apply.
+func ActivePrice(prices []ProductPrice, variantId *uint, t time.Time) *ProductPrice {
+	var active *ProductPrice
+	for i := range prices {
+		p := &prices[i]
+		if !sameVariant(p.VariantId, variantId) || !p.ActiveAt(t) {
+			continue
+		}
+		if active == nil || beats(p, active) {
+			active = p
+		}
+	}
+	return active
+}
+
+func beats(p *ProductPrice, other *ProductPrice) bool {
+	if (p.EffectiveTo != nil) != (other.EffectiveTo != nil) {
+		return p.EffectiveTo != nil
+	}
+	if !p.EffectiveFrom.Equal(other.EffectiveFrom) {
+		return p.EffectiveFrom.After(other.EffectiveFrom)
+	}
+	return p.Id > other.Id
+}
+
+func sameVariant(a *uint, b *uint) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
@@ -0,0 +1,31 @@
+package models
+
+// ProductVariant is one sellable combination of a product's option values,
+// such as a medium red shirt. It has its own SKU and stock, and its price is
+// the product's unless Price overrides it.
+type ProductVariant struct {
+	Base
+	ProductId    uint                 `gorm:"not null;index"`
+	Sku          string               `gorm:"type:text;size:100;uniqueIndex" sql:"type:text"`
+	Price        *float32             `gorm:"type:decimal(10,2)" sql:"type:decimal(10,2)"`
+	Stock        int                  `gorm:"default:0"`
+	IsActive     bool                 `gorm:"default:true"`
+	OptionValues []VariantOptionValue `gorm:"foreignKey:VariantId;constraint:OnDelete:CASCADE"`
+	Product      Product              `gorm:"foreignKey:ProductId;constraint:OnDelete:CASCADE"`
+}
+
+func (ProductVariant) TableName() string {
+	return "product_variants"
+}
+
+// VariantOptionValue is one of the option values that make up a variant.
+type VariantOptionValue struct {
+	Base
+	VariantId     uint        `gorm:"not null;index"`
+	OptionValueId uint        `gorm:"not null"`
+	OptionValue   OptionValue `gorm:"foreignKey:OptionValueId;constraint:OnDelete:CASCADE"`
+}
+
+func (VariantOptionValue) TableName() string {
+	return "variant_option_values"
+}
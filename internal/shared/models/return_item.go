@@ -0,0 +1,14 @@
+package models
+
+type ReturnItem struct {
+	Base
+	ReturnRequestId uint          `gorm:"not null;index"`
+	OrderItemId     uint          `gorm:"not null;index"`
+	Quantity        int           `gorm:"not null"`
+	ReturnRequest   ReturnRequest `gorm:"foreignKey:ReturnRequestId;constraint:OnDelete:CASCADE"`
+	OrderItem       OrderItem     `gorm:"foreignKey:OrderItemId;constraint:OnDelete:CASCADE"`
+}
+
+func (ri *ReturnItem) TableName() string {
+	return "return_items"
+}
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+type ReturnRequest struct {
+	Base
+	OrderId      uint         `gorm:"not null;index"`
+	PaymentId    *uint        `gorm:"index"`
+	Reason       string       `gorm:"type:text;not null"`
+	Status       ReturnStatus `gorm:"type:varchar(20);not null;default:'requested'"`
+	RefundAmount float64      `gorm:"not null;default:0"`
+	ReceivedDate *time.Time
+	Order        Order        `gorm:"foreignKey:OrderId;constraint:OnDelete:CASCADE"`
+	Items        []ReturnItem `gorm:"foreignKey:ReturnRequestId;constraint:OnDelete:CASCADE"`
+}
+
+type ReturnStatus string
+
+const (
+	ReturnStatusRequested ReturnStatus = "requested"
+	ReturnStatusApproved  ReturnStatus = "approved"
+	ReturnStatusReceived  ReturnStatus = "received"
+	ReturnStatusRefunded  ReturnStatus = "refunded"
+	ReturnStatusRejected  ReturnStatus = "rejected"
+)
+
+func (r *ReturnRequest) TableName() string {
+	return "return_requests"
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+type Shipment struct {
+	Base
+	OrderId        uint           `gorm:"not null;index"`
+	Carrier        string         `gorm:"type:varchar(50);not null"`
+	TrackingNumber string         `gorm:"type:varchar(100);not null"`
+	TrackingStatus string         `gorm:"type:varchar(30);not null;default:'label_created'"`
+	ShippedDate    time.Time      `gorm:"not null"`
+	DeliveredDate  *time.Time     `gorm:"index"`
+	Order          Order          `gorm:"foreignKey:OrderId;constraint:OnDelete:CASCADE"`
+	Items          []ShipmentItem `gorm:"foreignKey:ShipmentId;constraint:OnDelete:CASCADE"`
+}
+
+func (s *Shipment) TableName() string {
+	return "shipments"
+}
@@ -0,0 +1,14 @@
+package models
+
+type ShipmentItem struct {
+	Base
+	ShipmentId  uint      `gorm:"not null;index"`
+	OrderItemId uint      `gorm:"not null;index"`
+	Quantity    int       `gorm:"not null"`
+	Shipment    Shipment  `gorm:"foreignKey:ShipmentId;constraint:OnDelete:CASCADE"`
+	OrderItem   OrderItem `gorm:"foreignKey:OrderItemId;constraint:OnDelete:CASCADE"`
+}
+
+func (si *ShipmentItem) TableName() string {
+	return "shipment_items"
+}
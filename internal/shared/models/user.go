@@ -2,55 +2,38 @@ package models
 
 import (
 	"errors"
+	"strings"
 
-	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
+// LegacyAuthSubPrefix marks users created before Auth0 owned sign-in. The
+// migration gives them a placeholder sub, which is swapped for the real one
+// the first time they log in with the same email.
+const LegacyAuthSubPrefix = "legacy|"
+
 type User struct {
 	Base
 	FirstName string    `gorm:"not null;size:100"`
 	LastName  string    `gorm:"not null;size:100"`
 	Email     string    `gorm:"unique;size:250"`
-	Password  string    `gorm:"not null"`
 	Addresses []Address `gorm:"foreignKey:UserId;constraint:OnDelete:CASCADE"`
 	Orders    []Order   `gorm:"foreignKey:UserId;constraint:OnDelete:CASCADE"`
 	Reviews   []Review  `gorm:"foreignKey:UserId;constraint:OnDelete:CASCADE"`
-	AuthSub   string    `gorm:"unique;size:250"`
+	AuthSub   string    `gorm:"unique;not null;size:250"`
 }
 
 func (u *User) BeforeCreate(tx *gorm.DB) error {
-	if u.Password == "" {
-		return errors.New("password is required")
-	}
-	hashedPassword, err := bcrypt.GenerateFromPassword(
-		[]byte(u.Password),
-		bcrypt.DefaultCost,
-	)
-	if err != nil {
-		return err
-	}
-	u.Password = string(hashedPassword)
-	return nil
-}
-
-func (u *User) BeforeUpdate(tx *gorm.DB) error {
-	if tx.Statement.Changed("Password") {
-		hashedPassword, err := bcrypt.GenerateFromPassword(
-			[]byte(u.Password),
-			bcrypt.DefaultCost,
-		)
-		if err != nil {
-			return err
-		}
-		u.Password = string(hashedPassword)
+	if u.AuthSub == "" {
+		return errors.New("auth sub is required")
 	}
 	return nil
 }
 
-func (u *User) CheckPassword(password string) bool {
-	err := bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(password))
-	return err == nil
+// IsLegacy reports whether the user still has the placeholder sub given to
+// pre-Auth0 accounts.
+func (u *User) IsLegacy() bool {
+	return strings.HasPrefix(u.AuthSub, LegacyAuthSubPrefix)
 }
 
 func (u *User) FullName() string {
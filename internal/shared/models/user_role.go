@@ -0,0 +1,14 @@
+package models
+
+// UserRole grants a role to a user in addition to any the identity provider
+// puts in their token.
+type UserRole struct {
+	Base
+	UserId uint   `gorm:"not null;uniqueIndex:idx_user_roles_user_role"`
+	Role   string `gorm:"not null;size:50;uniqueIndex:idx_user_roles_user_role"`
+	User   User   `gorm:"foreignKey:UserId;constraint:OnDelete:CASCADE"`
+}
+
+func (UserRole) TableName() string {
+	return "user_roles"
+}
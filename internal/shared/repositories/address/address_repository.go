@@ -2,17 +2,32 @@ package address
 
 import (
 	"commerce/internal/shared/models"
+	"commerce/internal/shared/repositories/query"
+	"context"
 	"time"
 
 	"gorm.io/gorm"
 )
 
 type AddressRepositoryI interface {
-	GetById(id uint) (*models.Address, error)
-	GetByUserId(userId uint) ([]*models.Address, error)
-	GetAll() ([]*models.Address, error)
-	Save(address *models.Address) error
-	Delete(id uint, hard bool) error
+	GetById(ctx context.Context, id uint) (*models.Address, error)
+	GetByUserId(ctx context.Context, userId uint, opts query.Options) (*query.Page[models.Address], error)
+	GetAll(ctx context.Context, opts query.Options) (*query.Page[models.Address], error)
+	Save(ctx context.Context, address *models.Address) error
+	Delete(ctx context.Context, id uint, hard bool) error
+	AnonymizeByUserId(ctx context.Context, userId uint, erasedDate time.Time) error
+}
+
+// listFields are what address lists can be sorted and filtered by.
+var listFields = query.Fields{
+	Sort: map[string]string{
+		"created_date": "created_date",
+	},
+	Filter: map[string]query.Filter{
+		"country":    {Condition: "country = ?"},
+		"is_default": {Condition: "is_default = ?", Parse: query.Bool},
+	},
+	Default: []query.Sort{{Field: "created_date", Desc: true}},
 }
 
 type AddressRepository struct {
@@ -24,45 +39,51 @@ func NewAddressRepository(db *gorm.DB) AddressRepositoryI {
 	return &AddressRepository{db: db}
 }
 
-func (r *AddressRepository) GetById(id uint) (*models.Address, error) {
+func (r *AddressRepository) GetById(ctx context.Context, id uint) (*models.Address, error) {
 	var address models.Address
-	if err := r.db.First(&address, id).Error; err != nil {
+	if err := r.db.WithContext(ctx).First(&address, id).Error; err != nil {
 		return nil, err
 	}
 	return &address, nil
 }
 
-func (r *AddressRepository) GetByUserId(userId uint) ([]*models.Address, error) {
-	var addresses []*models.Address
-	if err := r.db.Where("user_id = ?", userId).Order("created_date desc").Find(&addresses).Error; err != nil {
-		return nil, err
-	}
-	return addresses, nil
+func (r *AddressRepository) GetByUserId(ctx context.Context, userId uint, opts query.Options) (*query.Page[models.Address], error) {
+	return query.Find[models.Address](r.db.WithContext(ctx).Where("user_id = ?", userId), opts, listFields)
 }
 
-func (r *AddressRepository) GetAll() ([]*models.Address, error) {
-	var addresses []*models.Address
-	if err := r.db.Find(&addresses).Error; err != nil {
-		return nil, err
-	}
-	return addresses, nil
+func (r *AddressRepository) GetAll(ctx context.Context, opts query.Options) (*query.Page[models.Address], error) {
+	return query.Find[models.Address](r.db.WithContext(ctx), opts, listFields)
 }
 
-func (r *AddressRepository) Save(address *models.Address) error {
+func (r *AddressRepository) Save(ctx context.Context, address *models.Address) error {
 	if address.Id == 0 {
-		return r.db.Create(address).Error
+		return r.db.WithContext(ctx).Create(address).Error
 	}
-	return r.db.Save(address).Error
+	return r.db.WithContext(ctx).Save(address).Error
 }
 
-func (r *AddressRepository) Delete(id uint, hard bool) error {
+func (r *AddressRepository) Delete(ctx context.Context, id uint, hard bool) error {
 	if hard {
-		return r.db.Delete(&models.Address{}, id).Error
+		return r.db.WithContext(ctx).Unscoped().Delete(&models.Address{}, id).Error
 	}
 	var address models.Address
-	if err := r.db.First(&address, id).Error; err != nil {
+	if err := r.db.WithContext(ctx).First(&address, id).Error; err != nil {
 		return err
 	}
-	address.DeletedDate = time.Now()
-	return r.db.Save(&address).Error
+	return r.db.WithContext(ctx).Delete(&address).Error
+}
+
+// AnonymizeByUserId blanks every address in the user's address book, including
+// ones already deleted, and soft-deletes it. Orders keep their own copies of
+// the addresses they used.
+func (r *AddressRepository) AnonymizeByUserId(ctx context.Context, userId uint, erasedDate time.Time) error {
+	return r.db.WithContext(ctx).Unscoped().Model(&models.Address{}).Where("user_id = ?", userId).Updates(map[string]any{
+		"street":       "",
+		"city":         "",
+		"state":        "",
+		"postal_code":  "",
+		"country":      "",
+		"is_default":   false,
+		"deleted_date": erasedDate,
+	}).Error
 }
@@ -0,0 +1,94 @@
+package apikey
+
+import (
+	"commerce/internal/shared/models"
+	"commerce/internal/shared/repositories/query"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ApiKeyRepositoryI interface {
+	GetById(ctx context.Context, id uint) (*models.ApiKey, error)
+	GetByPrefix(ctx context.Context, prefix string) (*models.ApiKey, error)
+	GetAll(ctx context.Context, opts query.Options) (*query.Page[models.ApiKey], error)
+	Save(ctx context.Context, apiKey *models.ApiKey) error
+	Revoke(ctx context.Context, id uint, revokedDate time.Time) error
+	Touch(ctx context.Context, id uint, lastUsedDate time.Time) error
+}
+
+// listFields are what API key lists can be sorted and filtered by.
+var listFields = query.Fields{
+	Sort: map[string]string{
+		"name":         "name",
+		"owner":        "owner",
+		"created_date": "created_date",
+	},
+	Filter: map[string]query.Filter{
+		"owner": {Condition: "owner = ?"},
+	},
+	Default: []query.Sort{{Field: "created_date", Desc: true}},
+}
+
+type ApiKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewApiKeyRepository(db *gorm.DB) ApiKeyRepositoryI {
+	return &ApiKeyRepository{db: db}
+}
+
+// GetById implements [ApiKeyRepositoryI].
+func (a *ApiKeyRepository) GetById(ctx context.Context, id uint) (*models.ApiKey, error) {
+	var apiKey models.ApiKey
+	if err := a.db.WithContext(ctx).First(&apiKey, id).Error; err != nil {
+		return nil, err
+	}
+	return &apiKey, nil
+}
+
+// GetByPrefix implements [ApiKeyRepositoryI].
+func (a *ApiKeyRepository) GetByPrefix(ctx context.Context, prefix string) (*models.ApiKey, error) {
+	var apiKey models.ApiKey
+	if err := a.db.WithContext(ctx).Where("prefix = ?", prefix).First(&apiKey).Error; err != nil {
+		return nil, err
+	}
+	return &apiKey, nil
+}
+
+// GetAll implements [ApiKeyRepositoryI].
+func (a *ApiKeyRepository) GetAll(ctx context.Context, opts query.Options) (*query.Page[models.ApiKey], error) {
+	return query.Find[models.ApiKey](a.db.WithContext(ctx), opts, listFields)
+}
+
+// Save implements [ApiKeyRepositoryI].
+func (a *ApiKeyRepository) Save(ctx context.Context, apiKey *models.ApiKey) error {
+	if apiKey.Id == 0 {
+		return a.db.WithContext(ctx).Create(apiKey).Error
+	}
+	return a.db.WithContext(ctx).Save(apiKey).Error
+}
+
+// Revoke implements [ApiKeyRepositoryI].
+func (a *ApiKeyRepository) Revoke(ctx context.Context, id uint, revokedDate time.Time) error {
+	result := a.db.WithContext(ctx).
+		Model(&models.ApiKey{}).
+		Where("id = ? AND revoked_date IS NULL", id).
+		Update("revoked_date", revokedDate)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// Touch implements [ApiKeyRepositoryI].
+func (a *ApiKeyRepository) Touch(ctx context.Context, id uint, lastUsedDate time.Time) error {
+	return a.db.WithContext(ctx).
+		Model(&models.ApiKey{}).
+		Where("id = ?", id).
+		UpdateColumn("last_used_date", lastUsedDate).Error
+}
@@ -0,0 +1,70 @@
+package auditevent
+
+import (
+	"commerce/internal/shared/models"
+	"commerce/internal/shared/repositories/query"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Filter narrows a search of the audit log. Zero fields don't filter.
+type Filter struct {
+	Subject    string
+	UserId     *uint
+	Action     models.AuditAction
+	EntityType string
+	EntityId   *uint
+	From       *time.Time
+	To         *time.Time
+}
+
+type AuditEventRepositoryI interface {
+	GetAll(ctx context.Context, filter Filter, opts query.Options) (*query.Page[models.AuditEvent], error)
+}
+
+// listFields are what the audit log can be sorted by; it is filtered with
+// [Filter].
+var listFields = query.Fields{
+	Sort: map[string]string{
+		"created_date": "created_date",
+	},
+	Default: []query.Sort{{Field: "created_date", Desc: true}},
+}
+
+type AuditEventRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditEventRepository(db *gorm.DB) AuditEventRepositoryI {
+	return &AuditEventRepository{db: db}
+}
+
+// GetAll implements [AuditEventRepositoryI]. Newest first; From is inclusive
+// and To exclusive.
+func (a *AuditEventRepository) GetAll(ctx context.Context, filter Filter, opts query.Options) (*query.Page[models.AuditEvent], error) {
+	q := a.db.WithContext(ctx)
+	if filter.Subject != "" {
+		q = q.Where("subject = ?", filter.Subject)
+	}
+	if filter.UserId != nil {
+		q = q.Where("user_id = ?", *filter.UserId)
+	}
+	if filter.Action != "" {
+		q = q.Where("action = ?", filter.Action)
+	}
+	if filter.EntityType != "" {
+		q = q.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityId != nil {
+		q = q.Where("entity_id = ?", *filter.EntityId)
+	}
+	if filter.From != nil {
+		q = q.Where("created_date >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		q = q.Where("created_date < ?", *filter.To)
+	}
+	return query.Find[models.AuditEvent](q, opts, listFields)
+}
@@ -2,17 +2,32 @@ package category
 
 import (
 	"commerce/internal/shared/models"
-	"time"
+	"commerce/internal/shared/repositories/query"
+	"context"
 
 	"gorm.io/gorm"
 )
 
 type CategoryRepositoryI interface {
-	GetById(id uint) (*models.Category, error)
-	GetByParentId(parentId uint) ([]*models.Category, error)
-	GetAll() ([]*models.Category, error)
-	Save(category *models.Category) error
-	Delete(id uint, hard bool) error
+	GetById(ctx context.Context, id uint) (*models.Category, error)
+	GetByParentId(ctx context.Context, parentId uint, opts query.Options) (*query.Page[models.Category], error)
+	GetAll(ctx context.Context, opts query.Options) (*query.Page[models.Category], error)
+	Save(ctx context.Context, category *models.Category) error
+	Delete(ctx context.Context, id uint, hard bool) error
+}
+
+// listFields are what category lists can be sorted and filtered by.
+var listFields = query.Fields{
+	Sort: map[string]string{
+		"name":         "name",
+		"slug":         "slug",
+		"created_date": "created_date",
+	},
+	Filter: map[string]query.Filter{
+		"name":      {Condition: "name ILIKE '%' || ? || '%'"},
+		"is_active": {Condition: "is_active = ?", Parse: query.Bool},
+	},
+	Default: []query.Sort{{Field: "name"}},
 }
 
 type CategoryRepository struct {
@@ -24,45 +39,36 @@ func NewCategoryRepository(db *gorm.DB) CategoryRepositoryI {
 	return &CategoryRepository{db: db}
 }
 
-func (r *CategoryRepository) GetById(id uint) (*models.Category, error) {
+func (r *CategoryRepository) GetById(ctx context.Context, id uint) (*models.Category, error) {
 	var category models.Category
-	if err := r.db.First(&category, id).Error; err != nil {
+	if err := r.db.WithContext(ctx).First(&category, id).Error; err != nil {
 		return nil, err
 	}
 	return &category, nil
 }
 
-func (r *CategoryRepository) GetByParentId(parentId uint) ([]*models.Category, error) {
-	var categories []*models.Category
-	if err := r.db.Where("parent_id = ?", parentId).Order("name asc").Find(&categories).Error; err != nil {
-		return nil, err
-	}
-	return categories, nil
+func (r *CategoryRepository) GetByParentId(ctx context.Context, parentId uint, opts query.Options) (*query.Page[models.Category], error) {
+	return query.Find[models.Category](r.db.WithContext(ctx).Where("parent_id = ?", parentId), opts, listFields)
 }
 
-func (r *CategoryRepository) GetAll() ([]*models.Category, error) {
-	var categories []*models.Category
-	if err := r.db.Order("name asc").Find(&categories).Error; err != nil {
-		return nil, err
-	}
-	return categories, nil
+func (r *CategoryRepository) GetAll(ctx context.Context, opts query.Options) (*query.Page[models.Category], error) {
+	return query.Find[models.Category](r.db.WithContext(ctx), opts, listFields)
 }
 
-func (r *CategoryRepository) Save(category *models.Category) error {
+func (r *CategoryRepository) Save(ctx context.Context, category *models.Category) error {
 	if category.Id == 0 {
-		return r.db.Create(category).Error
+		return r.db.WithContext(ctx).Create(category).Error
 	}
-	return r.db.Save(category).Error
+	return r.db.WithContext(ctx).Save(category).Error
 }
 
-func (r *CategoryRepository) Delete(id uint, hard bool) error {
+func (r *CategoryRepository) Delete(ctx context.Context, id uint, hard bool) error {
 	if hard {
-		return r.db.Delete(&models.Category{}, id).Error
+		return r.db.WithContext(ctx).Unscoped().Delete(&models.Category{}, id).Error
 	}
 	var category models.Category
-	if err := r.db.First(&category, id).Error; err != nil {
+	if err := r.db.WithContext(ctx).First(&category, id).Error; err != nil {
 		return err
 	}
-	category.DeletedDate = time.Now()
-	return r.db.Save(&category).Error
+	return r.db.WithContext(ctx).Delete(&category).Error
 }
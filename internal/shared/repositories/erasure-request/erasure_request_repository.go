@@ -0,0 +1,49 @@
+package erasurerequest
+
+import (
+	"commerce/internal/shared/models"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type ErasureRequestRepositoryI interface {
+	GetByUserId(ctx context.Context, userId uint) ([]*models.ErasureRequest, error)
+	GetPending(ctx context.Context) ([]*models.ErasureRequest, error)
+	Save(ctx context.Context, request *models.ErasureRequest) error
+}
+
+type ErasureRequestRepository struct {
+	db *gorm.DB
+}
+
+func NewErasureRequestRepository(db *gorm.DB) ErasureRequestRepositoryI {
+	return &ErasureRequestRepository{db: db}
+}
+
+// GetByUserId implements [ErasureRequestRepositoryI].
+func (e *ErasureRequestRepository) GetByUserId(ctx context.Context, userId uint) ([]*models.ErasureRequest, error) {
+	var requests []*models.ErasureRequest
+	if err := e.db.WithContext(ctx).Where("user_id = ?", userId).Order("created_date desc").Find(&requests).Error; err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+// GetPending implements [ErasureRequestRepositoryI]. Oldest first, so
+// requests are honoured in the order they came in.
+func (e *ErasureRequestRepository) GetPending(ctx context.Context) ([]*models.ErasureRequest, error) {
+	var requests []*models.ErasureRequest
+	if err := e.db.WithContext(ctx).Where("status = ?", models.ErasureStatusPending).Order("created_date").Find(&requests).Error; err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+// Save implements [ErasureRequestRepositoryI].
+func (e *ErasureRequestRepository) Save(ctx context.Context, request *models.ErasureRequest) error {
+	if request.Id == 0 {
+		return e.db.WithContext(ctx).Create(request).Error
+	}
+	return e.db.WithContext(ctx).Save(request).Error
+}
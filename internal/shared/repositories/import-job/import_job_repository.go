@@ -0,0 +1,66 @@
+package importjob
+
+import (
+	"commerce/internal/shared/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ImportJobRepositoryI interface {
+	GetById(ctx context.Context, id uint) (*models.ImportJob, error)
+	Save(ctx context.Context, job *models.ImportJob) error
+	Claim(ctx context.Context, now time.Time, lease time.Duration) (*models.ImportJob, error)
+}
+
+type ImportJobRepository struct {
+	db *gorm.DB
+}
+
+func NewImportJobRepository(db *gorm.DB) ImportJobRepositoryI {
+	return &ImportJobRepository{db: db}
+}
+
+// GetById implements [ImportJobRepositoryI]. The file is left out.
+func (i *ImportJobRepository) GetById(ctx context.Context, id uint) (*models.ImportJob, error) {
+	var job models.ImportJob
+	if err := i.db.WithContext(ctx).Omit("file").First(&job, id).Error; err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// Save implements [ImportJobRepositoryI].
+func (i *ImportJobRepository) Save(ctx context.Context, job *models.ImportJob) error {
+	if job.Id == 0 {
+		return i.db.WithContext(ctx).Create(job).Error
+	}
+	return i.db.WithContext(ctx).Save(job).Error
+}
+
+// Claim implements [ImportJobRepositoryI]. It marks the oldest pending job
+// running and returns it with its file, or [gorm.ErrRecordNotFound] if there
+// is none. A job still running lease after it started is taken to have been
+// cut off, by a restart say, and is claimed again. Rows being claimed by
+// another instance are skipped rather than waited for.
+func (i *ImportJobRepository) Claim(ctx context.Context, now time.Time, lease time.Duration) (*models.ImportJob, error) {
+	var job models.ImportJob
+	err := i.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? OR (status = ? AND started_date < ?)",
+				models.ImportStatusPending, models.ImportStatusRunning, now.Add(-lease)).
+			Order("id").
+			First(&job).Error; err != nil {
+			return err
+		}
+		job.Status = models.ImportStatusRunning
+		job.StartedDate = &now
+		return tx.Model(&job).Updates(map[string]any{"status": job.Status, "started_date": now}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
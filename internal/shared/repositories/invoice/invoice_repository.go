@@ -0,0 +1,120 @@
+package invoice
+
+import (
+	"commerce/internal/shared/models"
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrAlreadyIssued is returned when creating an invoice for an order that
+// already has one.
+var ErrAlreadyIssued = errors.New("order has already been invoiced")
+
+type InvoiceRepositoryI interface {
+	GetById(ctx context.Context, id uint) (*models.Invoice, error)
+	GetAllByOrderId(ctx context.Context, orderId uint) ([]*models.Invoice, error)
+	LockInvoice(ctx context.Context, orderId uint) error
+	Create(ctx context.Context, invoice *models.Invoice) error
+	NextNumber(ctx context.Context, invoiceType models.InvoiceType) (int64, error)
+}
+
+type InvoiceRepository struct {
+	db *gorm.DB
+}
+
+func NewInvoiceRepository(db *gorm.DB) InvoiceRepositoryI {
+	return &InvoiceRepository{db: db}
+}
+
+// GetById implements [InvoiceRepositoryI].
+func (r *InvoiceRepository) GetById(ctx context.Context, id uint) (*models.Invoice, error) {
+	var invoice models.Invoice
+	if err := r.db.WithContext(ctx).
+		Preload("Lines", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+		Preload("CreditedInvoice").
+		First(&invoice, id).Error; err != nil {
+		return nil, err
+	}
+	return &invoice, nil
+}
+
+// GetAllByOrderId implements [InvoiceRepositoryI]. The invoice comes first,
+// followed by its credit notes in the order they were issued.
+func (r *InvoiceRepository) GetAllByOrderId(ctx context.Context, orderId uint) ([]*models.Invoice, error) {
+	var invoices []*models.Invoice
+	if err := r.db.WithContext(ctx).
+		Preload("Lines", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+		Where("order_id = ?", orderId).
+		Order("issued_date, id").
+		Find(&invoices).
+		Error; err != nil {
+		return nil, err
+	}
+	return invoices, nil
+}
+
+// LockInvoice implements [InvoiceRepositoryI]. It locks the row of the
+// order's invoice until the transaction ends, so credit notes against it are
+// issued one after the other. It returns gorm.ErrRecordNotFound when the
+// order has no invoice.
+func (r *InvoiceRepository) LockInvoice(ctx context.Context, orderId uint) error {
+	var invoice models.Invoice
+	return r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		Where("order_id = ? AND type = ?", orderId, models.InvoiceTypeInvoice).
+		First(&invoice).Error
+}
+
+// Create implements [InvoiceRepositoryI]. Invoices are never updated once
+// issued, so there is no Save. An order's second invoice returns
+// ErrAlreadyIssued; the insert skips the conflict instead of failing on it,
+// so the caller's transaction can still read the invoice that won.
+func (r *InvoiceRepository) Create(ctx context.Context, invoice *models.Invoice) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.
+			Omit("Order", "CreditedInvoice", "Lines").
+			Clauses(clause.OnConflict{
+				Columns:     []clause.Column{{Name: "order_id"}},
+				TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "type = 'invoice'"}}},
+				DoNothing:   true,
+			}).
+			Create(invoice)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrAlreadyIssued
+		}
+		if len(invoice.Lines) == 0 {
+			return nil
+		}
+		for i := range invoice.Lines {
+			invoice.Lines[i].InvoiceId = invoice.Id
+		}
+		return tx.Create(&invoice.Lines).Error
+	})
+}
+
+// NextNumber implements [InvoiceRepositoryI]. Invoices and credit notes are
+// numbered from separate sequences.
+func (r *InvoiceRepository) NextNumber(ctx context.Context, invoiceType models.InvoiceType) (int64, error) {
+	sequence, ok := invoiceNumberSequences[invoiceType]
+	if !ok {
+		return 0, fmt.Errorf("no number sequence for %s", invoiceType)
+	}
+	var next int64
+	if err := r.db.WithContext(ctx).Raw("SELECT nextval(?)", sequence).Scan(&next).Error; err != nil {
+		return 0, err
+	}
+	return next, nil
+}
+
+var invoiceNumberSequences = map[models.InvoiceType]string{
+	models.InvoiceTypeInvoice:    "invoice_number_seq",
+	models.InvoiceTypeCreditNote: "credit_note_number_seq",
+}
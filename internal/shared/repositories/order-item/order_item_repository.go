@@ -2,16 +2,16 @@ package orderitem
 
 import (
 	"commerce/internal/shared/models"
-	"time"
+	"context"
 
 	"gorm.io/gorm"
 )
 
 type OrderItemRepositoryI interface {
-	GetById(id uint) (*models.OrderItem, error)
-	GetAllByOrder(orderId uint) ([]*models.OrderItem, error)
-	Save(item *models.OrderItem) error
-	Delete(id uint, hard bool) error
+	GetById(ctx context.Context, id uint) (*models.OrderItem, error)
+	GetAllByOrder(ctx context.Context, orderId uint) ([]*models.OrderItem, error)
+	Save(ctx context.Context, item *models.OrderItem) error
+	Delete(ctx context.Context, id uint, hard bool) error
 }
 
 type OrderItemRepository struct {
@@ -22,17 +22,17 @@ func NewOrderItemRepository(db *gorm.DB) OrderItemRepositoryI {
 	return &OrderItemRepository{db: db}
 }
 
-func (r *OrderItemRepository) GetById(id uint) (*models.OrderItem, error) {
+func (r *OrderItemRepository) GetById(ctx context.Context, id uint) (*models.OrderItem, error) {
 	var item models.OrderItem
-	if err := r.db.First(&item, id).Error; err != nil {
+	if err := r.db.WithContext(ctx).First(&item, id).Error; err != nil {
 		return nil, err
 	}
 	return &item, nil
 }
 
-func (r *OrderItemRepository) GetAllByOrder(orderId uint) ([]*models.OrderItem, error) {
+func (r *OrderItemRepository) GetAllByOrder(ctx context.Context, orderId uint) ([]*models.OrderItem, error) {
 	var items []*models.OrderItem
-	if err := r.db.Where("order_id = ?", orderId).
+	if err := r.db.WithContext(ctx).Where("order_id = ?", orderId).
 		Order("created_date desc").
 		Find(&items).Error; err != nil {
 		return nil, err
@@ -40,21 +40,20 @@ func (r *OrderItemRepository) GetAllByOrder(orderId uint) ([]*models.OrderItem,
 	return items, nil
 }
 
-func (r *OrderItemRepository) Save(item *models.OrderItem) error {
+func (r *OrderItemRepository) Save(ctx context.Context, item *models.OrderItem) error {
 	if item.Id == 0 {
-		return r.db.Create(item).Error
+		return r.db.WithContext(ctx).Create(item).Error
 	}
-	return r.db.Save(item).Error
+	return r.db.WithContext(ctx).Save(item).Error
 }
 
-func (r *OrderItemRepository) Delete(id uint, hard bool) error {
+func (r *OrderItemRepository) Delete(ctx context.Context, id uint, hard bool) error {
 	if hard {
-		return r.db.Delete(&models.OrderItem{}, id).Error
+		return r.db.WithContext(ctx).Unscoped().Delete(&models.OrderItem{}, id).Error
 	}
 	var orderItem models.OrderItem
-	if err := r.db.First(&orderItem, id).Error; err != nil {
+	if err := r.db.WithContext(ctx).First(&orderItem, id).Error; err != nil {
 		return err
 	}
-	orderItem.DeletedDate = time.Now()
-	return r.db.Save(&orderItem).Error
+	return r.db.WithContext(ctx).Delete(&orderItem).Error
 }
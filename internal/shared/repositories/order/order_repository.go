@@ -2,18 +2,47 @@ package order
 
 import (
 	"commerce/internal/shared/models"
+	"commerce/internal/shared/repositories/query"
+	"context"
+	"errors"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// ErrNotPending is returned when cancelling an order that is no longer
+// pending.
+var ErrNotPending = errors.New("order is no longer pending")
+
 type OrderRepositoryI interface {
-	GetById(id uint) (*models.Order, error)
-	GetAll() ([]*models.Order, error)
-	GetAllByUserId(userId uint) ([]*models.Order, error)
-	Save(order *models.Order) error
-	Delete(id uint, hard bool) error
-	UpdateStatus(id uint, status string) error
+	GetById(ctx context.Context, id uint) (*models.Order, error)
+	GetByIdForUpdate(ctx context.Context, id uint) (*models.Order, error)
+	GetAll(ctx context.Context, opts query.Options) (*query.Page[models.Order], error)
+	GetAllByUserId(ctx context.Context, userId uint, opts query.Options) (*query.Page[models.Order], error)
+	GetByOrderNumber(ctx context.Context, orderNumber string) (*models.Order, error)
+	NextOrderNumberSequence(ctx context.Context) (int64, error)
+	Save(ctx context.Context, order *models.Order) error
+	Delete(ctx context.Context, id uint, hard bool) error
+	Restore(ctx context.Context, id uint) error
+	UpdateStatus(ctx context.Context, id uint, status string) error
+	Cancel(ctx context.Context, id uint, reason string, cancelledDate time.Time) error
+}
+
+// listFields are what order lists can be sorted and filtered by.
+var listFields = query.Fields{
+	Sort: map[string]string{
+		"order_number": "order_number",
+		"total_amount": "total_amount",
+		"status":       "status",
+		"created_date": "created_date",
+	},
+	Filter: map[string]query.Filter{
+		"status": {Condition: "status = ?"},
+		"from":   {Condition: "created_date >= ?", Parse: query.Time},
+		"to":     {Condition: "created_date < ?", Parse: query.Time},
+	},
+	Default: []query.Sort{{Field: "created_date", Desc: true}},
 }
 
 type OrderRepository struct {
@@ -25,62 +54,129 @@ func NewOrderRepository(db *gorm.DB) OrderRepositoryI {
 }
 
 // Delete implements [OrderRepositoryI].
-func (o *OrderRepository) Delete(id uint, hard bool) error {
+func (o *OrderRepository) Delete(ctx context.Context, id uint, hard bool) error {
 	if hard {
-		return o.db.Delete(models.Order{}, id).Error
+		return o.db.WithContext(ctx).Unscoped().Delete(models.Order{}, id).Error
 	}
 	var order models.Order
-	if err := o.db.First(&order, id).Error; err != nil {
+	if err := o.db.WithContext(ctx).First(&order, id).Error; err != nil {
 		return err
 	}
-	order.DeletedDate = time.Now()
-	return o.db.Save(&order).Error
+	return o.db.WithContext(ctx).Delete(&order).Error
+}
+
+// Restore implements [OrderRepositoryI]. It returns [gorm.ErrRecordNotFound]
+// unless the order exists and is deleted.
+func (o *OrderRepository) Restore(ctx context.Context, id uint) error {
+	result := o.db.WithContext(ctx).
+		Unscoped().
+		Model(&models.Order{}).
+		Where("id = ? AND deleted_date IS NOT NULL", id).
+		Update("deleted_date", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
 }
 
 // GetAll implements [OrderRepositoryI].
-func (o *OrderRepository) GetAll() ([]*models.Order, error) {
-	var orders []*models.Order
-	if err := o.db.Order("created_date desc").Find(&orders).Error; err != nil {
+func (o *OrderRepository) GetAll(ctx context.Context, opts query.Options) (*query.Page[models.Order], error) {
+	return query.Find[models.Order](o.db.WithContext(ctx), opts, listFields)
+}
+
+// GetAllByUserId implements [OrderRepositoryI].
+func (o *OrderRepository) GetAllByUserId(ctx context.Context, userId uint, opts query.Options) (*query.Page[models.Order], error) {
+	return query.Find[models.Order](o.db.WithContext(ctx).Where("user_id = ?", userId), opts, listFields)
+}
+
+// GetById implements [OrderRepositoryI].
+func (o *OrderRepository) GetById(ctx context.Context, id uint) (*models.Order, error) {
+	var order models.Order
+	if err := o.db.WithContext(ctx).
+		Preload("OrderItems.Product", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Preload("Shipments", func(db *gorm.DB) *gorm.DB { return db.Order("shipped_date") }).
+		Preload("Shipments.Items").
+		First(&order, id).Error; err != nil {
 		return nil, err
 	}
-	return orders, nil
+	return &order, nil
 }
 
-// GetAllByUserId implements [OrderRepositoryI].
-func (o *OrderRepository) GetAllByUserId(userId uint) ([]*models.Order, error) {
-	var orders []*models.Order
-	if err := o.db.
-		Preload("BillingAddress").
-		Where("user_id = ?", userId).
-		Order("created_date desc").
-		Find(&orders).
-		Error; err != nil {
+// GetByIdForUpdate implements [OrderRepositoryI]. It is GetById with the
+// order's row locked until the transaction ends, so shipments and returns
+// created concurrently for the same order are checked one after the other.
+func (o *OrderRepository) GetByIdForUpdate(ctx context.Context, id uint) (*models.Order, error) {
+	var order models.Order
+	if err := o.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("OrderItems.Product", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Preload("Shipments", func(db *gorm.DB) *gorm.DB { return db.Order("shipped_date") }).
+		Preload("Shipments.Items").
+		First(&order, id).Error; err != nil {
 		return nil, err
 	}
-	return orders, nil
+	return &order, nil
 }
 
-// GetById implements [OrderRepositoryI].
-func (o *OrderRepository) GetById(id uint) (*models.Order, error) {
+// GetByOrderNumber implements [OrderRepositoryI].
+func (o *OrderRepository) GetByOrderNumber(ctx context.Context, orderNumber string) (*models.Order, error) {
 	var order models.Order
-	if err := o.db.Preload("BillingAddress").First(&order, id).Error; err != nil {
+	if err := o.db.WithContext(ctx).
+		Preload("OrderItems").
+		Preload("Shipments", func(db *gorm.DB) *gorm.DB { return db.Order("shipped_date") }).
+		Preload("Shipments.Items").
+		Where("order_number = ?", orderNumber).
+		First(&order).Error; err != nil {
 		return nil, err
 	}
 	return &order, nil
 }
 
+// NextOrderNumberSequence implements [OrderRepositoryI].
+func (o *OrderRepository) NextOrderNumberSequence(ctx context.Context) (int64, error) {
+	var next int64
+	if err := o.db.WithContext(ctx).Raw("SELECT nextval('order_number_seq')").Scan(&next).Error; err != nil {
+		return 0, err
+	}
+	return next, nil
+}
+
 // Save implements [OrderRepositoryI].
-func (o *OrderRepository) Save(order *models.Order) error {
+func (o *OrderRepository) Save(ctx context.Context, order *models.Order) error {
 	if order.Id == 0 {
-		return o.db.Create(order).Error
+		return o.db.WithContext(ctx).Create(order).Error
 	}
-	return o.db.Save(order).Error
+	return o.db.WithContext(ctx).Save(order).Error
 }
 
 // UpdateStatus implements [OrderRepositoryI].
-func (o *OrderRepository) UpdateStatus(id uint, status string) error {
-	return o.db.
+func (o *OrderRepository) UpdateStatus(ctx context.Context, id uint, status string) error {
+	return o.db.WithContext(ctx).
 		Model(&models.Order{}).
 		Where("id = ?", id).
-		Update("order_status", status).Error
+		Update("status", status).Error
+}
+
+// Cancel implements [OrderRepositoryI]. The update only matches a pending
+// order, so of two concurrent cancels the second waits on the row lock and
+// then gets [ErrNotPending].
+func (o *OrderRepository) Cancel(ctx context.Context, id uint, reason string, cancelledDate time.Time) error {
+	result := o.db.WithContext(ctx).
+		Model(&models.Order{}).
+		Where("id = ? AND status = ?", id, models.OrderStatusPending).
+		Updates(map[string]any{
+			"status":         models.OrderStatusCancelled,
+			"cancel_reason":  reason,
+			"cancelled_date": cancelledDate,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotPending
+	}
+	return nil
 }
@@ -2,18 +2,33 @@ package payment
 
 import (
 	"commerce/internal/shared/models"
-	"time"
+	"commerce/internal/shared/repositories/query"
+	"context"
 
 	"gorm.io/gorm"
 )
 
 type PaymentRepositoryI interface {
-	GetById(id uint) (*models.Payment, error)
-	GetAll() ([]*models.Payment, error)
-	GetByOrder(orderId uint) ([]*models.Payment, error)
-	Save(payment *models.Payment) error
-	Delete(id uint, hard bool) error
-	UpdateStatus(id uint, status string) error
+	GetById(ctx context.Context, id uint) (*models.Payment, error)
+	GetAll(ctx context.Context, opts query.Options) (*query.Page[models.Payment], error)
+	GetByOrder(ctx context.Context, orderId uint) ([]*models.Payment, error)
+	Save(ctx context.Context, payment *models.Payment) error
+	Delete(ctx context.Context, id uint, hard bool) error
+	UpdateStatus(ctx context.Context, id uint, status string) error
+}
+
+// listFields are what payment lists can be sorted and filtered by.
+var listFields = query.Fields{
+	Sort: map[string]string{
+		"amount":       "amount",
+		"status":       "status",
+		"created_date": "created_date",
+	},
+	Filter: map[string]query.Filter{
+		"status":   {Condition: "status = ?"},
+		"order_id": {Condition: "order_id = ?", Parse: query.Int},
+	},
+	Default: []query.Sort{{Field: "created_date", Desc: true}},
 }
 
 type PaymentRepository struct {
@@ -24,55 +39,50 @@ func NewPaymentRepository(db *gorm.DB) PaymentRepositoryI {
 	return &PaymentRepository{db: db}
 }
 
-func (r *PaymentRepository) GetById(id uint) (*models.Payment, error) {
+func (r *PaymentRepository) GetById(ctx context.Context, id uint) (*models.Payment, error) {
 	payment := models.Payment{}
-	if err := r.db.First(&payment, id).Error; err != nil {
+	if err := r.db.WithContext(ctx).First(&payment, id).Error; err != nil {
 		return nil, err
 	}
 	return &payment, nil
 }
 
-func (r *PaymentRepository) GetAll() ([]*models.Payment, error) {
-	payments := []*models.Payment{}
-	if err := r.db.Order("created_date desc").Find(&payments).Error; err != nil {
-		return nil, err
-	}
-	return payments, nil
+func (r *PaymentRepository) GetAll(ctx context.Context, opts query.Options) (*query.Page[models.Payment], error) {
+	return query.Find[models.Payment](r.db.WithContext(ctx), opts, listFields)
 }
 
-func (r *PaymentRepository) GetByOrder(orderId uint) ([]*models.Payment, error) {
+func (r *PaymentRepository) GetByOrder(ctx context.Context, orderId uint) ([]*models.Payment, error) {
 	payments := []*models.Payment{}
-	if err := r.db.Where("order_id = ?", orderId).Order("created_date desc").Find(&payments).Error; err != nil {
+	if err := r.db.WithContext(ctx).Where("order_id = ?", orderId).Order("created_date desc").Find(&payments).Error; err != nil {
 		return nil, err
 	}
 	return payments, nil
 }
 
-func (r *PaymentRepository) Save(payment *models.Payment) error {
+func (r *PaymentRepository) Save(ctx context.Context, payment *models.Payment) error {
 	if payment.Id == 0 {
-		return r.db.Create(payment).Error
+		return r.db.WithContext(ctx).Create(payment).Error
 	}
-	return r.db.Save(payment).Error
+	return r.db.WithContext(ctx).Save(payment).Error
 }
 
 // UpdateStauts implements [PaymentRepositoryI].
-func (r *PaymentRepository) UpdateStatus(id uint, status string) error {
-	model, err := r.GetById(id)
+func (r *PaymentRepository) UpdateStatus(ctx context.Context, id uint, status string) error {
+	model, err := r.GetById(ctx, id)
 	if err != nil {
 		return err
 	}
 	model.Status = models.PaymentStatus(status)
-	return r.Save(model)
+	return r.Save(ctx, model)
 }
 
-func (r *PaymentRepository) Delete(id uint, hard bool) error {
+func (r *PaymentRepository) Delete(ctx context.Context, id uint, hard bool) error {
 	if hard {
-		return r.db.Delete(&models.Payment{}, id).Error
+		return r.db.WithContext(ctx).Unscoped().Delete(&models.Payment{}, id).Error
 	}
 	payment := models.Payment{}
-	if err := r.db.First(&payment, id).Error; err != nil {
+	if err := r.db.WithContext(ctx).First(&payment, id).Error; err != nil {
 		return err
 	}
-	payment.DeletedDate = time.Now()
-	return r.db.Save(&payment).Error
+	return r.db.WithContext(ctx).Delete(&payment).Error
 }
@@ -0,0 +1,78 @@
+package productimage
+
+import (
+	"commerce/internal/shared/models"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type ProductImageRepositoryI interface {
+	GetById(ctx context.Context, id uint) (*models.ProductImage, error)
+	GetAllByProductId(ctx context.Context, productId uint) ([]*models.ProductImage, error)
+	Save(ctx context.Context, image *models.ProductImage) error
+	SaveAll(ctx context.Context, images []*models.ProductImage) error
+	Delete(ctx context.Context, id uint) error
+}
+
+type ProductImageRepository struct {
+	db *gorm.DB
+}
+
+func NewProductImageRepository(db *gorm.DB) ProductImageRepositoryI {
+	return &ProductImageRepository{db: db}
+}
+
+// GetById implements [ProductImageRepositoryI].
+func (r *ProductImageRepository) GetById(ctx context.Context, id uint) (*models.ProductImage, error) {
+	var image models.ProductImage
+	if err := r.db.WithContext(ctx).First(&image, id).Error; err != nil {
+		return nil, err
+	}
+	return &image, nil
+}
+
+// GetAllByProductId implements [ProductImageRepositoryI]. The images come in
+// position order.
+func (r *ProductImageRepository) GetAllByProductId(ctx context.Context, productId uint) ([]*models.ProductImage, error) {
+	var images []*models.ProductImage
+	if err := r.db.WithContext(ctx).
+		Where("product_id = ?", productId).
+		Order("position, id").
+		Find(&images).Error; err != nil {
+		return nil, err
+	}
+	return images, nil
+}
+
+// Save implements [ProductImageRepositoryI].
+func (r *ProductImageRepository) Save(ctx context.Context, image *models.ProductImage) error {
+	return r.db.WithContext(ctx).Omit("Product").Save(image).Error
+}
+
+// SaveAll implements [ProductImageRepositoryI]. The images are saved in one
+// transaction, so moving the primary flag or reordering never shows half
+// done.
+func (r *ProductImageRepository) SaveAll(ctx context.Context, images []*models.ProductImage) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, image := range images {
+			if err := tx.Omit("Product").Save(image).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Delete implements [ProductImageRepositoryI]. The row is deleted for good;
+// its files go with it, so there is nothing to restore.
+func (r *ProductImageRepository) Delete(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Unscoped().Delete(&models.ProductImage{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
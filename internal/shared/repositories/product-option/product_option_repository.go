@@ -0,0 +1,93 @@
+package productoption
+
+import (
+	"commerce/internal/shared/models"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type ProductOptionRepositoryI interface {
+	GetById(ctx context.Context, id uint) (*models.ProductOption, error)
+	GetAllByProductId(ctx context.Context, productId uint) ([]*models.ProductOption, error)
+	Save(ctx context.Context, option *models.ProductOption) error
+	Delete(ctx context.Context, id uint) error
+}
+
+type ProductOptionRepository struct {
+	db *gorm.DB
+}
+
+func NewProductOptionRepository(db *gorm.DB) ProductOptionRepositoryI {
+	return &ProductOptionRepository{db: db}
+}
+
+func byPosition(db *gorm.DB) *gorm.DB {
+	return db.Order("position, id")
+}
+
+// GetById implements [ProductOptionRepositoryI].
+func (r *ProductOptionRepository) GetById(ctx context.Context, id uint) (*models.ProductOption, error) {
+	var option models.ProductOption
+	if err := r.db.WithContext(ctx).Preload("Values", byPosition).First(&option, id).Error; err != nil {
+		return nil, err
+	}
+	return &option, nil
+}
+
+// GetAllByProductId implements [ProductOptionRepositoryI].
+func (r *ProductOptionRepository) GetAllByProductId(ctx context.Context, productId uint) ([]*models.ProductOption, error) {
+	var options []*models.ProductOption
+	if err := r.db.WithContext(ctx).
+		Preload("Values", byPosition).
+		Where("product_id = ?", productId).
+		Scopes(byPosition).
+		Find(&options).Error; err != nil {
+		return nil, err
+	}
+	return options, nil
+}
+
+// Save implements [ProductOptionRepositoryI]. Values missing from the option
+// are deleted, so variants made of them have to go first.
+func (r *ProductOptionRepository) Save(ctx context.Context, option *models.ProductOption) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if option.Id == 0 {
+			return tx.Create(option).Error
+		}
+		if err := tx.Omit("Values").Save(option).Error; err != nil {
+			return err
+		}
+		keep := make([]uint, 0, len(option.Values))
+		for i := range option.Values {
+			value := &option.Values[i]
+			value.OptionId = option.Id
+			if err := tx.Save(value).Error; err != nil {
+				return err
+			}
+			keep = append(keep, value.Id)
+		}
+		removed := tx.Where("option_id = ?", option.Id)
+		if len(keep) > 0 {
+			removed = removed.Where("id NOT IN ?", keep)
+		}
+		return removed.Delete(&models.OptionValue{}).Error
+	})
+}
+
+// Delete implements [ProductOptionRepositoryI].
+func (r *ProductOptionRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("option_id = ?", id).Delete(&models.OptionValue{}).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&models.ProductOption{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
@@ -0,0 +1,64 @@
+package productprice
+
+import (
+	"commerce/internal/shared/models"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type ProductPriceRepositoryI interface {
+	GetById(ctx context.Context, id uint) (*models.ProductPrice, error)
+	GetAllByProductId(ctx context.Context, productId uint) ([]*models.ProductPrice, error)
+	Save(ctx context.Context, price *models.ProductPrice) error
+	Delete(ctx context.Context, id uint) error
+}
+
+type ProductPriceRepository struct {
+	db *gorm.DB
+}
+
+func NewProductPriceRepository(db *gorm.DB) ProductPriceRepositoryI {
+	return &ProductPriceRepository{db: db}
+}
+
+// GetById implements [ProductPriceRepositoryI].
+func (r *ProductPriceRepository) GetById(ctx context.Context, id uint) (*models.ProductPrice, error) {
+	var price models.ProductPrice
+	if err := r.db.WithContext(ctx).First(&price, id).Error; err != nil {
+		return nil, err
+	}
+	return &price, nil
+}
+
+// GetAllByProductId implements [ProductPriceRepositoryI]. The prices of the
+// product and its variants come latest start first, which is the order of
+// its price history.
+func (r *ProductPriceRepository) GetAllByProductId(ctx context.Context, productId uint) ([]*models.ProductPrice, error) {
+	var prices []*models.ProductPrice
+	if err := r.db.WithContext(ctx).
+		Where("product_id = ?", productId).
+		Order("effective_from desc, id desc").
+		Find(&prices).Error; err != nil {
+		return nil, err
+	}
+	return prices, nil
+}
+
+// Save implements [ProductPriceRepositoryI].
+func (r *ProductPriceRepository) Save(ctx context.Context, price *models.ProductPrice) error {
+	return r.db.WithContext(ctx).Omit("Product", "Variant").Save(price).Error
+}
+
+// Delete implements [ProductPriceRepositoryI]. The row is deleted for good,
+// as only prices that never took effect are deleted and they aren't history.
+func (r *ProductPriceRepository) Delete(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Unscoped().Delete(&models.ProductPrice{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
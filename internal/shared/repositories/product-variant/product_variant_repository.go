@@ -0,0 +1,92 @@
+package productvariant
+
+import (
+	"commerce/internal/shared/models"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type ProductVariantRepositoryI interface {
+	GetById(ctx context.Context, id uint) (*models.ProductVariant, error)
+	GetAllByProductId(ctx context.Context, productId uint) ([]*models.ProductVariant, error)
+	Save(ctx context.Context, variant *models.ProductVariant) error
+	Delete(ctx context.Context, id uint) error
+	AdjustStock(ctx context.Context, id uint, quantity int) error
+}
+
+type ProductVariantRepository struct {
+	db *gorm.DB
+}
+
+func NewProductVariantRepository(db *gorm.DB) ProductVariantRepositoryI {
+	return &ProductVariantRepository{db: db}
+}
+
+// GetById implements [ProductVariantRepositoryI].
+func (r *ProductVariantRepository) GetById(ctx context.Context, id uint) (*models.ProductVariant, error) {
+	var variant models.ProductVariant
+	if err := r.db.WithContext(ctx).Preload("OptionValues.OptionValue").First(&variant, id).Error; err != nil {
+		return nil, err
+	}
+	return &variant, nil
+}
+
+// GetAllByProductId implements [ProductVariantRepositoryI].
+func (r *ProductVariantRepository) GetAllByProductId(ctx context.Context, productId uint) ([]*models.ProductVariant, error) {
+	var variants []*models.ProductVariant
+	if err := r.db.WithContext(ctx).
+		Preload("OptionValues.OptionValue").
+		Where("product_id = ?", productId).
+		Order("id").
+		Find(&variants).Error; err != nil {
+		return nil, err
+	}
+	return variants, nil
+}
+
+// Save implements [ProductVariantRepositoryI]. The variant's option values
+// are replaced with the ones it is given.
+func (r *ProductVariantRepository) Save(ctx context.Context, variant *models.ProductVariant) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if variant.Id == 0 {
+			return tx.Omit("OptionValues.OptionValue").Create(variant).Error
+		}
+		if err := tx.Omit("OptionValues").Save(variant).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("variant_id = ?", variant.Id).Delete(&models.VariantOptionValue{}).Error; err != nil {
+			return err
+		}
+		for i := range variant.OptionValues {
+			variant.OptionValues[i].Id = 0
+			variant.OptionValues[i].VariantId = variant.Id
+		}
+		if len(variant.OptionValues) == 0 {
+			return nil
+		}
+		return tx.Omit("OptionValue").Create(&variant.OptionValues).Error
+	})
+}
+
+// Delete implements [ProductVariantRepositoryI]. The variant is soft deleted
+// so orders for it keep pointing at it.
+func (r *ProductVariantRepository) Delete(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Delete(&models.ProductVariant{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// AdjustStock implements [ProductVariantRepositoryI]. The change is applied
+// in SQL so concurrent adjustments don't overwrite each other.
+func (r *ProductVariantRepository) AdjustStock(ctx context.Context, id uint, quantity int) error {
+	return r.db.WithContext(ctx).
+		Model(&models.ProductVariant{}).
+		Where("id = ?", id).
+		Update("stock", gorm.Expr("stock + ?", quantity)).Error
+}
@@ -2,17 +2,74 @@ package product
 
 import (
 	"commerce/internal/shared/models"
+	"commerce/internal/shared/repositories/query"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// ErrUnknownCategory is returned for category ids that don't exist or are
+// deleted.
+var ErrUnknownCategory = errors.New("unknown category")
+
+// ErrDuplicateCategory is returned when a product is added to a category it
+// is already in.
+var ErrDuplicateCategory = errors.New("product is already in the category")
+
 type ProductRepositoryI interface {
-	GetById(id uint) (*models.Product, error)
-	GetAll() ([]*models.Product, error)
-	GetAllByCategoryId(categoryId uint) ([]*models.Product, error)
-	Save(product *models.Product) error
-	Delete(id uint, hard bool) error
+	GetById(ctx context.Context, id uint) (*models.Product, error)
+	GetAll(ctx context.Context, opts query.Options) (*query.Page[models.Product], error)
+	GetAllByCategoryId(ctx context.Context, categoryId uint, opts query.Options) (*query.Page[models.Product], error)
+	Save(ctx context.Context, product *models.Product) error
+	Update(ctx context.Context, product *models.Product, categoryIds []uint, setStock bool) error
+	SetCategories(ctx context.Context, productId uint, categoryIds []uint) error
+	AddCategory(ctx context.Context, productId uint, categoryId uint) error
+	RemoveCategory(ctx context.Context, productId uint, categoryId uint) error
+	Delete(ctx context.Context, id uint, hard bool) error
+	Restore(ctx context.Context, id uint) error
+	AdjustStock(ctx context.Context, id uint, quantity int) error
+	Search(ctx context.Context, filter SearchFilter, opts query.Options) (*SearchResult, error)
+}
+
+// activePrice is the price a product sells for now, worked out in SQL the way
+// [models.Product.PriceAt] does it, so lists and search sort, filter and
+// bucket by what customers pay.
+const activePrice = `COALESCE((SELECT product_prices.price FROM product_prices
+	WHERE product_prices.product_id = products.id AND product_prices.variant_id IS NULL
+	AND product_prices.deleted_date IS NULL AND product_prices.effective_from <= now()
+	AND (product_prices.effective_to IS NULL OR product_prices.effective_to > now())
+	ORDER BY product_prices.effective_to IS NULL, product_prices.effective_from DESC, product_prices.id DESC
+	LIMIT 1), products.price)`
+
+// listFields are what product lists can be sorted and filtered by.
+var listFields = query.Fields{
+	Sort: map[string]string{
+		"name":         "products.name",
+		"price":        activePrice,
+		"stock":        "products.stock",
+		"sku":          "products.sku",
+		"created_date": "products.created_date",
+	},
+	Values: map[string]func(item any) any{
+		"price": func(item any) any {
+			price, _ := item.(*models.Product).PriceAt(time.Now())
+			return price
+		},
+	},
+	Filter: map[string]query.Filter{
+		"name":        {Condition: "products.name ILIKE '%' || ? || '%'"},
+		"sku":         {Condition: "products.sku = ?"},
+		"min_price":   {Condition: activePrice + " >= ?", Parse: query.Float},
+		"max_price":   {Condition: activePrice + " <= ?", Parse: query.Float},
+		"is_active":   {Condition: "products.is_active = ?", Parse: query.Bool},
+		"is_featured": {Condition: "products.is_featured = ?", Parse: query.Bool},
+	},
+	Default: []query.Sort{{Field: "created_date", Desc: true}},
 }
 
 type ProductRepository struct {
@@ -24,53 +81,212 @@ func NewProductRepository(db *gorm.DB) ProductRepositoryI {
 }
 
 // Delete implements [ProductRepositoryI].
-func (p *ProductRepository) Delete(id uint, hard bool) error {
+func (p *ProductRepository) Delete(ctx context.Context, id uint, hard bool) error {
 	if hard {
-		return p.db.Delete(models.Product{}, id).Error
+		return p.db.WithContext(ctx).Unscoped().Delete(models.Product{}, id).Error
 	}
 	var product models.Product
-	if err := p.db.First(&product, id).Error; err != nil {
+	if err := p.db.WithContext(ctx).First(&product, id).Error; err != nil {
 		return err
 	}
-	product.DeletedDate = time.Now()
-	return p.db.Save(&product).Error
+	return p.db.WithContext(ctx).Delete(&product).Error
 }
 
-// GetAll implements [ProductRepositoryI].
-func (p *ProductRepository) GetAll() ([]*models.Product, error) {
-	var products []*models.Product
-	if err := p.db.Order("created_date desc").Find(&products).Error; err != nil {
-		return nil, err
+// Restore implements [ProductRepositoryI]. It returns
+// [gorm.ErrRecordNotFound] unless the product exists and is deleted.
+func (p *ProductRepository) Restore(ctx context.Context, id uint) error {
+	result := p.db.WithContext(ctx).
+		Unscoped().
+		Model(&models.Product{}).
+		Where("id = ? AND deleted_date IS NOT NULL", id).
+		Update("deleted_date", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
 	}
-	return products, nil
+	return nil
+}
+
+// GetAll implements [ProductRepositoryI].
+func (p *ProductRepository) GetAll(ctx context.Context, opts query.Options) (*query.Page[models.Product], error) {
+	return query.Find[models.Product](p.db.WithContext(ctx).Scopes(withCategories, withImages, withPrices), opts, listFields)
 }
 
 // GetAllByCategoryId implements [ProductRepositoryI].
-func (p *ProductRepository) GetAllByCategoryId(categoryId uint) ([]*models.Product, error) {
-	var products []*models.Product
-	if err := p.db.
-		Joins("JOIN product_categories on product_categories.product_id = products.id").
-		Where("product_categories.category_id = ?", categoryId).
-		Order("name").
-		Find(&products).Error; err != nil {
-		return nil, err
-	}
-	return products, nil
+func (p *ProductRepository) GetAllByCategoryId(ctx context.Context, categoryId uint, opts query.Options) (*query.Page[models.Product], error) {
+	return query.Find[models.Product](p.db.WithContext(ctx).
+		Scopes(withCategories, withImages, withPrices).
+		Joins("JOIN product_categories on product_categories.product_id = products.id AND product_categories.deleted_date IS NULL").
+		Where("product_categories.category_id = ?", categoryId), opts, listFields)
 }
 
-// GetById implements [ProductRepositoryI].
-func (p *ProductRepository) GetById(id uint) (*models.Product, error) {
+// GetById implements [ProductRepositoryI]. The product comes with its
+// categories, images, options, variants and the prices in effect.
+func (p *ProductRepository) GetById(ctx context.Context, id uint) (*models.Product, error) {
+	byPosition := func(db *gorm.DB) *gorm.DB { return db.Order("position, id") }
 	var product models.Product
-	if err := p.db.First(&product, id).Error; err != nil {
+	if err := p.db.WithContext(ctx).
+		Scopes(withCategories, withImages, withPrices).
+		Preload("Options", byPosition).
+		Preload("Options.Values", byPosition).
+		Preload("Variants", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+		Preload("Variants.OptionValues.OptionValue").
+		First(&product, id).Error; err != nil {
 		return nil, err
 	}
 	return &product, nil
 }
 
 // Save implements [ProductRepositoryI].
-func (p *ProductRepository) Save(product *models.Product) error {
+func (p *ProductRepository) Save(ctx context.Context, product *models.Product) error {
 	if product.Id == 0 {
-		return p.db.Create(product).Error
+		return p.db.WithContext(ctx).Create(product).Error
 	}
-	return p.db.Save(product).Error
+	return p.db.WithContext(ctx).Save(product).Error
+}
+
+// Update implements [ProductRepositoryI]. The product's categories are
+// replaced with categoryIds in the same transaction, unless it is nil. A
+// product without an id is created. An existing product's stock is left alone
+// unless setStock is true: orders move it with AdjustStock (ADR-019), and
+// writing back the value read before the update would undo them.
+func (p *ProductRepository) Update(ctx context.Context, product *models.Product, categoryIds []uint, setStock bool) error {
+	return p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		write := tx.Omit(clause.Associations)
+		if product.Id != 0 && !setStock {
+			write = tx.Omit(clause.Associations, "stock")
+		}
+		if err := write.Save(product).Error; err != nil {
+			return err
+		}
+		if categoryIds == nil {
+			return nil
+		}
+		return NewProductRepository(tx).SetCategories(ctx, product.Id, categoryIds)
+	})
+}
+
+// SetCategories implements [ProductRepositoryI]. Links to categories not in
+// categoryIds are deleted, for good rather than soft deleted, and missing
+// ones added. It returns [ErrUnknownCategory] if any of the categories don't
+// exist.
+func (p *ProductRepository) SetCategories(ctx context.Context, productId uint, categoryIds []uint) error {
+	return p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkCategories(tx, categoryIds); err != nil {
+			return err
+		}
+		removed := tx.Unscoped().Where("product_id = ?", productId)
+		if len(categoryIds) > 0 {
+			removed = removed.Where("category_id NOT IN ?", categoryIds)
+		}
+		if err := removed.Delete(&models.ProductCategory{}).Error; err != nil {
+			return err
+		}
+		var existing []uint
+		if err := tx.Model(&models.ProductCategory{}).
+			Where("product_id = ?", productId).
+			Pluck("category_id", &existing).Error; err != nil {
+			return err
+		}
+		links := []models.ProductCategory{}
+		for _, categoryId := range categoryIds {
+			if !slices.Contains(existing, categoryId) {
+				existing = append(existing, categoryId)
+				links = append(links, models.ProductCategory{ProductId: productId, CategoryId: categoryId})
+			}
+		}
+		if len(links) == 0 {
+			return nil
+		}
+		return tx.Create(&links).Error
+	})
+}
+
+// AddCategory implements [ProductRepositoryI]. It returns
+// [ErrUnknownCategory] if the category doesn't exist and
+// [ErrDuplicateCategory] if the product is already in it.
+func (p *ProductRepository) AddCategory(ctx context.Context, productId uint, categoryId uint) error {
+	return p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkCategories(tx, []uint{categoryId}); err != nil {
+			return err
+		}
+		link := models.ProductCategory{ProductId: productId, CategoryId: categoryId}
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&link)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrDuplicateCategory
+		}
+		return nil
+	})
+}
+
+// RemoveCategory implements [ProductRepositoryI]. It returns
+// [gorm.ErrRecordNotFound] if the product isn't in the category.
+func (p *ProductRepository) RemoveCategory(ctx context.Context, productId uint, categoryId uint) error {
+	result := p.db.WithContext(ctx).
+		Unscoped().
+		Where("product_id = ? AND category_id = ?", productId, categoryId).
+		Delete(&models.ProductCategory{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// checkCategories fails with [ErrUnknownCategory] unless every id is a
+// category that isn't deleted.
+func checkCategories(db *gorm.DB, categoryIds []uint) error {
+	ids := slices.Compact(slices.Sorted(slices.Values(categoryIds)))
+	if len(ids) == 0 {
+		return nil
+	}
+	var found []uint
+	if err := db.Model(&models.Category{}).Where("id IN ?", ids).Pluck("id", &found).Error; err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if !slices.Contains(found, id) {
+			return fmt.Errorf("%w: %d", ErrUnknownCategory, id)
+		}
+	}
+	return nil
+}
+
+// withPrices preloads the prices in effect now, for
+// [models.Product.PriceAt]. The rest of a product's price history is left to
+// the product-price repository.
+func withPrices(db *gorm.DB) *gorm.DB {
+	return db.Preload("Prices", "effective_from <= now() AND (effective_to IS NULL OR effective_to > now())")
+}
+
+// withImages preloads a product's images in position order.
+func withImages(db *gorm.DB) *gorm.DB {
+	return db.Preload("Images", func(db *gorm.DB) *gorm.DB { return db.Order("position, id") })
+}
+
+// withCategories preloads a product's links to categories and, through them,
+// the categories. Links to deleted categories are left out.
+func withCategories(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("ProductCategories", func(db *gorm.DB) *gorm.DB {
+			live := db.Session(&gorm.Session{NewDB: true}).Model(&models.Category{}).Select("id")
+			return db.Where("category_id IN (?)", live).Order("id")
+		}).
+		Preload("ProductCategories.Category")
+}
+
+// AdjustStock implements [ProductRepositoryI]. The change is applied in SQL so
+// concurrent adjustments don't overwrite each other.
+func (p *ProductRepository) AdjustStock(ctx context.Context, id uint, quantity int) error {
+	return p.db.WithContext(ctx).
+		Model(&models.Product{}).
+		Where("id = ?", id).
+		Update("stock", gorm.Expr("stock + ?", quantity)).Error
 }
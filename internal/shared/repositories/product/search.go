@@ -0,0 +1,243 @@
+package product
+
+import (
+	"commerce/internal/shared/models"
+	"commerce/internal/shared/repositories/query"
+	"context"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PriceBuckets are the upper bounds of the price facet's buckets. The last
+// bucket has no upper bound.
+var PriceBuckets = []float64{25, 50, 100, 250, 500}
+
+// SearchFilter narrows a catalogue search. Only active products are searched.
+// Zero fields don't filter; CategoryId takes in its descendants as well, and
+// InStock counts a product in stock when any of its variants is.
+type SearchFilter struct {
+	Text       string
+	MinPrice   *float64
+	MaxPrice   *float64
+	CategoryId *uint
+	InStock    bool
+	Featured   bool
+	MinRating  *float64
+}
+
+// CategoryFacet counts the results assigned to a category.
+type CategoryFacet struct {
+	CategoryId uint
+	Name       string
+	Count      int64
+}
+
+// PriceFacet counts the results priced from Min up to, but not including,
+// Max. Max is nil for the last bucket.
+type PriceFacet struct {
+	Min   float64
+	Max   *float64
+	Count int64
+}
+
+// SearchResult is a page of products with the facets of the whole search.
+// Each facet is counted without its own filter, so picking a category still
+// shows how many results the other categories would have.
+type SearchResult struct {
+	Page       *query.Page[models.Product]
+	Categories []CategoryFacet
+	Prices     []PriceFacet
+}
+
+// searchSort are the fields search results can be sorted by. Relevance is the
+// default when there is text to rank by.
+var searchSort = map[string]string{
+	"relevance":    "",
+	"name":         "products.name",
+	"price":        activePrice,
+	"created_date": "products.created_date",
+}
+
+type facet int
+
+const (
+	noFacet facet = iota
+	categoryFacet
+	priceFacet
+)
+
+// Search implements [ProductRepositoryI]. The text is matched against the
+// products' search_vector with websearch syntax, so quotes and - work as on a
+// search engine.
+func (p *ProductRepository) Search(ctx context.Context, filter SearchFilter, opts query.Options) (*SearchResult, error) {
+	limit, offset, err := query.Window(opts)
+	if err != nil {
+		return nil, err
+	}
+	order, err := searchOrder(filter, opts.Sort)
+	if err != nil {
+		return nil, err
+	}
+	db := p.db.WithContext(ctx)
+
+	var total int64
+	if err := db.Model(&models.Product{}).Scopes(filter.scope(noFacet)).Count(&total).Error; err != nil {
+		return nil, err
+	}
+	var products []*models.Product
+	if err := db.Scopes(filter.scope(noFacet), withCategories, withImages, withPrices).
+		Order(order).
+		Offset(offset).
+		Limit(limit + 1).
+		Find(&products).Error; err != nil {
+		return nil, err
+	}
+	page := &query.Page[models.Product]{Items: products, Total: total}
+	if len(products) > limit {
+		page.Items = products[:limit]
+		page.NextCursor = query.OffsetCursor(offset + limit)
+	}
+
+	categories, err := p.categoryFacets(db, filter)
+	if err != nil {
+		return nil, err
+	}
+	prices, err := p.priceFacets(db, filter)
+	if err != nil {
+		return nil, err
+	}
+	return &SearchResult{Page: page, Categories: categories, Prices: prices}, nil
+}
+
+func (p *ProductRepository) categoryFacets(db *gorm.DB, filter SearchFilter) ([]CategoryFacet, error) {
+	matches := db.Model(&models.Product{}).Scopes(filter.scope(categoryFacet)).Select("products.id")
+	var facets []CategoryFacet
+	if err := db.Table("product_categories").
+		Select("categories.id AS category_id, categories.name, COUNT(DISTINCT product_categories.product_id) AS count").
+		Joins("JOIN categories ON categories.id = product_categories.category_id AND categories.deleted_date IS NULL").
+		Where("product_categories.deleted_date IS NULL AND product_categories.product_id IN (?)", matches).
+		Group("categories.id, categories.name").
+		Order("count desc, categories.name").
+		Scan(&facets).Error; err != nil {
+		return nil, err
+	}
+	return facets, nil
+}
+
+func (p *ProductRepository) priceFacets(db *gorm.DB, filter SearchFilter) ([]PriceFacet, error) {
+	bounds := make([]string, len(PriceBuckets))
+	for i, bound := range PriceBuckets {
+		bounds[i] = fmt.Sprint(bound)
+	}
+	var counts []struct {
+		Bucket int
+		Count  int64
+	}
+	if err := db.Model(&models.Product{}).
+		Scopes(filter.scope(priceFacet)).
+		Select("width_bucket(" + activePrice + ", ARRAY[" + strings.Join(bounds, ",") + "]::numeric[]) AS bucket, COUNT(*) AS count").
+		Group("bucket").
+		Scan(&counts).Error; err != nil {
+		return nil, err
+	}
+
+	facets := make([]PriceFacet, len(PriceBuckets)+1)
+	for i := range facets {
+		if i > 0 {
+			facets[i].Min = PriceBuckets[i-1]
+		}
+		if i < len(PriceBuckets) {
+			facets[i].Max = &PriceBuckets[i]
+		}
+	}
+	for _, c := range counts {
+		facets[c.Bucket].Count = c.Count
+	}
+	return facets, nil
+}
+
+// scope applies the filter, leaving out the one the facet being counted is
+// on.
+func (f SearchFilter) scope(counting facet) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		db = db.Where("products.is_active")
+		if f.Text != "" {
+			db = db.Where("products.search_vector @@ websearch_to_tsquery('english', ?)", f.Text)
+		}
+		if counting != priceFacet {
+			if f.MinPrice != nil {
+				db = db.Where(activePrice+" >= ?", *f.MinPrice)
+			}
+			if f.MaxPrice != nil {
+				db = db.Where(activePrice+" <= ?", *f.MaxPrice)
+			}
+		}
+		if counting != categoryFacet && f.CategoryId != nil {
+			db = db.Where(`products.id IN (
+				SELECT product_categories.product_id FROM product_categories
+				WHERE product_categories.deleted_date IS NULL AND product_categories.category_id IN (
+					WITH RECURSIVE tree AS (
+						SELECT id FROM categories WHERE id = ? AND deleted_date IS NULL
+						UNION
+						SELECT categories.id FROM categories JOIN tree ON categories.parent_id = tree.id
+						WHERE categories.deleted_date IS NULL
+					)
+					SELECT id FROM tree))`, *f.CategoryId)
+		}
+		if f.InStock {
+			db = db.Where(`(products.stock > 0 OR EXISTS (
+				SELECT 1 FROM product_variants WHERE product_variants.product_id = products.id
+				AND product_variants.is_active AND product_variants.stock > 0 AND product_variants.deleted_date IS NULL))`)
+		}
+		if f.Featured {
+			db = db.Where("products.is_featured")
+		}
+		if f.MinRating != nil {
+			db = db.Where(`(SELECT AVG(reviews.rating) FROM reviews
+				WHERE reviews.product_id = products.id AND reviews.deleted_date IS NULL) >= ?`, *f.MinRating)
+		}
+		return db
+	}
+}
+
+func searchOrder(filter SearchFilter, sorts []query.Sort) (clause.OrderBy, error) {
+	if len(sorts) == 0 {
+		sorts = []query.Sort{{Field: "created_date", Desc: true}}
+		if filter.Text != "" {
+			sorts = []query.Sort{{Field: "relevance", Desc: true}}
+		}
+	}
+	var order clause.OrderBy
+	for _, s := range sorts {
+		column, ok := searchSort[s.Field]
+		if !ok {
+			return order, fmt.Errorf("%w: can't sort by %q", query.ErrInvalid, s.Field)
+		}
+		var expression clause.Expression = clause.Expr{SQL: column}
+		if s.Field == "relevance" {
+			if filter.Text == "" {
+				continue
+			}
+			expression = clause.Expr{
+				SQL:  "ts_rank(products.search_vector, websearch_to_tsquery('english', ?))",
+				Vars: []any{filter.Text},
+			}
+		}
+		if s.Desc {
+			expression = clause.Expr{SQL: "? DESC", Vars: []any{expression}}
+		}
+		order.Expression = appendOrder(order.Expression, expression)
+	}
+	order.Expression = appendOrder(order.Expression, clause.Expr{SQL: "products.id"})
+	return order, nil
+}
+
+func appendOrder(order clause.Expression, next clause.Expression) clause.Expression {
+	if order == nil {
+		return next
+	}
+	return clause.Expr{SQL: "?, ?", Vars: []any{order, next}}
+}
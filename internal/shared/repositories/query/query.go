@@ -0,0 +1,326 @@
+package query
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	DefaultLimit = 50
+	MaxLimit     = 200
+)
+
+// ErrInvalid is returned for options a list can't honour: a sort or filter
+// field that isn't allowed, a limit out of range, or a cursor that is
+// malformed or from a list sorted differently.
+var ErrInvalid = errors.New("invalid list options")
+
+// Sort orders a list by one field.
+type Sort struct {
+	Field string
+	Desc  bool
+}
+
+// Options page, sort and filter a list. Field names are the API's, not
+// columns; each repository maps the ones it allows in its [Fields]. A page
+// starts at Cursor, taken from the previous page, or else skips Offset rows.
+type Options struct {
+	Limit          int
+	Offset         int
+	Cursor         string
+	Sort           []Sort
+	Filters        map[string]string
+	IncludeDeleted bool
+}
+
+// Fields is a repository's whitelist for [Options]. Sort maps a field to its
+// column, qualified when the list joins other tables; the columns must not be
+// nullable, as cursors compare against them. A sort column can also be an
+// expression, when Values gives the expression's value for a loaded item for
+// cursors to hold; it goes through JSON, so it should be a number or a
+// string. Default is the order used when none is asked for. The primary key
+// is always added as the last sort so the order is total.
+type Fields struct {
+	Sort    map[string]string
+	Values  map[string]func(item any) any
+	Filter  map[string]Filter
+	Default []Sort
+}
+
+// Filter is a condition with a single placeholder for the filter's value.
+// Parse turns the value into the column's type; values are passed as strings
+// when it is nil.
+type Filter struct {
+	Condition string
+	Parse     func(value string) (any, error)
+}
+
+// Parsers for [Filter.Parse].
+func Bool(value string) (any, error)  { return strconv.ParseBool(value) }
+func Int(value string) (any, error)   { return strconv.ParseInt(value, 10, 64) }
+func Float(value string) (any, error) { return strconv.ParseFloat(value, 64) }
+func Time(value string) (any, error)  { return time.Parse(time.RFC3339, value) }
+
+// Page is one page of a list. NextCursor is empty on the last page, and Total
+// counts every row the filters match.
+type Page[M any] struct {
+	Items      []*M
+	NextCursor string
+	Total      int64
+}
+
+type cursor struct {
+	Sort   string            `json:"s,omitempty"`
+	Values []json.RawMessage `json:"v,omitempty"`
+	Offset int               `json:"o,omitempty"`
+}
+
+type column struct {
+	field string
+	name  string
+	desc  bool
+	value func(item any) any
+}
+
+// Find loads the page of M that opts select from db, which may already be
+// scoped, for example to one user's rows.
+func Find[M any](db *gorm.DB, opts Options, fields Fields) (*Page[M], error) {
+	limit, err := limitOf(opts)
+	if err != nil {
+		return nil, err
+	}
+	stmt := &gorm.Statement{DB: db, Context: db.Statement.Context}
+	if err := stmt.Parse(new(M)); err != nil {
+		return nil, err
+	}
+	columns, err := order(stmt, opts.Sort, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	base := db.Model(new(M))
+	if opts.IncludeDeleted {
+		base = base.Unscoped()
+	}
+	for field, value := range opts.Filters {
+		filter, ok := fields.Filter[field]
+		if !ok {
+			return nil, fmt.Errorf("%w: can't filter by %q", ErrInvalid, field)
+		}
+		var arg any = value
+		if filter.Parse != nil {
+			if arg, err = filter.Parse(value); err != nil {
+				return nil, fmt.Errorf("%w: bad value for %q", ErrInvalid, field)
+			}
+		}
+		base = base.Where(filter.Condition, arg)
+	}
+	base = base.Session(&gorm.Session{})
+
+	q := base
+	if opts.Cursor != "" {
+		condition, args, err := after(stmt, columns, opts.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		q = q.Where(condition, args...)
+	}
+
+	var total int64
+	if err := base.Count(&total).Error; err != nil {
+		return nil, err
+	}
+	for _, c := range columns {
+		if c.desc {
+			q = q.Order(c.name + " desc")
+		} else {
+			q = q.Order(c.name)
+		}
+	}
+	var items []*M
+	if err := q.Offset(opts.Offset).Limit(limit + 1).Find(&items).Error; err != nil {
+		return nil, err
+	}
+
+	page := &Page[M]{Items: items, Total: total}
+	if len(items) > limit {
+		page.Items = items[:limit]
+		if page.NextCursor, err = encode(stmt, columns, page.Items[limit-1]); err != nil {
+			return nil, err
+		}
+	}
+	return page, nil
+}
+
+// Window resolves the limit and offset of lists that can't page by keyset
+// because they aren't ordered by columns, such as search results ordered by
+// relevance. Their cursors hold an offset and are made by [OffsetCursor].
+func Window(opts Options) (limit int, offset int, err error) {
+	if limit, err = limitOf(opts); err != nil {
+		return 0, 0, err
+	}
+	if opts.Cursor == "" {
+		return limit, opts.Offset, nil
+	}
+	c, err := decode(opts.Cursor)
+	if err != nil || c.Sort != "" || c.Offset <= 0 {
+		return 0, 0, fmt.Errorf("%w: bad cursor", ErrInvalid)
+	}
+	return limit, c.Offset, nil
+}
+
+// OffsetCursor makes the cursor for a [Window] list's page starting at offset.
+func OffsetCursor(offset int) string {
+	b, _ := json.Marshal(cursor{Offset: offset})
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decode(encoded string) (*cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+	var c cursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func limitOf(opts Options) (int, error) {
+	switch {
+	case opts.Limit < 0 || opts.Limit > MaxLimit:
+		return 0, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalid, MaxLimit)
+	case opts.Offset < 0:
+		return 0, fmt.Errorf("%w: offset can't be negative", ErrInvalid)
+	case opts.Offset > 0 && opts.Cursor != "":
+		return 0, fmt.Errorf("%w: use either a cursor or an offset", ErrInvalid)
+	case opts.Limit == 0:
+		return DefaultLimit, nil
+	}
+	return opts.Limit, nil
+}
+
+// order resolves the sort to columns, ending with the primary key.
+func order(stmt *gorm.Statement, sorts []Sort, fields Fields) ([]column, error) {
+	if len(sorts) == 0 {
+		sorts = fields.Default
+	}
+	primaryKey := stmt.Schema.Table + "." + stmt.Schema.PrioritizedPrimaryField.DBName
+	columns := make([]column, 0, len(sorts)+1)
+	for _, s := range sorts {
+		name, ok := fields.Sort[s.Field]
+		if !ok {
+			return nil, fmt.Errorf("%w: can't sort by %q", ErrInvalid, s.Field)
+		}
+		columns = append(columns, column{field: s.Field, name: name, desc: s.Desc, value: fields.Values[s.Field]})
+		if name == primaryKey || name == stmt.Schema.PrioritizedPrimaryField.DBName {
+			return columns, nil
+		}
+	}
+	return append(columns, column{field: stmt.Schema.PrioritizedPrimaryField.DBName, name: primaryKey}), nil
+}
+
+// key identifies a sort, so a cursor isn't used with a different one.
+func key(columns []column) string {
+	parts := make([]string, len(columns))
+	for i, c := range columns {
+		parts[i] = c.field
+		if c.desc {
+			parts[i] = "-" + c.field
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// encode makes the cursor for the rows after item.
+func encode(stmt *gorm.Statement, columns []column, item any) (string, error) {
+	rv := reflect.Indirect(reflect.ValueOf(item))
+	c := cursor{Sort: key(columns), Values: make([]json.RawMessage, len(columns))}
+	for i, col := range columns {
+		var value any
+		if col.value != nil {
+			value = col.value(item)
+		} else {
+			field := stmt.Schema.LookUpField(unqualified(col.name))
+			if field == nil {
+				return "", fmt.Errorf("query: no field for column %q", col.name)
+			}
+			value, _ = field.ValueOf(stmt.Context, rv)
+		}
+		b, err := json.Marshal(value)
+		if err != nil {
+			return "", err
+		}
+		c.Values[i] = b
+	}
+	b, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// after returns the condition for rows that sort after the cursor's row:
+// (a > ?) OR (a = ? AND b > ?) and so on, with < for descending columns.
+func after(stmt *gorm.Statement, columns []column, encoded string) (string, []any, error) {
+	invalid := fmt.Errorf("%w: bad cursor", ErrInvalid)
+	c, err := decode(encoded)
+	if err != nil || c.Sort != key(columns) || len(c.Values) != len(columns) {
+		return "", nil, invalid
+	}
+	values := make([]any, len(columns))
+	for i, col := range columns {
+		if col.value != nil {
+			if err := json.Unmarshal(c.Values[i], &values[i]); err != nil {
+				return "", nil, invalid
+			}
+			continue
+		}
+		field := stmt.Schema.LookUpField(unqualified(col.name))
+		if field == nil {
+			return "", nil, fmt.Errorf("query: no field for column %q", col.name)
+		}
+		// A float32 read back as float64 keeps the decimal it was
+		// printed as, so it still equals the row's numeric column.
+		typ := field.FieldType
+		if typ.Kind() == reflect.Float32 {
+			typ = reflect.TypeOf(float64(0))
+		}
+		value := reflect.New(typ)
+		if err := json.Unmarshal(c.Values[i], value.Interface()); err != nil {
+			return "", nil, invalid
+		}
+		values[i] = value.Elem().Interface()
+	}
+
+	terms := make([]string, len(columns))
+	args := []any{}
+	for i, col := range columns {
+		parts := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			parts = append(parts, columns[j].name+" = ?")
+			args = append(args, values[j])
+		}
+		op := " > ?"
+		if col.desc {
+			op = " < ?"
+		}
+		parts = append(parts, col.name+op)
+		args = append(args, values[i])
+		terms[i] = "(" + strings.Join(parts, " AND ") + ")"
+	}
+	return "(" + strings.Join(terms, " OR ") + ")", args, nil
+}
+
+func unqualified(column string) string {
+	return column[strings.LastIndex(column, ".")+1:]
+}
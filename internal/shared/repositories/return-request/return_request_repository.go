@@ -0,0 +1,82 @@
+package returnrequest
+
+import (
+	"commerce/internal/shared/models"
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReturnRequestRepositoryI interface {
+	GetById(ctx context.Context, id uint) (*models.ReturnRequest, error)
+	GetByIdForUpdate(ctx context.Context, id uint) (*models.ReturnRequest, error)
+	GetAllByOrderId(ctx context.Context, orderId uint) ([]*models.ReturnRequest, error)
+	Save(ctx context.Context, returnRequest *models.ReturnRequest) error
+	UpdateStatus(ctx context.Context, id uint, status string) error
+}
+
+type ReturnRequestRepository struct {
+	db *gorm.DB
+}
+
+func NewReturnRequestRepository(db *gorm.DB) ReturnRequestRepositoryI {
+	return &ReturnRequestRepository{db: db}
+}
+
+// GetById implements [ReturnRequestRepositoryI].
+func (r *ReturnRequestRepository) GetById(ctx context.Context, id uint) (*models.ReturnRequest, error) {
+	var returnRequest models.ReturnRequest
+	if err := r.db.WithContext(ctx).
+		Preload("Order").
+		Preload("Items.OrderItem").
+		First(&returnRequest, id).Error; err != nil {
+		return nil, err
+	}
+	return &returnRequest, nil
+}
+
+// GetByIdForUpdate implements [ReturnRequestRepositoryI]. It is GetById with
+// the return's row locked until the transaction ends, so a concurrent caller
+// waits and then reads the status this one leaves behind.
+func (r *ReturnRequestRepository) GetByIdForUpdate(ctx context.Context, id uint) (*models.ReturnRequest, error) {
+	var returnRequest models.ReturnRequest
+	if err := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("Order").
+		Preload("Items.OrderItem").
+		First(&returnRequest, id).Error; err != nil {
+		return nil, err
+	}
+	return &returnRequest, nil
+}
+
+// GetAllByOrderId implements [ReturnRequestRepositoryI].
+func (r *ReturnRequestRepository) GetAllByOrderId(ctx context.Context, orderId uint) ([]*models.ReturnRequest, error) {
+	var returnRequests []*models.ReturnRequest
+	if err := r.db.WithContext(ctx).
+		Preload("Items").
+		Where("order_id = ?", orderId).
+		Order("created_date desc").
+		Find(&returnRequests).
+		Error; err != nil {
+		return nil, err
+	}
+	return returnRequests, nil
+}
+
+// Save implements [ReturnRequestRepositoryI].
+func (r *ReturnRequestRepository) Save(ctx context.Context, returnRequest *models.ReturnRequest) error {
+	if returnRequest.Id == 0 {
+		return r.db.WithContext(ctx).Create(returnRequest).Error
+	}
+	return r.db.WithContext(ctx).Omit("Order", "Items").Save(returnRequest).Error
+}
+
+// UpdateStatus implements [ReturnRequestRepositoryI].
+func (r *ReturnRequestRepository) UpdateStatus(ctx context.Context, id uint, status string) error {
+	return r.db.WithContext(ctx).
+		Model(&models.ReturnRequest{}).
+		Where("id = ?", id).
+		Update("status", status).Error
+}
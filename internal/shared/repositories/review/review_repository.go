@@ -2,16 +2,32 @@ package review
 
 import (
 	"commerce/internal/shared/models"
-	"time"
+	"commerce/internal/shared/repositories/query"
+	"context"
 
 	"gorm.io/gorm"
 )
 
 type ReviewRepositoryI interface {
-	GetById(id uint) (*models.Review, error)
-	GetByProductId(productId uint) ([]*models.Review, error)
-	Save(review *models.Review) error
-	Delete(id uint, hard bool) error
+	GetById(ctx context.Context, id uint) (*models.Review, error)
+	GetByProductId(ctx context.Context, productId uint, opts query.Options) (*query.Page[models.Review], error)
+	GetAllByUserId(ctx context.Context, userId uint, opts query.Options) (*query.Page[models.Review], error)
+	Save(ctx context.Context, review *models.Review) error
+	Delete(ctx context.Context, id uint, hard bool) error
+	AnonymizeByUserId(ctx context.Context, userId uint) error
+}
+
+// listFields are what review lists can be sorted and filtered by.
+var listFields = query.Fields{
+	Sort: map[string]string{
+		"rating":       "rating",
+		"created_date": "created_date",
+	},
+	Filter: map[string]query.Filter{
+		"rating":     {Condition: "rating = ?", Parse: query.Int},
+		"min_rating": {Condition: "rating >= ?", Parse: query.Int},
+	},
+	Default: []query.Sort{{Field: "created_date", Desc: true}},
 }
 
 type ReviewRepository struct {
@@ -22,38 +38,46 @@ func NewReviewRepository(db *gorm.DB) ReviewRepositoryI {
 	return &ReviewRepository{db: db}
 }
 
-func (r *ReviewRepository) GetById(id uint) (*models.Review, error) {
+func (r *ReviewRepository) GetById(ctx context.Context, id uint) (*models.Review, error) {
 	var review models.Review
-	if err := r.db.First(&review, id).Error; err != nil {
+	if err := r.db.WithContext(ctx).First(&review, id).Error; err != nil {
 		return nil, err
 	}
 	return &review, nil
 }
 
-func (r *ReviewRepository) GetByProductId(productId uint) ([]*models.Review, error) {
-	var reviews []*models.Review
-	if err := r.db.Where("product_id = ?", productId).Order("created_date desc").Find(&reviews).Error; err != nil {
-		return nil, err
-	}
-	return reviews, nil
+func (r *ReviewRepository) GetByProductId(ctx context.Context, productId uint, opts query.Options) (*query.Page[models.Review], error) {
+	return query.Find[models.Review](r.db.WithContext(ctx).Where("product_id = ?", productId), opts, listFields)
 }
 
-func (r *ReviewRepository) Save(review *models.Review) error {
+func (r *ReviewRepository) GetAllByUserId(ctx context.Context, userId uint, opts query.Options) (*query.Page[models.Review], error) {
+	return query.Find[models.Review](r.db.WithContext(ctx).Where("user_id = ?", userId), opts, listFields)
+}
+
+func (r *ReviewRepository) Save(ctx context.Context, review *models.Review) error {
 	if review.Id == 0 {
-		return r.db.Create(review).Error
+		return r.db.WithContext(ctx).Create(review).Error
 	}
-	return r.db.Save(review).Error
+	return r.db.WithContext(ctx).Save(review).Error
 }
 
-func (r *ReviewRepository) Delete(id uint, hard bool) error {
+func (r *ReviewRepository) Delete(ctx context.Context, id uint, hard bool) error {
 	if hard {
-		return r.db.Delete(&models.Review{}, id).Error
+		return r.db.WithContext(ctx).Unscoped().Delete(&models.Review{}, id).Error
 	} else {
 		var review models.Review
-		if err := r.db.First(&review, id).Error; err != nil {
+		if err := r.db.WithContext(ctx).First(&review, id).Error; err != nil {
 			return err
 		}
-		review.DeletedDate = time.Now()
-		return r.db.Save(&review).Error
+		return r.db.WithContext(ctx).Delete(&review).Error
 	}
 }
+
+// AnonymizeByUserId blanks the text of the user's reviews, deleted ones
+// included, which may name them. Ratings stay so product scores don't change.
+func (r *ReviewRepository) AnonymizeByUserId(ctx context.Context, userId uint) error {
+	return r.db.WithContext(ctx).Unscoped().Model(&models.Review{}).Where("user_id = ?", userId).Updates(map[string]any{
+		"title":   "",
+		"comment": "",
+	}).Error
+}
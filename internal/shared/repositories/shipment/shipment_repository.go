@@ -0,0 +1,84 @@
+package shipment
+
+import (
+	"commerce/internal/shared/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ShipmentRepositoryI interface {
+	GetById(ctx context.Context, id uint) (*models.Shipment, error)
+	GetAllByOrderId(ctx context.Context, orderId uint) ([]*models.Shipment, error)
+	GetAllUndelivered(ctx context.Context) ([]*models.Shipment, error)
+	Create(ctx context.Context, shipment *models.Shipment, orderStatus models.OrderStatus) error
+	UpdateTracking(ctx context.Context, id uint, status string, deliveredDate *time.Time) error
+}
+
+type ShipmentRepository struct {
+	db *gorm.DB
+}
+
+func NewShipmentRepository(db *gorm.DB) ShipmentRepositoryI {
+	return &ShipmentRepository{db: db}
+}
+
+// GetById implements [ShipmentRepositoryI].
+func (s *ShipmentRepository) GetById(ctx context.Context, id uint) (*models.Shipment, error) {
+	var shipment models.Shipment
+	if err := s.db.WithContext(ctx).Preload("Items").First(&shipment, id).Error; err != nil {
+		return nil, err
+	}
+	return &shipment, nil
+}
+
+// GetAllByOrderId implements [ShipmentRepositoryI].
+func (s *ShipmentRepository) GetAllByOrderId(ctx context.Context, orderId uint) ([]*models.Shipment, error) {
+	var shipments []*models.Shipment
+	if err := s.db.WithContext(ctx).
+		Preload("Items").
+		Where("order_id = ?", orderId).
+		Order("shipped_date").
+		Find(&shipments).
+		Error; err != nil {
+		return nil, err
+	}
+	return shipments, nil
+}
+
+// GetAllUndelivered implements [ShipmentRepositoryI].
+func (s *ShipmentRepository) GetAllUndelivered(ctx context.Context) ([]*models.Shipment, error) {
+	var shipments []*models.Shipment
+	if err := s.db.WithContext(ctx).
+		Where("delivered_date is null").
+		Order("shipped_date").
+		Find(&shipments).
+		Error; err != nil {
+		return nil, err
+	}
+	return shipments, nil
+}
+
+// Create implements [ShipmentRepositoryI]. The shipment, its items and the
+// order's new status are written in one transaction so the order can never
+// report more (or less) shipped than its shipments add up to.
+func (s *ShipmentRepository) Create(ctx context.Context, shipment *models.Shipment, orderStatus models.OrderStatus) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(shipment).Error; err != nil {
+			return err
+		}
+		return tx.
+			Model(&models.Order{}).
+			Where("id = ?", shipment.OrderId).
+			Update("status", orderStatus).Error
+	})
+}
+
+// UpdateTracking implements [ShipmentRepositoryI].
+func (s *ShipmentRepository) UpdateTracking(ctx context.Context, id uint, status string, deliveredDate *time.Time) error {
+	return s.db.WithContext(ctx).
+		Model(&models.Shipment{}).
+		Where("id = ?", id).
+		Updates(map[string]any{"tracking_status": status, "delivered_date": deliveredDate}).Error
+}
@@ -0,0 +1,76 @@
+package uow
+
+import (
+	"commerce/internal/shared/models"
+	address_repo "commerce/internal/shared/repositories/address"
+	erasure_request_repo "commerce/internal/shared/repositories/erasure-request"
+	invoice_repo "commerce/internal/shared/repositories/invoice"
+	order_repo "commerce/internal/shared/repositories/order"
+	payment_repo "commerce/internal/shared/repositories/payment"
+	product_repo "commerce/internal/shared/repositories/product"
+	product_variant_repo "commerce/internal/shared/repositories/product-variant"
+	return_request_repo "commerce/internal/shared/repositories/return-request"
+	review_repo "commerce/internal/shared/repositories/review"
+	shipment_repo "commerce/internal/shared/repositories/shipment"
+	user_repo "commerce/internal/shared/repositories/user"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// Repositories are bound to the transaction they were handed out in.
+type Repositories struct {
+	Addresses       address_repo.AddressRepositoryI
+	ErasureRequests erasure_request_repo.ErasureRequestRepositoryI
+	Invoices        invoice_repo.InvoiceRepositoryI
+	Orders          order_repo.OrderRepositoryI
+	Payments        payment_repo.PaymentRepositoryI
+	Products        product_repo.ProductRepositoryI
+	Variants        product_variant_repo.ProductVariantRepositoryI
+	ReturnRequests  return_request_repo.ReturnRequestRepositoryI
+	Reviews         review_repo.ReviewRepositoryI
+	Shipments       shipment_repo.ShipmentRepositoryI
+	Users           user_repo.UserRepositoryI
+}
+
+// AdjustStock changes the stock an order item draws on: its variant's, or its
+// product's when it has no variant.
+func (r *Repositories) AdjustStock(ctx context.Context, item models.OrderItem, quantity int) error {
+	if item.VariantId != nil {
+		return r.Variants.AdjustStock(ctx, *item.VariantId, quantity)
+	}
+	return r.Products.AdjustStock(ctx, item.ProductId, quantity)
+}
+
+// UnitOfWorkI runs work that spans several repositories in one transaction.
+type UnitOfWorkI interface {
+	// Do commits when fn returns nil and rolls back otherwise.
+	Do(ctx context.Context, fn func(r *Repositories) error) error
+}
+
+type UnitOfWork struct {
+	db *gorm.DB
+}
+
+func NewUnitOfWork(db *gorm.DB) UnitOfWorkI {
+	return &UnitOfWork{db: db}
+}
+
+// Do implements [UnitOfWorkI].
+func (u *UnitOfWork) Do(ctx context.Context, fn func(r *Repositories) error) error {
+	return u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&Repositories{
+			Addresses:       address_repo.NewAddressRepository(tx),
+			ErasureRequests: erasure_request_repo.NewErasureRequestRepository(tx),
+			Invoices:        invoice_repo.NewInvoiceRepository(tx),
+			Orders:          order_repo.NewOrderRepository(tx),
+			Payments:        payment_repo.NewPaymentRepository(tx),
+			Products:        product_repo.NewProductRepository(tx),
+			Variants:        product_variant_repo.NewProductVariantRepository(tx),
+			ReturnRequests:  return_request_repo.NewReturnRequestRepository(tx),
+			Reviews:         review_repo.NewReviewRepository(tx),
+			Shipments:       shipment_repo.NewShipmentRepository(tx),
+			Users:           user_repo.NewUserRepository(tx),
+		})
+	})
+}
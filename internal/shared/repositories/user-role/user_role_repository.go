@@ -0,0 +1,46 @@
+package userrole
+
+import (
+	"commerce/internal/shared/models"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type UserRoleRepositoryI interface {
+	GetByUserId(ctx context.Context, userId uint) ([]*models.UserRole, error)
+	Replace(ctx context.Context, userId uint, roles []string) error
+}
+
+type UserRoleRepository struct {
+	db *gorm.DB
+}
+
+func NewUserRoleRepository(db *gorm.DB) UserRoleRepositoryI {
+	return &UserRoleRepository{db: db}
+}
+
+// GetByUserId implements [UserRoleRepositoryI].
+func (u *UserRoleRepository) GetByUserId(ctx context.Context, userId uint) ([]*models.UserRole, error) {
+	var roles []*models.UserRole
+	if err := u.db.WithContext(ctx).Where("user_id = ?", userId).Order("role").Find(&roles).Error; err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+// Replace implements [UserRoleRepositoryI]. The user's roles become exactly
+// the given ones.
+func (u *UserRoleRepository) Replace(ctx context.Context, userId uint, roles []string) error {
+	return u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userId).Delete(&models.UserRole{}).Error; err != nil {
+			return err
+		}
+		for _, role := range roles {
+			if err := tx.Create(&models.UserRole{UserId: userId, Role: role}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
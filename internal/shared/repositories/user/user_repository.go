@@ -2,18 +2,36 @@ package user
 
 import (
 	"commerce/internal/shared/models"
+	"commerce/internal/shared/repositories/query"
+	"context"
+	"fmt"
 	"time"
 
 	"gorm.io/gorm"
 )
 
 type UserRepositoryI interface {
-	GetById(id uint) (*models.User, error)
-	GetByEmail(email string) (*models.User, error)
-	GetByAuthSub(sub string) (*models.User, error)
-	GetAll() ([]*models.User, error)
-	Save(user *models.User) error
-	Delete(id uint, hard bool) error
+	GetById(ctx context.Context, id uint) (*models.User, error)
+	GetByEmail(ctx context.Context, email string) (*models.User, error)
+	GetByAuthSub(ctx context.Context, sub string) (*models.User, error)
+	GetAll(ctx context.Context, opts query.Options) (*query.Page[models.User], error)
+	Save(ctx context.Context, user *models.User) error
+	Delete(ctx context.Context, id uint, hard bool) error
+	Anonymize(ctx context.Context, id uint, erasedDate time.Time) error
+}
+
+// listFields are what user lists can be sorted and filtered by.
+var listFields = query.Fields{
+	Sort: map[string]string{
+		"email":        "email",
+		"last_name":    "last_name",
+		"created_date": "created_date",
+	},
+	Filter: map[string]query.Filter{
+		"email": {Condition: "email ILIKE '%' || ? || '%'"},
+		"name":  {Condition: "first_name || ' ' || last_name ILIKE '%' || ? || '%'"},
+	},
+	Default: []query.Sort{{Field: "created_date", Desc: true}},
 }
 
 type UserRepository struct {
@@ -24,63 +42,81 @@ func NewUserRepository(db *gorm.DB) UserRepositoryI {
 	return &UserRepository{db: db}
 }
 
-// GetByAuthSub implements [UserRepositoryI].
-func (u *UserRepository) GetByAuthSub(sub string) (*models.User, error) {
+// GetByAuthSub implements [UserRepositoryI]. Deleted users are found too:
+// auth_sub is unique across them, so callers must not take a deleted user for
+// a new one.
+func (u *UserRepository) GetByAuthSub(ctx context.Context, sub string) (*models.User, error) {
 	var user models.User
-	if err := u.db.Where("auth_sub = ?", sub).First(&user).Error; err != nil {
+	if err := u.db.WithContext(ctx).Unscoped().Where("auth_sub = ?", sub).First(&user).Error; err != nil {
 		return nil, err
 	}
 	return &user, nil
 }
 
 // Delete implements [UserRepositoryI].
-func (u *UserRepository) Delete(id uint, hard bool) error {
+func (u *UserRepository) Delete(ctx context.Context, id uint, hard bool) error {
 	if hard {
-		return u.db.Delete(&models.User{}, id).Error
+		return u.db.WithContext(ctx).Unscoped().Delete(&models.User{}, id).Error
 	}
 	var user models.User
-	if err := u.db.First(&user, id).Error; err != nil {
+	if err := u.db.WithContext(ctx).First(&user, id).Error; err != nil {
 		return err
 	}
-	user.DeletedDate = time.Now()
-	return u.db.Save(&user).Error
+	return u.db.WithContext(ctx).Delete(&user).Error
 }
 
 // GetAll implements [UserRepositoryI].
-func (u *UserRepository) GetAll() ([]*models.User, error) {
-	var users []*models.User
-	if err := u.db.Order("created_date desc").Find(&users).Error; err != nil {
-		return nil, err
-	}
-	return users, nil
+func (u *UserRepository) GetAll(ctx context.Context, opts query.Options) (*query.Page[models.User], error) {
+	return query.Find[models.User](u.db.WithContext(ctx), opts, listFields)
 }
 
 // GetByEmail implements [UserRepositoryI].
-func (u *UserRepository) GetByEmail(email string) (*models.User, error) {
+func (u *UserRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
 	var user models.User
-	if err := u.db.Where("email = ?", email).First(&user).Error; err != nil {
+	if err := u.db.WithContext(ctx).Where("email = ?", email).First(&user).Error; err != nil {
 		return nil, err
 	}
 	return &user, nil
 }
 
 // GetById implements [UserRepositoryI].
-func (u *UserRepository) GetById(id uint) (*models.User, error) {
+func (u *UserRepository) GetById(ctx context.Context, id uint) (*models.User, error) {
 	var user models.User
-	if err := u.db.First(&user, id).Error; err != nil {
+	if err := u.db.WithContext(ctx).First(&user, id).Error; err != nil {
 		return nil, err
 	}
 	return &user, nil
 }
 
 // Save implements [UserRepositoryI].
-func (u *UserRepository) Save(user *models.User) error {
+func (u *UserRepository) Save(ctx context.Context, user *models.User) error {
 	if user.Id == 0 {
-		return u.db.Create(user).Error
+		return u.db.WithContext(ctx).Create(user).Error
 	} else {
-		if _, err := u.GetById(user.Id); err != nil {
+		if _, err := u.GetById(ctx, user.Id); err != nil {
 			return err
 		}
-		return u.db.Save(user).Error
+		return u.db.WithContext(ctx).Save(user).Error
+	}
+}
+
+// Anonymize implements [UserRepositoryI]. The row stays so orders and reviews
+// keep their owner, but nothing in it identifies the person any more. The
+// email and sub are replaced rather than blanked because both are unique. A
+// user who was already deleted is still erased.
+func (u *UserRepository) Anonymize(ctx context.Context, id uint, erasedDate time.Time) error {
+	result := u.db.WithContext(ctx).Unscoped().Model(&models.User{}).Where("id = ?", id).Updates(map[string]any{
+		"first_name":   "Erased",
+		"last_name":    "User",
+		"email":        fmt.Sprintf("erased-%d@invalid", id),
+		"auth_sub":     fmt.Sprintf("erased|%d", id),
+		"deleted_date": erasedDate,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
 	}
+	return nil
 }
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"commerce/utils/internal/devauth"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+const defaultKeyPath = "configs/dev-auth.pem"
+
+// mintToken prints a token the API accepts when AUTH_JWKS points at the
+// output of writeJWKS. Issuer and audience default to the API's own
+// AUTH_DOMAIN and AUTH_AUDIENCE so both sides read the same .env.
+func mintToken(args []string) error {
+	fs := flag.NewFlagSet("token", flag.ExitOnError)
+	keyPath := fs.String("key", defaultKeyPath, "signing key, created if missing")
+	domain := fs.String("domain", os.Getenv("AUTH_DOMAIN"), "issuer domain, the API's AUTH_DOMAIN")
+	audience := fs.String("audience", os.Getenv("AUTH_AUDIENCE"), "audience, the API's AUTH_AUDIENCE")
+	sub := fs.String("sub", "dev|local-user", `subject; end it with "@clients" for an M2M token`)
+	email := fs.String("email", "dev@example.com", "email claim, required for user tokens")
+	firstName := fs.String("first-name", "Dev", "given_name claim")
+	lastName := fs.String("last-name", "User", "family_name claim")
+	scope := fs.String("scope", "", `space separated scopes, e.g. "orders:read orders:write"`)
+	roles := fs.String("roles", "", "comma separated roles, e.g. admin,support")
+	rolesClaim := fs.String("roles-claim", envOrDefault("AUTH_ROLES_CLAIM", "https://commerce.api/roles"), "claim the roles are written to")
+	ttl := fs.Duration("ttl", time.Hour, "how long the token is valid")
+	_ = fs.Parse(args)
+
+	if *domain == "" || *audience == "" {
+		return fmt.Errorf("token: -domain and -audience are required when AUTH_DOMAIN and AUTH_AUDIENCE aren't set")
+	}
+	key, err := devauth.LoadOrCreateKey(*keyPath)
+	if err != nil {
+		return fmt.Errorf("token: %w", err)
+	}
+
+	now := time.Now()
+	claims := map[string]any{
+		"iss":   "https://" + *domain + "/",
+		"aud":   []string{*audience},
+		"sub":   *sub,
+		"iat":   now.Unix(),
+		"nbf":   now.Unix(),
+		"exp":   now.Add(*ttl).Unix(),
+		"scope": strings.Join(strings.Fields(*scope), " "),
+	}
+	if !strings.HasSuffix(*sub, "@clients") {
+		claims["email"] = *email
+		claims["given_name"] = *firstName
+		claims["family_name"] = *lastName
+	}
+	if *roles != "" {
+		claims[*rolesClaim] = strings.Split(*roles, ",")
+	}
+
+	token, err := devauth.Sign(key, claims)
+	if err != nil {
+		return fmt.Errorf("token: %w", err)
+	}
+	fmt.Println(token)
+	return nil
+}
+
+// writeJWKS writes the public half of the signing key as a JWKS document.
+func writeJWKS(args []string) error {
+	fs := flag.NewFlagSet("jwks", flag.ExitOnError)
+	keyPath := fs.String("key", defaultKeyPath, "signing key, created if missing")
+	out := fs.String("out", "-", `file to write, "-" for stdout`)
+	_ = fs.Parse(args)
+
+	key, err := devauth.LoadOrCreateKey(*keyPath)
+	if err != nil {
+		return fmt.Errorf("jwks: %w", err)
+	}
+	data, err := json.MarshalIndent(devauth.NewJWKS(&key.PublicKey), "", "  ")
+	if err != nil {
+		return fmt.Errorf("jwks: %w", err)
+	}
+	if *out == "-" {
+		fmt.Println(string(data))
+		return nil
+	}
+	return os.WriteFile(*out, append(data, '\n'), 0o644)
+}
+
+func envOrDefault(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -2,11 +2,13 @@ module commerce/utils
 
 go 1.26.4
 
-require github.com/akhakpouri/gorm-kit v1.0.0
+require (
+	github.com/akhakpouri/gorm-kit v1.0.0
+	gorm.io/gorm v1.31.2
+)
 
 require (
 	github.com/jinzhu/inflection v1.0.0 // indirect
 	github.com/jinzhu/now v1.1.5 // indirect
 	golang.org/x/text v0.38.0 // indirect
-	gorm.io/gorm v1.31.2 // indirect
 )
@@ -6,14 +6,51 @@ import (
 	"embed"
 	"fmt"
 	"log/slog"
+	"os"
 )
 
 //go:embed configs/config.json
 var content embed.FS
 
+const usage = `usage: utils [command] [flags]
+
+commands:
+  migrate   run the database migrations (default)
+  token     mint a development JWT signed with the local key
+  jwks      write the JWKS document for the local key
+  import    import products from CSV, as POST /api/products/import does
+  export    export products as CSV, as GET /api/products/export does
+
+run "utils <command> -h" for the flags of a command.
+`
+
 func main() {
-	fmt.Println("Welcome to the Commerce Utility Application!")
-	migrateDatabase("configs/config.json")
+	command, args := "migrate", os.Args[1:]
+	if len(args) > 0 {
+		command, args = args[0], args[1:]
+	}
+
+	var err error
+	switch command {
+	case "migrate":
+		fmt.Println("Welcome to the Commerce Utility Application!")
+		migrateDatabase("configs/config.json")
+	case "token":
+		err = mintToken(args)
+	case "jwks":
+		err = writeJWKS(args)
+	case "import":
+		err = importProducts(args)
+	case "export":
+		err = exportProducts(args)
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
 }
 
 // migrateDatabase is the main entry point for the utility application.